@@ -212,6 +212,12 @@ const buildObserverJob = (event: Event, version?: string) => {
 }
 jobs[buildObserverJobName] = buildObserverJob
 
+const buildReadinessWaiterJobName = "build-readiness-waiter"
+const buildReadinessWaiterJob = (event: Event, version?: string) => {
+  return new BuildImageJob("readiness-waiter", event, version)
+}
+jobs[buildReadinessWaiterJobName] = buildReadinessWaiterJob
+
 const buildSchedulerJobName = "build-scheduler"
 const buildSchedulerJob = (event: Event, version?: string) => {
   return new BuildImageJob("scheduler", event, version)
@@ -337,6 +343,7 @@ events.on("brigade.sh/github", "ci:pipeline_requested", async event => {
       buildGitInitializerJob(event),
       buildLoggerLinuxJob(event),
       buildObserverJob(event),
+      buildReadinessWaiterJob(event),
       buildSchedulerJob(event),
       buildWorkerJob(event),
       buildBrigadierJob(event),
@@ -365,6 +372,7 @@ events.on("brigade.sh/github", "cd:pipeline_requested", async event => {
       buildGitInitializerJob(event, version),
       buildLoggerLinuxJob(event, version),
       buildObserverJob(event, version),
+      buildReadinessWaiterJob(event, version),
       buildSchedulerJob(event, version),
       buildWorkerJob(event, version)
     ),
@@ -391,6 +399,7 @@ events.on("brigade.sh/cron", "nightly-cleanup", async event => {
     "brigade2-git-initializer",
     "brigade2-logger",
     "brigade2-observer",
+    "brigade2-readiness-waiter",
     "brigade2-scheduler",
     "brigade2-worker"
   ]
@@ -8,6 +8,7 @@ import (
 // than expose functions for obtaining more specialized clients for different
 // areas of concern, like User management or Project management.
 type APIClient interface {
+	Audit() AuditClient
 	Authn() AuthnClient
 	Authz() SystemAuthzClient
 	Core() CoreClient
@@ -15,6 +16,7 @@ type APIClient interface {
 }
 
 type apiClient struct {
+	auditClient  AuditClient
 	authnClient  AuthnClient
 	authzClient  SystemAuthzClient
 	coreClient   CoreClient
@@ -28,6 +30,7 @@ func NewAPIClient(
 	opts *restmachinery.APIClientOptions,
 ) APIClient {
 	return &apiClient{
+		auditClient:  NewAuditClient(apiAddress, apiToken, opts),
 		authnClient:  NewAuthnClient(apiAddress, apiToken, opts),
 		authzClient:  NewSystemAuthzClient(apiAddress, apiToken, opts),
 		coreClient:   NewCoreClient(apiAddress, apiToken, opts),
@@ -35,6 +38,10 @@ func NewAPIClient(
 	}
 }
 
+func (a *apiClient) Audit() AuditClient {
+	return a.auditClient
+}
+
 func (a *apiClient) Authn() AuthnClient {
 	return a.authnClient
 }
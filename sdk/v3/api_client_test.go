@@ -14,6 +14,8 @@ const (
 func TestNewAPIClient(t *testing.T) {
 	client, ok := NewAPIClient(testAPIAddress, testAPIToken, nil).(*apiClient)
 	require.True(t, ok)
+	require.NotNil(t, client.auditClient)
+	require.Equal(t, client.auditClient, client.Audit())
 	require.NotNil(t, client.authnClient)
 	require.Equal(t, client.authnClient, client.Authn())
 	require.NotNil(t, client.authzClient)
@@ -0,0 +1,213 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	rm "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/brigadecore/brigade/sdk/v3/restmachinery"
+)
+
+// AuditRecordKind represents the canonical AuditRecord kind string
+const AuditRecordKind = "AuditRecord"
+
+// AuditResult represents the outcome of an audited operation.
+type AuditResult string
+
+const (
+	// AuditResultFailed represents the outcome of an audited operation that
+	// failed, including operations that failed because the principal was not
+	// authorized to perform them.
+	AuditResultFailed AuditResult = "FAILED"
+	// AuditResultSucceeded represents the outcome of an audited operation that
+	// succeeded.
+	AuditResultSucceeded AuditResult = "SUCCEEDED"
+)
+
+// AuditRecord records a single mutating operation, who attempted it, and
+// what the outcome was.
+type AuditRecord struct {
+	// ID is the AuditRecord's unique identifier.
+	ID string `json:"id"`
+	// Time indicates when the operation was attempted.
+	Time time.Time `json:"time"`
+	// Principal references the principal that attempted the operation. It will
+	// be nil if the operation was attempted by an unauthenticated client.
+	Principal *PrincipalReference `json:"principal,omitempty"`
+	// Action describes the operation that was attempted-- for instance,
+	// "projects.create".
+	Action string `json:"action"`
+	// TargetType indicates what kind of resource the operation targeted-- for
+	// instance, a Project.
+	TargetType string `json:"targetType"`
+	// TargetID identifies the resource the operation targeted. It will be empty
+	// for operations that target many resources at once.
+	TargetID string `json:"targetID,omitempty"`
+	// ProjectID identifies the Project that the targeted resource belongs to, if
+	// applicable.
+	ProjectID string `json:"projectID,omitempty"`
+	// Details contains additional, operation-specific information-- for
+	// instance, the reason given for canceling an Event.
+	Details map[string]string `json:"details,omitempty"`
+	// Result indicates the outcome of the operation.
+	Result AuditResult `json:"result"`
+	// Error contains the error message returned by a failed operation.
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON amends AuditRecord instances with type metadata so that clients
+// do not need to be concerned with the tedium of doing so.
+func (a AuditRecord) MarshalJSON() ([]byte, error) {
+	type Alias AuditRecord
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       AuditRecordKind,
+			},
+			Alias: (Alias)(a),
+		},
+	)
+}
+
+// AuditRecordList is an ordered and pageable list of AuditRecords.
+type AuditRecordList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of AuditRecords.
+	Items []AuditRecord `json:"items,omitempty"`
+}
+
+// MarshalJSON amends AuditRecordList instances with type metadata so that
+// clients do not need to be concerned with the tedium of doing so.
+func (a AuditRecordList) MarshalJSON() ([]byte, error) {
+	type Alias AuditRecordList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "AuditRecordList",
+			},
+			Alias: (Alias)(a),
+		},
+	)
+}
+
+// AuditRecordsSelector represents useful filter criteria when selecting
+// multiple AuditRecords for API group operations like list. All criteria are
+// optional.
+type AuditRecordsSelector struct {
+	// Action specifies that only AuditRecords of the specified action should be
+	// selected.
+	Action string
+	// TargetType specifies that only AuditRecords of operations that targeted
+	// the specified kind of resource should be selected.
+	TargetType string
+	// TargetID specifies that only AuditRecords of operations that targeted the
+	// resource with the specified identifier should be selected.
+	TargetID string
+	// ProjectID specifies that only AuditRecords of operations that targeted
+	// resources belonging to the specified Project should be selected.
+	ProjectID string
+	// PrincipalType specifies that only AuditRecords of operations attempted by
+	// principals of the specified type should be selected.
+	PrincipalType PrincipalType
+	// PrincipalID specifies that only AuditRecords of operations attempted by
+	// the principal with the specified identifier should be selected.
+	PrincipalID string
+	// Result specifies that only AuditRecords with the specified outcome should
+	// be selected.
+	Result AuditResult
+	// Since specifies that only AuditRecords of operations attempted at or
+	// after the specified time should be selected.
+	Since *time.Time
+	// Until specifies that only AuditRecords of operations attempted before the
+	// specified time should be selected.
+	Until *time.Time
+}
+
+// AuditClient is the client for reviewing the record of mutating operations
+// performed via the Brigade API. Only admins may use it.
+type AuditClient interface {
+	// List returns an AuditRecordList, with its Items (AuditRecords) ordered by
+	// time, newest first. Criteria for which AuditRecords should be retrieved
+	// can be specified using the AuditRecordsSelector parameter.
+	List(
+		context.Context,
+		*AuditRecordsSelector,
+		*meta.ListOptions,
+	) (AuditRecordList, error)
+}
+
+type auditClient struct {
+	*rm.BaseClient
+}
+
+// NewAuditClient returns a client for reviewing the record of mutating
+// operations performed via the Brigade API.
+func NewAuditClient(
+	apiAddress string,
+	apiToken string,
+	opts *restmachinery.APIClientOptions,
+) AuditClient {
+	return &auditClient{
+		BaseClient: rm.NewBaseClient(apiAddress, apiToken, opts),
+	}
+}
+
+func (a *auditClient) List(
+	ctx context.Context,
+	selector *AuditRecordsSelector,
+	opts *meta.ListOptions,
+) (AuditRecordList, error) {
+	queryParams := map[string]string{}
+	if selector != nil {
+		if selector.Action != "" {
+			queryParams["action"] = selector.Action
+		}
+		if selector.TargetType != "" {
+			queryParams["targetType"] = selector.TargetType
+		}
+		if selector.TargetID != "" {
+			queryParams["targetID"] = selector.TargetID
+		}
+		if selector.ProjectID != "" {
+			queryParams["projectID"] = selector.ProjectID
+		}
+		if selector.PrincipalType != "" {
+			queryParams["principalType"] = string(selector.PrincipalType)
+		}
+		if selector.PrincipalID != "" {
+			queryParams["principalID"] = selector.PrincipalID
+		}
+		if selector.Result != "" {
+			queryParams["result"] = string(selector.Result)
+		}
+		if selector.Since != nil {
+			queryParams["since"] = selector.Since.Format(time.RFC3339)
+		}
+		if selector.Until != nil {
+			queryParams["until"] = selector.Until.Format(time.RFC3339)
+		}
+	}
+	records := AuditRecordList{}
+	return records, a.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        "v2/audit-records",
+			QueryParams: a.AppendListQueryParams(queryParams, opts),
+			SuccessCode: http.StatusOK,
+			RespObj:     &records,
+		},
+	)
+}
@@ -0,0 +1,93 @@
+package sdk
+
+// nolint: lll
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	rmTesting "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery/testing"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	metaTesting "github.com/brigadecore/brigade/sdk/v3/meta/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRecordMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, AuditRecord{}, AuditRecordKind)
+}
+
+func TestAuditRecordListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, AuditRecordList{}, "AuditRecordList")
+}
+
+func TestNewAuditClient(t *testing.T) {
+	client, ok := NewAuditClient(
+		rmTesting.TestAPIAddress,
+		rmTesting.TestAPIToken,
+		nil,
+	).(*auditClient)
+	require.True(t, ok)
+	rmTesting.RequireBaseClient(t, client.BaseClient)
+}
+
+func TestAuditClientList(t *testing.T) {
+	testSince := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testRecords := AuditRecordList{
+		Items: []AuditRecord{
+			{
+				ID:     "12345",
+				Action: "projects.create",
+				Result: AuditResultSucceeded,
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/v2/audit-records", r.URL.Path)
+				require.Equal(t, "projects.create", r.URL.Query().Get("action"))
+				require.Equal(
+					t,
+					string(PrincipalTypeUser),
+					r.URL.Query().Get("principalType"),
+				)
+				require.Equal(
+					t,
+					string(AuditResultSucceeded),
+					r.URL.Query().Get("result"),
+				)
+				require.Equal(
+					t,
+					testSince.Format(time.RFC3339),
+					r.URL.Query().Get("since"),
+				)
+				require.Equal(t, "10", r.URL.Query().Get("limit"))
+				bodyBytes, err := json.Marshal(testRecords)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewAuditClient(server.URL, rmTesting.TestAPIToken, nil)
+	records, err := client.List(
+		context.Background(),
+		&AuditRecordsSelector{
+			Action:        "projects.create",
+			PrincipalType: PrincipalTypeUser,
+			Result:        AuditResultSucceeded,
+			Since:         &testSince,
+		},
+		&meta.ListOptions{
+			Limit: 10,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, testRecords, records)
+}
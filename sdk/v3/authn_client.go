@@ -14,6 +14,8 @@ type AuthnClient interface {
 	// WhoAmI returns a PrincipalReference for the currently authenticated
 	// principal.
 	WhoAmI(context.Context) (PrincipalReference, error)
+	// Gateways returns a specialized client for Gateway management.
+	Gateways() GatewaysClient
 	// ServiceAccounts returns a specialized client for ServiceAccount management.
 	ServiceAccounts() ServiceAccountsClient
 	// Sessions returns a specialized client for Session management.
@@ -24,6 +26,8 @@ type AuthnClient interface {
 
 type authnClient struct {
 	*rm.BaseClient
+	// gatewaysClient is a specialized client for Gateway management.
+	gatewaysClient GatewaysClient
 	// serviceAccountsClient is a specialized client for ServiceAccount
 	// management.
 	serviceAccountsClient ServiceAccountsClient
@@ -42,7 +46,8 @@ func NewAuthnClient(
 	opts *restmachinery.APIClientOptions,
 ) AuthnClient {
 	return &authnClient{
-		BaseClient: rm.NewBaseClient(apiAddress, apiToken, opts),
+		BaseClient:     rm.NewBaseClient(apiAddress, apiToken, opts),
+		gatewaysClient: NewGatewaysClient(apiAddress, apiToken, opts),
 		serviceAccountsClient: NewServiceAccountsClient(
 			apiAddress,
 			apiToken,
@@ -66,6 +71,10 @@ func (a *authnClient) WhoAmI(ctx context.Context) (PrincipalReference, error) {
 	)
 }
 
+func (a *authnClient) Gateways() GatewaysClient {
+	return a.gatewaysClient
+}
+
 func (a *authnClient) ServiceAccounts() ServiceAccountsClient {
 	return a.serviceAccountsClient
 }
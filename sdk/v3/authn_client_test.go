@@ -19,6 +19,8 @@ func TestNewAuthnClient(t *testing.T) {
 		nil,
 	).(*authnClient)
 	require.True(t, ok)
+	require.NotNil(t, client.gatewaysClient)
+	require.Equal(t, client.gatewaysClient, client.Gateways())
 	require.NotNil(t, client.serviceAccountsClient)
 	require.Equal(t, client.serviceAccountsClient, client.ServiceAccounts())
 	require.NotNil(t, client.sessionsClient)
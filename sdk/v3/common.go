@@ -1,3 +1,17 @@
 package sdk
 
+import "strconv"
+
 const trueStr = "true"
+
+// ifMatchHeaders returns HTTP headers that make a request conditional upon the
+// target resource's current version matching the one specified. No headers are
+// returned if the specified version is zero.
+func ifMatchHeaders(resourceVersion int64) map[string]string {
+	if resourceVersion == 0 {
+		return nil
+	}
+	return map[string]string{
+		"If-Match": strconv.FormatInt(resourceVersion, 10),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	rm "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery"
 	"github.com/brigadecore/brigade/sdk/v3/meta"
@@ -90,6 +91,11 @@ type Event struct {
 	// to begin with) is that event payloads may contain REFERENCES to sensitive
 	// details that are useful only to properly configured Workers.
 	Payload string `json:"payload,omitempty"`
+	// Inputs optionally contains values for inputs declared by a Project for
+	// Events having this Event's Source and Type. When the Event is created for
+	// such a Project, these are validated against the Project's declarations and
+	// any defaults are applied.
+	Inputs map[string]string `json:"inputs,omitempty"`
 	// Summary is a counterpart to Payload. If Payload is free-form Worker input,
 	// then Summary is free-form Worker output. It can optionally be set by a
 	// Worker to provide a summary of the work completed by the Worker and its
@@ -218,6 +224,23 @@ type EventsSelector struct {
 	// Labels specifies that only Events labeled with these key/value pairs should
 	// be selected.
 	Labels map[string]string
+	// CreatedAfter specifies that only Events created after the indicated time
+	// should be selected.
+	CreatedAfter *time.Time
+	// CreatedBefore specifies that only Events created before the indicated time
+	// should be selected.
+	CreatedBefore *time.Time
+	// Commit specifies that only Events whose git details reference the
+	// indicated commit (by SHA) should be selected.
+	Commit string
+	// Ref specifies that only Events whose git details reference the indicated
+	// git ref (e.g. a branch or tag) should be selected.
+	Ref string
+	// Text specifies that only Events whose ShortTitle, LongTitle, or Summary
+	// match the indicated text search should be selected. The search is
+	// case-insensitive and matches whole words. Multiple words are ORed
+	// together, while quoted phrases must match exactly.
+	Text string
 }
 
 // GitDetails represents git-specific Event details. These may override
@@ -240,15 +263,209 @@ type GitDetails struct {
 // CancelManyEventsResult represents a summary of a mass Event cancellation
 // operation.
 type CancelManyEventsResult struct {
-	// Count represents the number of Events canceled.
+	// Count represents the number of Events canceled or, for a dry run, the
+	// number of Events that would have been canceled.
 	Count int64 `json:"count"`
+	// DryRun indicates whether the operation was a dry run, in which case no
+	// Events were actually canceled.
+	DryRun bool `json:"dryRun,omitempty"`
+	// SampleIDs enumerates the IDs of a limited sample of the Events that would
+	// have been canceled, newest first. It is only populated for a dry run.
+	SampleIDs []string `json:"sampleIDs,omitempty"`
 }
 
 // DeleteManyEventsResult represents a summary of a mass Event deletion
 // operation.
 type DeleteManyEventsResult struct {
-	// Count represents the number of Events deleted.
+	// Count represents the number of Events deleted or, for a dry run, the
+	// number of Events that would have been deleted.
+	Count int64 `json:"count"`
+	// DryRun indicates whether the operation was a dry run, in which case no
+	// Events were actually deleted.
+	DryRun bool `json:"dryRun,omitempty"`
+	// SampleIDs enumerates the IDs of a limited sample of the Events that would
+	// have been deleted, newest first. It is only populated for a dry run.
+	SampleIDs []string `json:"sampleIDs,omitempty"`
+}
+
+// EventCopyResult represents the outcome of retrying or cloning a single Event
+// as part of a mass retry or clone operation.
+type EventCopyResult struct {
+	// SourceEventID is the identifier of the Event that was retried or cloned.
+	SourceEventID string `json:"sourceEventID"`
+	// EventID is the identifier of the new Event that was created. It is empty
+	// if the operation failed.
+	EventID string `json:"eventID,omitempty"`
+	// Error describes why the operation failed. It is empty if the operation
+	// succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// RetryManyEventsResult represents a summary of a mass Event retry operation.
+type RetryManyEventsResult struct {
+	// Count represents the number of Events successfully retried.
+	Count int64 `json:"count"`
+	// FailedCount represents the number of Events that could not be retried.
+	FailedCount int64 `json:"failedCount"`
+	// Items enumerates the outcome of retrying each selected Event.
+	Items []EventCopyResult `json:"items,omitempty"`
+}
+
+// CloneManyEventsResult represents a summary of a mass Event clone operation.
+type CloneManyEventsResult struct {
+	// Count represents the number of Events successfully cloned.
+	Count int64 `json:"count"`
+	// FailedCount represents the number of Events that could not be cloned.
+	FailedCount int64 `json:"failedCount"`
+	// Items enumerates the outcome of cloning each selected Event.
+	Items []EventCopyResult `json:"items,omitempty"`
+}
+
+// EventStatsGrouping represents a dimension along which Event statistics are
+// aggregated.
+type EventStatsGrouping string
+
+const (
+	// EventStatsGroupingProject represents aggregation of Event statistics by
+	// Project.
+	EventStatsGroupingProject EventStatsGrouping = "PROJECT"
+	// EventStatsGroupingSourceType represents aggregation of Event statistics by
+	// Event source and type.
+	EventStatsGroupingSourceType EventStatsGrouping = "SOURCE_TYPE"
+	// EventStatsGroupingTime represents aggregation of Event statistics into
+	// fixed-size time buckets, based on when each Event was created.
+	EventStatsGroupingTime EventStatsGrouping = "TIME"
+)
+
+// EventStatsOptions represents useful, optional criteria for aggregating Event
+// statistics.
+type EventStatsOptions struct {
+	// ProjectID optionally specifies that only Events belonging to the
+	// indicated Project should be considered.
+	ProjectID string
+	// GroupBy specifies how Event statistics should be grouped. If not
+	// specified, the server will group by Project.
+	GroupBy EventStatsGrouping
+	// Since specifies the beginning of the window of time in which Events must
+	// have been created to be considered. If not specified, the server will
+	// assume a window of seven days, ending at Until.
+	Since *time.Time
+	// Until specifies the end of the window of time in which Events must have
+	// been created to be considered. If not specified, the server will assume
+	// the current time.
+	Until *time.Time
+	// BucketSize specifies the size of each time bucket. This is applicable only
+	// when GroupBy is EventStatsGroupingTime. If not specified, the server will
+	// assume one hour.
+	BucketSize time.Duration
+}
+
+// EventStats represents aggregated statistics about Events created within a
+// window of time.
+type EventStats struct {
+	// GroupBy indicates how the statistics are grouped.
+	GroupBy EventStatsGrouping `json:"groupBy"`
+	// Since indicates the beginning of the window of time the statistics cover.
+	Since time.Time `json:"since"`
+	// Until indicates the end of the window of time the statistics cover.
+	Until time.Time `json:"until"`
+	// BucketSize indicates the size of each time bucket, expressed as a
+	// duration string (e.g. "1h0m0s"). It is only populated when GroupBy is
+	// EventStatsGroupingTime.
+	BucketSize string `json:"bucketSize,omitempty"`
+	// Groups contains statistics for each group.
+	Groups []EventStatsGroup `json:"groups,omitempty"`
+}
+
+// EventStatsGroup represents aggregated statistics about a group of Events.
+// Which of ProjectID, Source, Type, and BucketStart are populated depends on
+// how the statistics were grouped.
+type EventStatsGroup struct {
+	// ProjectID identifies the Project to which all Events in the group belong.
+	ProjectID string `json:"projectID,omitempty"`
+	// Source is the source of all Events in the group.
+	Source string `json:"source,omitempty"`
+	// Type is the type of all Events in the group.
+	Type string `json:"type,omitempty"`
+	// BucketStart indicates the beginning of the time bucket in which all Events
+	// in the group were created.
+	BucketStart *time.Time `json:"bucketStart,omitempty"`
+	// Total is the number of Events in the group.
+	Total int64 `json:"total"`
+	// PhaseCounts breaks down the number of Events in the group by the phase of
+	// their Workers. Phases with no Events are omitted.
+	PhaseCounts map[WorkerPhase]int64 `json:"phaseCounts,omitempty"`
+	// SuccessRate is the fraction, between 0 and 1, of Events in the group whose
+	// Workers reached a terminal phase that SUCCEEDED.
+	SuccessRate float64 `json:"successRate"`
+	// QueueWait summarizes how long Events in the group waited between creation
+	// and their Workers starting.
+	QueueWait DurationPercentiles `json:"queueWait"`
+	// RunDuration summarizes how long the Workers of Events in the group ran
+	// between starting and reaching a terminal phase.
+	RunDuration DurationPercentiles `json:"runDuration"`
+	// JobFailureRates breaks down the failure rate of Jobs spawned by Workers
+	// of Events in the group by Job name, highest failure rate first.
+	JobFailureRates []JobFailureRate `json:"jobFailureRates,omitempty"`
+}
+
+// DurationPercentiles summarizes a distribution of durations. Percentiles are
+// estimates that are accurate to within about 5%.
+type DurationPercentiles struct {
+	// Count is the number of durations in the distribution.
 	Count int64 `json:"count"`
+	// P50Seconds is the median duration, in seconds.
+	P50Seconds float64 `json:"p50Seconds"`
+	// P95Seconds is the 95th percentile duration, in seconds.
+	P95Seconds float64 `json:"p95Seconds"`
+}
+
+// JobFailureRate represents the failure rate of all Jobs having a given name.
+type JobFailureRate struct {
+	// JobName is the name of the Jobs.
+	JobName string `json:"jobName"`
+	// Total is the number of Jobs having the name that reached a terminal
+	// phase.
+	Total int64 `json:"total"`
+	// Failed is the number of Jobs having the name that reached a terminal phase
+	// other than SUCCEEDED.
+	Failed int64 `json:"failed"`
+	// FailureRate is the fraction, between 0 and 1, of Jobs having the name
+	// that reached a terminal phase other than SUCCEEDED.
+	FailureRate float64 `json:"failureRate"`
+}
+
+// EventCreateDryRunResult represents the outcome of evaluating a prospective
+// Event against Projects' EventSubscriptions without actually creating any
+// Events.
+type EventCreateDryRunResult struct {
+	// Subscribers enumerates the IDs of Projects that would have been sent the
+	// Event.
+	Subscribers []string `json:"subscribers,omitempty"`
+	// NonSubscribers enumerates Projects that were evaluated, but would NOT
+	// have been sent the Event, along with an explanation of why not.
+	NonSubscribers []NonSubscriber `json:"nonSubscribers,omitempty"`
+}
+
+// NonSubscriber describes a Project that would not have been sent an Event
+// and explains why.
+type NonSubscriber struct {
+	// ProjectID is the ID of the Project.
+	ProjectID string `json:"projectID"`
+	// Subscriptions describes, for each of the Project's EventSubscriptions,
+	// which criteria the Event failed to meet. If empty, the Project has no
+	// EventSubscriptions at all.
+	Subscriptions []SubscriptionMismatch `json:"subscriptions,omitempty"`
+}
+
+// SubscriptionMismatch describes which criteria of a single EventSubscription
+// an Event failed to meet.
+type SubscriptionMismatch struct {
+	// Index is the position of the EventSubscription within the Project's list
+	// of EventSubscriptions.
+	Index int `json:"index"`
+	// Reasons enumerates the criteria the Event failed to meet.
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // EventCreateOptions represents useful, optional settings for creating a new
@@ -256,6 +473,12 @@ type DeleteManyEventsResult struct {
 // future expansion without having to change client function signatures.
 type EventCreateOptions struct{}
 
+// EventCreateDryRunOptions represents useful, optional settings for
+// evaluating a prospective Event against Projects' EventSubscriptions. It
+// currently has no fields, but exists to preserve the possibility of future
+// expansion without having to change client function signatures.
+type EventCreateDryRunOptions struct{}
+
 // EventGetOptions represents useful, optional criteria for retrieval of an
 // Event. It currently has no fields, but exists to preserve the possibility of
 // future expansion without having to change client function signatures.
@@ -268,10 +491,14 @@ type EventGetOptions struct{}
 type EventCloneOptions struct{}
 
 // EventSourceStateUpdateOptions represents useful, optional settings for
-// updating an Event's SourceState. It currently has no fields, but exists to
-// preserve the possibility of future expansion without having to change client
-// function signatures.
-type EventSourceStateUpdateOptions struct{}
+// updating an Event's SourceState.
+type EventSourceStateUpdateOptions struct {
+	// ResourceVersion, when non-zero, specifies that the update should only be
+	// applied if the Event's current ResourceVersion matches. If it does not
+	// match, because the Event's SourceState has been updated by someone else in
+	// the interim, a *meta.ErrConflict is returned.
+	ResourceVersion int64
+}
 
 // EventSummaryUpdateOptions represents useful, optional settings for updating
 // an Event's summary. It currently has no fields, but exists to preserve the
@@ -280,31 +507,62 @@ type EventSourceStateUpdateOptions struct{}
 type EventSummaryUpdateOptions struct{}
 
 // EventCancelOptions represents useful, optional settings for canceling an
-// Event. It currently has no fields, but exists to preserve the possibility of
-// future expansion without having to change client function signatures.
-type EventCancelOptions struct{}
+// Event.
+type EventCancelOptions struct {
+	// Reason is an optional, human-readable explanation of why the Event is
+	// being canceled. It is recorded in the status of the Event's Worker.
+	Reason string
+}
 
 // EventCancelManyOptions represents useful, optional settings for canceling
-// many Events. It currently has no fields, but exists to preserve the
-// possibility of future expansion without having to change client function
-// signatures.
-type EventCancelManyOptions struct{}
+// many Events.
+type EventCancelManyOptions struct {
+	// Reason is an optional, human-readable explanation of why the Events are
+	// being canceled. It is recorded in the status of each Event's Worker.
+	Reason string
+	// DryRun indicates that the Events that would be canceled should be
+	// returned without actually canceling them.
+	DryRun bool
+}
 
 // EventDeleteOptions represents useful, optional settings for deleting an
-// Event. It currently has no fields, but exists to preserve the possibility of
-// future expansion without having to change client function signatures.
-type EventDeleteOptions struct{}
+// Event.
+type EventDeleteOptions struct {
+	// Reason is an optional, human-readable explanation of why the Event is
+	// being deleted.
+	Reason string
+}
 
 // EventDeleteManyOptions represents useful, optional settings for deleting many
-// Events. It currently has no fields, but exists to preserve the possibility of
-// future expansion without having to change client function signatures.
-type EventDeleteManyOptions struct{}
+// Events.
+type EventDeleteManyOptions struct {
+	// Reason is an optional, human-readable explanation of why the Events are
+	// being deleted.
+	Reason string
+	// DryRun indicates that the Events that would be deleted should be returned
+	// without actually deleting them.
+	DryRun bool
+}
 
 // EventRetryOptions represents useful, optional settings for retrying an
-// existing Event. It currently has no fields, but exists to preserve the
-// possibility of future expansion without having to change client function
-// signatures.
-type EventRetryOptions struct{}
+// existing Event.
+type EventRetryOptions struct {
+	// FromJob optionally names a Job of the original Event from which execution
+	// should resume. Eligible Jobs preceding it are inherited by the retry and
+	// it and all Jobs following it are re-executed. If not specified, execution
+	// resumes from the first Job that did not succeed.
+	FromJob string
+}
+
+// EventRetryManyOptions represents useful, optional settings for retrying many
+// Events. It currently has no fields, but exists to preserve the possibility
+// of future expansion without having to change client function signatures.
+type EventRetryManyOptions struct{}
+
+// EventCloneManyOptions represents useful, optional settings for cloning many
+// Events. It currently has no fields, but exists to preserve the possibility
+// of future expansion without having to change client function signatures.
+type EventCloneManyOptions struct{}
 
 // EventsClient is the specialized client for managing Events with the Brigade
 // API.
@@ -314,10 +572,25 @@ type EventsClient interface {
 	// discrete Events may be created-- one for each subscribed Project. An
 	// EventList is returned containing all newly created Events.
 	Create(context.Context, Event, *EventCreateOptions) (EventList, error)
+	// CreateDryRun evaluates the Event provided against Projects'
+	// EventSubscriptions exactly as Create() would, but creates no Events.
+	// Instead, an EventCreateDryRunResult is returned indicating which Projects
+	// would have been sent the Event and why others would not. If the Event
+	// provided references a Project by ID, only that Project is evaluated.
+	CreateDryRun(
+		context.Context,
+		Event,
+		*EventCreateDryRunOptions,
+	) (EventCreateDryRunResult, error)
 	// List returns an EventList, with its Items (Events) ordered by age, newest
 	// first. Criteria for which Events should be retrieved can be specified using
 	// the EventsSelector parameter.
 	List(context.Context, *EventsSelector, *meta.ListOptions) (EventList, error)
+	// Stats returns aggregated statistics about Events created within a window
+	// of time. Criteria for which Events should be considered and how
+	// statistics should be grouped can be specified using the
+	// EventStatsOptions parameter.
+	Stats(context.Context, *EventStatsOptions) (EventStats, error)
 	// Get retrieves a single Event specified by its identifier.
 	Get(context.Context, string, *EventGetOptions) (Event, error)
 	// Clones a pre-existing Event, removing the original's metadata and Worker
@@ -362,6 +635,26 @@ type EventsClient interface {
 	// are inherited and the job not re-scheduled, for example when a job has
 	// succeeded and does not make use of a shared workspace.
 	Retry(context.Context, string, *EventRetryOptions) (Event, error)
+	// RetryMany retries multiple Events specified by the EventsSelector
+	// parameter, exactly as Retry would retry each of them individually. The
+	// selector must be qualified by Project and by terminal Worker phase(s) and
+	// may select no more than 100 Events. Failures to retry individual Events
+	// are reported in the result.
+	RetryMany(
+		context.Context,
+		EventsSelector,
+		*EventRetryManyOptions,
+	) (RetryManyEventsResult, error)
+	// CloneMany clones multiple Events specified by the EventsSelector
+	// parameter, exactly as Clone would clone each of them individually. The
+	// selector must be qualified by Project and by Worker phase(s) and may
+	// select no more than 100 Events. Failures to clone individual Events are
+	// reported in the result.
+	CloneMany(
+		context.Context,
+		EventsSelector,
+		*EventCloneManyOptions,
+	) (CloneManyEventsResult, error)
 
 	// Workers returns a specialized client for Worker management.
 	Workers() WorkersClient
@@ -407,6 +700,27 @@ func (e *eventsClient) Create(
 	)
 }
 
+func (e *eventsClient) CreateDryRun(
+	ctx context.Context,
+	event Event,
+	_ *EventCreateDryRunOptions,
+) (EventCreateDryRunResult, error) {
+	result := EventCreateDryRunResult{}
+	return result, e.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method: http.MethodPost,
+			Path:   "v2/events",
+			QueryParams: map[string]string{
+				"dryRun": "true",
+			},
+			ReqBodyObj:  event,
+			SuccessCode: http.StatusOK,
+			RespObj:     &result,
+		},
+	)
+}
+
 func (e *eventsClient) List(
 	ctx context.Context,
 	selector *EventsSelector,
@@ -426,6 +740,41 @@ func (e *eventsClient) List(
 	)
 }
 
+func (e *eventsClient) Stats(
+	ctx context.Context,
+	opts *EventStatsOptions,
+) (EventStats, error) {
+	queryParams := map[string]string{}
+	if opts != nil {
+		if opts.ProjectID != "" {
+			queryParams["projectID"] = opts.ProjectID
+		}
+		if opts.GroupBy != "" {
+			queryParams["groupBy"] = string(opts.GroupBy)
+		}
+		if opts.Since != nil {
+			queryParams["since"] = opts.Since.Format(time.RFC3339)
+		}
+		if opts.Until != nil {
+			queryParams["until"] = opts.Until.Format(time.RFC3339)
+		}
+		if opts.BucketSize != 0 {
+			queryParams["bucketSize"] = opts.BucketSize.String()
+		}
+	}
+	stats := EventStats{}
+	return stats, e.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        "v2/event-stats",
+			QueryParams: queryParams,
+			SuccessCode: http.StatusOK,
+			RespObj:     &stats,
+		},
+	)
+}
+
 func (e *eventsClient) Get(
 	ctx context.Context,
 	id string,
@@ -464,13 +813,18 @@ func (e *eventsClient) UpdateSourceState(
 	ctx context.Context,
 	id string,
 	sourceState SourceState,
-	_ *EventSourceStateUpdateOptions,
+	opts *EventSourceStateUpdateOptions,
 ) error {
+	var headers map[string]string
+	if opts != nil {
+		headers = ifMatchHeaders(opts.ResourceVersion)
+	}
 	return e.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
 			Method:      http.MethodPut,
 			Path:        fmt.Sprintf("v2/events/%s/source-state", id),
+			Headers:     headers,
 			ReqBodyObj:  sourceState,
 			SuccessCode: http.StatusOK,
 		},
@@ -497,13 +851,18 @@ func (e *eventsClient) UpdateSummary(
 func (e *eventsClient) Cancel(
 	ctx context.Context,
 	id string,
-	_ *EventCancelOptions,
+	opts *EventCancelOptions,
 ) error {
+	var reason string
+	if opts != nil {
+		reason = opts.Reason
+	}
 	return e.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
 			Method:      http.MethodPut,
 			Path:        fmt.Sprintf("v2/events/%s/cancellation", id),
+			QueryParams: reasonToQueryParams(reason),
 			SuccessCode: http.StatusOK,
 		},
 	)
@@ -512,9 +871,17 @@ func (e *eventsClient) Cancel(
 func (e *eventsClient) CancelMany(
 	ctx context.Context,
 	selector EventsSelector,
-	_ *EventCancelManyOptions,
+	opts *EventCancelManyOptions,
 ) (CancelManyEventsResult, error) {
 	queryParams := eventsSelectorToQueryParams(&selector)
+	if opts != nil {
+		if opts.Reason != "" {
+			queryParams["reason"] = opts.Reason
+		}
+		if opts.DryRun {
+			queryParams["dryRun"] = "true"
+		}
+	}
 	result := CancelManyEventsResult{}
 	return result, e.ExecuteRequest(
 		ctx,
@@ -531,13 +898,18 @@ func (e *eventsClient) CancelMany(
 func (e *eventsClient) Delete(
 	ctx context.Context,
 	id string,
-	_ *EventDeleteOptions,
+	opts *EventDeleteOptions,
 ) error {
+	var reason string
+	if opts != nil {
+		reason = opts.Reason
+	}
 	return e.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
 			Method:      http.MethodDelete,
 			Path:        fmt.Sprintf("v2/events/%s", id),
+			QueryParams: reasonToQueryParams(reason),
 			SuccessCode: http.StatusOK,
 		},
 	)
@@ -546,9 +918,17 @@ func (e *eventsClient) Delete(
 func (e *eventsClient) DeleteMany(
 	ctx context.Context,
 	selector EventsSelector,
-	_ *EventDeleteManyOptions,
+	opts *EventDeleteManyOptions,
 ) (DeleteManyEventsResult, error) {
 	queryParams := eventsSelectorToQueryParams(&selector)
+	if opts != nil {
+		if opts.Reason != "" {
+			queryParams["reason"] = opts.Reason
+		}
+		if opts.DryRun {
+			queryParams["dryRun"] = "true"
+		}
+	}
 	result := DeleteManyEventsResult{}
 	return result, e.ExecuteRequest(
 		ctx,
@@ -565,20 +945,61 @@ func (e *eventsClient) DeleteMany(
 func (e *eventsClient) Retry(
 	ctx context.Context,
 	id string,
-	_ *EventRetryOptions,
+	opts *EventRetryOptions,
 ) (Event, error) {
+	queryParams := map[string]string{}
+	if opts != nil && opts.FromJob != "" {
+		queryParams["fromJob"] = opts.FromJob
+	}
 	event := Event{}
 	return event, e.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
 			Method:      http.MethodPost,
 			Path:        fmt.Sprintf("v2/events/%s/retries", id),
+			QueryParams: queryParams,
 			SuccessCode: http.StatusCreated,
 			RespObj:     &event,
 		},
 	)
 }
 
+func (e *eventsClient) RetryMany(
+	ctx context.Context,
+	selector EventsSelector,
+	_ *EventRetryManyOptions,
+) (RetryManyEventsResult, error) {
+	result := RetryManyEventsResult{}
+	return result, e.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodPost,
+			Path:        "v2/events/retries",
+			QueryParams: eventsSelectorToQueryParams(&selector),
+			SuccessCode: http.StatusOK,
+			RespObj:     &result,
+		},
+	)
+}
+
+func (e *eventsClient) CloneMany(
+	ctx context.Context,
+	selector EventsSelector,
+	_ *EventCloneManyOptions,
+) (CloneManyEventsResult, error) {
+	result := CloneManyEventsResult{}
+	return result, e.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodPost,
+			Path:        "v2/events/clones",
+			QueryParams: eventsSelectorToQueryParams(&selector),
+			SuccessCode: http.StatusOK,
+			RespObj:     &result,
+		},
+	)
+}
+
 func (e *eventsClient) Workers() WorkersClient {
 	return e.workersClient
 }
@@ -635,5 +1056,32 @@ func eventsSelectorToQueryParams(selector *EventsSelector) map[string]string {
 		}
 		queryParams["workerPhases"] = strings.Join(workerPhaseStrs, ",")
 	}
+	if selector.CreatedAfter != nil {
+		queryParams["createdAfter"] = selector.CreatedAfter.Format(time.RFC3339)
+	}
+	if selector.CreatedBefore != nil {
+		queryParams["createdBefore"] = selector.CreatedBefore.Format(time.RFC3339)
+	}
+	if selector.Commit != "" {
+		queryParams["commit"] = selector.Commit
+	}
+	if selector.Ref != "" {
+		queryParams["ref"] = selector.Ref
+	}
+	if selector.Text != "" {
+		queryParams["text"] = selector.Text
+	}
 	return queryParams
 }
+
+// reasonToQueryParams returns query parameters conveying the provided reason
+// for a cancellation, deletion, or timeout. It returns nil if the reason is
+// empty.
+func reasonToQueryParams(reason string) map[string]string {
+	if reason == "" {
+		return nil
+	}
+	return map[string]string{
+		"reason": reason,
+	}
+}
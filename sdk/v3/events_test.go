@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	rmTesting "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery/testing" // nolint: lll
 	"github.com/brigadecore/brigade/sdk/v3/meta"
@@ -89,6 +90,56 @@ func TestEventsClientCreate(t *testing.T) {
 	require.Equal(t, testEvents, events)
 }
 
+func TestEventsClientCreateDryRun(t *testing.T) {
+	testEvent := Event{
+		Source: "brigade.sh/cli",
+		Type:   "exec",
+	}
+	testResult := EventCreateDryRunResult{
+		Subscribers: []string{"blue-book"},
+		NonSubscribers: []NonSubscriber{
+			{
+				ProjectID: "orange-book",
+				Subscriptions: []SubscriptionMismatch{
+					{
+						Index:   0,
+						Reasons: []string{`type "exec" does not match any of [push]`},
+					},
+				},
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v2/events", r.URL.Path)
+				require.Equal(t, "true", r.URL.Query().Get("dryRun"))
+				bodyBytes, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				event := Event{}
+				err = json.Unmarshal(bodyBytes, &event)
+				require.NoError(t, err)
+				require.Equal(t, testEvent, event)
+				bodyBytes, err = json.Marshal(testResult)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
+	result, err := client.CreateDryRun(
+		context.Background(),
+		testEvent,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, testResult, result)
+}
+
 func TestEventsClientList(t *testing.T) {
 	const testProjectID = "bluebook"
 	const testSource = "foo-gateway"
@@ -178,6 +229,66 @@ func TestEventsClientList(t *testing.T) {
 	})
 }
 
+func TestEventsClientStats(t *testing.T) {
+	since := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	testStats := EventStats{
+		GroupBy:    EventStatsGroupingTime,
+		Since:      since,
+		Until:      until,
+		BucketSize: "6h0m0s",
+		Groups: []EventStatsGroup{
+			{
+				BucketStart: &since,
+				Total:       2,
+				PhaseCounts: map[WorkerPhase]int64{
+					WorkerPhaseSucceeded: 2,
+				},
+				SuccessRate: 1,
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/v2/event-stats", r.URL.Path)
+				require.Equal(t, "blue-book", r.URL.Query().Get("projectID"))
+				require.Equal(t, "TIME", r.URL.Query().Get("groupBy"))
+				require.Equal(
+					t,
+					"2021-05-01T00:00:00Z",
+					r.URL.Query().Get("since"),
+				)
+				require.Equal(
+					t,
+					"2021-05-02T00:00:00Z",
+					r.URL.Query().Get("until"),
+				)
+				require.Equal(t, "6h0m0s", r.URL.Query().Get("bucketSize"))
+				bodyBytes, err := json.Marshal(testStats)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
+	stats, err := client.Stats(
+		context.Background(),
+		&EventStatsOptions{
+			ProjectID:  "blue-book",
+			GroupBy:    EventStatsGroupingTime,
+			Since:      &since,
+			Until:      &until,
+			BucketSize: 6 * time.Hour,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, testStats, stats)
+}
+
 func TestEventsClientGet(t *testing.T) {
 	testEvent := Event{
 		ObjectMeta: meta.ObjectMeta{
@@ -261,6 +372,7 @@ func TestEventsClientUpdateSourceState(t *testing.T) {
 				err = json.Unmarshal(bodyBytes, &sourceState)
 				require.NoError(t, err)
 				require.Equal(t, testSourceState, sourceState)
+				require.Equal(t, "3", r.Header.Get("If-Match"))
 				w.WriteHeader(http.StatusOK)
 				fmt.Fprintln(w, "{}")
 			},
@@ -272,7 +384,9 @@ func TestEventsClientUpdateSourceState(t *testing.T) {
 		context.Background(),
 		testEventID,
 		testSourceState,
-		nil,
+		&EventSourceStateUpdateOptions{
+			ResourceVersion: 3,
+		},
 	)
 	require.NoError(t, err)
 }
@@ -316,6 +430,7 @@ func TestEventsClientUpdateSummary(t *testing.T) {
 
 func TestEventsClientCancel(t *testing.T) {
 	const testEventID = "12345"
+	const testReason = "no longer needed"
 	server := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
@@ -325,13 +440,20 @@ func TestEventsClientCancel(t *testing.T) {
 					fmt.Sprintf("/v2/events/%s/cancellation", testEventID),
 					r.URL.Path,
 				)
+				require.Equal(t, testReason, r.URL.Query().Get("reason"))
 				w.WriteHeader(http.StatusOK)
 			},
 		),
 	)
 	defer server.Close()
 	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
-	err := client.Cancel(context.Background(), testEventID, nil)
+	err := client.Cancel(
+		context.Background(),
+		testEventID,
+		&EventCancelOptions{
+			Reason: testReason,
+		},
+	)
 	require.NoError(t, err)
 }
 
@@ -341,7 +463,9 @@ func TestEventsClientCancelMany(t *testing.T) {
 	const testType = "bar-event"
 	const testWorkerPhase = WorkerPhaseRunning
 	testResult := CancelManyEventsResult{
-		Count: 42,
+		Count:     42,
+		DryRun:    true,
+		SampleIDs: []string{"foo", "bar"},
 	}
 	server := httptest.NewServer(
 		http.HandlerFunc(
@@ -353,6 +477,7 @@ func TestEventsClientCancelMany(t *testing.T) {
 				require.Contains(t, r.URL.Query().Get("sourceState"), "foo=bar")
 				require.Contains(t, r.URL.Query().Get("sourceState"), "bat=baz")
 				require.Equal(t, testType, r.URL.Query().Get("type"))
+				require.Equal(t, "true", r.URL.Query().Get("dryRun"))
 				require.Equal(
 					t,
 					testWorkerPhase,
@@ -379,6 +504,99 @@ func TestEventsClientCancelMany(t *testing.T) {
 			Type:         testType,
 			WorkerPhases: []WorkerPhase{WorkerPhaseRunning},
 		},
+		&EventCancelManyOptions{
+			DryRun: true,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, testResult, result)
+}
+
+func TestEventsClientRetryMany(t *testing.T) {
+	const testProjectID = "bluebook"
+	testResult := RetryManyEventsResult{
+		Count:       1,
+		FailedCount: 1,
+		Items: []EventCopyResult{
+			{
+				SourceEventID: "foo",
+				EventID:       "bar",
+			},
+			{
+				SourceEventID: "bat",
+				Error:         "something went wrong",
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v2/events/retries", r.URL.Path)
+				require.Equal(t, testProjectID, r.URL.Query().Get("projectID"))
+				require.Equal(
+					t,
+					WorkerPhaseFailed,
+					WorkerPhase(r.URL.Query().Get("workerPhases")),
+				)
+				bodyBytes, err := json.Marshal(testResult)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
+	result, err := client.RetryMany(
+		context.Background(),
+		EventsSelector{
+			ProjectID:    testProjectID,
+			WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, testResult, result)
+}
+
+func TestEventsClientCloneMany(t *testing.T) {
+	const testProjectID = "bluebook"
+	testResult := CloneManyEventsResult{
+		Count: 1,
+		Items: []EventCopyResult{
+			{
+				SourceEventID: "foo",
+				EventID:       "bar",
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v2/events/clones", r.URL.Path)
+				require.Equal(t, testProjectID, r.URL.Query().Get("projectID"))
+				require.Equal(
+					t,
+					WorkerPhaseSucceeded,
+					WorkerPhase(r.URL.Query().Get("workerPhases")),
+				)
+				bodyBytes, err := json.Marshal(testResult)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
+	result, err := client.CloneMany(
+		context.Background(),
+		EventsSelector{
+			ProjectID:    testProjectID,
+			WorkerPhases: []WorkerPhase{WorkerPhaseSucceeded},
+		},
 		nil,
 	)
 	require.NoError(t, err)
@@ -457,6 +675,8 @@ func TestEventsClientDeleteMany(t *testing.T) {
 }
 
 func TestEventsSelectorToQueryParams(t *testing.T) {
+	createdAfter := time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := createdAfter.Add(24 * time.Hour)
 	testCases := []struct {
 		name       string
 		selector   *EventsSelector
@@ -485,8 +705,13 @@ func TestEventsSelectorToQueryParams(t *testing.T) {
 					"foo": "bar",
 					"bat": "baz",
 				},
-				Type:         "exec",
-				WorkerPhases: []WorkerPhase{WorkerPhasePending, WorkerPhaseStarting},
+				Type:          "exec",
+				WorkerPhases:  []WorkerPhase{WorkerPhasePending, WorkerPhaseStarting},
+				CreatedAfter:  &createdAfter,
+				CreatedBefore: &createdBefore,
+				Commit:        "1234567",
+				Ref:           "refs/heads/main",
+				Text:          "deploy",
 			},
 			assertions: func(queryParams map[string]string) {
 				qualifiers, ok := queryParams["qualifiers"]
@@ -507,10 +732,15 @@ func TestEventsSelectorToQueryParams(t *testing.T) {
 				require.Equal(
 					t,
 					map[string]string{
-						"projectID":    "blue-book",
-						"source":       "brigade.sh/cli",
-						"type":         "exec",
-						"workerPhases": "PENDING,STARTING",
+						"projectID":     "blue-book",
+						"source":        "brigade.sh/cli",
+						"type":          "exec",
+						"workerPhases":  "PENDING,STARTING",
+						"createdAfter":  "2021-05-01T00:00:00Z",
+						"createdBefore": "2021-05-02T00:00:00Z",
+						"commit":        "1234567",
+						"ref":           "refs/heads/main",
+						"text":          "deploy",
 					},
 					queryParams,
 				)
@@ -540,6 +770,7 @@ func TestEventsClientRetry(t *testing.T) {
 					fmt.Sprintf("/v2/events/%s/retries", testEventID),
 					r.URL.Path,
 				)
+				require.Equal(t, "foo", r.URL.Query().Get("fromJob"))
 				bodyBytes, err := json.Marshal(testEvent)
 				require.NoError(t, err)
 				w.WriteHeader(http.StatusCreated)
@@ -549,7 +780,13 @@ func TestEventsClientRetry(t *testing.T) {
 	)
 	defer server.Close()
 	client := NewEventsClient(server.URL, rmTesting.TestAPIToken, nil)
-	event, err := client.Retry(context.Background(), testEventID, nil)
+	event, err := client.Retry(
+		context.Background(),
+		testEventID,
+		&EventRetryOptions{
+			FromJob: "foo",
+		},
+	)
 	require.NoError(t, err)
 	require.Equal(t, testEvent, event)
 }
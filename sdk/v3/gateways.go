@@ -0,0 +1,211 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	rm "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/brigadecore/brigade/sdk/v3/restmachinery"
+)
+
+// Gateway represents a component that creates Events from some external
+// source. Each Gateway exclusively owns a single Event source. Once a Gateway
+// owns a source, no other principal may create Events from that source.
+type Gateway struct {
+	// ObjectMeta encapsulates Gateway metadata.
+	meta.ObjectMeta `json:"metadata"`
+	// Description is a natural language description of the Gateway's purpose.
+	Description string `json:"description,omitempty"`
+	// Source is the Event source owned by the Gateway. It is unique across all
+	// Gateways.
+	Source string `json:"source,omitempty"`
+	// LastSeen indicates when the Gateway last created an Event. If this field's
+	// value is nil, the Gateway has never created an Event. This field is
+	// read-only.
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+}
+
+// MarshalJSON amends Gateway instances with type metadata so that clients do
+// not need to be concerned with the tedium of doing so.
+func (g Gateway) MarshalJSON() ([]byte, error) {
+	type Alias Gateway
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "Gateway",
+			},
+			Alias: (Alias)(g),
+		},
+	)
+}
+
+// GatewayList is an ordered and pageable list of Gateways.
+type GatewayList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of Gateways.
+	Items []Gateway `json:"items,omitempty"`
+}
+
+// MarshalJSON amends GatewayList instances with type metadata so that clients
+// do not need to be concerned with the tedium of doing so.
+func (g GatewayList) MarshalJSON() ([]byte, error) {
+	type Alias GatewayList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "GatewayList",
+			},
+			Alias: (Alias)(g),
+		},
+	)
+}
+
+// GatewayCreateOptions represents useful, optional settings for the creation
+// of new Gateways. It currently has no fields, but exists to preserve the
+// possibility of future expansion without having to change client function
+// signatures.
+type GatewayCreateOptions struct{}
+
+// GatewayGetOptions represents useful, optional criteria for the retrieval of
+// a Gateway. It currently has no fields, but exists to preserve the
+// possibility of future expansion without having to change client function
+// signatures.
+type GatewayGetOptions struct{}
+
+// GatewayUpdateOptions represents useful, optional settings for updating a
+// Gateway. It currently has no fields, but exists to preserve the possibility
+// of future expansion without having to change client function signatures.
+type GatewayUpdateOptions struct{}
+
+// GatewayDeleteOptions represents useful, optional settings for the deletion
+// of a Gateway. It currently has no fields, but exists to preserve the
+// possibility of future expansion without having to change client function
+// signatures.
+type GatewayDeleteOptions struct{}
+
+// GatewaysClient is the specialized client for managing Gateways with the
+// Brigade API.
+type GatewaysClient interface {
+	// Create creates a new Gateway. The Token returned is the only opportunity
+	// to retrieve the Gateway's token.
+	Create(context.Context, Gateway, *GatewayCreateOptions) (Token, error)
+	// List returns a GatewayList.
+	List(context.Context, *meta.ListOptions) (GatewayList, error)
+	// Get retrieves a single Gateway specified by its identifier.
+	Get(context.Context, string, *GatewayGetOptions) (Gateway, error)
+	// Update updates an existing Gateway's description and source.
+	Update(context.Context, Gateway, *GatewayUpdateOptions) error
+	// Delete deletes a single Gateway specified by its identifier.
+	Delete(context.Context, string, *GatewayDeleteOptions) error
+}
+
+type gatewaysClient struct {
+	*rm.BaseClient
+}
+
+// NewGatewaysClient returns a specialized client for managing Gateways.
+func NewGatewaysClient(
+	apiAddress string,
+	apiToken string,
+	opts *restmachinery.APIClientOptions,
+) GatewaysClient {
+	return &gatewaysClient{
+		BaseClient: rm.NewBaseClient(apiAddress, apiToken, opts),
+	}
+}
+
+func (g *gatewaysClient) Create(
+	ctx context.Context,
+	gateway Gateway,
+	_ *GatewayCreateOptions,
+) (Token, error) {
+	token := Token{}
+	return token, g.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodPost,
+			Path:        "v2/gateways",
+			ReqBodyObj:  gateway,
+			SuccessCode: http.StatusCreated,
+			RespObj:     &token,
+		},
+	)
+}
+
+func (g *gatewaysClient) List(
+	ctx context.Context,
+	opts *meta.ListOptions,
+) (GatewayList, error) {
+	gateways := GatewayList{}
+	return gateways, g.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        "v2/gateways",
+			QueryParams: g.AppendListQueryParams(nil, opts),
+			SuccessCode: http.StatusOK,
+			RespObj:     &gateways,
+		},
+	)
+}
+
+func (g *gatewaysClient) Get(
+	ctx context.Context,
+	id string,
+	_ *GatewayGetOptions,
+) (Gateway, error) {
+	gateway := Gateway{}
+	return gateway, g.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        fmt.Sprintf("v2/gateways/%s", id),
+			SuccessCode: http.StatusOK,
+			RespObj:     &gateway,
+		},
+	)
+}
+
+func (g *gatewaysClient) Update(
+	ctx context.Context,
+	gateway Gateway,
+	_ *GatewayUpdateOptions,
+) error {
+	return g.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodPut,
+			Path:        fmt.Sprintf("v2/gateways/%s", gateway.ID),
+			ReqBodyObj:  gateway,
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (g *gatewaysClient) Delete(
+	ctx context.Context,
+	id string,
+	_ *GatewayDeleteOptions,
+) error {
+	return g.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodDelete,
+			Path:        fmt.Sprintf("v2/gateways/%s", id),
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
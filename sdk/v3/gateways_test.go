@@ -0,0 +1,189 @@
+package sdk
+
+// nolint: lll
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rmTesting "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery/testing"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	metaTesting "github.com/brigadecore/brigade/sdk/v3/meta/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, Gateway{}, "Gateway")
+}
+
+func TestGatewayListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, GatewayList{}, "GatewayList")
+}
+
+func TestNewGatewaysClient(t *testing.T) {
+	client, ok := NewGatewaysClient(
+		rmTesting.TestAPIAddress,
+		rmTesting.TestAPIToken,
+		nil,
+	).(*gatewaysClient)
+	require.True(t, ok)
+	rmTesting.RequireBaseClient(t, client.BaseClient)
+}
+
+func TestGatewaysClientCreate(t *testing.T) {
+	testGateway := Gateway{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "github",
+		},
+		Source: "brigade.sh/github",
+	}
+	testGatewayToken := Token{
+		Value: "opensesame",
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v2/gateways", r.URL.Path)
+				bodyBytes, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				gateway := Gateway{}
+				err = json.Unmarshal(bodyBytes, &gateway)
+				require.NoError(t, err)
+				require.Equal(t, testGateway, gateway)
+				bodyBytes, err = json.Marshal(testGatewayToken)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewGatewaysClient(server.URL, rmTesting.TestAPIToken, nil)
+	token, err := client.Create(context.Background(), testGateway, nil)
+	require.NoError(t, err)
+	require.Equal(t, testGatewayToken, token)
+}
+
+func TestGatewaysClientList(t *testing.T) {
+	testGateways := GatewayList{
+		Items: []Gateway{
+			{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "github",
+				},
+			},
+			{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "slack",
+				},
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/v2/gateways", r.URL.Path)
+				bodyBytes, err := json.Marshal(testGateways)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewGatewaysClient(server.URL, rmTesting.TestAPIToken, nil)
+	gateways, err := client.List(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, testGateways, gateways)
+}
+
+func TestGatewaysClientGet(t *testing.T) {
+	testGateway := Gateway{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "github",
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/gateways/%s", testGateway.ID),
+					r.URL.Path,
+				)
+				w.WriteHeader(http.StatusOK)
+				bodyBytes, err := json.Marshal(testGateway)
+				require.NoError(t, err)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewGatewaysClient(server.URL, rmTesting.TestAPIToken, nil)
+	gateway, err := client.Get(context.Background(), testGateway.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, testGateway, gateway)
+}
+
+func TestGatewaysClientUpdate(t *testing.T) {
+	testGateway := Gateway{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "github",
+		},
+		Description: "Receives webhooks from GitHub",
+		Source:      "brigade.sh/github",
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				require.Equal(t, http.MethodPut, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/gateways/%s", testGateway.ID),
+					r.URL.Path,
+				)
+				bodyBytes, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				gateway := Gateway{}
+				err = json.Unmarshal(bodyBytes, &gateway)
+				require.NoError(t, err)
+				require.Equal(t, testGateway, gateway)
+				w.WriteHeader(http.StatusOK)
+			},
+		),
+	)
+	defer server.Close()
+	client := NewGatewaysClient(server.URL, rmTesting.TestAPIToken, nil)
+	err := client.Update(context.Background(), testGateway, nil)
+	require.NoError(t, err)
+}
+
+func TestGatewaysClientDelete(t *testing.T) {
+	const testGatewayID = "github"
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/gateways/%s", testGatewayID),
+					r.URL.Path,
+				)
+				w.WriteHeader(http.StatusOK)
+			},
+		),
+	)
+	defer server.Close()
+	client := NewGatewaysClient(server.URL, rmTesting.TestAPIToken, nil)
+	err := client.Delete(context.Background(), testGatewayID, nil)
+	require.NoError(t, err)
+}
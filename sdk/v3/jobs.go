@@ -97,6 +97,10 @@ type Job struct {
 	Spec JobSpec `json:"spec"`
 	// Status contains details of the Job's current state.
 	Status *JobStatus `json:"status,omitempty"`
+	// Timeline is a chronological record of every phase the Job has
+	// transitioned through. This is recorded by the system. Clients must leave
+	// the value of this field set to nil when using the API to create a Job.
+	Timeline []PhaseTransition `json:"timeline,omitempty"`
 }
 
 // MarshalJSON amends Job instances with type metadata so that clients do not
@@ -123,14 +127,18 @@ type JobSpec struct {
 	// cornerstone of the Job. Job success or failure is tied to completion and
 	// exit code of this container.
 	PrimaryContainer JobContainerSpec `json:"primaryContainer"`
+	// InitContainers specifies the details of OCI containers that run to
+	// completion, one at a time and in the order specified, before any of the
+	// Job's other containers are started. If any of them fails, the Job fails.
+	InitContainers []JobInitContainerSpec `json:"initContainers,omitempty"`
 	// SidecarContainers specifies the details of supplemental, "sidecar"
 	// containers. Their completion and exit code do not directly impact Job
-	// status. Brigade does not understand dependencies between a Job's multiple
-	// containers and cannot enforce any specific startup or shutdown order. When
-	// such dependencies exist (for instance, a primary container than cannot
-	// proceed with a suite of tests until a database is launched and READY in a
-	// sidecar container), then logic within those containers must account for
-	// these constraints.
+	// status. Sidecar containers are started before the primary container. When
+	// the primary container depends on a sidecar (for instance, a primary
+	// container that cannot proceed with a suite of tests until a database is
+	// launched and READY in a sidecar container), that sidecar should specify a
+	// ReadinessProbe. The primary container is then not started until the
+	// sidecar is ready. Brigade does not enforce any shutdown order.
 	SidecarContainers map[string]JobContainerSpec `json:"sidecarContainers,omitempty"` // nolint: lll
 	// TimeoutDuration specifies the time duration that must elapse before a
 	// running Job should be considered to have timed out. This duration string
@@ -143,6 +151,10 @@ type JobSpec struct {
 	// non-default operating system (i.e. Windows) or specific hardware (e.g. a
 	// GPU.)
 	Host *JobHost `json:"host,omitempty"`
+	// Volumes specifies, by name, volumes in addition to the shared workspace
+	// and source code that may be mounted into any of the Job's containers using
+	// each container's VolumeMounts.
+	Volumes map[string]JobVolume `json:"volumes,omitempty"`
 }
 
 // JobContainerSpec amends the ContainerSpec type with additional Job-specific
@@ -168,6 +180,18 @@ type JobContainerSpec struct {
 	// for the container, but that may be disallowed by Project-level
 	// configuration.
 	Privileged bool `json:"privileged"`
+	// VolumeMounts specifies which of the volumes declared in the JobSpec should
+	// be mounted into the OCI container and where.
+	VolumeMounts []JobVolumeMount `json:"volumeMounts,omitempty"`
+	// SecretRefs specifies which of the Project's secrets should be made
+	// available to the OCI container and how. Unlike values in Environment, the
+	// values of these secrets are never included in the Job's specification.
+	SecretRefs []SecretRef `json:"secretRefs,omitempty"`
+	// ReadinessProbe optionally specifies how to determine whether a sidecar
+	// container is ready. When specified, the Job's primary container is not
+	// started until the sidecar is ready, and the Job fails if the sidecar does
+	// not become ready. This is applicable only to sidecar containers.
+	ReadinessProbe *ReadinessProbe `json:"readinessProbe,omitempty"`
 	// UseHostDockerSocket indicates whether the OCI container should mount the
 	// host's Docker socket into its own file system. This is commonly used to
 	// effect "Docker-out-of-Docker" ("DooD") scenarios wherein one of a Job's OCI
@@ -186,6 +210,69 @@ type JobContainerSpec struct {
 	// UseHostDockerSocket bool `json:"useHostDockerSocket"`
 }
 
+// JobInitContainerSpec amends the JobContainerSpec type with a name, since,
+// unlike sidecar containers, init containers are declared in an ordered list.
+type JobInitContainerSpec struct {
+	// Name is the init container's name. It must be unique among all of a Job's
+	// containers.
+	Name string `json:"name"`
+	// JobContainerSpec encapsulates Job-specific specifications for an OCI
+	// container.
+	JobContainerSpec `json:",inline"`
+}
+
+// ReadinessProbe describes how to determine whether a sidecar container is
+// ready. Exactly one of Exec, TCPSocket, or HTTPGet must be non-nil.
+type ReadinessProbe struct {
+	// Exec specifies a command to execute within the sidecar container. The
+	// sidecar is ready once the command exits with a zero exit code.
+	Exec *ExecProbe `json:"exec,omitempty"`
+	// TCPSocket specifies a port on which the sidecar container accepts TCP
+	// connections. The sidecar is ready once a connection can be opened.
+	TCPSocket *TCPSocketProbe `json:"tcpSocket,omitempty"`
+	// HTTPGet specifies an HTTP endpoint exposed by the sidecar container. The
+	// sidecar is ready once a GET request to that endpoint returns a status code
+	// greater than or equal to 200 and less than 400.
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+	// InitialDelaySeconds specifies the number of seconds to wait after the
+	// sidecar container has started before probing it for the first time.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	// PeriodSeconds specifies the number of seconds to wait between attempts to
+	// probe the sidecar container. When zero, the default is 5 seconds.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds specifies the number of seconds after which a single
+	// attempt to probe the sidecar container times out. When zero, the default
+	// is 1 second.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold specifies the number of consecutive failed attempts to
+	// probe the sidecar container after which the sidecar is considered to have
+	// failed to become ready, in which case the Job fails. When zero, the default
+	// is 12.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// ExecProbe describes a command-based readiness probe.
+type ExecProbe struct {
+	// Command is the command to execute within the container. Only the first
+	// element is the actual command. Subsequent elements are treated as
+	// arguments. The command is not run in a shell.
+	Command []string `json:"command"`
+}
+
+// TCPSocketProbe describes a TCP-based readiness probe.
+type TCPSocketProbe struct {
+	// Port is the port on which the container accepts TCP connections.
+	Port int32 `json:"port"`
+}
+
+// HTTPGetProbe describes an HTTP-based readiness probe.
+type HTTPGetProbe struct {
+	// Path is the path of the HTTP endpoint. When empty, "/" is assumed.
+	Path string `json:"path,omitempty"`
+	// Port is the port on which the container accepts HTTP requests.
+	Port int32 `json:"port"`
+}
+
 // JobHost represents criteria for selecting a suitable host (substrate node)
 // for a Job.
 type JobHost struct {
@@ -199,6 +286,108 @@ type JobHost struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
+// StorageMedium represents the type of storage backing an EmptyDirVolume.
+type StorageMedium string
+
+const (
+	// StorageMediumDefault represents storage backed by whatever medium backs
+	// the substrate node's own file system.
+	StorageMediumDefault StorageMedium = ""
+	// StorageMediumMemory represents storage backed by RAM (tmpfs). Files
+	// written to such a volume count against the memory available to the Job.
+	StorageMediumMemory StorageMedium = "Memory"
+)
+
+// JobVolume represents a volume that may be mounted into any of a Job's
+// containers. Exactly one of its fields must be non-nil.
+type JobVolume struct {
+	// EmptyDir specifies an initially empty scratch directory that exists for
+	// the lifetime of the Job. This is useful for sharing files between a Job's
+	// primary container and its sidecars.
+	EmptyDir *EmptyDirVolume `json:"emptyDir,omitempty"`
+	// ProjectSecret specifies that some or all of the Project's secrets should
+	// be made available as files.
+	ProjectSecret *ProjectSecretVolume `json:"projectSecret,omitempty"`
+	// ConfigFiles specifies files whose contents are provided inline.
+	ConfigFiles *ConfigFilesVolume `json:"configFiles,omitempty"`
+}
+
+// EmptyDirVolume represents an initially empty scratch directory that exists
+// for the lifetime of a Job.
+type EmptyDirVolume struct {
+	// Medium specifies the type of storage backing the directory. When empty,
+	// the substrate node's default medium is used.
+	Medium StorageMedium `json:"medium,omitempty"`
+	// SizeLimit optionally specifies the maximum amount of storage the
+	// directory may use, expressed as a quantity such as "500Mi" or "1Gi".
+	SizeLimit string `json:"sizeLimit,omitempty"`
+}
+
+// ProjectSecretVolume represents a volume containing a Project's secrets as
+// files.
+type ProjectSecretVolume struct {
+	// Items optionally specifies which of the Project's secrets should be
+	// included in the volume and the relative paths at which they should appear.
+	// When empty, every one of the Project's secrets is included, each in a file
+	// named after the secret's key.
+	Items []ProjectSecretItem `json:"items,omitempty"`
+}
+
+// ProjectSecretItem maps a single Project secret to a file.
+type ProjectSecretItem struct {
+	// Key is the key of the Project secret.
+	Key string `json:"key"`
+	// Path is the path, relative to the volume's mount path, of the file that
+	// should contain the Project secret's value.
+	Path string `json:"path"`
+}
+
+// ConfigFilesVolume represents a volume containing files whose contents are
+// provided inline. Since a Job's specification is readable by anyone with read
+// access to its Event, these files should not contain sensitive information.
+// Use a ProjectSecretVolume for that instead.
+type ConfigFilesVolume struct {
+	// Files specifies the files that should appear in the volume.
+	Files []ConfigFile `json:"files"`
+}
+
+// ConfigFile represents a single file in a ConfigFilesVolume.
+type ConfigFile struct {
+	// Path is the path of the file, relative to the volume's mount path.
+	Path string `json:"path"`
+	// Content is the content of the file.
+	Content string `json:"content"`
+}
+
+// JobVolumeMount represents the mounting of one of a Job's volumes into one of
+// its containers.
+type JobVolumeMount struct {
+	// Name is the name of the volume to mount. It must match the name of one of
+	// the volumes declared in the JobSpec.
+	Name string `json:"name"`
+	// MountPath is the path in the OCI container's file system where the volume
+	// should be mounted.
+	MountPath string `json:"mountPath"`
+	// ReadOnly indicates whether the volume should be mounted read-only.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// SecretRef makes the value of one of a Project's secrets available to one of
+// a Job's containers, either as an environment variable or as a file. Unlike
+// values in a container's Environment, the secret's value is never included in
+// the Job's specification. It is resolved by the substrate when the Job's
+// containers are created. Exactly one of EnvVar or Path must be non-empty.
+type SecretRef struct {
+	// Key is the key of the Project secret.
+	Key string `json:"key"`
+	// EnvVar is the name of the environment variable that should contain the
+	// Project secret's value.
+	EnvVar string `json:"envVar,omitempty"`
+	// Path is the absolute path, in the OCI container's file system, of a
+	// read-only file that should contain the Project secret's value.
+	Path string `json:"path,omitempty"`
+}
+
 // JobStatus represents the status of a Job.
 type JobStatus struct {
 	// Started indicates the time the Job began execution.
@@ -208,6 +397,11 @@ type JobStatus struct {
 	Ended *time.Time `json:"ended,omitempty"`
 	// Phase indicates where the Job is in its lifecycle.
 	Phase JobPhase `json:"phase,omitempty"`
+	// Reason is an optional, human-readable explanation of why the Job timed
+	// out or failed, e.g. because a sidecar container never became ready. This
+	// is recorded by the system. Other clients must leave this field empty when
+	// updating a Job's status.
+	Reason string `json:"reason,omitempty"`
 }
 
 // MarshalJSON amends JobStatus instances with type metadata so that clients do
@@ -262,9 +456,11 @@ type JobStatusUpdateOptions struct{}
 type JobCleanupOptions struct{}
 
 // JobTimeoutOptions represents useful, optional settings for timing out a Job.
-// It currently has no fields, but exists to preserve the possibility of future
-// expansion without having to change client function signatures.
-type JobTimeoutOptions struct{}
+type JobTimeoutOptions struct {
+	// Reason is an optional, human-readable explanation of why the Job is being
+	// timed out. It is recorded in the Job's status.
+	Reason string
+}
 
 // JobsClient is the specialized client for managing Event Jobs with the
 // Brigade API.
@@ -480,8 +676,12 @@ func (j *jobsClient) Timeout(
 	ctx context.Context,
 	eventID,
 	jobName string,
-	_ *JobTimeoutOptions,
+	opts *JobTimeoutOptions,
 ) error {
+	var reason string
+	if opts != nil {
+		reason = opts.Reason
+	}
 	return j.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
@@ -491,6 +691,7 @@ func (j *jobsClient) Timeout(
 				eventID,
 				jobName,
 			),
+			QueryParams: reasonToQueryParams(reason),
 			SuccessCode: http.StatusOK,
 		},
 	)
@@ -6,18 +6,47 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	rm "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
 	"github.com/brigadecore/brigade/sdk/v3/restmachinery"
 )
 
+// LogStream represents one of the standard streams an OCI container writes
+// its output to.
+type LogStream string
+
+const (
+	// LogStreamStdout represents a container's standard output.
+	LogStreamStdout LogStream = "stdout"
+	// LogStreamStderr represents a container's standard error.
+	LogStreamStderr LogStream = "stderr"
+)
+
 // LogEntry represents one line of output from an OCI container.
 type LogEntry struct {
 	// Time is the time the line was written.
 	Time *time.Time `json:"time,omitempty"`
 	// Message is a single line of log output from an OCI container.
 	Message string `json:"message,omitempty"`
+	// Stream is the stream the line was written to. It is empty if that could
+	// not be determined.
+	Stream LogStream `json:"stream,omitempty"`
+	// Level is the level of a line that is a JSON object having a "level",
+	// "lvl", or "severity" field. It is normalized to lower case.
+	Level string `json:"level,omitempty"`
+	// Fields are the fields, other than the level, of a line that is a JSON
+	// object.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Job is the name of the Job whose container wrote the line. It is empty for
+	// lines written by the Worker's containers. It is only guaranteed to be set
+	// when logs are streamed from all containers.
+	Job string `json:"job,omitempty"`
+	// Container is the name of the container that wrote the line. It is only
+	// guaranteed to be set when logs are streamed from all containers.
+	Container string `json:"container,omitempty"`
 }
 
 // LogsSelector represents useful criteria for selecting logs to be streamed
@@ -32,6 +61,11 @@ type LogsSelector struct {
 	// presume logs are desired from a container having the same name as the
 	// selected Worker or Job.
 	Container string
+	// All specifies that logs should be streamed from every container of the
+	// Worker and of every Job that has started. It is mutually exclusive with Job
+	// and Container. Entries from all containers are interleaved and each is
+	// tagged with the Job and Container it came from.
+	All bool
 }
 
 // LogStreamOptions represents useful options for streaming logs from some
@@ -42,6 +76,92 @@ type LogStreamOptions struct {
 	// until closed by the client (true), continuing to send new lines as they
 	// become available.
 	Follow bool `json:"follow"`
+	// TailLines, if greater than zero, specifies that only the specified number
+	// of most recent lines available when the stream is opened should be sent,
+	// followed by any new lines if Follow is true.
+	TailLines int64 `json:"tailLines,omitempty"`
+	// Stream, if non-empty, specifies that only lines written to the specified
+	// stream should be sent.
+	Stream LogStream `json:"stream,omitempty"`
+	// Since, if non-nil, specifies that only lines written at or after the
+	// specified time should be sent.
+	Since *time.Time `json:"since,omitempty"`
+	// Until, if non-nil, specifies that only lines written before the specified
+	// time should be sent. The stream concludes once such a line is encountered,
+	// even if Follow is true.
+	Until *time.Time `json:"until,omitempty"`
+	// Unredacted specifies that the values of the Project's Secrets should NOT
+	// be redacted from the logs. Only admins may request this.
+	Unredacted bool `json:"unredacted,omitempty"`
+}
+
+// LogsSearchSelector represents useful criteria for selecting log entries
+// when searching a Project's logs. Exactly one of Text or Regex must be
+// specified. All other criteria are optional.
+type LogsSearchSelector struct {
+	// Text specifies words or phrases that selected log entries must contain.
+	// Matching uses the data store's full text search, so words are matched
+	// regardless of case or word ending.
+	Text string
+	// Regex specifies a regular expression that selected log entries must
+	// match.
+	Regex string
+	// Job specifies that only log entries written by containers of the Job
+	// with the specified name should be selected.
+	Job string
+	// Container specifies that only log entries written by containers with the
+	// specified name should be selected.
+	Container string
+	// WrittenSince specifies that only log entries written at or after the
+	// specified time should be selected. This bounds when individual lines were
+	// written, not when the Events that wrote them were created.
+	WrittenSince *time.Time
+	// WrittenUntil specifies that only log entries written before the specified
+	// time should be selected. This bounds when individual lines were written,
+	// not when the Events that wrote them were created.
+	WrittenUntil *time.Time
+}
+
+// LogSearchResult represents one line of output that matched a log search,
+// along with the Event, Job, and container that wrote it.
+type LogSearchResult struct {
+	// Time is the time the line was written.
+	Time *time.Time `json:"time,omitempty"`
+	// EventID is the identifier of the Event whose Worker or Job wrote the line.
+	EventID string `json:"eventID,omitempty"`
+	// Job is the name of the Job whose container wrote the line. It is empty for
+	// lines written by the Worker's containers.
+	Job string `json:"job,omitempty"`
+	// Container is the name of the container that wrote the line.
+	Container string `json:"container,omitempty"`
+	// Message is a single line of log output from an OCI container.
+	Message string `json:"message,omitempty"`
+}
+
+// LogSearchResultList is an ordered and pageable list of LogSearchResults.
+type LogSearchResultList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of LogSearchResults.
+	Items []LogSearchResult `json:"items,omitempty"`
+}
+
+// MarshalJSON amends LogSearchResultList instances with type metadata so that
+// clients do not need to be concerned with the tedium of doing so.
+func (l LogSearchResultList) MarshalJSON() ([]byte, error) {
+	type Alias LogSearchResultList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "LogSearchResultList",
+			},
+			Alias: (Alias)(l),
+		},
+	)
 }
 
 // LogsClient is the specialized client for managing Logs with the Brigade API.
@@ -55,6 +175,24 @@ type LogsClient interface {
 		selector *LogsSelector,
 		opts *LogStreamOptions,
 	) (<-chan LogEntry, <-chan error, error)
+	// Archive returns a gzipped tarball of the logs of every container of an
+	// Event's Worker and of every Job that has started, including Jobs
+	// inherited from another Event. Each container's logs are a separate file
+	// within the archive. If some logs could not be retrieved in full, the
+	// archive also includes an ERRORS.txt file listing the files that may be
+	// incomplete or missing. Callers MUST close the returned io.ReadCloser.
+	Archive(ctx context.Context, eventID string) (io.ReadCloser, error)
+	// Search returns a LogSearchResultList, with its Items (LogSearchResults)
+	// ordered by time, newest first, of log entries written by any Worker or Job
+	// belonging to the specified Project. Criteria for which log entries should
+	// be retrieved are specified using the LogsSearchSelector parameter. Logs
+	// that have been moved to object storage are not searched.
+	Search(
+		ctx context.Context,
+		projectID string,
+		selector *LogsSearchSelector,
+		opts *meta.ListOptions,
+	) (LogSearchResultList, error)
 }
 
 type logsClient struct {
@@ -86,9 +224,29 @@ func (l *logsClient) Stream(
 		if selector.Container != "" {
 			queryParams["container"] = selector.Container
 		}
+		if selector.All {
+			queryParams["all"] = trueStr
+		}
 	}
-	if opts != nil && opts.Follow {
-		queryParams["follow"] = trueStr
+	if opts != nil {
+		if opts.Follow {
+			queryParams["follow"] = trueStr
+		}
+		if opts.TailLines > 0 {
+			queryParams["tailLines"] = strconv.FormatInt(opts.TailLines, 10)
+		}
+		if opts.Stream != "" {
+			queryParams["stream"] = string(opts.Stream)
+		}
+		if opts.Since != nil {
+			queryParams["since"] = opts.Since.Format(time.RFC3339)
+		}
+		if opts.Until != nil {
+			queryParams["until"] = opts.Until.Format(time.RFC3339)
+		}
+		if opts.Unredacted {
+			queryParams["unredacted"] = trueStr
+		}
 	}
 
 	resp, err := l.SubmitRequest( // nolint: bodyclose
@@ -144,3 +302,63 @@ func (l *logsClient) receiveStream(
 		}
 	}
 }
+
+func (l *logsClient) Archive(
+	ctx context.Context,
+	eventID string,
+) (io.ReadCloser, error) {
+	resp, err := l.SubmitRequest( // nolint: bodyclose
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        fmt.Sprintf("v2/events/%s/logs/archive", eventID),
+			SuccessCode: http.StatusOK,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (l *logsClient) Search(
+	ctx context.Context,
+	projectID string,
+	selector *LogsSearchSelector,
+	opts *meta.ListOptions,
+) (LogSearchResultList, error) {
+	queryParams := map[string]string{}
+	if selector != nil {
+		if selector.Text != "" {
+			queryParams["text"] = selector.Text
+		}
+		if selector.Regex != "" {
+			queryParams["regex"] = selector.Regex
+		}
+		if selector.Job != "" {
+			queryParams["job"] = selector.Job
+		}
+		if selector.Container != "" {
+			queryParams["container"] = selector.Container
+		}
+		if selector.WrittenSince != nil {
+			queryParams["writtenSince"] =
+				selector.WrittenSince.Format(time.RFC3339)
+		}
+		if selector.WrittenUntil != nil {
+			queryParams["writtenUntil"] =
+				selector.WrittenUntil.Format(time.RFC3339)
+		}
+	}
+	results := LogSearchResultList{}
+	return results, l.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        fmt.Sprintf("v2/projects/%s/logs/search", projectID),
+			QueryParams: l.AppendListQueryParams(queryParams, opts),
+			SuccessCode: http.StatusOK,
+			RespObj:     &results,
+		},
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -11,9 +12,19 @@ import (
 	"time"
 
 	rmTesting "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery/testing" // nolint: lll
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	metaTesting "github.com/brigadecore/brigade/sdk/v3/meta/testing"
 	"github.com/stretchr/testify/require"
 )
 
+func TestLogSearchResultListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(
+		t,
+		LogSearchResultList{},
+		"LogSearchResultList",
+	)
+}
+
 func TestNewLogsClient(t *testing.T) {
 	client, ok := NewLogsClient(
 		rmTesting.TestAPIAddress,
@@ -36,6 +47,7 @@ func TestLogsClientStream(t *testing.T) {
 	testLogEntry := LogEntry{
 		Message: "Captain's log, Stardate 41153.7. Our destination is Planet " +
 			"Deneb IV, beyond which lies the great unexplored mass of the galaxy...",
+		Stream: LogStreamStdout,
 	}
 
 	t.Run("nil logs selector", func(t *testing.T) {
@@ -86,6 +98,101 @@ func TestLogsClientStream(t *testing.T) {
 		}
 	})
 
+	t.Run("tail, stream, time range, and unredacted options", func(t *testing.T) {
+		since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+		until := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+		server := httptest.NewServer(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(t, "100", r.URL.Query().Get("tailLines"))
+					require.Equal(t, "stderr", r.URL.Query().Get("stream"))
+					require.Equal(
+						t,
+						since.Format(time.RFC3339),
+						r.URL.Query().Get("since"),
+					)
+					require.Equal(
+						t,
+						until.Format(time.RFC3339),
+						r.URL.Query().Get("until"),
+					)
+					require.Equal(t, trueStr, r.URL.Query().Get("unredacted"))
+					bodyBytes, err := json.Marshal(testLogEntry)
+					require.NoError(t, err)
+					w.Header().Set("Content-Type", "text/event-stream")
+					flusher, ok := w.(http.Flusher)
+					require.True(t, ok)
+					flusher.Flush()
+					fmt.Fprintln(w, string(bodyBytes))
+					flusher.Flush()
+				},
+			),
+		)
+		defer server.Close()
+		client := NewLogsClient(server.URL, rmTesting.TestAPIToken, nil)
+		logsCh, _, err := client.Stream(
+			context.Background(),
+			testEventID,
+			nil,
+			&LogStreamOptions{
+				TailLines:  100,
+				Stream:     LogStreamStderr,
+				Since:      &since,
+				Until:      &until,
+				Unredacted: true,
+			},
+		)
+		require.NoError(t, err)
+		select {
+		case logEntry := <-logsCh:
+			require.Equal(t, testLogEntry, logEntry)
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "timed out waiting for logs")
+		}
+	})
+
+	t.Run("all containers", func(t *testing.T) {
+		taggedLogEntry := LogEntry{
+			Message:   testLogEntry.Message,
+			Job:       "farpoint",
+			Container: "enterprise",
+		}
+		server := httptest.NewServer(
+			http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					require.Equal(t, trueStr, r.URL.Query().Get("all"))
+					require.Empty(t, r.URL.Query().Get("job"))
+					require.Empty(t, r.URL.Query().Get("container"))
+					bodyBytes, err := json.Marshal(taggedLogEntry)
+					require.NoError(t, err)
+					w.Header().Set("Content-Type", "text/event-stream")
+					flusher, ok := w.(http.Flusher)
+					require.True(t, ok)
+					flusher.Flush()
+					fmt.Fprintln(w, string(bodyBytes))
+					flusher.Flush()
+				},
+			),
+		)
+		defer server.Close()
+		client := NewLogsClient(server.URL, rmTesting.TestAPIToken, nil)
+		logsCh, _, err := client.Stream(
+			context.Background(),
+			testEventID,
+			&LogsSelector{
+				All: true,
+			},
+			nil,
+		)
+		require.NoError(t, err)
+		select {
+		case logEntry := <-logsCh:
+			require.Equal(t, taggedLogEntry, logEntry)
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "timed out waiting for logs")
+		}
+	})
+
 	t.Run("non-nil logs selector", func(t *testing.T) {
 		server := httptest.NewServer(
 			http.HandlerFunc(
@@ -139,3 +246,86 @@ func TestLogsClientStream(t *testing.T) {
 		}
 	})
 }
+
+func TestLogsClientArchive(t *testing.T) {
+	const testEventID = "12345"
+	const testArchive = "not really a tarball"
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/events/%s/logs/archive", testEventID),
+					r.URL.Path,
+				)
+				w.Header().Set("Content-Type", "application/gzip")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprint(w, testArchive)
+			},
+		),
+	)
+	defer server.Close()
+	client := NewLogsClient(server.URL, rmTesting.TestAPIToken, nil)
+	archive, err := client.Archive(context.Background(), testEventID)
+	require.NoError(t, err)
+	defer archive.Close()
+	archiveBytes, err := io.ReadAll(archive)
+	require.NoError(t, err)
+	require.Equal(t, testArchive, string(archiveBytes))
+}
+
+func TestLogsClientSearch(t *testing.T) {
+	const testProjectID = "italian"
+	testSince := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testResults := LogSearchResultList{
+		Items: []LogSearchResult{
+			{
+				EventID:   "12345",
+				Job:       "farpoint",
+				Container: "enterprise",
+				Message:   "Red alert!",
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/projects/%s/logs/search", testProjectID),
+					r.URL.Path,
+				)
+				require.Equal(t, "alert", r.URL.Query().Get("text"))
+				require.Equal(t, "farpoint", r.URL.Query().Get("job"))
+				require.Equal(
+					t,
+					testSince.Format(time.RFC3339),
+					r.URL.Query().Get("writtenSince"),
+				)
+				require.Equal(t, "10", r.URL.Query().Get("limit"))
+				bodyBytes, err := json.Marshal(testResults)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewLogsClient(server.URL, rmTesting.TestAPIToken, nil)
+	results, err := client.Search(
+		context.Background(),
+		testProjectID,
+		&LogsSearchSelector{
+			Text:         "alert",
+			Job:          "farpoint",
+			WrittenSince: &testSince,
+		},
+		&meta.ListOptions{
+			Limit: 10,
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, testResults, results)
+}
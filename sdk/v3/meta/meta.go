@@ -26,6 +26,12 @@ type ObjectMeta struct {
 	// recorded by the system. Clients must leave the value of this field set to
 	// nil when using the API to create or update resources.
 	Created *time.Time `json:"created,omitempty"`
+	// ResourceVersion is recorded by the system for resource types that support
+	// optimistic concurrency. It changes each time the portions of a resource
+	// that clients may update are changed. Clients may supply a previously
+	// obtained value when updating such a resource to ensure they do not
+	// overwrite changes made by others in the interim.
+	ResourceVersion int64 `json:"resourceVersion,omitempty"`
 }
 
 // ListMeta is metadata for ordered collections of resources.
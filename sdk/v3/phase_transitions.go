@@ -0,0 +1,18 @@
+package sdk
+
+import "time"
+
+// PhaseTransition records a single change in the phase of a Worker or Job.
+type PhaseTransition struct {
+	// Phase is the phase that was transitioned to. This will be either a
+	// WorkerPhase or a JobPhase, depending on what transitioned.
+	Phase string `json:"phase"`
+	// Time indicates when the transition occurred.
+	Time time.Time `json:"time"`
+	// Principal references the principal that effected the transition. It will
+	// be nil for transitions effected by Brigade's own internal components, such
+	// as the scheduler or observer.
+	Principal *PrincipalReference `json:"principal,omitempty"`
+	// Reason is an optional, human-readable explanation of the transition.
+	Reason string `json:"reason,omitempty"`
+}
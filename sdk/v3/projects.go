@@ -98,8 +98,44 @@ type ProjectSpec struct {
 	// EventSubscriptions defines a set of trigger conditions under which a new
 	// Worker should be created.
 	EventSubscriptions []EventSubscription `json:"eventSubscriptions,omitempty"`
+	// EventInputs declares typed inputs accepted by Events of specific sources
+	// and types. Inputs supplied with such Events are validated against these
+	// declarations when the Events are created.
+	EventInputs []EventInputs `json:"eventInputs,omitempty"`
 	// WorkerTemplate is a prototypical WorkerSpec.
 	WorkerTemplate WorkerSpec `json:"workerTemplate"`
+	// RetentionPolicy optionally specifies rules for automatically pruning the
+	// Project's Events once their Workers have reached a terminal phase. Rules
+	// specified here override the corresponding system-wide rules.
+	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty"`
+	// SecretPolicies optionally specifies policies governing how the Project's
+	// secrets are made available to its Workers and Jobs.
+	SecretPolicies *SecretPolicies `json:"secretPolicies,omitempty"`
+}
+
+// RetentionPolicy specifies rules for automatically pruning Events whose
+// Workers have reached a terminal phase. Rules for Events whose Workers
+// succeeded are kept separately from rules for Events whose Workers did not.
+type RetentionPolicy struct {
+	// Succeeded specifies how long and/or how many Events whose Workers have
+	// SUCCEEDED should be retained.
+	Succeeded *RetentionRule `json:"succeeded,omitempty"`
+	// Failed specifies how long and/or how many Events whose Workers have
+	// reached any terminal phase other than SUCCEEDED (i.e. ABORTED, CANCELED,
+	// FAILED, SCHEDULING_FAILED, or TIMED_OUT) should be retained.
+	Failed *RetentionRule `json:"failed,omitempty"`
+}
+
+// RetentionRule specifies limits on the age and/or number of Events that
+// should be retained. When both are specified, an Event is pruned when it
+// exceeds EITHER limit.
+type RetentionRule struct {
+	// MaxAge optionally specifies the age, expressed as a duration string (e.g.
+	// "720h"), beyond which Events are pruned.
+	MaxAge string `json:"maxAge,omitempty"`
+	// MaxCount optionally specifies how many of the most recent Events are
+	// retained. Older Events are pruned.
+	MaxCount int64 `json:"maxCount,omitempty"`
 }
 
 // EventSubscription defines a set of Events of interest. ProjectSpecs utilize
@@ -112,9 +148,12 @@ type EventSubscription struct {
 	Source string `json:"source,omitempty"`
 	// Types enumerates specific Events of interest from the specified Source.
 	// This is useful in narrowing a subscription when a Source also emits many
-	// Event types that are NOT of interest. This is a required field. The value
-	// "*" may be utilized to denote that ALL events originating from the
-	// specified Source are of interest.
+	// Event types that are NOT of interest. This is a required field. Values
+	// may be glob patterns, wherein "*" matches any sequence of characters and
+	// "?" matches any single character. e.g. The value "*" may be utilized to
+	// denote that ALL events originating from the specified Source are of
+	// interest, while "push:*" would match Event types "push:branch" and
+	// "push:tag".
 	Types []string `json:"types,omitempty"`
 	// Qualifiers specifies an EXACT set of key/value pairs with which an Event
 	// MUST also be qualified for a Project to be considered subscribed. To
@@ -142,7 +181,71 @@ type EventSubscription struct {
 	// that Label in their EventSubscription. Note that the Labels field's "MAY
 	// match" subscription semantics differ from the Qualifiers field's "MUST
 	// match" subscription semantics.
+	//
+	// Values of both Qualifiers and Labels may be glob patterns, following the
+	// same rules as the Types field. e.g. A label value of "refs/heads/release-*"
+	// would match all Events labeled with a release branch.
 	Labels map[string]string `json:"labels,omitempty"`
+	// Filter is an optional CEL (Common Expression Language) expression that
+	// further narrows the subscription. It is evaluated only after all other
+	// criteria have matched and it MUST evaluate to a boolean. The expression may
+	// reference a single variable, "event", with the keys "source", "type",
+	// "qualifiers", "labels", "git" (containing "cloneURL", "commit", and
+	// "ref"), and "payload". If the Event's payload is valid JSON, it is
+	// available in parsed form. e.g.
+	// event.payload.pull_request.base.ref == "main"
+	Filter string `json:"filter,omitempty"`
+}
+
+// EventInputType represents the type of value an EventInput accepts.
+type EventInputType string
+
+const (
+	// EventInputTypeBoolean represents an EventInput that accepts a boolean
+	// value.
+	EventInputTypeBoolean EventInputType = "boolean"
+	// EventInputTypeEnum represents an EventInput that accepts one of an
+	// enumerated set of string values.
+	EventInputTypeEnum EventInputType = "enum"
+	// EventInputTypeString represents an EventInput that accepts any string
+	// value.
+	EventInputTypeString EventInputType = "string"
+)
+
+// EventInputs declares the inputs a Project accepts for Events having a
+// specific Source and Type. This is primarily useful for manually triggered
+// Events, e.g. an Event that requests deployment of a specific version to a
+// specific environment.
+type EventInputs struct {
+	// Source specifies the Source of the Events to which these input
+	// declarations apply.
+	Source string `json:"source,omitempty"`
+	// Type specifies the Type of the Events to which these input declarations
+	// apply.
+	Type string `json:"type,omitempty"`
+	// Inputs enumerates the inputs accepted by Events having the specified
+	// Source and Type.
+	Inputs []EventInput `json:"inputs,omitempty"`
+}
+
+// EventInput declares a single, typed input that may be supplied with an
+// Event.
+type EventInput struct {
+	// Name is the name of the input.
+	Name string `json:"name,omitempty"`
+	// Description is an optional, natural language description of the input.
+	Description string `json:"description,omitempty"`
+	// Type specifies the type of value the input accepts. If unspecified, the
+	// input accepts any string value.
+	Type EventInputType `json:"type,omitempty"`
+	// Required indicates whether a value MUST be supplied for the input. An
+	// input that is required but also specifies a Default is always satisfied.
+	Required bool `json:"required,omitempty"`
+	// Default optionally specifies the value used for the input when no value
+	// is supplied.
+	Default string `json:"default,omitempty"`
+	// Options enumerates the values accepted by an input of type enum.
+	Options []string `json:"options,omitempty"`
 }
 
 // KubernetesDetails represents Kubernetes-specific configuration.
@@ -170,6 +273,11 @@ type ProjectUpdateOptions struct {
 	// CreateIfNotFound when set to true will cause a non-existing Project to be
 	// created instead of updated.
 	CreateIfNotFound bool
+	// ResourceVersion, when non-zero, specifies that the update should only be
+	// applied if the Project's current ResourceVersion matches. If it does not
+	// match, because the Project has been modified by someone else in the
+	// interim, a *meta.ErrConflict is returned.
+	ResourceVersion int64
 }
 
 // ProjectDeleteOptions represents useful, optional settings for deleting a
@@ -227,6 +335,9 @@ type ProjectsClient interface {
 
 	// Secrets returns a specialized client for Secret management.
 	Secrets() SecretsClient
+
+	// Webhooks returns a specialized client for Webhook management.
+	Webhooks() WebhooksClient
 }
 
 type projectsClient struct {
@@ -236,6 +347,8 @@ type projectsClient struct {
 	authzClient ProjectAuthzClient
 	// secretsClient is a specialized client for Secret management.
 	secretsClient SecretsClient
+	// webhooksClient is a specialized client for Webhook management.
+	webhooksClient WebhooksClient
 }
 
 // NewProjectsClient returns a specialized client for managing Projects.
@@ -245,9 +358,10 @@ func NewProjectsClient(
 	opts *restmachinery.APIClientOptions,
 ) ProjectsClient {
 	return &projectsClient{
-		BaseClient:    rm.NewBaseClient(apiAddress, apiToken, opts),
-		authzClient:   NewProjectAuthzClient(apiAddress, apiToken, opts),
-		secretsClient: NewSecretsClient(apiAddress, apiToken, opts),
+		BaseClient:     rm.NewBaseClient(apiAddress, apiToken, opts),
+		authzClient:    NewProjectAuthzClient(apiAddress, apiToken, opts),
+		secretsClient:  NewSecretsClient(apiAddress, apiToken, opts),
+		webhooksClient: NewWebhooksClient(apiAddress, apiToken, opts),
 	}
 }
 
@@ -328,8 +442,12 @@ func (p *projectsClient) Update(
 	opts *ProjectUpdateOptions,
 ) (Project, error) {
 	queryParams := map[string]string{}
-	if opts != nil && opts.CreateIfNotFound {
-		queryParams["create"] = trueStr
+	var headers map[string]string
+	if opts != nil {
+		if opts.CreateIfNotFound {
+			queryParams["create"] = trueStr
+		}
+		headers = ifMatchHeaders(opts.ResourceVersion)
 	}
 	updatedProject := Project{}
 	return updatedProject, p.ExecuteRequest(
@@ -338,6 +456,7 @@ func (p *projectsClient) Update(
 			Method:      http.MethodPut,
 			Path:        fmt.Sprintf("v2/projects/%s", project.ID),
 			QueryParams: queryParams,
+			Headers:     headers,
 			ReqBodyObj:  project,
 			SuccessCode: http.StatusOK,
 			RespObj:     &updatedProject,
@@ -352,8 +471,12 @@ func (p *projectsClient) UpdateFromBytes(
 	opts *ProjectUpdateOptions,
 ) (Project, error) {
 	queryParams := map[string]string{}
-	if opts != nil && opts.CreateIfNotFound {
-		queryParams["create"] = trueStr
+	var headers map[string]string
+	if opts != nil {
+		if opts.CreateIfNotFound {
+			queryParams["create"] = trueStr
+		}
+		headers = ifMatchHeaders(opts.ResourceVersion)
 	}
 	updatedProject := Project{}
 	return updatedProject, p.ExecuteRequest(
@@ -362,6 +485,7 @@ func (p *projectsClient) UpdateFromBytes(
 			Method:      http.MethodPut,
 			Path:        fmt.Sprintf("v2/projects/%s", projectID),
 			QueryParams: queryParams,
+			Headers:     headers,
 			ReqBodyObj:  projectBytes,
 			SuccessCode: http.StatusOK,
 			RespObj:     &updatedProject,
@@ -391,3 +515,7 @@ func (p *projectsClient) Authz() ProjectAuthzClient {
 func (p *projectsClient) Secrets() SecretsClient {
 	return p.secretsClient
 }
+
+func (p *projectsClient) Webhooks() WebhooksClient {
+	return p.webhooksClient
+}
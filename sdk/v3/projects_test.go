@@ -36,6 +36,8 @@ func TestNewProjectsClient(t *testing.T) {
 	require.Equal(t, client.authzClient, client.Authz())
 	require.NotNil(t, client.secretsClient)
 	require.Equal(t, client.secretsClient, client.Secrets())
+	require.NotNil(t, client.webhooksClient)
+	require.Equal(t, client.webhooksClient, client.Webhooks())
 }
 
 func TestProjectsClientCreate(t *testing.T) {
@@ -169,6 +171,7 @@ func TestProjectsClientUpdate(t *testing.T) {
 	}
 	testOpts := ProjectUpdateOptions{
 		CreateIfNotFound: true,
+		ResourceVersion:  3,
 	}
 	server := httptest.NewServer(
 		http.HandlerFunc(
@@ -185,6 +188,7 @@ func TestProjectsClientUpdate(t *testing.T) {
 					strconv.FormatBool(testOpts.CreateIfNotFound),
 					r.URL.Query().Get("create"),
 				)
+				require.Equal(t, "3", r.Header.Get("If-Match"))
 				bodyBytes, err := ioutil.ReadAll(r.Body)
 				require.NoError(t, err)
 				project := Project{}
@@ -18,11 +18,17 @@ const (
 	// string
 	RoleAssignmentListKind = "RoleAssignmentList"
 
+	// PrincipalTypeGateway represents a principal that is a Gateway.
+	PrincipalTypeGateway PrincipalType = "GATEWAY"
 	// PrincipalTypeServiceAccount represents a principal that is a
 	// ServiceAccount.
 	PrincipalTypeServiceAccount PrincipalType = "SERVICE_ACCOUNT"
 	// PrincipalTypeUser represents a principal that is a User.
 	PrincipalTypeUser PrincipalType = "USER"
+	// PrincipalTypeWebhook represents a principal that is a Project's Webhook.
+	// References to such principals identify the Webhook as
+	// <project ID>/<webhook ID>.
+	PrincipalTypeWebhook PrincipalType = "WEBHOOK"
 )
 
 // RoleAssignment represents the assignment of a Role to a principal such as a
@@ -11,6 +11,18 @@ import (
 	"github.com/brigadecore/brigade/sdk/v3/restmachinery"
 )
 
+// SecretPolicies represents policies governing how a Project's secrets are
+// made available to its Workers and Jobs.
+type SecretPolicies struct {
+	// HideFromWorker specifies whether the Project's secrets should be withheld
+	// from its Workers entirely. When true, a Worker's view of the Project
+	// includes no secrets and Jobs can only access secrets by referencing them
+	// (see SecretRef) or by mounting them as a volume (see
+	// ProjectSecretVolume). In either case, secret values never pass through the
+	// Worker.
+	HideFromWorker bool `json:"hideFromWorker"`
+}
+
 // Secret represents Project-level sensitive information.
 type Secret struct {
 	// Key is a key by which the secret can referred.
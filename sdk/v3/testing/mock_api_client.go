@@ -5,12 +5,17 @@ import (
 )
 
 type MockAPIClient struct {
+	AuditClient  sdk.AuditClient
 	AuthnClient  sdk.AuthnClient
 	AuthzClient  sdk.SystemAuthzClient
 	CoreClient   sdk.CoreClient
 	SystemClient sdk.SystemClient
 }
 
+func (m *MockAPIClient) Audit() sdk.AuditClient {
+	return m.AuditClient
+}
+
 func (m *MockAPIClient) Authn() sdk.AuthnClient {
 	return m.AuthnClient
 }
@@ -0,0 +1,24 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+)
+
+type MockAuditClient struct {
+	ListFn func(
+		context.Context,
+		*sdk.AuditRecordsSelector,
+		*meta.ListOptions,
+	) (sdk.AuditRecordList, error)
+}
+
+func (m *MockAuditClient) List(
+	ctx context.Context,
+	selector *sdk.AuditRecordsSelector,
+	opts *meta.ListOptions,
+) (sdk.AuditRecordList, error) {
+	return m.ListFn(ctx, selector, opts)
+}
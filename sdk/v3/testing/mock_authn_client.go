@@ -8,6 +8,7 @@ import (
 
 type MockAuthnClient struct {
 	WhoAmIFn              func(context.Context) (sdk.PrincipalReference, error)
+	GatewaysClient        sdk.GatewaysClient
 	ServiceAccountsClient sdk.ServiceAccountsClient
 	SessionsClient        sdk.SessionsClient
 	UsersClient           sdk.UsersClient
@@ -19,6 +20,10 @@ func (m *MockAuthnClient) WhoAmI(
 	return m.WhoAmIFn(ctx)
 }
 
+func (m *MockAuthnClient) Gateways() sdk.GatewaysClient {
+	return m.GatewaysClient
+}
+
 func (m *MockAuthnClient) ServiceAccounts() sdk.ServiceAccountsClient {
 	return m.ServiceAccountsClient
 }
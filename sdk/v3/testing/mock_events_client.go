@@ -13,11 +13,20 @@ type MockEventsClient struct {
 		sdk.Event,
 		*sdk.EventCreateOptions,
 	) (sdk.EventList, error)
+	CreateDryRunFn func(
+		context.Context,
+		sdk.Event,
+		*sdk.EventCreateDryRunOptions,
+	) (sdk.EventCreateDryRunResult, error)
 	ListFn func(
 		context.Context,
 		*sdk.EventsSelector,
 		*meta.ListOptions,
 	) (sdk.EventList, error)
+	StatsFn func(
+		context.Context,
+		*sdk.EventStatsOptions,
+	) (sdk.EventStats, error)
 	GetFn func(
 		context.Context,
 		string,
@@ -57,6 +66,16 @@ type MockEventsClient struct {
 		string,
 		*sdk.EventRetryOptions,
 	) (sdk.Event, error)
+	RetryManyFn func(
+		context.Context,
+		sdk.EventsSelector,
+		*sdk.EventRetryManyOptions,
+	) (sdk.RetryManyEventsResult, error)
+	CloneManyFn func(
+		context.Context,
+		sdk.EventsSelector,
+		*sdk.EventCloneManyOptions,
+	) (sdk.CloneManyEventsResult, error)
 	WorkersClient sdk.WorkersClient
 	LogsClient    sdk.LogsClient
 }
@@ -69,6 +88,14 @@ func (m *MockEventsClient) Create(
 	return m.CreateFn(ctx, event, opts)
 }
 
+func (m *MockEventsClient) CreateDryRun(
+	ctx context.Context,
+	event sdk.Event,
+	opts *sdk.EventCreateDryRunOptions,
+) (sdk.EventCreateDryRunResult, error) {
+	return m.CreateDryRunFn(ctx, event, opts)
+}
+
 func (m *MockEventsClient) List(
 	ctx context.Context,
 	selector *sdk.EventsSelector,
@@ -77,6 +104,13 @@ func (m *MockEventsClient) List(
 	return m.ListFn(ctx, selector, opts)
 }
 
+func (m *MockEventsClient) Stats(
+	ctx context.Context,
+	opts *sdk.EventStatsOptions,
+) (sdk.EventStats, error) {
+	return m.StatsFn(ctx, opts)
+}
+
 func (m *MockEventsClient) Get(
 	ctx context.Context,
 	id string,
@@ -151,6 +185,22 @@ func (m *MockEventsClient) Retry(
 	return m.RetryFn(ctx, id, opts)
 }
 
+func (m *MockEventsClient) RetryMany(
+	ctx context.Context,
+	selector sdk.EventsSelector,
+	opts *sdk.EventRetryManyOptions,
+) (sdk.RetryManyEventsResult, error) {
+	return m.RetryManyFn(ctx, selector, opts)
+}
+
+func (m *MockEventsClient) CloneMany(
+	ctx context.Context,
+	selector sdk.EventsSelector,
+	opts *sdk.EventCloneManyOptions,
+) (sdk.CloneManyEventsResult, error) {
+	return m.CloneManyFn(ctx, selector, opts)
+}
+
 func (m *MockEventsClient) Workers() sdk.WorkersClient {
 	return m.WorkersClient
 }
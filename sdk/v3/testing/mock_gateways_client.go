@@ -0,0 +1,63 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+)
+
+type MockGatewaysClient struct {
+	CreateFn func(
+		context.Context,
+		sdk.Gateway,
+		*sdk.GatewayCreateOptions,
+	) (sdk.Token, error)
+	ListFn func(context.Context, *meta.ListOptions) (sdk.GatewayList, error)
+	GetFn  func(
+		context.Context,
+		string,
+		*sdk.GatewayGetOptions,
+	) (sdk.Gateway, error)
+	UpdateFn func(context.Context, sdk.Gateway, *sdk.GatewayUpdateOptions) error
+	DeleteFn func(context.Context, string, *sdk.GatewayDeleteOptions) error
+}
+
+func (m *MockGatewaysClient) Create(
+	ctx context.Context,
+	gateway sdk.Gateway,
+	opts *sdk.GatewayCreateOptions,
+) (sdk.Token, error) {
+	return m.CreateFn(ctx, gateway, opts)
+}
+
+func (m *MockGatewaysClient) List(
+	ctx context.Context,
+	opts *meta.ListOptions,
+) (sdk.GatewayList, error) {
+	return m.ListFn(ctx, opts)
+}
+
+func (m *MockGatewaysClient) Get(
+	ctx context.Context,
+	id string,
+	opts *sdk.GatewayGetOptions,
+) (sdk.Gateway, error) {
+	return m.GetFn(ctx, id, opts)
+}
+
+func (m *MockGatewaysClient) Update(
+	ctx context.Context,
+	gateway sdk.Gateway,
+	opts *sdk.GatewayUpdateOptions,
+) error {
+	return m.UpdateFn(ctx, gateway, opts)
+}
+
+func (m *MockGatewaysClient) Delete(
+	ctx context.Context,
+	id string,
+	opts *sdk.GatewayDeleteOptions,
+) error {
+	return m.DeleteFn(ctx, id, opts)
+}
@@ -2,8 +2,10 @@ package testing
 
 import (
 	"context"
+	"io"
 
 	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
 )
 
 type MockLogsClient struct {
@@ -13,6 +15,13 @@ type MockLogsClient struct {
 		selector *sdk.LogsSelector,
 		opts *sdk.LogStreamOptions,
 	) (<-chan sdk.LogEntry, <-chan error, error)
+	ArchiveFn func(ctx context.Context, eventID string) (io.ReadCloser, error)
+	SearchFn  func(
+		ctx context.Context,
+		projectID string,
+		selector *sdk.LogsSearchSelector,
+		opts *meta.ListOptions,
+	) (sdk.LogSearchResultList, error)
 }
 
 func (m *MockLogsClient) Stream(
@@ -23,3 +32,19 @@ func (m *MockLogsClient) Stream(
 ) (<-chan sdk.LogEntry, <-chan error, error) {
 	return m.StreamFn(ctx, eventID, selector, opts)
 }
+
+func (m *MockLogsClient) Archive(
+	ctx context.Context,
+	eventID string,
+) (io.ReadCloser, error) {
+	return m.ArchiveFn(ctx, eventID)
+}
+
+func (m *MockLogsClient) Search(
+	ctx context.Context,
+	projectID string,
+	selector *sdk.LogsSearchSelector,
+	opts *meta.ListOptions,
+) (sdk.LogSearchResultList, error) {
+	return m.SearchFn(ctx, projectID, selector, opts)
+}
@@ -39,9 +39,10 @@ type MockProjectsClient struct {
 		[]byte,
 		*sdk.ProjectUpdateOptions,
 	) (sdk.Project, error)
-	DeleteFn      func(context.Context, string, *sdk.ProjectDeleteOptions) error
-	AuthzClient   sdk.ProjectAuthzClient
-	SecretsClient sdk.SecretsClient
+	DeleteFn       func(context.Context, string, *sdk.ProjectDeleteOptions) error
+	AuthzClient    sdk.ProjectAuthzClient
+	SecretsClient  sdk.SecretsClient
+	WebhooksClient sdk.WebhooksClient
 }
 
 func (m *MockProjectsClient) Create(
@@ -108,3 +109,7 @@ func (m *MockProjectsClient) Authz() sdk.ProjectAuthzClient {
 func (m *MockProjectsClient) Secrets() sdk.SecretsClient {
 	return m.SecretsClient
 }
+
+func (m *MockProjectsClient) Webhooks() sdk.WebhooksClient {
+	return m.WebhooksClient
+}
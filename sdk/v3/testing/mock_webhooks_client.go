@@ -0,0 +1,69 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+)
+
+type MockWebhooksClient struct {
+	CreateFn func(
+		ctx context.Context,
+		projectID string,
+		webhook sdk.Webhook,
+		opts *sdk.WebhookCreateOptions,
+	) (sdk.Token, error)
+	ListFn func(
+		ctx context.Context,
+		projectID string,
+		opts *meta.ListOptions,
+	) (sdk.WebhookList, error)
+	GetFn func(
+		ctx context.Context,
+		projectID string,
+		id string,
+		opts *sdk.WebhookGetOptions,
+	) (sdk.Webhook, error)
+	DeleteFn func(
+		ctx context.Context,
+		projectID string,
+		id string,
+		opts *sdk.WebhookDeleteOptions,
+	) error
+}
+
+func (m *MockWebhooksClient) Create(
+	ctx context.Context,
+	projectID string,
+	webhook sdk.Webhook,
+	opts *sdk.WebhookCreateOptions,
+) (sdk.Token, error) {
+	return m.CreateFn(ctx, projectID, webhook, opts)
+}
+
+func (m *MockWebhooksClient) List(
+	ctx context.Context,
+	projectID string,
+	opts *meta.ListOptions,
+) (sdk.WebhookList, error) {
+	return m.ListFn(ctx, projectID, opts)
+}
+
+func (m *MockWebhooksClient) Get(
+	ctx context.Context,
+	projectID string,
+	id string,
+	opts *sdk.WebhookGetOptions,
+) (sdk.Webhook, error) {
+	return m.GetFn(ctx, projectID, id, opts)
+}
+
+func (m *MockWebhooksClient) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+	opts *sdk.WebhookDeleteOptions,
+) error {
+	return m.DeleteFn(ctx, projectID, id, opts)
+}
@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	rm "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/brigadecore/brigade/sdk/v3/restmachinery"
+)
+
+// WebhookAuthType represents a means by which callers of a Webhook are
+// authenticated.
+type WebhookAuthType string
+
+const (
+	// WebhookAuthTypeToken represents authentication by means of a random token
+	// that callers must present in the X-Brigade-Webhook-Token header.
+	WebhookAuthTypeToken WebhookAuthType = "TOKEN"
+	// WebhookAuthTypeHMAC represents authentication by means of a shared secret
+	// that callers must use to sign the request body using HMAC-SHA256. By
+	// default, the signature is expected in the X-Brigade-Signature-256 header.
+	WebhookAuthTypeHMAC WebhookAuthType = "HMAC"
+)
+
+// Webhook represents an inbound HTTP endpoint, owned by a single Project, that
+// converts arbitrary POST requests into Events for that Project. Webhooks are
+// suitable for simple integrations where deploying a dedicated gateway would
+// be overkill.
+type Webhook struct {
+	// ObjectMeta encapsulates Webhook metadata. The ID is unique only within the
+	// scope of the Project that owns the Webhook.
+	meta.ObjectMeta `json:"metadata"`
+	// ProjectID specifies the Project that owns the Webhook. This field is
+	// read-only.
+	ProjectID string `json:"projectID,omitempty"`
+	// Description is a natural language description of the Webhook's purpose.
+	Description string `json:"description,omitempty"`
+	// AuthType specifies how callers of the Webhook are authenticated. If not
+	// specified, WebhookAuthTypeToken is assumed.
+	AuthType WebhookAuthType `json:"authType,omitempty"`
+	// SignatureHeader optionally overrides the name of the HTTP header in which
+	// callers present the request body's signature. This is applicable only
+	// when AuthType is WebhookAuthTypeHMAC.
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+	// Source specifies the Source of all Events created by the Webhook. If not
+	// specified, "brigade.sh/webhook" is assumed.
+	Source string `json:"source,omitempty"`
+	// Type specifies how the Type of each Event created by the Webhook should
+	// be determined. If not specified, or if no value can be extracted from the
+	// request, "webhook" is assumed.
+	Type *WebhookValueSource `json:"type,omitempty"`
+	// Labels specifies how the Labels of each Event created by the Webhook
+	// should be determined. Keys are label keys. Labels whose values cannot be
+	// extracted from the request are omitted.
+	Labels map[string]WebhookValueSource `json:"labels,omitempty"`
+}
+
+// MarshalJSON amends Webhook instances with type metadata so that clients do
+// not need to be concerned with the tedium of doing so.
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type Alias Webhook
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "Webhook",
+			},
+			Alias: (Alias)(w),
+		},
+	)
+}
+
+// WebhookValueSource describes how a single value should be determined from
+// an inbound Webhook request. Exactly one field should be specified.
+type WebhookValueSource struct {
+	// Value specifies a static value.
+	Value string `json:"value,omitempty"`
+	// Header specifies the name of an HTTP header whose value should be used.
+	Header string `json:"header,omitempty"`
+	// JSONPath specifies a dot-delimited path to a field within a JSON request
+	// body whose value should be used, e.g. "pull_request.base.ref". Numeric
+	// path elements index into arrays. An optional "$." prefix is permitted.
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// WebhookList is an ordered and pageable list of Webhooks.
+type WebhookList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of Webhooks.
+	Items []Webhook `json:"items,omitempty"`
+}
+
+// MarshalJSON amends WebhookList instances with type metadata so that clients
+// do not need to be concerned with the tedium of doing so.
+func (w WebhookList) MarshalJSON() ([]byte, error) {
+	type Alias WebhookList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "WebhookList",
+			},
+			Alias: (Alias)(w),
+		},
+	)
+}
+
+// WebhookCreateOptions represents useful, optional settings for creating a
+// new Webhook. It currently has no fields, but exists to preserve the
+// possibility of future expansion without having to change client function
+// signatures.
+type WebhookCreateOptions struct{}
+
+// WebhookGetOptions represents useful, optional settings for retrieving a
+// Webhook. It currently has no fields, but exists to preserve the possibility
+// of future expansion without having to change client function signatures.
+type WebhookGetOptions struct{}
+
+// WebhookDeleteOptions represents useful, optional settings for deleting a
+// Webhook. It currently has no fields, but exists to preserve the possibility
+// of future expansion without having to change client function signatures.
+type WebhookDeleteOptions struct{}
+
+// WebhooksClient is the specialized client for managing Webhooks with the
+// Brigade API.
+type WebhooksClient interface {
+	// Create creates a new Webhook for the specified Project. The Token returned
+	// contains either the Webhook's token or its HMAC secret, depending on the
+	// Webhook's AuthType. This value cannot be retrieved again later.
+	Create(
+		ctx context.Context,
+		projectID string,
+		webhook Webhook,
+		opts *WebhookCreateOptions,
+	) (Token, error)
+	// List returns a WebhookList for the specified Project.
+	List(
+		ctx context.Context,
+		projectID string,
+		opts *meta.ListOptions,
+	) (WebhookList, error)
+	// Get retrieves a single Webhook specified by Project and Webhook
+	// identifiers.
+	Get(
+		ctx context.Context,
+		projectID string,
+		id string,
+		opts *WebhookGetOptions,
+	) (Webhook, error)
+	// Delete deletes a single Webhook specified by Project and Webhook
+	// identifiers.
+	Delete(
+		ctx context.Context,
+		projectID string,
+		id string,
+		opts *WebhookDeleteOptions,
+	) error
+}
+
+type webhooksClient struct {
+	*rm.BaseClient
+}
+
+// NewWebhooksClient returns a specialized client for managing Webhooks.
+func NewWebhooksClient(
+	apiAddress string,
+	apiToken string,
+	opts *restmachinery.APIClientOptions,
+) WebhooksClient {
+	return &webhooksClient{
+		BaseClient: rm.NewBaseClient(apiAddress, apiToken, opts),
+	}
+}
+
+func (w *webhooksClient) Create(
+	ctx context.Context,
+	projectID string,
+	webhook Webhook,
+	_ *WebhookCreateOptions,
+) (Token, error) {
+	token := Token{}
+	return token, w.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodPost,
+			Path:        fmt.Sprintf("v2/projects/%s/webhooks", projectID),
+			ReqBodyObj:  webhook,
+			SuccessCode: http.StatusCreated,
+			RespObj:     &token,
+		},
+	)
+}
+
+func (w *webhooksClient) List(
+	ctx context.Context,
+	projectID string,
+	opts *meta.ListOptions,
+) (WebhookList, error) {
+	webhooks := WebhookList{}
+	return webhooks, w.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        fmt.Sprintf("v2/projects/%s/webhooks", projectID),
+			QueryParams: w.AppendListQueryParams(nil, opts),
+			SuccessCode: http.StatusOK,
+			RespObj:     &webhooks,
+		},
+	)
+}
+
+func (w *webhooksClient) Get(
+	ctx context.Context,
+	projectID string,
+	id string,
+	_ *WebhookGetOptions,
+) (Webhook, error) {
+	webhook := Webhook{}
+	return webhook, w.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodGet,
+			Path:        fmt.Sprintf("v2/projects/%s/webhooks/%s", projectID, id),
+			SuccessCode: http.StatusOK,
+			RespObj:     &webhook,
+		},
+	)
+}
+
+func (w *webhooksClient) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+	_ *WebhookDeleteOptions,
+) error {
+	return w.ExecuteRequest(
+		ctx,
+		rm.OutboundRequest{
+			Method:      http.MethodDelete,
+			Path:        fmt.Sprintf("v2/projects/%s/webhooks/%s", projectID, id),
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
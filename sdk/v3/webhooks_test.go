@@ -0,0 +1,188 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rmTesting "github.com/brigadecore/brigade/sdk/v3/internal/restmachinery/testing" // nolint: lll
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	metaTesting "github.com/brigadecore/brigade/sdk/v3/meta/testing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, Webhook{}, "Webhook")
+}
+
+func TestWebhookListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, WebhookList{}, "WebhookList")
+}
+
+func TestNewWebhooksClient(t *testing.T) {
+	client, ok := NewWebhooksClient(
+		rmTesting.TestAPIAddress,
+		rmTesting.TestAPIToken,
+		nil,
+	).(*webhooksClient)
+	require.True(t, ok)
+	rmTesting.RequireBaseClient(t, client.BaseClient)
+}
+
+func TestWebhooksClientCreate(t *testing.T) {
+	const testProjectID = "bluebook"
+	testWebhook := Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "ci",
+		},
+		AuthType: WebhookAuthTypeHMAC,
+		Type: &WebhookValueSource{
+			Header: "X-Event-Type",
+		},
+		Labels: map[string]WebhookValueSource{
+			"branch": {
+				JSONPath: "ref",
+			},
+		},
+	}
+	testToken := Token{
+		Value: "opensesame",
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/projects/%s/webhooks", testProjectID),
+					r.URL.Path,
+				)
+				bodyBytes, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				webhook := Webhook{}
+				err = json.Unmarshal(bodyBytes, &webhook)
+				require.NoError(t, err)
+				require.Equal(t, testWebhook, webhook)
+				bodyBytes, err = json.Marshal(testToken)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhooksClient(server.URL, rmTesting.TestAPIToken, nil)
+	token, err := client.Create(
+		context.Background(),
+		testProjectID,
+		testWebhook,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, testToken, token)
+}
+
+func TestWebhooksClientList(t *testing.T) {
+	const testProjectID = "bluebook"
+	testWebhooks := WebhookList{
+		Items: []Webhook{
+			{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "ci",
+				},
+				ProjectID: testProjectID,
+			},
+		},
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf("/v2/projects/%s/webhooks", testProjectID),
+					r.URL.Path,
+				)
+				bodyBytes, err := json.Marshal(testWebhooks)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhooksClient(server.URL, rmTesting.TestAPIToken, nil)
+	webhooks, err := client.List(context.Background(), testProjectID, nil)
+	require.NoError(t, err)
+	require.Equal(t, testWebhooks, webhooks)
+}
+
+func TestWebhooksClientGet(t *testing.T) {
+	testWebhook := Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "ci",
+		},
+		ProjectID: "bluebook",
+	}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf(
+						"/v2/projects/%s/webhooks/%s",
+						testWebhook.ProjectID,
+						testWebhook.ID,
+					),
+					r.URL.Path,
+				)
+				bodyBytes, err := json.Marshal(testWebhook)
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintln(w, string(bodyBytes))
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhooksClient(server.URL, rmTesting.TestAPIToken, nil)
+	webhook, err := client.Get(
+		context.Background(),
+		testWebhook.ProjectID,
+		testWebhook.ID,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, testWebhook, webhook)
+}
+
+func TestWebhooksClientDelete(t *testing.T) {
+	const testProjectID = "bluebook"
+	const testWebhookID = "ci"
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(
+					t,
+					fmt.Sprintf(
+						"/v2/projects/%s/webhooks/%s",
+						testProjectID,
+						testWebhookID,
+					),
+					r.URL.Path,
+				)
+				w.WriteHeader(http.StatusOK)
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhooksClient(server.URL, rmTesting.TestAPIToken, nil)
+	err := client.Delete(context.Background(), testProjectID, testWebhookID, nil)
+	require.NoError(t, err)
+}
@@ -141,6 +141,14 @@ type Worker struct {
 	// Jobs contains details of all Jobs spawned by the Worker during handling of
 	// the Event.
 	Jobs []Job `json:"jobs,omitempty"`
+	// RetryJobs is set only on the Worker of an Event that retries another. It
+	// names, in their original order, the Jobs of the original Event that are
+	// to be executed again rather than inherited. This is recorded by the
+	// system.
+	RetryJobs []string `json:"retryJobs,omitempty"`
+	// Timeline is a chronological record of every phase the Worker has
+	// transitioned through. This is recorded by the system.
+	Timeline []PhaseTransition `json:"timeline,omitempty"`
 }
 
 // Job retrieves a Job by name. It returns a boolean indicating whether the
@@ -252,6 +260,14 @@ type WorkerStatus struct {
 	Ended *time.Time `json:"ended,omitempty"`
 	// Phase indicates where the Worker is in its lifecycle.
 	Phase WorkerPhase `json:"phase,omitempty"`
+	// Reason is an optional, human-readable explanation of why the Worker was
+	// canceled, aborted, or timed out. This is recorded by the system. Clients
+	// must leave this field empty when updating a Worker's status.
+	Reason string `json:"reason,omitempty"`
+	// Principal references the principal that canceled or aborted the Worker,
+	// if applicable. This is recorded by the system. Clients must leave this
+	// field nil when updating a Worker's status.
+	Principal *PrincipalReference `json:"principal,omitempty"`
 }
 
 // MarshalJSON amends WorkerStatus instances with type metadata so that clients
@@ -302,9 +318,12 @@ type WorkerStatusUpdateOptions struct{}
 type WorkerCleanupOptions struct{}
 
 // WorkerTimeoutOptions represents useful, optional settings for timing out a
-// Worker. It currently has no fields, but exists to preserve the possibility of
-// future expansion without having to change client function signatures.
-type WorkerTimeoutOptions struct{}
+// Worker.
+type WorkerTimeoutOptions struct {
+	// Reason is an optional, human-readable explanation of why the Worker is
+	// being timed out. It is recorded in the Worker's status.
+	Reason string
+}
 
 // WorkersClient is the specialized client for managing Event Workers with the
 // Brigade API.
@@ -458,13 +477,18 @@ func (w *workersClient) Cleanup(
 func (w *workersClient) Timeout(
 	ctx context.Context,
 	eventID string,
-	_ *WorkerTimeoutOptions,
+	opts *WorkerTimeoutOptions,
 ) error {
+	var reason string
+	if opts != nil {
+		reason = opts.Reason
+	}
 	return w.ExecuteRequest(
 		ctx,
 		rm.OutboundRequest{
 			Method:      http.MethodPut,
 			Path:        fmt.Sprintf("v2/events/%s/worker/timeout", eventID),
+			QueryParams: reasonToQueryParams(reason),
 			SuccessCode: http.StatusOK,
 		},
 	)
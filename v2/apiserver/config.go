@@ -1,5 +1,6 @@
 package main
 
+// nolint: lll
 import (
 	"context"
 	"fmt"
@@ -13,6 +14,9 @@ import (
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/kubernetes"
 	myOIDC "github.com/brigadecore/brigade/v2/apiserver/internal/api/oidc"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/rest"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage/filesystem"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage/s3"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/queue/amqp"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
 	"github.com/coreos/go-oidc"
@@ -28,6 +32,10 @@ const (
 	thirdPartyAuthStrategyDisabled = "disabled"
 	thirdPartyAuthStrategyOIDC     = "oidc"
 	thirdPartyAuthStrategyGitHub   = "github"
+
+	coolLogsStoreMongoDB    = "mongodb"
+	coolLogsStoreFilesystem = "filesystem"
+	coolLogsStoreS3         = "s3"
 )
 
 // databaseConnection returns a *mongo.Database connection based on
@@ -131,6 +139,18 @@ func substrateConfig() (kubernetes.SubstrateConfig, error) {
 	}
 	config.GitInitializerWindowsImagePullPolicy =
 		api.ImagePullPolicy(gitInitializerWindowsImagePullPolicyStr)
+	config.ReadinessWaiterImage, err =
+		os.GetRequiredEnvVar("READINESS_WAITER_IMAGE")
+	if err != nil {
+		return config, err
+	}
+	readinessWaiterImagePullPolicyStr, err :=
+		os.GetRequiredEnvVar("READINESS_WAITER_IMAGE_PULL_POLICY")
+	if err != nil {
+		return config, err
+	}
+	config.ReadinessWaiterImagePullPolicy =
+		api.ImagePullPolicy(readinessWaiterImagePullPolicyStr)
 	config.DefaultWorkerImage, err = os.GetRequiredEnvVar("DEFAULT_WORKER_IMAGE")
 	if err != nil {
 		return config, err
@@ -254,6 +274,142 @@ func sessionsServiceConfig() (api.SessionsServiceConfig, error) {
 	return config, err
 }
 
+// eventsPrunerConfig returns an api.EventsPrunerConfig based on configuration
+// obtained from environment variables.
+func eventsPrunerConfig() (api.EventsPrunerConfig, error) {
+	config := api.EventsPrunerConfig{}
+	var err error
+	if config.Interval, err =
+		os.GetDurationFromEnvVar("EVENT_PRUNING_INTERVAL", time.Hour); err != nil {
+		return config, err
+	}
+	if config.Interval <= 0 {
+		return config, errors.Errorf(
+			"EVENT_PRUNING_INTERVAL %s is not a positive duration",
+			config.Interval,
+		)
+	}
+	if config.DefaultRetentionPolicy.Succeeded, err =
+		retentionRule("EVENT_RETENTION_SUCCEEDED"); err != nil {
+		return config, err
+	}
+	config.DefaultRetentionPolicy.Failed, err =
+		retentionRule("EVENT_RETENTION_FAILED")
+	return config, err
+}
+
+// warmLogsStoreConfig returns a kubernetes.LogsStoreConfig based on
+// configuration obtained from environment variables.
+func warmLogsStoreConfig() (kubernetes.LogsStoreConfig, error) {
+	config := kubernetes.LogsStoreConfig{}
+	var err error
+	config.SplitStreams, err = os.GetBoolFromEnvVar("SPLIT_LOG_STREAMS", false)
+	return config, err
+}
+
+// coolLogsBucket returns an objectstorage.Bucket for storing cool logs based
+// on configuration obtained from environment variables. If cool logs are to
+// remain in the database, nil is returned.
+func coolLogsBucket() (objectstorage.Bucket, error) {
+	coolLogsStore := os.GetEnvVar("COOL_LOGS_STORE", coolLogsStoreMongoDB)
+	switch coolLogsStore {
+	case coolLogsStoreFilesystem:
+		path, err := os.GetRequiredEnvVar("COOL_LOGS_FILESYSTEM_PATH")
+		if err != nil {
+			return nil, err
+		}
+		return filesystem.NewBucket(path)
+	case coolLogsStoreS3:
+		config := s3.BucketConfig{}
+		var err error
+		if config.Bucket, err =
+			os.GetRequiredEnvVar("COOL_LOGS_S3_BUCKET"); err != nil {
+			return nil, err
+		}
+		config.Endpoint = os.GetEnvVar("COOL_LOGS_S3_ENDPOINT", "")
+		config.Region = os.GetEnvVar("COOL_LOGS_S3_REGION", "us-east-1")
+		config.AccessKeyID = os.GetEnvVar("COOL_LOGS_S3_ACCESS_KEY_ID", "")
+		config.SecretAccessKey =
+			os.GetEnvVar("COOL_LOGS_S3_SECRET_ACCESS_KEY", "")
+		if config.PathStyle, err =
+			os.GetBoolFromEnvVar("COOL_LOGS_S3_PATH_STYLE", false); err != nil {
+			return nil, err
+		}
+		return s3.NewBucket(config)
+	case coolLogsStoreMongoDB:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unrecognized COOL_LOGS_STORE %q", coolLogsStore)
+	}
+}
+
+// logsMigratorConfig returns an api.LogsMigratorConfig based on configuration
+// obtained from environment variables.
+func logsMigratorConfig() (api.LogsMigratorConfig, error) {
+	config := api.LogsMigratorConfig{}
+	var err error
+	if config.Interval, err = os.GetDurationFromEnvVar(
+		"LOGS_MIGRATION_INTERVAL",
+		10*time.Minute,
+	); err != nil {
+		return config, err
+	}
+	if config.Interval <= 0 {
+		return config, errors.Errorf(
+			"LOGS_MIGRATION_INTERVAL %s is not a positive duration",
+			config.Interval,
+		)
+	}
+	if config.GracePeriod, err = os.GetDurationFromEnvVar(
+		"LOGS_MIGRATION_GRACE_PERIOD",
+		7*24*time.Hour,
+	); err != nil {
+		return config, err
+	}
+	config.LeaseDuration, err = os.GetDurationFromEnvVar(
+		"LOGS_MIGRATION_LEASE_DURATION",
+		30*time.Minute,
+	)
+	return config, err
+}
+
+// auditRecorderConfig returns an api.AuditRecorderConfig based on
+// configuration obtained from environment variables.
+func auditRecorderConfig() (api.AuditRecorderConfig, error) {
+	config := api.AuditRecorderConfig{}
+	var err error
+	config.RetentionPeriod, err = os.GetDurationFromEnvVar(
+		"AUDIT_RECORD_RETENTION_PERIOD",
+		90*24*time.Hour,
+	)
+	return config, err
+}
+
+// retentionRule returns an *api.RetentionRule based on configuration obtained
+// from environment variables having the specified prefix. If neither a max age
+// nor a max count is specified, nil is returned.
+func retentionRule(prefix string) (*api.RetentionRule, error) {
+	maxAge, err := os.GetDurationFromEnvVar(fmt.Sprintf("%s_MAX_AGE", prefix), 0)
+	if err != nil {
+		return nil, err
+	}
+	maxCount, err := os.GetIntFromEnvVar(fmt.Sprintf("%s_MAX_COUNT", prefix), 0)
+	if err != nil {
+		return nil, err
+	}
+	if maxAge <= 0 && maxCount <= 0 {
+		return nil, nil
+	}
+	rule := &api.RetentionRule{}
+	if maxAge > 0 {
+		rule.MaxAge = maxAge.String()
+	}
+	if maxCount > 0 {
+		rule.MaxCount = int64(maxCount)
+	}
+	return rule, nil
+}
+
 // usersServiceConfig returns an api.UsersServiceConfig based on configuration
 // obtained from environment variables. nolint: gocyclo
 func usersServiceConfig() api.UsersServiceConfig {
@@ -8,10 +8,12 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/kubernetes"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/rest"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/queue/amqp"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
 	"github.com/stretchr/testify/require"
@@ -166,6 +168,8 @@ func TestSubstrateConfig(t *testing.T) {
 		testGitInitializerImagePullPolicy        = api.ImagePullPolicy("IfNotPresent")
 		testGitInitializerWindowsImage           = "brigadecore/brigade2-git-initializer-windows:2.0.0"
 		testGitInitializerWindowsImagePullPolicy = api.ImagePullPolicy("IfNotPresent")
+		testReadinessWaiterImage                 = "brigadecore/brigade2-readiness-waiter:2.0.0"
+		testReadinessWaiterImagePullPolicy       = api.ImagePullPolicy("IfNotPresent")
 		testDefaultWorkerImage                   = "brigadecore/brigade2-worker:2.0.0"
 		testDefaultWorkerImagePullPolicy         = api.ImagePullPolicy("IfNotPresent")
 		testWorkspaceStorageClass                = "nfs"
@@ -250,13 +254,38 @@ func TestSubstrateConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "DEFAULT_WORKER_IMAGE not set",
+			name: "READINESS_WAITER_IMAGE not set",
 			setup: func() {
 				t.Setenv(
 					"GIT_INITIALIZER_WINDOWS_IMAGE_PULL_POLICY",
 					string(testGitInitializerWindowsImagePullPolicy),
 				)
 			},
+			assertions: func(_ kubernetes.SubstrateConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "value not found for")
+				require.Contains(t, err.Error(), "READINESS_WAITER_IMAGE")
+			},
+		},
+		{
+			name: "READINESS_WAITER_IMAGE_PULL_POLICY not set",
+			setup: func() {
+				t.Setenv("READINESS_WAITER_IMAGE", testReadinessWaiterImage)
+			},
+			assertions: func(_ kubernetes.SubstrateConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "value not found for")
+				require.Contains(t, err.Error(), "READINESS_WAITER_IMAGE_PULL_POLICY")
+			},
+		},
+		{
+			name: "DEFAULT_WORKER_IMAGE not set",
+			setup: func() {
+				t.Setenv(
+					"READINESS_WAITER_IMAGE_PULL_POLICY",
+					string(testReadinessWaiterImagePullPolicy),
+				)
+			},
 			assertions: func(_ kubernetes.SubstrateConfig, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "value not found for")
@@ -313,6 +342,12 @@ func TestSubstrateConfig(t *testing.T) {
 					testGitInitializerWindowsImagePullPolicy,
 					config.GitInitializerWindowsImagePullPolicy,
 				)
+				require.Equal(t, testReadinessWaiterImage, config.ReadinessWaiterImage)
+				require.Equal(
+					t,
+					testReadinessWaiterImagePullPolicy,
+					config.ReadinessWaiterImagePullPolicy,
+				)
 				require.Equal(t, testDefaultWorkerImage, config.DefaultWorkerImage)
 				require.Equal(
 					t,
@@ -568,6 +603,349 @@ func TestSessionsServiceConfig(t *testing.T) {
 	}
 }
 
+func TestAuditRecorderConfig(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func()
+		assertions func(api.AuditRecorderConfig, error)
+	}{
+		{
+			name: "AUDIT_RECORD_RETENTION_PERIOD not parsable as duration",
+			setup: func() {
+				t.Setenv("AUDIT_RECORD_RETENTION_PERIOD", "forever")
+			},
+			assertions: func(_ api.AuditRecorderConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "AUDIT_RECORD_RETENTION_PERIOD")
+			},
+		},
+		{
+			name: "success",
+			setup: func() {
+				t.Setenv("AUDIT_RECORD_RETENTION_PERIOD", "720h")
+			},
+			assertions: func(config api.AuditRecorderConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					api.AuditRecorderConfig{
+						RetentionPeriod: 720 * time.Hour,
+					},
+					config,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.setup()
+			config, err := auditRecorderConfig()
+			testCase.assertions(config, err)
+		})
+	}
+}
+
+func TestEventsPrunerConfig(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func()
+		assertions func(api.EventsPrunerConfig, error)
+	}{
+		{
+			name: "EVENT_PRUNING_INTERVAL not parsable as duration",
+			setup: func() {
+				t.Setenv("EVENT_PRUNING_INTERVAL", "every so often")
+			},
+			assertions: func(_ api.EventsPrunerConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "EVENT_PRUNING_INTERVAL")
+			},
+		},
+		{
+			name: "EVENT_PRUNING_INTERVAL not positive",
+			setup: func() {
+				t.Setenv("EVENT_PRUNING_INTERVAL", "0s")
+			},
+			assertions: func(_ api.EventsPrunerConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "is not a positive duration")
+				require.Contains(t, err.Error(), "EVENT_PRUNING_INTERVAL")
+			},
+		},
+		{
+			name: "EVENT_RETENTION_SUCCEEDED_MAX_AGE not parsable as duration",
+			setup: func() {
+				t.Setenv("EVENT_PRUNING_INTERVAL", "10m")
+				t.Setenv("EVENT_RETENTION_SUCCEEDED_MAX_AGE", "a month")
+			},
+			assertions: func(_ api.EventsPrunerConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "EVENT_RETENTION_SUCCEEDED_MAX_AGE")
+			},
+		},
+		{
+			name: "EVENT_RETENTION_FAILED_MAX_COUNT not parsable as int",
+			setup: func() {
+				t.Setenv("EVENT_RETENTION_SUCCEEDED_MAX_AGE", "720h")
+				t.Setenv("EVENT_RETENTION_FAILED_MAX_COUNT", "lots")
+			},
+			assertions: func(_ api.EventsPrunerConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as an int")
+				require.Contains(t, err.Error(), "EVENT_RETENTION_FAILED_MAX_COUNT")
+			},
+		},
+		{
+			name: "success",
+			setup: func() {
+				t.Setenv("EVENT_RETENTION_FAILED_MAX_COUNT", "100")
+			},
+			assertions: func(config api.EventsPrunerConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					api.EventsPrunerConfig{
+						Interval: 10 * time.Minute,
+						DefaultRetentionPolicy: api.RetentionPolicy{
+							Succeeded: &api.RetentionRule{
+								MaxAge: "720h0m0s",
+							},
+							Failed: &api.RetentionRule{
+								MaxCount: 100,
+							},
+						},
+					},
+					config,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.setup()
+			config, err := eventsPrunerConfig()
+			testCase.assertions(config, err)
+		})
+	}
+}
+
+func TestWarmLogsStoreConfig(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func()
+		assertions func(kubernetes.LogsStoreConfig, error)
+	}{
+		{
+			name: "SPLIT_LOG_STREAMS not parsable as bool",
+			setup: func() {
+				t.Setenv("SPLIT_LOG_STREAMS", "nope")
+			},
+			assertions: func(_ kubernetes.LogsStoreConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a bool")
+				require.Contains(t, err.Error(), "SPLIT_LOG_STREAMS")
+			},
+		},
+		{
+			name: "success",
+			setup: func() {
+				t.Setenv("SPLIT_LOG_STREAMS", "true")
+			},
+			assertions: func(config kubernetes.LogsStoreConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					kubernetes.LogsStoreConfig{
+						SplitStreams: true,
+					},
+					config,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.setup()
+			config, err := warmLogsStoreConfig()
+			testCase.assertions(config, err)
+		})
+	}
+}
+
+func TestCoolLogsBucket(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func()
+		assertions func(objectstorage.Bucket, error)
+	}{
+		{
+			name:  "COOL_LOGS_STORE not set",
+			setup: func() {},
+			assertions: func(bucket objectstorage.Bucket, err error) {
+				require.NoError(t, err)
+				require.Nil(t, bucket)
+			},
+		},
+		{
+			name: "COOL_LOGS_STORE has invalid value",
+			setup: func() {
+				t.Setenv("COOL_LOGS_STORE", "bogus")
+			},
+			assertions: func(_ objectstorage.Bucket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unrecognized COOL_LOGS_STORE")
+			},
+		},
+		{
+			name: "COOL_LOGS_FILESYSTEM_PATH required but not set",
+			setup: func() {
+				t.Setenv("COOL_LOGS_STORE", coolLogsStoreFilesystem)
+			},
+			assertions: func(_ objectstorage.Bucket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "value not found for")
+				require.Contains(t, err.Error(), "COOL_LOGS_FILESYSTEM_PATH")
+			},
+		},
+		{
+			name: "filesystem success",
+			setup: func() {
+				t.Setenv("COOL_LOGS_FILESYSTEM_PATH", t.TempDir())
+			},
+			assertions: func(bucket objectstorage.Bucket, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, bucket)
+			},
+		},
+		{
+			name: "COOL_LOGS_S3_BUCKET required but not set",
+			setup: func() {
+				t.Setenv("COOL_LOGS_STORE", coolLogsStoreS3)
+			},
+			assertions: func(_ objectstorage.Bucket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "value not found for")
+				require.Contains(t, err.Error(), "COOL_LOGS_S3_BUCKET")
+			},
+		},
+		{
+			name: "COOL_LOGS_S3_PATH_STYLE not parsable as bool",
+			setup: func() {
+				t.Setenv("COOL_LOGS_S3_BUCKET", "logs")
+				t.Setenv("COOL_LOGS_S3_PATH_STYLE", "sometimes")
+			},
+			assertions: func(_ objectstorage.Bucket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a bool")
+				require.Contains(t, err.Error(), "COOL_LOGS_S3_PATH_STYLE")
+			},
+		},
+		{
+			name: "s3 success",
+			setup: func() {
+				t.Setenv("COOL_LOGS_S3_ENDPOINT", "http://minio:9000")
+				t.Setenv("COOL_LOGS_S3_ACCESS_KEY_ID", "minio")
+				t.Setenv("COOL_LOGS_S3_SECRET_ACCESS_KEY", "minio123")
+				t.Setenv("COOL_LOGS_S3_PATH_STYLE", "true")
+			},
+			assertions: func(bucket objectstorage.Bucket, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, bucket)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.setup()
+			bucket, err := coolLogsBucket()
+			testCase.assertions(bucket, err)
+		})
+	}
+}
+
+func TestLogsMigratorConfig(t *testing.T) {
+	testCases := []struct {
+		name       string
+		setup      func()
+		assertions func(api.LogsMigratorConfig, error)
+	}{
+		{
+			name: "LOGS_MIGRATION_INTERVAL not parsable as duration",
+			setup: func() {
+				t.Setenv("LOGS_MIGRATION_INTERVAL", "every so often")
+			},
+			assertions: func(_ api.LogsMigratorConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "LOGS_MIGRATION_INTERVAL")
+			},
+		},
+		{
+			name: "LOGS_MIGRATION_INTERVAL not positive",
+			setup: func() {
+				t.Setenv("LOGS_MIGRATION_INTERVAL", "-1h")
+			},
+			assertions: func(_ api.LogsMigratorConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "is not a positive duration")
+				require.Contains(t, err.Error(), "LOGS_MIGRATION_INTERVAL")
+			},
+		},
+		{
+			name: "LOGS_MIGRATION_GRACE_PERIOD not parsable as duration",
+			setup: func() {
+				t.Setenv("LOGS_MIGRATION_INTERVAL", "1h")
+				t.Setenv("LOGS_MIGRATION_GRACE_PERIOD", "a little while")
+			},
+			assertions: func(_ api.LogsMigratorConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "LOGS_MIGRATION_GRACE_PERIOD")
+			},
+		},
+		{
+			name: "LOGS_MIGRATION_LEASE_DURATION not parsable as duration",
+			setup: func() {
+				t.Setenv("LOGS_MIGRATION_GRACE_PERIOD", "15m")
+				t.Setenv("LOGS_MIGRATION_LEASE_DURATION", "a good while")
+			},
+			assertions: func(_ api.LogsMigratorConfig, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "was not parsable as a duration")
+				require.Contains(t, err.Error(), "LOGS_MIGRATION_LEASE_DURATION")
+			},
+		},
+		{
+			name: "success",
+			setup: func() {
+				t.Setenv("LOGS_MIGRATION_LEASE_DURATION", "1h")
+			},
+			assertions: func(config api.LogsMigratorConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					api.LogsMigratorConfig{
+						Interval:      time.Hour,
+						GracePeriod:   15 * time.Minute,
+						LeaseDuration: time.Hour,
+					},
+					config,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.setup()
+			config, err := logsMigratorConfig()
+			testCase.assertions(config, err)
+		})
+	}
+}
+
 func TestUsersServiceConfig(t *testing.T) {
 	testCases := []struct {
 		name       string
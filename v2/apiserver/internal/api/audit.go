@@ -0,0 +1,298 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditRecordKind represents the canonical AuditRecord kind string
+const AuditRecordKind = "AuditRecord"
+
+// AuditResult represents the outcome of an audited operation.
+type AuditResult string
+
+const (
+	// AuditResultFailed represents the outcome of an audited operation that
+	// failed, including operations that failed because the principal was not
+	// authorized to perform them.
+	AuditResultFailed AuditResult = "FAILED"
+	// AuditResultSucceeded represents the outcome of an audited operation that
+	// succeeded.
+	AuditResultSucceeded AuditResult = "SUCCEEDED"
+)
+
+// AuditRecord records a single mutating operation, who attempted it, and
+// what the outcome was.
+type AuditRecord struct {
+	// ID is the AuditRecord's unique identifier.
+	ID string `json:"id" bson:"id"`
+	// Time indicates when the operation was attempted.
+	Time time.Time `json:"time" bson:"time"`
+	// Expires indicates when the AuditRecord will be removed from the underlying
+	// data store.
+	Expires time.Time `json:"-" bson:"expires"`
+	// Principal references the principal that attempted the operation. It will
+	// be nil if the operation was attempted by an unauthenticated client or by
+	// one of Brigade's own internal components.
+	Principal *PrincipalReference `json:"principal,omitempty" bson:"principal,omitempty"` // nolint: lll
+	// Action describes the operation that was attempted-- for instance,
+	// "projects.create".
+	Action string `json:"action" bson:"action"`
+	// TargetType indicates what kind of resource the operation targeted-- for
+	// instance, a Project.
+	TargetType string `json:"targetType" bson:"targetType"`
+	// TargetID identifies the resource the operation targeted. It will be empty
+	// for operations that target many resources at once.
+	TargetID string `json:"targetID,omitempty" bson:"targetID,omitempty"`
+	// ProjectID identifies the Project that the targeted resource belongs to, if
+	// applicable.
+	ProjectID string `json:"projectID,omitempty" bson:"projectID,omitempty"`
+	// Details contains additional, operation-specific information-- for
+	// instance, the reason given for canceling an Event.
+	Details map[string]string `json:"details,omitempty" bson:"details,omitempty"` // nolint: lll
+	// Result indicates the outcome of the operation.
+	Result AuditResult `json:"result" bson:"result"`
+	// Error contains the error message returned by a failed operation.
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// MarshalJSON amends AuditRecord instances with type metadata.
+func (a AuditRecord) MarshalJSON() ([]byte, error) {
+	type Alias AuditRecord
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       AuditRecordKind,
+			},
+			Alias: (Alias)(a),
+		},
+	)
+}
+
+// AuditRecordList is an ordered and pageable list of AuditRecords.
+type AuditRecordList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of AuditRecords.
+	Items []AuditRecord `json:"items,omitempty"`
+}
+
+// MarshalJSON amends AuditRecordList instances with type metadata.
+func (a AuditRecordList) MarshalJSON() ([]byte, error) {
+	type Alias AuditRecordList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "AuditRecordList",
+			},
+			Alias: (Alias)(a),
+		},
+	)
+}
+
+// AuditRecordsSelector represents useful filter criteria when selecting
+// multiple AuditRecords for API group operations like list. All criteria are
+// optional.
+type AuditRecordsSelector struct {
+	// Action specifies that only AuditRecords of the specified action should be
+	// selected.
+	Action string
+	// TargetType specifies that only AuditRecords of operations that targeted
+	// the specified kind of resource should be selected.
+	TargetType string
+	// TargetID specifies that only AuditRecords of operations that targeted the
+	// resource with the specified identifier should be selected.
+	TargetID string
+	// ProjectID specifies that only AuditRecords of operations that targeted
+	// resources belonging to the specified Project should be selected.
+	ProjectID string
+	// PrincipalType specifies that only AuditRecords of operations attempted by
+	// principals of the specified type should be selected.
+	PrincipalType PrincipalType
+	// PrincipalID specifies that only AuditRecords of operations attempted by
+	// the principal with the specified identifier should be selected.
+	PrincipalID string
+	// Result specifies that only AuditRecords with the specified outcome should
+	// be selected.
+	Result AuditResult
+	// Since specifies that only AuditRecords of operations attempted at or
+	// after the specified time should be selected.
+	Since *time.Time
+	// Until specifies that only AuditRecords of operations attempted before the
+	// specified time should be selected.
+	Until *time.Time
+}
+
+// AuditTarget describes the resource(s) targeted by an audited operation.
+type AuditTarget struct {
+	// Type indicates what kind of resource was targeted.
+	Type string
+	// ID identifies the targeted resource. It should be left empty for
+	// operations that target many resources at once.
+	ID string
+	// ProjectID identifies the Project the targeted resource belongs to, if
+	// applicable.
+	ProjectID string
+	// Details contains additional, operation-specific information.
+	Details map[string]string
+}
+
+// AuditRecorderConfig encapsulates configuration options for an
+// AuditRecorder.
+type AuditRecorderConfig struct {
+	// RetentionPeriod specifies how long AuditRecords are retained before being
+	// removed from the underlying data store.
+	RetentionPeriod time.Duration
+}
+
+// AuditRecorder is an interface for components that record the outcome of
+// mutating operations.
+type AuditRecorder interface {
+	// Record records that the principal found in the provided context.Context
+	// attempted the specified action against the specified target, and whether
+	// that attempt succeeded (the provided error is nil) or failed.
+	// Implementations MUST NOT return errors; a failure to record an operation
+	// must not alter the outcome of that operation. Implementations record
+	// synchronously, so callers incur the cost of writing the record.
+	Record(
+		ctx context.Context,
+		action string,
+		target AuditTarget,
+		err error,
+	)
+}
+
+// auditRecorder is an implementation of the AuditRecorder interface.
+type auditRecorder struct {
+	auditStore AuditStore
+	config     AuditRecorderConfig
+}
+
+// NewAuditRecorder returns an AuditRecorder that persists AuditRecords using
+// the provided AuditStore.
+func NewAuditRecorder(
+	auditStore AuditStore,
+	config AuditRecorderConfig,
+) AuditRecorder {
+	return &auditRecorder{
+		auditStore: auditStore,
+		config:     config,
+	}
+}
+
+func (a *auditRecorder) Record(
+	ctx context.Context,
+	action string,
+	target AuditTarget,
+	err error,
+) {
+	now := time.Now().UTC()
+	record := AuditRecord{
+		ID:         uuid.NewV4().String(),
+		Time:       now,
+		Expires:    now.Add(a.config.RetentionPeriod),
+		Action:     action,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		ProjectID:  target.ProjectID,
+		Details:    target.Details,
+		Result:     AuditResultSucceeded,
+	}
+	if ref, ok := principalReferenceFromContext(ctx); ok {
+		record.Principal = &ref
+	}
+	if err != nil {
+		record.Result = AuditResultFailed
+		record.Error = err.Error()
+	}
+	// Deliberately not using ctx. The operation being audited has already
+	// happened, so it should be recorded even if the client has gone away.
+	storeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := a.auditStore.Create(storeCtx, record); err != nil {
+		log.Println(
+			errors.Wrapf(err, "error storing audit record for %q action", action),
+		)
+	}
+}
+
+// AuditService is the specialized interface for retrieving AuditRecords. It's
+// decoupled from underlying technology choices (e.g. data store) to keep
+// business logic reusable and consistent while the underlying tech stack
+// remains free to change.
+type AuditService interface {
+	// List retrieves an AuditRecordList, with its Items (AuditRecords) ordered
+	// by time, newest first. Criteria for which AuditRecords should be retrieved
+	// can be specified using the AuditRecordsSelector parameter.
+	List(
+		context.Context,
+		AuditRecordsSelector,
+		meta.ListOptions,
+	) (AuditRecordList, error)
+}
+
+// auditService is an implementation of the AuditService interface.
+type auditService struct {
+	authorize  AuthorizeFn
+	auditStore AuditStore
+}
+
+// NewAuditService returns a specialized interface for retrieving
+// AuditRecords.
+func NewAuditService(
+	authorizeFn AuthorizeFn,
+	auditStore AuditStore,
+) AuditService {
+	return &auditService{
+		authorize:  authorizeFn,
+		auditStore: auditStore,
+	}
+}
+
+func (a *auditService) List(
+	ctx context.Context,
+	selector AuditRecordsSelector,
+	opts meta.ListOptions,
+) (AuditRecordList, error) {
+	if err := a.authorize(ctx, RoleAdmin, ""); err != nil {
+		return AuditRecordList{}, err
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	records, err := a.auditStore.List(ctx, selector, opts)
+	if err != nil {
+		return records,
+			errors.Wrap(err, "error retrieving audit records from store")
+	}
+	return records, nil
+}
+
+// AuditStore is an interface for components that implement AuditRecord
+// persistence concerns.
+type AuditStore interface {
+	// Create persists a new AuditRecord in the underlying data store.
+	Create(context.Context, AuditRecord) error
+	// List retrieves an AuditRecordList from the underlying data store, with
+	// its Items (AuditRecords) ordered by time, newest first.
+	List(
+		context.Context,
+		AuditRecordsSelector,
+		meta.ListOptions,
+	) (AuditRecordList, error)
+}
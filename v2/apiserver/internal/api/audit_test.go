@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRecordMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, AuditRecord{}, AuditRecordKind)
+}
+
+func TestAuditRecordListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, AuditRecordList{}, "AuditRecordList")
+}
+
+func TestNewAuditRecorder(t *testing.T) {
+	auditStore := &mockAuditStore{}
+	config := AuditRecorderConfig{
+		RetentionPeriod: time.Hour,
+	}
+	recorder, ok := NewAuditRecorder(auditStore, config).(*auditRecorder)
+	require.True(t, ok)
+	require.Same(t, auditStore, recorder.auditStore)
+	require.Equal(t, config, recorder.config)
+}
+
+func TestAuditRecorderRecord(t *testing.T) {
+	testUser := &User{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "tony@starkindustries.com",
+		},
+	}
+	testTarget := AuditTarget{
+		Type:      ProjectKind,
+		ID:        "italian",
+		ProjectID: "italian",
+		Details: map[string]string{
+			"foo": "bar",
+		},
+	}
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		storeFn func(context.Context, AuditRecord) error
+	}{
+		{
+			name: "operation succeeded",
+			ctx:  ContextWithPrincipal(context.Background(), testUser),
+			storeFn: func(_ context.Context, record AuditRecord) error {
+				require.NotEmpty(t, record.ID)
+				require.False(t, record.Time.IsZero())
+				require.Equal(t, record.Time.Add(time.Hour), record.Expires)
+				require.Equal(
+					t,
+					&PrincipalReference{
+						Type: PrincipalTypeUser,
+						ID:   testUser.ID,
+					},
+					record.Principal,
+				)
+				require.Equal(t, "projects.update", record.Action)
+				require.Equal(t, testTarget.Type, record.TargetType)
+				require.Equal(t, testTarget.ID, record.TargetID)
+				require.Equal(t, testTarget.ProjectID, record.ProjectID)
+				require.Equal(t, testTarget.Details, record.Details)
+				require.Equal(t, AuditResultSucceeded, record.Result)
+				require.Empty(t, record.Error)
+				return nil
+			},
+		},
+		{
+			name: "operation performed via a webhook",
+			ctx: ContextWithPrincipal(
+				context.Background(),
+				GetWebhookPrincipal("italian", "github"),
+			),
+			storeFn: func(_ context.Context, record AuditRecord) error {
+				require.Equal(
+					t,
+					&PrincipalReference{
+						Type: PrincipalTypeWebhook,
+						ID:   "italian/github",
+					},
+					record.Principal,
+				)
+				return nil
+			},
+		},
+		{
+			name: "operation failed",
+			ctx:  context.Background(),
+			err:  errors.New("something went wrong"),
+			storeFn: func(_ context.Context, record AuditRecord) error {
+				require.Nil(t, record.Principal)
+				require.Equal(t, AuditResultFailed, record.Result)
+				require.Equal(t, "something went wrong", record.Error)
+				return nil
+			},
+		},
+		{
+			name: "error storing audit record",
+			ctx:  context.Background(),
+			storeFn: func(context.Context, AuditRecord) error {
+				return errors.New("store error")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stored bool
+			recorder := &auditRecorder{
+				auditStore: &mockAuditStore{
+					CreateFn: func(ctx context.Context, record AuditRecord) error {
+						stored = true
+						return testCase.storeFn(ctx, record)
+					},
+				},
+				config: AuditRecorderConfig{
+					RetentionPeriod: time.Hour,
+				},
+			}
+			recorder.Record(
+				testCase.ctx,
+				"projects.update",
+				testTarget,
+				testCase.err,
+			)
+			require.True(t, stored)
+		})
+	}
+}
+
+func TestNewAuditService(t *testing.T) {
+	auditStore := &mockAuditStore{}
+	svc, ok := NewAuditService(alwaysAuthorize, auditStore).(*auditService)
+	require.True(t, ok)
+	require.NotNil(t, svc.authorize)
+	require.Same(t, auditStore, svc.auditStore)
+}
+
+func TestAuditServiceList(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    AuditService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &auditService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting audit records from store",
+			service: &auditService{
+				authorize: alwaysAuthorize,
+				auditStore: &mockAuditStore{
+					ListFn: func(
+						context.Context,
+						AuditRecordsSelector,
+						meta.ListOptions,
+					) (AuditRecordList, error) {
+						return AuditRecordList{}, errors.New("error listing records")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error listing records")
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving audit records from store",
+				)
+			},
+		},
+		{
+			name: "success",
+			service: &auditService{
+				authorize: alwaysAuthorize,
+				auditStore: &mockAuditStore{
+					ListFn: func(
+						_ context.Context,
+						_ AuditRecordsSelector,
+						opts meta.ListOptions,
+					) (AuditRecordList, error) {
+						require.Equal(t, int64(20), opts.Limit)
+						return AuditRecordList{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.service.List(
+				context.Background(),
+				AuditRecordsSelector{},
+				meta.ListOptions{},
+			)
+			testCase.assertions(err)
+		})
+	}
+}
+
+type mockAuditRecorder struct {
+	RecordFn func(context.Context, string, AuditTarget, error)
+}
+
+func (m *mockAuditRecorder) Record(
+	ctx context.Context,
+	action string,
+	target AuditTarget,
+	err error,
+) {
+	m.RecordFn(ctx, action, target, err)
+}
+
+type mockAuditStore struct {
+	CreateFn func(context.Context, AuditRecord) error
+	ListFn   func(
+		context.Context,
+		AuditRecordsSelector,
+		meta.ListOptions,
+	) (AuditRecordList, error)
+}
+
+func (m *mockAuditStore) Create(ctx context.Context, record AuditRecord) error {
+	return m.CreateFn(ctx, record)
+}
+
+func (m *mockAuditStore) List(
+	ctx context.Context,
+	selector AuditRecordsSelector,
+	opts meta.ListOptions,
+) (AuditRecordList, error) {
+	return m.ListFn(ctx, selector, opts)
+}
@@ -0,0 +1,806 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// The types in this file decorate the services that mutate Brigade's
+// configuration, access controls, or Events on behalf of users, service
+// accounts, and gateways, recording the outcome of every mutating operation
+// using an AuditRecorder. Read-only operations pass straight through to the
+// decorated service. Operations performed exclusively by Brigade's own
+// components (the scheduler, observer, and workers) while handling Events are
+// not audited.
+//
+// Every audited operation writes its AuditRecord synchronously, before the
+// operation returns to the caller, so each one costs an additional insert into
+// the underlying data store.
+
+// secretKind represents the kind of resource targeted by audited operations on
+// Secrets.
+const secretKind = "Secret"
+
+type auditedEventsService struct {
+	EventsService
+	recorder AuditRecorder
+}
+
+// NewAuditedEventsService returns an EventsService that records the outcome
+// of every mutating operation performed via the provided EventsService.
+func NewAuditedEventsService(
+	eventsService EventsService,
+	recorder AuditRecorder,
+) EventsService {
+	return &auditedEventsService{
+		EventsService: eventsService,
+		recorder:      recorder,
+	}
+}
+
+func (a *auditedEventsService) Create(
+	ctx context.Context,
+	event Event,
+) (EventList, error) {
+	events, err := a.EventsService.Create(ctx, event)
+	a.recorder.Record(
+		ctx,
+		"events.create",
+		AuditTarget{
+			Type:      EventKind,
+			ProjectID: event.ProjectID,
+			Details: map[string]string{
+				"source": event.Source,
+				"type":   event.Type,
+				"count":  strconv.Itoa(len(events.Items)),
+			},
+		},
+		err,
+	)
+	return events, err
+}
+
+func (a *auditedEventsService) Clone(
+	ctx context.Context,
+	id string,
+) (Event, error) {
+	event, err := a.EventsService.Clone(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"events.clone",
+		AuditTarget{
+			Type:      EventKind,
+			ID:        id,
+			ProjectID: event.ProjectID,
+			Details: map[string]string{
+				"newEventID": event.ID,
+			},
+		},
+		err,
+	)
+	return event, err
+}
+
+func (a *auditedEventsService) Cancel(
+	ctx context.Context,
+	id string,
+	opts EventCancelOptions,
+) error {
+	// Look up the Event first so its Project can be recorded. Any error is
+	// deliberately ignored here since the operation itself will surface it.
+	event, _ := a.EventsService.Get(ctx, id)
+	err := a.EventsService.Cancel(ctx, id, opts)
+	a.recorder.Record(
+		ctx,
+		"events.cancel",
+		AuditTarget{
+			Type:      EventKind,
+			ID:        id,
+			ProjectID: event.ProjectID,
+			Details:   reasonDetails(opts.Reason),
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedEventsService) UpdateSourceState(
+	ctx context.Context,
+	id string,
+	sourceState SourceState,
+	opts EventSourceStateUpdateOptions,
+) error {
+	// Look up the Event first so its Project can be recorded. Any error is
+	// deliberately ignored here since the operation itself will surface it.
+	event, _ := a.EventsService.Get(ctx, id)
+	err := a.EventsService.UpdateSourceState(ctx, id, sourceState, opts)
+	a.recorder.Record(
+		ctx,
+		"events.updateSourceState",
+		AuditTarget{
+			Type:      EventKind,
+			ID:        id,
+			ProjectID: event.ProjectID,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedEventsService) CancelMany(
+	ctx context.Context,
+	selector EventsSelector,
+	opts EventCancelManyOptions,
+) (CancelManyEventsResult, error) {
+	result, err := a.EventsService.CancelMany(ctx, selector, opts)
+	if opts.DryRun {
+		// Nothing was changed, so there is nothing to record
+		return result, err
+	}
+	details := reasonDetails(opts.Reason)
+	details["count"] = strconv.FormatInt(result.Count, 10)
+	a.recorder.Record(
+		ctx,
+		"events.cancelMany",
+		AuditTarget{
+			Type:      EventKind,
+			ProjectID: selector.ProjectID,
+			Details:   details,
+		},
+		err,
+	)
+	return result, err
+}
+
+func (a *auditedEventsService) Delete(
+	ctx context.Context,
+	id string,
+	opts EventDeleteOptions,
+) error {
+	// Look up the Event first so its Project can be recorded. Any error is
+	// deliberately ignored here since the operation itself will surface it.
+	event, _ := a.EventsService.Get(ctx, id)
+	err := a.EventsService.Delete(ctx, id, opts)
+	a.recorder.Record(
+		ctx,
+		"events.delete",
+		AuditTarget{
+			Type:      EventKind,
+			ID:        id,
+			ProjectID: event.ProjectID,
+			Details:   reasonDetails(opts.Reason),
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedEventsService) DeleteMany(
+	ctx context.Context,
+	selector EventsSelector,
+	opts EventDeleteManyOptions,
+) (DeleteManyEventsResult, error) {
+	result, err := a.EventsService.DeleteMany(ctx, selector, opts)
+	if opts.DryRun {
+		// Nothing was changed, so there is nothing to record
+		return result, err
+	}
+	details := reasonDetails(opts.Reason)
+	details["count"] = strconv.FormatInt(result.Count, 10)
+	a.recorder.Record(
+		ctx,
+		"events.deleteMany",
+		AuditTarget{
+			Type:      EventKind,
+			ProjectID: selector.ProjectID,
+			Details:   details,
+		},
+		err,
+	)
+	return result, err
+}
+
+func (a *auditedEventsService) Retry(
+	ctx context.Context,
+	id string,
+	opts EventRetryOptions,
+) (Event, error) {
+	event, err := a.EventsService.Retry(ctx, id, opts)
+	details := map[string]string{
+		"newEventID": event.ID,
+	}
+	if opts.FromJob != "" {
+		details["fromJob"] = opts.FromJob
+	}
+	a.recorder.Record(
+		ctx,
+		"events.retry",
+		AuditTarget{
+			Type:      EventKind,
+			ID:        id,
+			ProjectID: event.ProjectID,
+			Details:   details,
+		},
+		err,
+	)
+	return event, err
+}
+
+func (a *auditedEventsService) RetryMany(
+	ctx context.Context,
+	selector EventsSelector,
+) (RetryManyEventsResult, error) {
+	result, err := a.EventsService.RetryMany(ctx, selector)
+	a.recorder.Record(
+		ctx,
+		"events.retryMany",
+		AuditTarget{
+			Type:      EventKind,
+			ProjectID: selector.ProjectID,
+			Details: map[string]string{
+				"count":       strconv.FormatInt(result.Count, 10),
+				"failedCount": strconv.FormatInt(result.FailedCount, 10),
+			},
+		},
+		err,
+	)
+	return result, err
+}
+
+func (a *auditedEventsService) CloneMany(
+	ctx context.Context,
+	selector EventsSelector,
+) (CloneManyEventsResult, error) {
+	result, err := a.EventsService.CloneMany(ctx, selector)
+	a.recorder.Record(
+		ctx,
+		"events.cloneMany",
+		AuditTarget{
+			Type:      EventKind,
+			ProjectID: selector.ProjectID,
+			Details: map[string]string{
+				"count":       strconv.FormatInt(result.Count, 10),
+				"failedCount": strconv.FormatInt(result.FailedCount, 10),
+			},
+		},
+		err,
+	)
+	return result, err
+}
+
+type auditedGatewaysService struct {
+	GatewaysService
+	recorder AuditRecorder
+}
+
+// NewAuditedGatewaysService returns a GatewaysService that records the
+// outcome of every mutating operation performed via the provided
+// GatewaysService.
+func NewAuditedGatewaysService(
+	gatewaysService GatewaysService,
+	recorder AuditRecorder,
+) GatewaysService {
+	return &auditedGatewaysService{
+		GatewaysService: gatewaysService,
+		recorder:        recorder,
+	}
+}
+
+func (a *auditedGatewaysService) Create(
+	ctx context.Context,
+	gateway Gateway,
+) (Token, error) {
+	token, err := a.GatewaysService.Create(ctx, gateway)
+	a.recorder.Record(
+		ctx,
+		"gateways.create",
+		AuditTarget{
+			Type: GatewayKind,
+			ID:   gateway.ID,
+		},
+		err,
+	)
+	return token, err
+}
+
+func (a *auditedGatewaysService) Update(
+	ctx context.Context,
+	gateway Gateway,
+) error {
+	err := a.GatewaysService.Update(ctx, gateway)
+	a.recorder.Record(
+		ctx,
+		"gateways.update",
+		AuditTarget{
+			Type: GatewayKind,
+			ID:   gateway.ID,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedGatewaysService) Delete(ctx context.Context, id string) error {
+	err := a.GatewaysService.Delete(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"gateways.delete",
+		AuditTarget{
+			Type: GatewayKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+type auditedProjectsService struct {
+	ProjectsService
+	recorder AuditRecorder
+}
+
+// NewAuditedProjectsService returns a ProjectsService that records the
+// outcome of every mutating operation performed via the provided
+// ProjectsService.
+func NewAuditedProjectsService(
+	projectsService ProjectsService,
+	recorder AuditRecorder,
+) ProjectsService {
+	return &auditedProjectsService{
+		ProjectsService: projectsService,
+		recorder:        recorder,
+	}
+}
+
+func (a *auditedProjectsService) Create(
+	ctx context.Context,
+	project Project,
+) (Project, error) {
+	created, err := a.ProjectsService.Create(ctx, project)
+	a.recorder.Record(
+		ctx,
+		"projects.create",
+		AuditTarget{
+			Type:      ProjectKind,
+			ID:        project.ID,
+			ProjectID: project.ID,
+		},
+		err,
+	)
+	return created, err
+}
+
+func (a *auditedProjectsService) Update(
+	ctx context.Context,
+	project Project,
+	opts ProjectUpdateOptions,
+) error {
+	err := a.ProjectsService.Update(ctx, project, opts)
+	a.recorder.Record(
+		ctx,
+		"projects.update",
+		AuditTarget{
+			Type:      ProjectKind,
+			ID:        project.ID,
+			ProjectID: project.ID,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedProjectsService) Delete(ctx context.Context, id string) error {
+	err := a.ProjectsService.Delete(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"projects.delete",
+		AuditTarget{
+			Type:      ProjectKind,
+			ID:        id,
+			ProjectID: id,
+		},
+		err,
+	)
+	return err
+}
+
+type auditedProjectRoleAssignmentsService struct {
+	ProjectRoleAssignmentsService
+	recorder AuditRecorder
+}
+
+// NewAuditedProjectRoleAssignmentsService returns a
+// ProjectRoleAssignmentsService that records the outcome of every mutating
+// operation performed via the provided ProjectRoleAssignmentsService.
+func NewAuditedProjectRoleAssignmentsService(
+	projectRoleAssignmentsService ProjectRoleAssignmentsService,
+	recorder AuditRecorder,
+) ProjectRoleAssignmentsService {
+	return &auditedProjectRoleAssignmentsService{
+		ProjectRoleAssignmentsService: projectRoleAssignmentsService,
+		recorder:                      recorder,
+	}
+}
+
+func (a *auditedProjectRoleAssignmentsService) Grant(
+	ctx context.Context,
+	projectRoleAssignment ProjectRoleAssignment,
+) error {
+	err := a.ProjectRoleAssignmentsService.Grant(ctx, projectRoleAssignment)
+	a.recorder.Record(
+		ctx,
+		"projectRoleAssignments.grant",
+		projectRoleAssignmentAuditTarget(projectRoleAssignment),
+		err,
+	)
+	return err
+}
+
+func (a *auditedProjectRoleAssignmentsService) Revoke(
+	ctx context.Context,
+	projectRoleAssignment ProjectRoleAssignment,
+) error {
+	err := a.ProjectRoleAssignmentsService.Revoke(ctx, projectRoleAssignment)
+	a.recorder.Record(
+		ctx,
+		"projectRoleAssignments.revoke",
+		projectRoleAssignmentAuditTarget(projectRoleAssignment),
+		err,
+	)
+	return err
+}
+
+type auditedRoleAssignmentsService struct {
+	RoleAssignmentsService
+	recorder AuditRecorder
+}
+
+// NewAuditedRoleAssignmentsService returns a RoleAssignmentsService that
+// records the outcome of every mutating operation performed via the provided
+// RoleAssignmentsService.
+func NewAuditedRoleAssignmentsService(
+	roleAssignmentsService RoleAssignmentsService,
+	recorder AuditRecorder,
+) RoleAssignmentsService {
+	return &auditedRoleAssignmentsService{
+		RoleAssignmentsService: roleAssignmentsService,
+		recorder:               recorder,
+	}
+}
+
+func (a *auditedRoleAssignmentsService) Grant(
+	ctx context.Context,
+	roleAssignment RoleAssignment,
+) error {
+	err := a.RoleAssignmentsService.Grant(ctx, roleAssignment)
+	a.recorder.Record(
+		ctx,
+		"roleAssignments.grant",
+		roleAssignmentAuditTarget(roleAssignment),
+		err,
+	)
+	return err
+}
+
+func (a *auditedRoleAssignmentsService) Revoke(
+	ctx context.Context,
+	roleAssignment RoleAssignment,
+) error {
+	err := a.RoleAssignmentsService.Revoke(ctx, roleAssignment)
+	a.recorder.Record(
+		ctx,
+		"roleAssignments.revoke",
+		roleAssignmentAuditTarget(roleAssignment),
+		err,
+	)
+	return err
+}
+
+type auditedSecretsService struct {
+	SecretsService
+	recorder AuditRecorder
+}
+
+// NewAuditedSecretsService returns a SecretsService that records the outcome
+// of every mutating operation performed via the provided SecretsService.
+// Secret values are never recorded.
+func NewAuditedSecretsService(
+	secretsService SecretsService,
+	recorder AuditRecorder,
+) SecretsService {
+	return &auditedSecretsService{
+		SecretsService: secretsService,
+		recorder:       recorder,
+	}
+}
+
+func (a *auditedSecretsService) Set(
+	ctx context.Context,
+	projectID string,
+	secret Secret,
+) error {
+	err := a.SecretsService.Set(ctx, projectID, secret)
+	a.recorder.Record(
+		ctx,
+		"secrets.set",
+		AuditTarget{
+			Type:      secretKind,
+			ID:        secret.Key,
+			ProjectID: projectID,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedSecretsService) Unset(
+	ctx context.Context,
+	projectID string,
+	key string,
+) error {
+	err := a.SecretsService.Unset(ctx, projectID, key)
+	a.recorder.Record(
+		ctx,
+		"secrets.unset",
+		AuditTarget{
+			Type:      secretKind,
+			ID:        key,
+			ProjectID: projectID,
+		},
+		err,
+	)
+	return err
+}
+
+type auditedServiceAccountsService struct {
+	ServiceAccountsService
+	recorder AuditRecorder
+}
+
+// NewAuditedServiceAccountsService returns a ServiceAccountsService that
+// records the outcome of every mutating operation performed via the provided
+// ServiceAccountsService.
+func NewAuditedServiceAccountsService(
+	serviceAccountsService ServiceAccountsService,
+	recorder AuditRecorder,
+) ServiceAccountsService {
+	return &auditedServiceAccountsService{
+		ServiceAccountsService: serviceAccountsService,
+		recorder:               recorder,
+	}
+}
+
+func (a *auditedServiceAccountsService) Create(
+	ctx context.Context,
+	serviceAccount ServiceAccount,
+) (Token, error) {
+	token, err := a.ServiceAccountsService.Create(ctx, serviceAccount)
+	a.recorder.Record(
+		ctx,
+		"serviceAccounts.create",
+		AuditTarget{
+			Type: ServiceAccountKind,
+			ID:   serviceAccount.ID,
+		},
+		err,
+	)
+	return token, err
+}
+
+func (a *auditedServiceAccountsService) Lock(
+	ctx context.Context,
+	id string,
+) error {
+	err := a.ServiceAccountsService.Lock(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"serviceAccounts.lock",
+		AuditTarget{
+			Type: ServiceAccountKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedServiceAccountsService) Unlock(
+	ctx context.Context,
+	id string,
+) (Token, error) {
+	token, err := a.ServiceAccountsService.Unlock(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"serviceAccounts.unlock",
+		AuditTarget{
+			Type: ServiceAccountKind,
+			ID:   id,
+		},
+		err,
+	)
+	return token, err
+}
+
+func (a *auditedServiceAccountsService) Delete(
+	ctx context.Context,
+	id string,
+) error {
+	err := a.ServiceAccountsService.Delete(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"serviceAccounts.delete",
+		AuditTarget{
+			Type: ServiceAccountKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+type auditedUsersService struct {
+	UsersService
+	recorder AuditRecorder
+}
+
+// NewAuditedUsersService returns a UsersService that records the outcome of
+// every mutating operation performed via the provided UsersService.
+func NewAuditedUsersService(
+	usersService UsersService,
+	recorder AuditRecorder,
+) UsersService {
+	return &auditedUsersService{
+		UsersService: usersService,
+		recorder:     recorder,
+	}
+}
+
+func (a *auditedUsersService) Lock(ctx context.Context, id string) error {
+	err := a.UsersService.Lock(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"users.lock",
+		AuditTarget{
+			Type: UserKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedUsersService) Unlock(ctx context.Context, id string) error {
+	err := a.UsersService.Unlock(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"users.unlock",
+		AuditTarget{
+			Type: UserKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+func (a *auditedUsersService) Delete(ctx context.Context, id string) error {
+	err := a.UsersService.Delete(ctx, id)
+	a.recorder.Record(
+		ctx,
+		"users.delete",
+		AuditTarget{
+			Type: UserKind,
+			ID:   id,
+		},
+		err,
+	)
+	return err
+}
+
+type auditedWebhooksService struct {
+	WebhooksService
+	recorder AuditRecorder
+}
+
+// NewAuditedWebhooksService returns a WebhooksService that records the outcome
+// of every mutating operation performed via the provided WebhooksService.
+// Events created by webhook deliveries are audited by the EventsService.
+func NewAuditedWebhooksService(
+	webhooksService WebhooksService,
+	recorder AuditRecorder,
+) WebhooksService {
+	return &auditedWebhooksService{
+		WebhooksService: webhooksService,
+		recorder:        recorder,
+	}
+}
+
+func (a *auditedWebhooksService) Create(
+	ctx context.Context,
+	webhook Webhook,
+) (Token, error) {
+	token, err := a.WebhooksService.Create(ctx, webhook)
+	a.recorder.Record(
+		ctx,
+		"webhooks.create",
+		AuditTarget{
+			Type:      WebhookKind,
+			ID:        webhook.ID,
+			ProjectID: webhook.ProjectID,
+		},
+		err,
+	)
+	return token, err
+}
+
+func (a *auditedWebhooksService) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+) error {
+	err := a.WebhooksService.Delete(ctx, projectID, id)
+	a.recorder.Record(
+		ctx,
+		"webhooks.delete",
+		AuditTarget{
+			Type:      WebhookKind,
+			ID:        id,
+			ProjectID: projectID,
+		},
+		err,
+	)
+	return err
+}
+
+// reasonDetails returns AuditTarget details conveying the provided reason, if
+// any.
+func reasonDetails(reason string) map[string]string {
+	details := map[string]string{}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	return details
+}
+
+// roleAssignmentAuditTarget returns an AuditTarget describing the provided
+// RoleAssignment.
+func roleAssignmentAuditTarget(roleAssignment RoleAssignment) AuditTarget {
+	return AuditTarget{
+		Type: RoleAssignmentKind,
+		ID: fmt.Sprintf(
+			"%s:%s:%s",
+			roleAssignment.Role,
+			roleAssignment.Principal.Type,
+			roleAssignment.Principal.ID,
+		),
+		Details: map[string]string{
+			"role":          string(roleAssignment.Role),
+			"principalType": string(roleAssignment.Principal.Type),
+			"principalID":   roleAssignment.Principal.ID,
+			"scope":         roleAssignment.Scope,
+		},
+	}
+}
+
+// projectRoleAssignmentAuditTarget returns an AuditTarget describing the
+// provided ProjectRoleAssignment.
+func projectRoleAssignmentAuditTarget(
+	projectRoleAssignment ProjectRoleAssignment,
+) AuditTarget {
+	return AuditTarget{
+		Type: ProjectRoleAssignmentKind,
+		ID: fmt.Sprintf(
+			"%s:%s:%s",
+			projectRoleAssignment.Role,
+			projectRoleAssignment.Principal.Type,
+			projectRoleAssignment.Principal.ID,
+		),
+		ProjectID: projectRoleAssignment.ProjectID,
+		Details: map[string]string{
+			"role":          string(projectRoleAssignment.Role),
+			"principalType": string(projectRoleAssignment.Principal.Type),
+			"principalID":   projectRoleAssignment.Principal.ID,
+		},
+	}
+}
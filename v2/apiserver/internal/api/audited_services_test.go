@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditedSecretsServiceSet(t *testing.T) {
+	const testProjectID = "italian"
+	testSecret := Secret{
+		Key:   "foo",
+		Value: "bar",
+	}
+	var recorded bool
+	svc := NewAuditedSecretsService(
+		&secretsService{
+			projectAuthorize: neverProjectAuthorize,
+		},
+		&mockAuditRecorder{
+			RecordFn: func(
+				_ context.Context,
+				action string,
+				target AuditTarget,
+				err error,
+			) {
+				recorded = true
+				require.Equal(t, "secrets.set", action)
+				require.Equal(
+					t,
+					AuditTarget{
+						Type:      secretKind,
+						ID:        testSecret.Key,
+						ProjectID: testProjectID,
+					},
+					target,
+				)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+	)
+	err := svc.Set(context.Background(), testProjectID, testSecret)
+	require.IsType(t, &meta.ErrAuthorization{}, err)
+	require.True(t, recorded)
+}
+
+func TestAuditedEventsServiceUpdateSourceState(t *testing.T) {
+	const testEventID = "123456789"
+	var recorded bool
+	svc := NewAuditedEventsService(
+		&eventsService{
+			authorize: func(_ context.Context, role Role, _ string) error {
+				if role == RoleReader {
+					return nil
+				}
+				return &meta.ErrAuthorization{}
+			},
+			eventsStore: &mockEventsStore{
+				GetFn: func(context.Context, string) (Event, error) {
+					return Event{
+						ObjectMeta: meta.ObjectMeta{
+							ID: testEventID,
+						},
+						ProjectID: "italian",
+					}, nil
+				},
+			},
+		},
+		&mockAuditRecorder{
+			RecordFn: func(
+				_ context.Context,
+				action string,
+				target AuditTarget,
+				err error,
+			) {
+				recorded = true
+				require.Equal(t, "events.updateSourceState", action)
+				require.Equal(
+					t,
+					AuditTarget{
+						Type:      EventKind,
+						ID:        testEventID,
+						ProjectID: "italian",
+					},
+					target,
+				)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+	)
+	err := svc.UpdateSourceState(
+		context.Background(),
+		testEventID,
+		SourceState{},
+		EventSourceStateUpdateOptions{},
+	)
+	require.IsType(t, &meta.ErrAuthorization{}, err)
+	require.True(t, recorded)
+}
+
+func TestAuditedEventsServiceCancelMany(t *testing.T) {
+	var recorded bool
+	svc := NewAuditedEventsService(
+		&eventsService{
+			projectAuthorize: neverProjectAuthorize,
+		},
+		&mockAuditRecorder{
+			RecordFn: func(
+				_ context.Context,
+				action string,
+				target AuditTarget,
+				err error,
+			) {
+				recorded = true
+				require.Equal(t, "events.cancelMany", action)
+				require.Equal(t, EventKind, target.Type)
+				require.Equal(t, "italian", target.ProjectID)
+				require.Equal(t, "no longer needed", target.Details["reason"])
+				require.Equal(t, "0", target.Details["count"])
+				require.Error(t, err)
+			},
+		},
+	)
+	_, err := svc.CancelMany(
+		context.Background(),
+		EventsSelector{
+			ProjectID: "italian",
+		},
+		EventCancelManyOptions{
+			Reason: "no longer needed",
+		},
+	)
+	require.Error(t, err)
+	require.True(t, recorded)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+// EventInputType represents the type of value an EventInput accepts.
+type EventInputType string
+
+const (
+	// EventInputTypeBoolean represents an EventInput that accepts a boolean
+	// value.
+	EventInputTypeBoolean EventInputType = "boolean"
+	// EventInputTypeEnum represents an EventInput that accepts one of an
+	// enumerated set of string values.
+	EventInputTypeEnum EventInputType = "enum"
+	// EventInputTypeString represents an EventInput that accepts any string
+	// value.
+	EventInputTypeString EventInputType = "string"
+)
+
+// EventInputs declares the inputs a Project accepts for Events having a
+// specific Source and Type. This is primarily useful for manually triggered
+// Events, e.g. an Event that requests deployment of a specific version to a
+// specific environment.
+type EventInputs struct {
+	// Source specifies the Source of the Events to which these input
+	// declarations apply.
+	Source string `json:"source,omitempty" bson:"source,omitempty"`
+	// Type specifies the Type of the Events to which these input declarations
+	// apply.
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
+	// Inputs enumerates the inputs accepted by Events having the specified
+	// Source and Type.
+	Inputs []EventInput `json:"inputs,omitempty" bson:"inputs,omitempty"`
+}
+
+// EventInput declares a single, typed input that may be supplied with an
+// Event.
+type EventInput struct {
+	// Name is the name of the input.
+	Name string `json:"name,omitempty" bson:"name,omitempty"`
+	// Description is an optional, natural language description of the input.
+	Description string `json:"description,omitempty" bson:"description,omitempty"` // nolint: lll
+	// Type specifies the type of value the input accepts. If unspecified, the
+	// input accepts any string value.
+	Type EventInputType `json:"type,omitempty" bson:"type,omitempty"`
+	// Required indicates whether a value MUST be supplied for the input. An
+	// input that is required but also specifies a Default is always satisfied.
+	Required bool `json:"required,omitempty" bson:"required,omitempty"`
+	// Default optionally specifies the value used for the input when no value
+	// is supplied.
+	Default string `json:"default,omitempty" bson:"default,omitempty"`
+	// Options enumerates the values accepted by an input of type enum.
+	Options []string `json:"options,omitempty" bson:"options,omitempty"`
+}
+
+// validate returns a slice of strings describing any problems with the value
+// supplied for the EventInput. The (possibly normalized) value is also
+// returned.
+func (e EventInput) validate(value string) (string, []string) {
+	switch e.Type {
+	case EventInputTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value, []string{
+				fmt.Sprintf("input %q: %q is not a valid boolean", e.Name, value),
+			}
+		}
+		return strconv.FormatBool(b), nil
+	case EventInputTypeEnum:
+		for _, option := range e.Options {
+			if value == option {
+				return value, nil
+			}
+		}
+		return value, []string{
+			fmt.Sprintf(
+				"input %q: %q is not one of the permitted values %q",
+				e.Name,
+				value,
+				e.Options,
+			),
+		}
+	}
+	return value, nil
+}
+
+// validateEventInputs returns a *meta.ErrBadRequest if any of the provided
+// EventInputs declarations is internally inconsistent, e.g. declares the same
+// input twice or declares an enum input with no options.
+func validateEventInputs(eventInputs []EventInputs) error {
+	var details []string
+	for i, decl := range eventInputs {
+		names := map[string]struct{}{}
+		for j, input := range decl.Inputs {
+			path := fmt.Sprintf("eventInputs[%d].inputs[%d]", i, j)
+			if _, ok := names[input.Name]; ok {
+				details = append(
+					details,
+					fmt.Sprintf("%s: duplicate input name %q", path, input.Name),
+				)
+			}
+			names[input.Name] = struct{}{}
+			if input.Type == EventInputTypeEnum && len(input.Options) == 0 {
+				details = append(
+					details,
+					fmt.Sprintf("%s: enum input %q has no options", path, input.Name),
+				)
+			}
+			if input.Default != "" {
+				if _, problems := input.validate(input.Default); len(problems) > 0 {
+					details = append(
+						details,
+						fmt.Sprintf("%s: invalid default: %s", path, problems[0]),
+					)
+				}
+			}
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Project contains one or more invalid event input declarations",
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// ResolveEventInputs validates the inputs supplied with the provided Event
+// against the Project's input declarations for Events of the same Source and
+// Type. If valid, the complete set of inputs, including defaults for any
+// omitted inputs, is returned. If the Project declares no inputs for such
+// Events, the Event's inputs are returned unaltered.
+func (p Project) ResolveEventInputs(event Event) (map[string]string, error) {
+	var decl *EventInputs
+	for i := range p.Spec.EventInputs {
+		if p.Spec.EventInputs[i].Source == event.Source &&
+			p.Spec.EventInputs[i].Type == event.Type {
+			decl = &p.Spec.EventInputs[i]
+			break
+		}
+	}
+	if decl == nil {
+		return event.Inputs, nil
+	}
+
+	var details []string
+	declared := map[string]struct{}{}
+	resolved := map[string]string{}
+	for _, input := range decl.Inputs {
+		declared[input.Name] = struct{}{}
+		value, supplied := event.Inputs[input.Name]
+		if !supplied {
+			if input.Default != "" {
+				resolved[input.Name] = input.Default
+			} else if input.Required {
+				details = append(
+					details,
+					fmt.Sprintf("input %q is required", input.Name),
+				)
+			}
+			continue
+		}
+		value, problems := input.validate(value)
+		details = append(details, problems...)
+		resolved[input.Name] = value
+	}
+	undeclared := []string{}
+	for name := range event.Inputs {
+		if _, ok := declared[name]; !ok {
+			undeclared = append(undeclared, name)
+		}
+	}
+	// Sort so that error details are deterministic
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		details = append(details, fmt.Sprintf("input %q is not declared", name))
+	}
+
+	if len(details) > 0 {
+		return nil, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				"Invalid inputs for project %q",
+				p.ID,
+			),
+			Details: details,
+		}
+	}
+	return resolved, nil
+}
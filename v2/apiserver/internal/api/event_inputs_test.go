@@ -0,0 +1,192 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventInputs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		eventInputs []EventInputs
+		assertions  func(error)
+	}{
+		{
+			name: "no declarations",
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid declarations",
+			eventInputs: []EventInputs{
+				{
+					Inputs: []EventInput{
+						{
+							Name: "environment",
+							Type: EventInputTypeEnum,
+						},
+						{
+							Name: "environment",
+						},
+						{
+							Name:    "dry-run",
+							Type:    EventInputTypeBoolean,
+							Default: "maybe",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`eventInputs[0].inputs[0]: enum input "environment" has no ` +
+							`options`,
+						`eventInputs[0].inputs[1]: duplicate input name "environment"`,
+						`eventInputs[0].inputs[2]: invalid default: input "dry-run": ` +
+							`"maybe" is not a valid boolean`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid declarations",
+			eventInputs: []EventInputs{
+				{
+					Inputs: []EventInput{
+						{
+							Name:    "environment",
+							Type:    EventInputTypeEnum,
+							Options: []string{"dev", "prod"},
+							Default: "dev",
+						},
+						{
+							Name:    "dry-run",
+							Type:    EventInputTypeBoolean,
+							Default: "false",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateEventInputs(testCase.eventInputs))
+		})
+	}
+}
+
+func TestProjectResolveEventInputs(t *testing.T) {
+	project := Project{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "italian",
+		},
+		Spec: ProjectSpec{
+			EventInputs: []EventInputs{
+				{
+					Source: "brigade.sh/cli",
+					Type:   "deploy",
+					Inputs: []EventInput{
+						{
+							Name:     "environment",
+							Type:     EventInputTypeEnum,
+							Required: true,
+							Options:  []string{"dev", "prod"},
+						},
+						{
+							Name:    "version",
+							Type:    EventInputTypeString,
+							Default: "latest",
+						},
+						{
+							Name: "dry-run",
+							Type: EventInputTypeBoolean,
+						},
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name       string
+		event      Event
+		assertions func(map[string]string, error)
+	}{
+		{
+			name: "no declarations for event",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "exec",
+				Inputs: map[string]string{
+					"foo": "bar",
+				},
+			},
+			assertions: func(inputs map[string]string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, map[string]string{"foo": "bar"}, inputs)
+			},
+		},
+		{
+			name: "invalid inputs",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "deploy",
+				Inputs: map[string]string{
+					"dry-run": "maybe",
+					"foo":     "bar",
+				},
+			},
+			assertions: func(_ map[string]string, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`input "environment" is required`,
+						`input "dry-run": "maybe" is not a valid boolean`,
+						`input "foo" is not declared`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid inputs",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "deploy",
+				Inputs: map[string]string{
+					"environment": "prod",
+					"dry-run":     "1",
+				},
+			},
+			assertions: func(inputs map[string]string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]string{
+						"environment": "prod",
+						"version":     "latest",
+						"dry-run":     "true",
+					},
+					inputs,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(project.ResolveEventInputs(testCase.event))
+		})
+	}
+}
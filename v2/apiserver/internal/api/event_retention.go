@@ -0,0 +1,346 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// RetentionPolicy specifies rules for automatically pruning Events whose
+// Workers have reached a terminal phase. Rules for Events whose Workers
+// succeeded are kept separately from rules for Events whose Workers did not.
+type RetentionPolicy struct {
+	// Succeeded specifies how long and/or how many Events whose Workers have
+	// SUCCEEDED should be retained.
+	Succeeded *RetentionRule `json:"succeeded,omitempty" bson:"succeeded,omitempty"` // nolint: lll
+	// Failed specifies how long and/or how many Events whose Workers have
+	// reached any terminal phase other than SUCCEEDED (i.e. ABORTED, CANCELED,
+	// FAILED, SCHEDULING_FAILED, or TIMED_OUT) should be retained.
+	Failed *RetentionRule `json:"failed,omitempty" bson:"failed,omitempty"`
+}
+
+// RetentionRule specifies limits on the age and/or number of Events that
+// should be retained. When both are specified, an Event is pruned when it
+// exceeds EITHER limit.
+type RetentionRule struct {
+	// MaxAge optionally specifies the age, expressed as a duration string (e.g.
+	// "720h"), beyond which Events are pruned.
+	MaxAge string `json:"maxAge,omitempty" bson:"maxAge,omitempty"`
+	// MaxCount optionally specifies how many of the most recent Events are
+	// retained. Older Events are pruned.
+	MaxCount int64 `json:"maxCount,omitempty" bson:"maxCount,omitempty"`
+}
+
+// merge returns a new RetentionPolicy wherein rules from the provided
+// RetentionPolicy override the corresponding rules of this one.
+func (r RetentionPolicy) merge(overrides *RetentionPolicy) RetentionPolicy {
+	if overrides == nil {
+		return r
+	}
+	if overrides.Succeeded != nil {
+		r.Succeeded = overrides.Succeeded
+	}
+	if overrides.Failed != nil {
+		r.Failed = overrides.Failed
+	}
+	return r
+}
+
+// validateRetentionPolicy returns a *meta.ErrBadRequest if the provided
+// RetentionPolicy contains any invalid rules.
+func validateRetentionPolicy(policy *RetentionPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	var details []string
+	for name, rule := range map[string]*RetentionRule{
+		"succeeded": policy.Succeeded,
+		"failed":    policy.Failed,
+	} {
+		if rule == nil || rule.MaxAge == "" {
+			continue
+		}
+		if maxAge, err := time.ParseDuration(rule.MaxAge); err != nil {
+			details = append(
+				details,
+				fmt.Sprintf("retentionPolicy.%s.maxAge: %s", name, err),
+			)
+		} else if maxAge <= 0 {
+			details = append(
+				details,
+				fmt.Sprintf("retentionPolicy.%s.maxAge: must be positive", name),
+			)
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Project contains an invalid retention policy",
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// ProjectPruneResult summarizes Events pruned from a single Project.
+type ProjectPruneResult struct {
+	// ProjectID identifies the Project Events were pruned from.
+	ProjectID string
+	// Succeeded is the number of Events whose Workers SUCCEEDED that were
+	// pruned.
+	Succeeded int64
+	// Failed is the number of Events whose Workers reached any other terminal
+	// phase that were pruned.
+	Failed int64
+}
+
+// eventsPrunerLeaseName is the name of the lease that permits only one API
+// server replica at a time to prune Events.
+const eventsPrunerLeaseName = "events-pruner"
+
+// EventsPrunerConfig encapsulates configuration for the EventsPruner.
+type EventsPrunerConfig struct {
+	// Interval specifies how frequently retention policies are applied.
+	Interval time.Duration
+	// DefaultRetentionPolicy specifies system-wide retention rules. Any rules
+	// specified by an individual Project's RetentionPolicy override the
+	// corresponding system-wide rule.
+	DefaultRetentionPolicy RetentionPolicy
+}
+
+// EventsPruner is an interface for a component that periodically prunes
+// Events (and their logs) in accordance with system-wide and Project-level
+// RetentionPolicies.
+type EventsPruner interface {
+	// Run periodically applies retention policies until the provided context is
+	// canceled.
+	Run(context.Context)
+	// Prune applies retention policies once and returns a summary of what was
+	// pruned from each affected Project.
+	Prune(context.Context) ([]ProjectPruneResult, error)
+}
+
+type eventsPruner struct {
+	projectsStore ProjectsStore
+	eventsStore   EventsStore
+	logsStore     CoolLogsStore
+	leasesStore   LeasesStore
+	substrate     Substrate
+	config        EventsPrunerConfig
+	// holder uniquely identifies this EventsPruner when acquiring the lease that
+	// permits only one API server replica at a time to prune Events.
+	holder string
+}
+
+// NewEventsPruner returns an implementation of the EventsPruner interface.
+func NewEventsPruner(
+	projectsStore ProjectsStore,
+	eventsStore EventsStore,
+	logsStore CoolLogsStore,
+	leasesStore LeasesStore,
+	substrate Substrate,
+	config EventsPrunerConfig,
+) EventsPruner {
+	return &eventsPruner{
+		projectsStore: projectsStore,
+		eventsStore:   eventsStore,
+		logsStore:     logsStore,
+		leasesStore:   leasesStore,
+		substrate:     substrate,
+		config:        config,
+		holder:        uuid.NewV4().String(),
+	}
+}
+
+func (e *eventsPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.pruneIfLeaseHeld(ctx)
+		}
+	}
+}
+
+// pruneIfLeaseHeld applies retention policies once, but only if this
+// EventsPruner holds, or is able to acquire, the lease that permits only one
+// API server replica at a time to do so. Every replica runs an EventsPruner,
+// so without the lease, each would attempt to prune the same Events. The lease
+// lasts for two intervals so that the replica holding it renews it well
+// before it expires, while another replica takes over if the holder goes away.
+func (e *eventsPruner) pruneIfLeaseHeld(ctx context.Context) {
+	held, err := e.leasesStore.Acquire(
+		ctx,
+		eventsPrunerLeaseName,
+		e.holder,
+		time.Now().UTC().Add(2*e.config.Interval),
+	)
+	if err != nil {
+		log.Println(errors.Wrap(err, "error acquiring events pruner lease"))
+		return
+	}
+	if !held {
+		return
+	}
+	results, err := e.Prune(ctx)
+	if err != nil {
+		log.Println(errors.Wrap(err, "error pruning events"))
+	}
+	for _, result := range results {
+		log.Printf(
+			"pruned %d succeeded and %d failed events from project %q",
+			result.Succeeded,
+			result.Failed,
+			result.ProjectID,
+		)
+	}
+}
+
+func (e *eventsPruner) Prune(
+	ctx context.Context,
+) ([]ProjectPruneResult, error) {
+	var results []ProjectPruneResult
+	now := time.Now().UTC()
+	listOpts := meta.ListOptions{Limit: 100}
+	for {
+		projects, err := e.projectsStore.List(ctx, listOpts)
+		if err != nil {
+			return results, errors.Wrap(err, "error retrieving projects from store")
+		}
+		for _, project := range projects.Items {
+			policy :=
+				e.config.DefaultRetentionPolicy.merge(project.Spec.RetentionPolicy)
+			result := ProjectPruneResult{ProjectID: project.ID}
+			if result.Succeeded, err = e.pruneProjectEvents(
+				ctx,
+				project,
+				[]WorkerPhase{WorkerPhaseSucceeded},
+				policy.Succeeded,
+				now,
+			); err != nil {
+				return results, err
+			}
+			if result.Failed, err = e.pruneProjectEvents(
+				ctx,
+				project,
+				[]WorkerPhase{
+					WorkerPhaseAborted,
+					WorkerPhaseCanceled,
+					WorkerPhaseFailed,
+					WorkerPhaseSchedulingFailed,
+					WorkerPhaseTimedOut,
+				},
+				policy.Failed,
+				now,
+			); err != nil {
+				return results, err
+			}
+			if result.Succeeded > 0 || result.Failed > 0 {
+				results = append(results, result)
+			}
+		}
+		if projects.Continue == "" {
+			return results, nil
+		}
+		listOpts.Continue = projects.Continue
+	}
+}
+
+// pruneProjectEvents deletes the provided Project's Events whose Workers are
+// in any of the specified phases and that are not retained by the provided
+// RetentionRule. It returns the number of Events deleted.
+func (e *eventsPruner) pruneProjectEvents(
+	ctx context.Context,
+	project Project,
+	phases []WorkerPhase,
+	rule *RetentionRule,
+	now time.Time,
+) (int64, error) {
+	if rule == nil {
+		return 0, nil
+	}
+
+	// Events created before the cutoff are pruned. Whichever of the rule's
+	// limits yields the later cutoff is the one that applies.
+	var cutoff *time.Time
+	if rule.MaxAge != "" {
+		maxAge, err := time.ParseDuration(rule.MaxAge)
+		if err != nil {
+			return 0, errors.Wrapf(
+				err,
+				"error parsing retention policy max age for project %q",
+				project.ID,
+			)
+		}
+		ageCutoff := now.Add(-maxAge)
+		cutoff = &ageCutoff
+	}
+	if rule.MaxCount > 0 {
+		events, err := e.eventsStore.List(
+			ctx,
+			EventsSelector{
+				ProjectID:    project.ID,
+				WorkerPhases: phases,
+			},
+			meta.ListOptions{Limit: rule.MaxCount},
+		)
+		if err != nil {
+			return 0, errors.Wrapf(
+				err,
+				"error retrieving events for project %q from store",
+				project.ID,
+			)
+		}
+		// If there are more events than we're meant to retain, the oldest event
+		// retained determines the cutoff.
+		if events.Continue != "" && int64(len(events.Items)) == rule.MaxCount {
+			countCutoff := events.Items[rule.MaxCount-1].Created
+			if countCutoff != nil && (cutoff == nil || countCutoff.After(*cutoff)) {
+				cutoff = countCutoff
+			}
+		}
+	}
+	if cutoff == nil {
+		return 0, nil
+	}
+
+	eventCh, count, err := e.eventsStore.DeleteMany(
+		ctx,
+		EventsSelector{
+			ProjectID:     project.ID,
+			WorkerPhases:  phases,
+			CreatedBefore: cutoff,
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrapf(
+			err,
+			"error deleting events for project %q from store",
+			project.ID,
+		)
+	}
+	for event := range eventCh {
+		if err := e.substrate.DeleteWorkerAndJobs(ctx, project, event); err != nil {
+			log.Println(errors.Wrapf(
+				err,
+				"error deleting event %q worker and jobs from the substrate",
+				event.ID,
+			))
+		}
+		if err := e.logsStore.DeleteEventLogs(ctx, event.ID); err != nil {
+			log.Println(errors.Wrapf(
+				err,
+				"error deleting logs for event %q",
+				event.ID,
+			))
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,445 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicyMerge(t *testing.T) {
+	defaults := RetentionPolicy{
+		Succeeded: &RetentionRule{MaxAge: "720h"},
+		Failed:    &RetentionRule{MaxCount: 100},
+	}
+	testCases := []struct {
+		name      string
+		overrides *RetentionPolicy
+		expected  RetentionPolicy
+	}{
+		{
+			name:     "no overrides",
+			expected: defaults,
+		},
+		{
+			name: "partial overrides",
+			overrides: &RetentionPolicy{
+				Failed: &RetentionRule{MaxAge: "24h"},
+			},
+			expected: RetentionPolicy{
+				Succeeded: &RetentionRule{MaxAge: "720h"},
+				Failed:    &RetentionRule{MaxAge: "24h"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, defaults.merge(testCase.overrides))
+		})
+	}
+}
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *RetentionPolicy
+		assertions func(error)
+	}{
+		{
+			name: "nil policy",
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "unparsable max age",
+			policy: &RetentionPolicy{
+				Succeeded: &RetentionRule{MaxAge: "a month"},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Len(t, err.(*meta.ErrBadRequest).Details, 1)
+				require.Contains(
+					t,
+					err.(*meta.ErrBadRequest).Details[0],
+					"retentionPolicy.succeeded.maxAge",
+				)
+			},
+		},
+		{
+			name: "non-positive max age",
+			policy: &RetentionPolicy{
+				Failed: &RetentionRule{MaxAge: "-1h"},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{"retentionPolicy.failed.maxAge: must be positive"},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid policy",
+			policy: &RetentionPolicy{
+				Succeeded: &RetentionRule{MaxAge: "720h", MaxCount: 100},
+				Failed:    &RetentionRule{MaxCount: 1000},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateRetentionPolicy(testCase.policy))
+		})
+	}
+}
+
+func TestNewEventsPruner(t *testing.T) {
+	projectsStore := &mockProjectsStore{}
+	eventsStore := &mockEventsStore{}
+	logsStore := &mockLogsStore{}
+	leasesStore := &mockLeasesStore{}
+	substrate := &mockSubstrate{}
+	config := EventsPrunerConfig{Interval: time.Minute}
+	pruner, ok := NewEventsPruner(
+		projectsStore,
+		eventsStore,
+		logsStore,
+		leasesStore,
+		substrate,
+		config,
+	).(*eventsPruner)
+	require.True(t, ok)
+	require.Same(t, projectsStore, pruner.projectsStore)
+	require.Same(t, eventsStore, pruner.eventsStore)
+	require.Same(t, logsStore, pruner.logsStore)
+	require.Same(t, leasesStore, pruner.leasesStore)
+	require.Same(t, substrate, pruner.substrate)
+	require.Equal(t, config, pruner.config)
+	require.NotEmpty(t, pruner.holder)
+}
+
+func TestEventsPrunerPruneIfLeaseHeld(t *testing.T) {
+	testCases := []struct {
+		name          string
+		acquireFn     func() (bool, error)
+		expectPruning bool
+	}{
+		{
+			name: "error acquiring lease",
+			acquireFn: func() (bool, error) {
+				return false, errors.New("something went wrong")
+			},
+		},
+		{
+			name: "lease held by another replica",
+			acquireFn: func() (bool, error) {
+				return false, nil
+			},
+		},
+		{
+			name: "lease held",
+			acquireFn: func() (bool, error) {
+				return true, nil
+			},
+			expectPruning: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var pruned bool
+			pruner := &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						pruned = true
+						return ProjectList{}, nil
+					},
+				},
+				leasesStore: &mockLeasesStore{
+					AcquireFn: func(
+						_ context.Context,
+						name string,
+						holder string,
+						until time.Time,
+					) (bool, error) {
+						require.Equal(t, eventsPrunerLeaseName, name)
+						require.Equal(t, "tony", holder)
+						require.True(t, until.After(time.Now().Add(time.Hour)))
+						return testCase.acquireFn()
+					},
+				},
+				config: EventsPrunerConfig{Interval: time.Hour},
+				holder: "tony",
+			}
+			pruner.pruneIfLeaseHeld(context.Background())
+			require.Equal(t, testCase.expectPruning, pruned)
+		})
+	}
+}
+
+func TestEventsPrunerPrune(t *testing.T) {
+	oldest := time.Now().UTC().Add(-time.Hour)
+	testCases := []struct {
+		name       string
+		pruner     *eventsPruner
+		assertions func([]ProjectPruneResult, error)
+	}{
+		{
+			name: "error listing projects",
+			pruner: &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						return ProjectList{}, errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(_ []ProjectPruneResult, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error retrieving projects from store")
+			},
+		},
+		{
+			name: "no applicable retention rules",
+			pruner: &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{{ObjectMeta: meta.ObjectMeta{ID: "italian"}}},
+						}, nil
+					},
+				},
+			},
+			assertions: func(results []ProjectPruneResult, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "error listing events",
+			pruner: &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{{ObjectMeta: meta.ObjectMeta{ID: "italian"}}},
+						}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{}, errors.New("something went wrong")
+					},
+				},
+				config: EventsPrunerConfig{
+					DefaultRetentionPolicy: RetentionPolicy{
+						Succeeded: &RetentionRule{MaxCount: 1},
+					},
+				},
+			},
+			assertions: func(_ []ProjectPruneResult, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(
+					t,
+					err.Error(),
+					`error retrieving events for project "italian" from store`,
+				)
+			},
+		},
+		{
+			name: "error deleting events",
+			pruner: &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{{ObjectMeta: meta.ObjectMeta{ID: "italian"}}},
+						}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					DeleteManyFn: func(
+						context.Context,
+						EventsSelector,
+					) (<-chan Event, int64, error) {
+						return nil, 0, errors.New("something went wrong")
+					},
+				},
+				config: EventsPrunerConfig{
+					DefaultRetentionPolicy: RetentionPolicy{
+						Succeeded: &RetentionRule{MaxAge: "1h"},
+					},
+				},
+			},
+			assertions: func(_ []ProjectPruneResult, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(
+					t,
+					err.Error(),
+					`error deleting events for project "italian" from store`,
+				)
+			},
+		},
+		{
+			name: "success",
+			pruner: &eventsPruner{
+				projectsStore: &mockProjectsStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{
+								{
+									ObjectMeta: meta.ObjectMeta{ID: "italian"},
+									Spec: ProjectSpec{
+										// Overrides the system-wide rule for failed events
+										RetentionPolicy: &RetentionPolicy{
+											Failed: &RetentionRule{MaxCount: 1},
+										},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					ListFn: func(
+						_ context.Context,
+						selector EventsSelector,
+						opts meta.ListOptions,
+					) (EventList, error) {
+						require.Equal(t, "italian", selector.ProjectID)
+						require.NotContains(
+							t,
+							selector.WorkerPhases,
+							WorkerPhaseSucceeded,
+						)
+						require.Equal(t, int64(1), opts.Limit)
+						return EventList{
+							ListMeta: meta.ListMeta{
+								Continue:           "more",
+								RemainingItemCount: 1,
+							},
+							Items: []Event{
+								{
+									ObjectMeta: meta.ObjectMeta{
+										Created: &oldest,
+									},
+								},
+							},
+						}, nil
+					},
+					DeleteManyFn: func(
+						_ context.Context,
+						selector EventsSelector,
+					) (<-chan Event, int64, error) {
+						require.Equal(t, "italian", selector.ProjectID)
+						require.NotNil(t, selector.CreatedBefore)
+						var count int64 = 1
+						if len(selector.WorkerPhases) == 1 {
+							// Succeeded events; pruned by age
+							require.Equal(
+								t,
+								[]WorkerPhase{WorkerPhaseSucceeded},
+								selector.WorkerPhases,
+							)
+							require.True(t, selector.CreatedBefore.After(oldest))
+							count = 2
+						} else {
+							// Failed events; pruned by count
+							require.Equal(t, oldest, *selector.CreatedBefore)
+						}
+						eventCh := make(chan Event, count)
+						for i := int64(0); i < count; i++ {
+							eventCh <- Event{}
+						}
+						close(eventCh)
+						return eventCh, count, nil
+					},
+				},
+				logsStore: &mockLogsStore{
+					DeleteEventLogsFn: func(context.Context, string) error {
+						return errors.New("something went wrong")
+					},
+				},
+				substrate: &mockSubstrate{
+					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
+						return nil
+					},
+				},
+				config: EventsPrunerConfig{
+					DefaultRetentionPolicy: RetentionPolicy{
+						Succeeded: &RetentionRule{MaxAge: "30m"},
+						Failed:    &RetentionRule{MaxAge: "720h"},
+					},
+				},
+			},
+			assertions: func(results []ProjectPruneResult, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]ProjectPruneResult{
+						{
+							ProjectID: "italian",
+							Succeeded: 2,
+							Failed:    1,
+						},
+					},
+					results,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(testCase.pruner.Prune(context.Background()))
+		})
+	}
+}
+
+type mockLeasesStore struct {
+	AcquireFn func(
+		ctx context.Context,
+		name string,
+		holder string,
+		until time.Time,
+	) (bool, error)
+}
+
+func (m *mockLeasesStore) Acquire(
+	ctx context.Context,
+	name string,
+	holder string,
+	until time.Time,
+) (bool, error) {
+	return m.AcquireFn(ctx, name, holder, until)
+}
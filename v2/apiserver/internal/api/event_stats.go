@@ -0,0 +1,201 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+const (
+	// EventStatsKind represents the canonical EventStats kind string
+	EventStatsKind = "EventStats"
+
+	defaultEventStatsWindow     = 7 * 24 * time.Hour
+	defaultEventStatsBucketSize = time.Hour
+	maxEventStatsBuckets        = 1000
+)
+
+// EventStatsGrouping represents a dimension along which Event statistics are
+// aggregated.
+type EventStatsGrouping string
+
+const (
+	// EventStatsGroupingProject represents aggregation of Event statistics by
+	// Project.
+	EventStatsGroupingProject EventStatsGrouping = "PROJECT"
+	// EventStatsGroupingSourceType represents aggregation of Event statistics by
+	// Event source and type.
+	EventStatsGroupingSourceType EventStatsGrouping = "SOURCE_TYPE"
+	// EventStatsGroupingTime represents aggregation of Event statistics into
+	// fixed-size time buckets, based on when each Event was created.
+	EventStatsGroupingTime EventStatsGrouping = "TIME"
+)
+
+// EventStatsOptions represents criteria for aggregating Event statistics.
+type EventStatsOptions struct {
+	// ProjectID optionally specifies that only Events belonging to the
+	// indicated Project should be considered.
+	ProjectID string
+	// GroupBy specifies how Event statistics should be grouped. If not
+	// specified, EventStatsGroupingProject is assumed.
+	GroupBy EventStatsGrouping
+	// Since specifies the beginning of the window of time in which Events must
+	// have been created to be considered. If not specified, a window of seven
+	// days, ending at Until, is assumed.
+	Since time.Time
+	// Until specifies the end of the window of time in which Events must have
+	// been created to be considered. If not specified, the current time is
+	// assumed.
+	Until time.Time
+	// BucketSize specifies the size of each time bucket. This is applicable only
+	// when GroupBy is EventStatsGroupingTime. If not specified, one hour is
+	// assumed.
+	BucketSize time.Duration
+}
+
+// EventStats represents aggregated statistics about Events created within a
+// window of time.
+type EventStats struct {
+	// GroupBy indicates how the statistics are grouped.
+	GroupBy EventStatsGrouping `json:"groupBy"`
+	// Since indicates the beginning of the window of time the statistics cover.
+	Since time.Time `json:"since"`
+	// Until indicates the end of the window of time the statistics cover.
+	Until time.Time `json:"until"`
+	// BucketSize indicates the size of each time bucket, expressed as a
+	// duration string (e.g. "1h0m0s"). It is only populated when GroupBy is
+	// EventStatsGroupingTime.
+	BucketSize string `json:"bucketSize,omitempty"`
+	// Groups contains statistics for each group.
+	Groups []EventStatsGroup `json:"groups,omitempty"`
+}
+
+// MarshalJSON amends EventStats instances with type metadata.
+func (e EventStats) MarshalJSON() ([]byte, error) {
+	type Alias EventStats
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       EventStatsKind,
+			},
+			Alias: (Alias)(e),
+		},
+	)
+}
+
+// EventStatsGroup represents aggregated statistics about a group of Events.
+// Which of ProjectID, Source, Type, and BucketStart are populated depends on
+// how the statistics were grouped.
+type EventStatsGroup struct {
+	// ProjectID identifies the Project to which all Events in the group belong.
+	ProjectID string `json:"projectID,omitempty"`
+	// Source is the source of all Events in the group.
+	Source string `json:"source,omitempty"`
+	// Type is the type of all Events in the group.
+	Type string `json:"type,omitempty"`
+	// BucketStart indicates the beginning of the time bucket in which all Events
+	// in the group were created.
+	BucketStart *time.Time `json:"bucketStart,omitempty"`
+	// Total is the number of Events in the group.
+	Total int64 `json:"total"`
+	// PhaseCounts breaks down the number of Events in the group by the phase of
+	// their Workers. Phases with no Events are omitted.
+	PhaseCounts map[WorkerPhase]int64 `json:"phaseCounts,omitempty"`
+	// SuccessRate is the fraction, between 0 and 1, of Events in the group whose
+	// Workers reached a terminal phase that SUCCEEDED.
+	SuccessRate float64 `json:"successRate"`
+	// QueueWait summarizes how long Events in the group waited between creation
+	// and their Workers starting.
+	QueueWait DurationPercentiles `json:"queueWait"`
+	// RunDuration summarizes how long the Workers of Events in the group ran
+	// between starting and reaching a terminal phase.
+	RunDuration DurationPercentiles `json:"runDuration"`
+	// JobFailureRates breaks down the failure rate of Jobs spawned by Workers
+	// of Events in the group by Job name, highest failure rate first.
+	JobFailureRates []JobFailureRate `json:"jobFailureRates,omitempty"`
+}
+
+// DurationPercentiles summarizes a distribution of durations. Implementations
+// MAY estimate percentiles rather than compute them exactly.
+type DurationPercentiles struct {
+	// Count is the number of durations in the distribution.
+	Count int64 `json:"count"`
+	// P50Seconds is the median duration, in seconds.
+	P50Seconds float64 `json:"p50Seconds"`
+	// P95Seconds is the 95th percentile duration, in seconds.
+	P95Seconds float64 `json:"p95Seconds"`
+}
+
+// JobFailureRate represents the failure rate of all Jobs having a given name.
+type JobFailureRate struct {
+	// JobName is the name of the Jobs.
+	JobName string `json:"jobName"`
+	// Total is the number of Jobs having the name that reached a terminal
+	// phase.
+	Total int64 `json:"total"`
+	// Failed is the number of Jobs having the name that reached a terminal phase
+	// other than SUCCEEDED.
+	Failed int64 `json:"failed"`
+	// FailureRate is the fraction, between 0 and 1, of Jobs having the name
+	// that reached a terminal phase other than SUCCEEDED.
+	FailureRate float64 `json:"failureRate"`
+}
+
+// applyDefaults returns a copy of the EventStatsOptions with defaults applied
+// to any unspecified fields.
+func (e EventStatsOptions) applyDefaults(now time.Time) EventStatsOptions {
+	if e.GroupBy == "" {
+		e.GroupBy = EventStatsGroupingProject
+	}
+	if e.Until.IsZero() {
+		e.Until = now
+	}
+	if e.Since.IsZero() {
+		e.Since = e.Until.Add(-defaultEventStatsWindow)
+	}
+	if e.GroupBy == EventStatsGroupingTime && e.BucketSize == 0 {
+		e.BucketSize = defaultEventStatsBucketSize
+	}
+	return e
+}
+
+// validate returns a *meta.ErrBadRequest if the EventStatsOptions are invalid.
+func (e EventStatsOptions) validate() error {
+	var details []string
+	switch e.GroupBy {
+	case EventStatsGroupingProject,
+		EventStatsGroupingSourceType,
+		EventStatsGroupingTime:
+	default:
+		details = append(details, fmt.Sprintf("unrecognized grouping %q", e.GroupBy))
+	}
+	if !e.Since.Before(e.Until) {
+		details = append(details, "since must be before until")
+	}
+	if e.GroupBy == EventStatsGroupingTime {
+		if e.BucketSize < time.Minute {
+			details = append(details, "bucket size must be at least one minute")
+		} else if e.Until.Sub(e.Since)/e.BucketSize > maxEventStatsBuckets {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"window may not span more than %d buckets",
+					maxEventStatsBuckets,
+				),
+			)
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Invalid event statistics options",
+			Details: details,
+		}
+	}
+	return nil
+}
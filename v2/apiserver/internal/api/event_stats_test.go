@@ -0,0 +1,125 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStatsMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, &EventStats{}, EventStatsKind)
+}
+
+func TestEventStatsOptionsApplyDefaults(t *testing.T) {
+	now := time.Now().UTC()
+	opts := EventStatsOptions{
+		GroupBy: EventStatsGroupingTime,
+	}.applyDefaults(now)
+	require.Equal(t, now, opts.Until)
+	require.Equal(t, now.Add(-defaultEventStatsWindow), opts.Since)
+	require.Equal(t, defaultEventStatsBucketSize, opts.BucketSize)
+	opts = EventStatsOptions{}.applyDefaults(now)
+	require.Equal(t, EventStatsGroupingProject, opts.GroupBy)
+	require.Zero(t, opts.BucketSize)
+}
+
+func TestEventStatsOptionsValidate(t *testing.T) {
+	until := time.Now().UTC()
+	since := until.Add(-24 * time.Hour)
+	testCases := []struct {
+		name       string
+		opts       EventStatsOptions
+		assertions func(error)
+	}{
+		{
+			name: "unrecognized grouping",
+			opts: EventStatsOptions{
+				GroupBy: "COLOR",
+				Since:   since,
+				Until:   until,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{`unrecognized grouping "COLOR"`},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "window ends before it begins",
+			opts: EventStatsOptions{
+				GroupBy: EventStatsGroupingProject,
+				Since:   until,
+				Until:   since,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{"since must be before until"},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "bucket size too small",
+			opts: EventStatsOptions{
+				GroupBy:    EventStatsGroupingTime,
+				Since:      since,
+				Until:      until,
+				BucketSize: time.Second,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{"bucket size must be at least one minute"},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "too many buckets",
+			opts: EventStatsOptions{
+				GroupBy:    EventStatsGroupingTime,
+				Since:      until.Add(-30 * 24 * time.Hour),
+				Until:      until,
+				BucketSize: time.Minute,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{"window may not span more than 1000 buckets"},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid options",
+			opts: EventStatsOptions{
+				GroupBy:    EventStatsGroupingTime,
+				Since:      since,
+				Until:      until,
+				BucketSize: time.Hour,
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(testCase.opts.validate())
+		})
+	}
+}
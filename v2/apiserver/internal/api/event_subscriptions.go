@@ -0,0 +1,350 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// celEnv is the CEL environment in which all EventSubscription filters are
+// compiled and evaluated. Filters have access to a single variable, "event",
+// which is a map containing the Event's source, type, qualifiers, labels, git
+// details, and payload. If the payload is valid JSON, it is made available in
+// its parsed form. Otherwise, it is made available as a string.
+var celEnv *cel.Env
+
+// eventSubscriptionCacheSize is the maximum number of compiled filters and
+// compiled glob patterns that are each retained in memory.
+const eventSubscriptionCacheSize = 1024
+
+// The same EventSubscriptions are matched against every Event from a given
+// source, so compiled filters and glob patterns are cached, keyed by the
+// filter or pattern itself. Compiled programs and regular expressions are both
+// safe for concurrent use.
+var (
+	eventFilterCache *lru.Cache
+	globPatternCache *lru.Cache
+)
+
+func init() {
+	var err error
+	if celEnv, err = cel.NewEnv(
+		cel.Declarations(
+			decls.NewVar("event", decls.NewMapType(decls.String, decls.Dyn)),
+		),
+	); err != nil {
+		panic(errors.Wrap(err, "error initializing CEL environment"))
+	}
+	if eventFilterCache, err = lru.New(eventSubscriptionCacheSize); err != nil {
+		panic(errors.Wrap(err, "error initializing event filter cache"))
+	}
+	if globPatternCache, err = lru.New(eventSubscriptionCacheSize); err != nil {
+		panic(errors.Wrap(err, "error initializing glob pattern cache"))
+	}
+}
+
+// compileEventFilter compiles the provided CEL expression into an executable
+// cel.Program. An error is returned if the expression is invalid or if it does
+// not evaluate to a boolean.
+func compileEventFilter(filter string) (cel.Program, error) {
+	ast, issues := celEnv.Compile(filter)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	switch ast.ResultType().GetTypeKind().(type) {
+	case *exprpb.Type_Dyn:
+	case *exprpb.Type_Primitive:
+		if ast.ResultType().GetPrimitive() != exprpb.Type_BOOL {
+			return nil, errors.New("filter does not evaluate to a boolean")
+		}
+	default:
+		return nil, errors.New("filter does not evaluate to a boolean")
+	}
+	return celEnv.Program(ast)
+}
+
+// ValidateFilter returns an error if the EventSubscription's Filter field is
+// non-empty and is not a valid CEL expression that evaluates to a boolean.
+func (e EventSubscription) ValidateFilter() error {
+	if e.Filter == "" {
+		return nil
+	}
+	_, err := compileEventFilter(e.Filter)
+	return err
+}
+
+// validateEventSubscriptions returns a *meta.ErrBadRequest if any of the
+// provided EventSubscriptions specifies an invalid Filter.
+func validateEventSubscriptions(subscriptions []EventSubscription) error {
+	var details []string
+	for i, subscription := range subscriptions {
+		if err := subscription.ValidateFilter(); err != nil {
+			details = append(
+				details,
+				fmt.Sprintf("eventSubscriptions[%d].filter: %s", i, err),
+			)
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Project contains one or more invalid event subscriptions",
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// IsSubscribedTo returns a boolean indicating whether ANY of the Project's
+// EventSubscriptions matches the provided Event. EventSubscriptions whose
+// Filters cannot be evaluated against the Event are treated as non-matching.
+// This is evaluated for every subscriber of every Event, so such errors are not
+// reported here. Mismatches() reports them instead, which surfaces them in dry
+// runs.
+func (p Project) IsSubscribedTo(event Event) bool {
+	for _, subscription := range p.Spec.EventSubscriptions {
+		if matched, _ := subscription.Matches(event); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns a boolean indicating whether the provided Event meets ALL of
+// the EventSubscription's criteria. Types, qualifier values, and label values
+// in the EventSubscription may be expressed as glob patterns, wherein "*"
+// matches any sequence of characters and "?" matches any single character. If
+// the EventSubscription specifies a Filter, it is evaluated last. An error is
+// returned only if the Filter cannot be evaluated.
+func (e EventSubscription) Matches(event Event) (bool, error) {
+	mismatches, err := e.mismatches(event)
+	return err == nil && len(mismatches) == 0, err
+}
+
+// Mismatches returns natural language descriptions of each of the
+// EventSubscription's criteria that the provided Event fails to meet. An empty
+// result indicates a match. As with Matches(), the Filter is only evaluated if
+// all other criteria are met. If the Filter cannot be evaluated, that is
+// reported as a mismatch.
+func (e EventSubscription) Mismatches(event Event) []string {
+	mismatches, err := e.mismatches(event)
+	if err != nil {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("filter could not be evaluated: %s", errors.Cause(err)),
+		)
+	}
+	return mismatches
+}
+
+func (e EventSubscription) mismatches(event Event) ([]string, error) {
+	var mismatches []string
+	if e.Source != event.Source {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf("source %q does not match %q", event.Source, e.Source),
+		)
+	}
+	if !e.matchesType(event.Type) {
+		mismatches = append(
+			mismatches,
+			fmt.Sprintf(
+				"type %q does not match any of [%s]",
+				event.Type,
+				strings.Join(e.Types, ", "),
+			),
+		)
+	}
+	// Qualifiers are "MUST match" in BOTH directions-- every qualifier on the
+	// Event must be matched by the subscription and every qualifier on the
+	// subscription must be matched by the Event.
+	for _, key := range sortedKeys(event.Qualifiers) {
+		if _, ok := e.Qualifiers[key]; !ok {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("qualifier %q is not expected by subscription", key),
+			)
+		}
+	}
+	for _, key := range sortedKeys(e.Qualifiers) {
+		pattern := e.Qualifiers[key]
+		value, ok := event.Qualifiers[key]
+		if !ok {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("event is missing qualifier %q", key),
+			)
+		} else if !globMatch(pattern, value) {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf(
+					"qualifier %q value %q does not match %q",
+					key,
+					value,
+					pattern,
+				),
+			)
+		}
+	}
+	// Labels are "MAY match"-- additional labels on the Event do not preclude
+	// a match.
+	for _, key := range sortedKeys(e.Labels) {
+		pattern := e.Labels[key]
+		value, ok := event.Labels[key]
+		if !ok {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf("event is missing label %q", key),
+			)
+		} else if !globMatch(pattern, value) {
+			mismatches = append(
+				mismatches,
+				fmt.Sprintf(
+					"label %q value %q does not match %q",
+					key,
+					value,
+					pattern,
+				),
+			)
+		}
+	}
+	if len(mismatches) > 0 || e.Filter == "" {
+		return mismatches, nil
+	}
+	matched, err := evaluateEventFilter(e.Filter, event)
+	if err != nil {
+		return mismatches, err
+	}
+	if !matched {
+		mismatches = append(mismatches, "filter evaluated to false")
+	}
+	return mismatches, nil
+}
+
+func (e EventSubscription) matchesType(eventType string) bool {
+	for _, pattern := range e.Types {
+		if globMatch(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// cachedEventFilter returns the compiled form of the provided CEL expression,
+// compiling it only if it isn't already cached.
+func cachedEventFilter(filter string) (cel.Program, error) {
+	if program, ok := eventFilterCache.Get(filter); ok {
+		return program.(cel.Program), nil
+	}
+	program, err := compileEventFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	eventFilterCache.Add(filter, program)
+	return program, nil
+}
+
+// evaluateEventFilter evaluates the provided CEL expression against the
+// provided Event.
+func evaluateEventFilter(filter string, event Event) (bool, error) {
+	program, err := cachedEventFilter(filter)
+	if err != nil {
+		return false, errors.Wrapf(err, "error compiling filter %q", filter)
+	}
+	out, _, err := program.Eval(
+		map[string]interface{}{
+			"event": eventFilterInput(event),
+		},
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "error evaluating filter %q", filter)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf(
+			"filter %q did not evaluate to a boolean",
+			filter,
+		)
+	}
+	return matched, nil
+}
+
+// eventFilterInput returns the representation of the provided Event that is
+// exposed to CEL filters.
+func eventFilterInput(event Event) map[string]interface{} {
+	qualifiers := map[string]string{}
+	for k, v := range event.Qualifiers {
+		qualifiers[k] = v
+	}
+	labels := map[string]string{}
+	for k, v := range event.Labels {
+		labels[k] = v
+	}
+	git := map[string]string{}
+	if event.Git != nil {
+		git["cloneURL"] = event.Git.CloneURL
+		git["commit"] = event.Git.Commit
+		git["ref"] = event.Git.Ref
+	}
+	var payload interface{} = event.Payload
+	var parsedPayload interface{}
+	if err := json.Unmarshal([]byte(event.Payload), &parsedPayload); err == nil {
+		payload = parsedPayload
+	}
+	return map[string]interface{}{
+		"source":     event.Source,
+		"type":       event.Type,
+		"qualifiers": qualifiers,
+		"labels":     labels,
+		"git":        git,
+		"payload":    payload,
+	}
+}
+
+// sortedKeys returns the keys of the provided map in lexical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// globMatch returns a boolean indicating whether the provided value matches
+// the provided glob pattern, wherein "*" matches any sequence of characters
+// (including "/") and "?" matches any single character. Patterns containing
+// neither of these must match the value exactly.
+func globMatch(pattern, value string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == value
+	}
+	if regex, ok := globPatternCache.Get(pattern); ok {
+		return regex.(*regexp.Regexp).MatchString(value)
+	}
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	// Every rune that isn't a wildcard is quoted, so this cannot fail
+	regex := regexp.MustCompile(sb.String())
+	globPatternCache.Add(pattern, regex)
+	return regex.MatchString(value)
+}
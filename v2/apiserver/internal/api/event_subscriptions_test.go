@@ -0,0 +1,478 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventSubscriptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		subscriptions []EventSubscription
+		assertions    func(error)
+	}{
+		{
+			name: "no filters",
+			subscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"*"},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "valid filter",
+			subscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"*"},
+					Filter: `event.payload.foo == "bar"`,
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "filter with syntax error",
+			subscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"*"},
+					Filter: `event.type ==`,
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Len(t, err.(*meta.ErrBadRequest).Details, 1)
+				require.Contains(
+					t,
+					err.(*meta.ErrBadRequest).Details[0],
+					"eventSubscriptions[0].filter",
+				)
+			},
+		},
+		{
+			name: "filter does not evaluate to a boolean",
+			subscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"*"},
+					Filter: `"foo"`,
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(
+					t,
+					err.(*meta.ErrBadRequest).Details[0],
+					"does not evaluate to a boolean",
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateEventSubscriptions(testCase.subscriptions))
+		})
+	}
+}
+
+func TestEventSubscriptionMatches(t *testing.T) {
+	testEvent := Event{
+		Source: "brigade.sh/github",
+		Type:   "pull_request:opened",
+		Qualifiers: Qualifiers{
+			"repo": "example-org/example-repo",
+		},
+		Labels: map[string]string{
+			"ref": "refs/heads/release-1.0",
+		},
+		Git: &GitDetails{
+			Ref: "refs/heads/release-1.0",
+		},
+		Payload: `{"pull_request":{"base":{"ref":"main"}}}`,
+	}
+	testCases := []struct {
+		name         string
+		subscription EventSubscription
+		assertions   func(bool, error)
+	}{
+		{
+			name: "source does not match",
+			subscription: EventSubscription{
+				Source: "brigade.sh/bitbucket",
+				Types:  []string{"*"},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "type does not match",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"push", "pull_request:closed"},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "type matches glob",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"pull_request:*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.True(t, matched)
+			},
+		},
+		{
+			name: "subscription missing event qualifier",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "event missing subscription qualifier",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+					"foo":  "*",
+				},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "qualifier matches glob",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/*",
+				},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.True(t, matched)
+			},
+		},
+		{
+			name: "label does not match",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Labels: map[string]string{
+					"ref": "refs/heads/main",
+				},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "label matches glob",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Labels: map[string]string{
+					"ref": "refs/heads/release-*",
+				},
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.True(t, matched)
+			},
+		},
+		{
+			name: "filter does not match",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Filter: `event.payload.pull_request.base.ref == "develop"`,
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.False(t, matched)
+			},
+		},
+		{
+			name: "filter matches",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Filter: `event.payload.pull_request.base.ref == "main" && ` +
+					`event.git.ref.startsWith("refs/heads/release-")`,
+			},
+			assertions: func(matched bool, err error) {
+				require.NoError(t, err)
+				require.True(t, matched)
+			},
+		},
+		{
+			name: "error evaluating filter",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"*"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Filter: `event.payload.issue.number == 42`,
+			},
+			assertions: func(matched bool, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error evaluating filter")
+				require.False(t, matched)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(testCase.subscription.Matches(testEvent))
+		})
+	}
+}
+
+func TestEventSubscriptionMismatches(t *testing.T) {
+	testEvent := Event{
+		Source: "brigade.sh/github",
+		Type:   "push",
+		Qualifiers: Qualifiers{
+			"repo": "example-org/example-repo",
+		},
+		Labels: map[string]string{
+			"ref": "refs/heads/main",
+		},
+	}
+	testCases := []struct {
+		name         string
+		subscription EventSubscription
+		assertions   func([]string)
+	}{
+		{
+			name: "match",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"push"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/*",
+				},
+			},
+			assertions: func(mismatches []string) {
+				require.Empty(t, mismatches)
+			},
+		},
+		{
+			name: "multiple criteria not met",
+			subscription: EventSubscription{
+				Source: "brigade.sh/bitbucket",
+				Types:  []string{"pull_request:*"},
+				Qualifiers: Qualifiers{
+					"repo":  "other-org/*",
+					"forge": "*",
+				},
+				Labels: map[string]string{
+					"ref":    "refs/tags/*",
+					"author": "*",
+				},
+				Filter: `event.payload.foo == "bar"`,
+			},
+			assertions: func(mismatches []string) {
+				require.Equal(
+					t,
+					[]string{
+						`source "brigade.sh/github" does not match "brigade.sh/bitbucket"`,
+						`type "push" does not match any of [pull_request:*]`,
+						`event is missing qualifier "forge"`,
+						`qualifier "repo" value "example-org/example-repo" does not ` +
+							`match "other-org/*"`,
+						`event is missing label "author"`,
+						`label "ref" value "refs/heads/main" does not match ` +
+							`"refs/tags/*"`,
+					},
+					mismatches,
+				)
+			},
+		},
+		{
+			name: "unexpected qualifier",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"push"},
+			},
+			assertions: func(mismatches []string) {
+				require.Equal(
+					t,
+					[]string{`qualifier "repo" is not expected by subscription`},
+					mismatches,
+				)
+			},
+		},
+		{
+			name: "filter evaluates to false",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"push"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Filter: `event.labels.ref == "refs/heads/develop"`,
+			},
+			assertions: func(mismatches []string) {
+				require.Equal(t, []string{"filter evaluated to false"}, mismatches)
+			},
+		},
+		{
+			name: "filter cannot be evaluated",
+			subscription: EventSubscription{
+				Source: "brigade.sh/github",
+				Types:  []string{"push"},
+				Qualifiers: Qualifiers{
+					"repo": "example-org/example-repo",
+				},
+				Filter: `event.payload.foo == "bar"`,
+			},
+			assertions: func(mismatches []string) {
+				require.Len(t, mismatches, 1)
+				require.Contains(t, mismatches[0], "filter could not be evaluated")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(testCase.subscription.Mismatches(testEvent))
+		})
+	}
+}
+
+func TestProjectIsSubscribedTo(t *testing.T) {
+	testEvent := Event{
+		Source: "brigade.sh/cli",
+		Type:   "exec",
+	}
+	project := Project{
+		Spec: ProjectSpec{
+			EventSubscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"exec"},
+					Filter: "event.payload.foo", // Cannot be evaluated
+				},
+			},
+		},
+	}
+	require.False(t, project.IsSubscribedTo(testEvent))
+	project.Spec.EventSubscriptions = append(
+		project.Spec.EventSubscriptions,
+		EventSubscription{
+			Source: "brigade.sh/cli",
+			Types:  []string{"e?ec"},
+		},
+	)
+	require.True(t, project.IsSubscribedTo(testEvent))
+}
+
+func TestGlobMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		value   string
+		matched bool
+	}{
+		{"push", "push", true},
+		{"push", "pushed", false},
+		{"*", "", true},
+		{"*", "anything/at/all", true},
+		{"push:*", "push:tag", true},
+		{"push:*", "pull_request:opened", false},
+		{"refs/heads/*", "refs/heads/feature/foo", true},
+		{"v?.0", "v1.0", true},
+		{"v?.0", "v10.0", false},
+		{"a.c", "abc", false}, // "." is not a wildcard
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern+" "+testCase.value, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.matched,
+				globMatch(testCase.pattern, testCase.value),
+			)
+			// Again, now that any compiled pattern is cached
+			require.Equal(
+				t,
+				testCase.matched,
+				globMatch(testCase.pattern, testCase.value),
+			)
+		})
+	}
+	require.True(t, globPatternCache.Contains("push:*"))
+	require.False(t, globPatternCache.Contains("push"))
+}
+
+func TestCachedEventFilter(t *testing.T) {
+	const testFilter = `event.type == "cached"`
+	_, err := cachedEventFilter("event.type ==")
+	require.Error(t, err)
+	require.False(t, eventFilterCache.Contains("event.type =="))
+	program, err := cachedEventFilter(testFilter)
+	require.NoError(t, err)
+	require.True(t, eventFilterCache.Contains(testFilter))
+	cachedProgram, err := cachedEventFilter(testFilter)
+	require.NoError(t, err)
+	require.Equal(t, program, cachedProgram)
+}
+
+// subscribedProjects returns a ProjectList containing the specified number of
+// Projects, each of which is subscribed to ALL Events from the provided
+// Event's source that are qualified identically to the provided Event.
+func subscribedProjects(event Event, count int) ProjectList {
+	projects := ProjectList{
+		Items: make([]Project, count),
+	}
+	for i := range projects.Items {
+		projects.Items[i].Spec.EventSubscriptions = []EventSubscription{
+			{
+				Source:     event.Source,
+				Types:      []string{"*"},
+				Qualifiers: event.Qualifiers,
+			},
+		}
+	}
+	return projects
+}
@@ -25,7 +25,22 @@ const (
 	// on any retried event
 	RetryLabelKey = "brigade.sh/retryOf"
 
+	// RetryFromJobLabelKey is the label key used for recording, on any event
+	// retried from a specific job, the name of the job from which execution
+	// resumes
+	RetryFromJobLabelKey = "brigade.sh/retryFromJob"
+
 	defaultWorkspaceSize = "10Gi"
+
+	// maxDryRunSampleSize is the maximum number of Event IDs included in the
+	// result of a dry run of a mass cancellation or deletion
+	maxDryRunSampleSize = 10
+
+	// maxCopyManySelectionSize is the maximum number of Events that may be
+	// selected by a single request to retry or clone multiple Events. Each
+	// selected Event is copied synchronously, so this bounds the time taken to
+	// handle such a request.
+	maxCopyManySelectionSize = 100
 )
 
 // Event represents an occurrence in some upstream system. Once accepted into
@@ -103,6 +118,11 @@ type Event struct {
 	// to begin with) is that event payloads may contain REFERENCES to sensitive
 	// details that are useful only to properly configured Workers.
 	Payload string `json:"payload,omitempty" bson:"payload,omitempty"`
+	// Inputs optionally contains values for inputs declared by a Project for
+	// Events having this Event's Source and Type. When the Event is created for
+	// such a Project, these are validated against the Project's declarations and
+	// any defaults are applied.
+	Inputs map[string]string `json:"inputs,omitempty" bson:"inputs,omitempty"`
 	// Summary is a counterpart to Payload. If Payload is free-form Worker input,
 	// then Summary is free-form Worker output. It can optionally be set by a
 	// Worker to provide a summary of the work completed by the Worker and its
@@ -185,6 +205,23 @@ type EventsSelector struct {
 	// Labels specifies that only Events labeled with these key/value pairs should
 	// be selected.
 	Labels map[string]string
+	// CreatedAfter specifies that only Events created after the indicated time
+	// should be selected.
+	CreatedAfter *time.Time
+	// CreatedBefore specifies that only Events created before the indicated time
+	// should be selected.
+	CreatedBefore *time.Time
+	// Commit specifies that only Events whose git details reference the
+	// indicated commit (by SHA) should be selected.
+	Commit string
+	// Ref specifies that only Events whose git details reference the indicated
+	// git ref (e.g. a branch or tag) should be selected.
+	Ref string
+	// Text specifies that only Events whose ShortTitle, LongTitle, or Summary
+	// match the indicated text search should be selected. The search is
+	// case-insensitive and matches whole words. Multiple words are ORed
+	// together, while quoted phrases must match exactly.
+	Text string
 }
 
 // EventList is an ordered and pageable list of Events.
@@ -212,11 +249,76 @@ func (e EventList) MarshalJSON() ([]byte, error) {
 	)
 }
 
+// EventSourceStateUpdateOptions represents useful, optional settings for
+// updating an Event's SourceState.
+type EventSourceStateUpdateOptions struct {
+	// ResourceVersion, when non-zero, specifies that the update should only be
+	// applied if the Event's current ResourceVersion matches. This permits
+	// clients to avoid overwriting SourceState updated by others in the interim.
+	ResourceVersion int64
+}
+
+// EventCancelOptions represents useful, optional settings for canceling an
+// Event.
+type EventCancelOptions struct {
+	// Reason is an optional, human-readable explanation of why the Event is
+	// being canceled.
+	Reason string
+}
+
+// EventCancelManyOptions represents useful, optional settings for canceling
+// many Events.
+type EventCancelManyOptions struct {
+	// Reason is an optional, human-readable explanation of why the Events are
+	// being canceled.
+	Reason string
+	// DryRun indicates that the Events that would be canceled should be
+	// returned without actually canceling them.
+	DryRun bool
+}
+
+// EventDeleteOptions represents useful, optional settings for deleting an
+// Event.
+type EventDeleteOptions struct {
+	// Reason is an optional, human-readable explanation of why the Event is
+	// being deleted.
+	Reason string
+}
+
+// EventDeleteManyOptions represents useful, optional settings for deleting
+// many Events.
+type EventDeleteManyOptions struct {
+	// Reason is an optional, human-readable explanation of why the Events are
+	// being deleted.
+	Reason string
+	// DryRun indicates that the Events that would be deleted should be returned
+	// without actually deleting them.
+	DryRun bool
+}
+
+// EventRetryOptions represents useful, optional settings for retrying an
+// Event.
+type EventRetryOptions struct {
+	// FromJob optionally names a Job of the original Event from which execution
+	// should resume. Eligible Jobs preceding it are inherited by the retry and
+	// it and all Jobs following it are re-executed. If not specified, execution
+	// resumes from the first Job that did not succeed.
+	FromJob string
+}
+
 // CancelManyEventsResult represents a summary of a mass Event cancellation
 // operation.
 type CancelManyEventsResult struct {
-	// Count represents the number of Events canceled.
+	// Count represents the number of Events canceled or, for a dry run, the
+	// number of Events that would have been canceled.
 	Count int64 `json:"count"`
+	// DryRun indicates whether the operation was a dry run, in which case no
+	// Events were actually canceled.
+	DryRun bool `json:"dryRun,omitempty"`
+	// SampleIDs enumerates the IDs of up to maxDryRunSampleSize of the Events
+	// that would have been canceled, newest first. It is only populated for a dry
+	// run.
+	SampleIDs []string `json:"sampleIDs,omitempty"`
 }
 
 // MarshalJSON amends CancelManyEventsResult instances with type metadata.
@@ -239,8 +341,16 @@ func (c CancelManyEventsResult) MarshalJSON() ([]byte, error) {
 // DeleteManyEventsResult represents a summary of a mass Event deletion
 // operation.
 type DeleteManyEventsResult struct {
-	// Count represents the number of Events deleted.
+	// Count represents the number of Events deleted or, for a dry run, the
+	// number of Events that would have been deleted.
 	Count int64 `json:"count"`
+	// DryRun indicates whether the operation was a dry run, in which case no
+	// Events were actually deleted.
+	DryRun bool `json:"dryRun,omitempty"`
+	// SampleIDs enumerates the IDs of up to maxDryRunSampleSize of the Events
+	// that would have been deleted, newest first. It is only populated for a dry
+	// run.
+	SampleIDs []string `json:"sampleIDs,omitempty"`
 }
 
 // MarshalJSON amends DeleteManyEventsResult instances with type metadata.
@@ -260,6 +370,123 @@ func (d DeleteManyEventsResult) MarshalJSON() ([]byte, error) {
 	)
 }
 
+// EventCopyResult represents the outcome of retrying or cloning a single Event
+// as part of a mass retry or clone operation.
+type EventCopyResult struct {
+	// SourceEventID is the identifier of the Event that was retried or cloned.
+	SourceEventID string `json:"sourceEventID"`
+	// EventID is the identifier of the new Event that was created. It is empty
+	// if the operation failed.
+	EventID string `json:"eventID,omitempty"`
+	// Error describes why the operation failed. It is empty if the operation
+	// succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// RetryManyEventsResult represents a summary of a mass Event retry operation.
+type RetryManyEventsResult struct {
+	// Count represents the number of Events successfully retried.
+	Count int64 `json:"count"`
+	// FailedCount represents the number of Events that could not be retried.
+	FailedCount int64 `json:"failedCount"`
+	// Items enumerates the outcome of retrying each selected Event.
+	Items []EventCopyResult `json:"items,omitempty"`
+}
+
+// MarshalJSON amends RetryManyEventsResult instances with type metadata.
+func (r RetryManyEventsResult) MarshalJSON() ([]byte, error) {
+	type Alias RetryManyEventsResult
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "RetryManyEventsResult",
+			},
+			Alias: (Alias)(r),
+		},
+	)
+}
+
+// CloneManyEventsResult represents a summary of a mass Event clone operation.
+type CloneManyEventsResult struct {
+	// Count represents the number of Events successfully cloned.
+	Count int64 `json:"count"`
+	// FailedCount represents the number of Events that could not be cloned.
+	FailedCount int64 `json:"failedCount"`
+	// Items enumerates the outcome of cloning each selected Event.
+	Items []EventCopyResult `json:"items,omitempty"`
+}
+
+// MarshalJSON amends CloneManyEventsResult instances with type metadata.
+func (c CloneManyEventsResult) MarshalJSON() ([]byte, error) {
+	type Alias CloneManyEventsResult
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "CloneManyEventsResult",
+			},
+			Alias: (Alias)(c),
+		},
+	)
+}
+
+// EventCreateDryRunResult represents the outcome of evaluating a prospective
+// Event against Projects' EventSubscriptions without actually creating any
+// Events.
+type EventCreateDryRunResult struct {
+	// Subscribers enumerates the IDs of Projects that would have been sent the
+	// Event.
+	Subscribers []string `json:"subscribers,omitempty"`
+	// NonSubscribers enumerates Projects that were evaluated, but would NOT
+	// have been sent the Event, along with an explanation of why not.
+	NonSubscribers []NonSubscriber `json:"nonSubscribers,omitempty"`
+}
+
+// MarshalJSON amends EventCreateDryRunResult instances with type metadata.
+func (e EventCreateDryRunResult) MarshalJSON() ([]byte, error) {
+	type Alias EventCreateDryRunResult
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "EventCreateDryRunResult",
+			},
+			Alias: (Alias)(e),
+		},
+	)
+}
+
+// NonSubscriber describes a Project that would not have been sent an Event
+// and explains why.
+type NonSubscriber struct {
+	// ProjectID is the ID of the Project.
+	ProjectID string `json:"projectID"`
+	// Subscriptions describes, for each of the Project's EventSubscriptions,
+	// which criteria the Event failed to meet. If empty, the Project has no
+	// EventSubscriptions at all.
+	Subscriptions []SubscriptionMismatch `json:"subscriptions,omitempty"`
+}
+
+// SubscriptionMismatch describes which criteria of a single EventSubscription
+// an Event failed to meet.
+type SubscriptionMismatch struct {
+	// Index is the position of the EventSubscription within the Project's list
+	// of EventSubscriptions.
+	Index int `json:"index"`
+	// Reasons enumerates the criteria the Event failed to meet.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
 // EventsService is the specialized interface for managing Events. It's
 // decoupled from underlying technology choices (e.g. data store, message bus,
 // etc.) to keep business logic reusable and consistent while the underlying
@@ -270,6 +497,12 @@ type EventsService interface {
 		EventList,
 		error,
 	)
+	// CreateDryRun evaluates the provided Event against Projects'
+	// EventSubscriptions exactly as Create() would, but, instead of creating any
+	// Events, returns an EventCreateDryRunResult indicating which Projects
+	// would have been sent the Event and why others would not. If the Event
+	// references a specific Project, only that Project is evaluated.
+	CreateDryRun(context.Context, Event) (EventCreateDryRunResult, error)
 	// List retrieves an EventList, with its Items (Events) ordered by age, newest
 	// first. Criteria for which Events should be retrieved can be specified using
 	// the EventListOptions parameter.
@@ -278,6 +511,10 @@ type EventsService interface {
 		EventsSelector,
 		meta.ListOptions,
 	) (EventList, error)
+	// Stats aggregates statistics about Events created within a window of time
+	// in accordance with the provided EventStatsOptions. If the options are
+	// invalid, implementations MUST return a *meta.ErrBadRequest error.
+	Stats(context.Context, EventStatsOptions) (EventStats, error)
 	// Get retrieves a single Event specified by its identifier. If no such event
 	// is found, implementations MUST return a *meta.ErrNotFound error.
 	Get(context.Context, string) (Event, error)
@@ -289,8 +526,15 @@ type EventsService interface {
 	// metadata and Worker configuration
 	Clone(context.Context, string) (Event, error)
 	// UpdateSourceState updates source-specific (e.g. gateway-specific) Event
-	// state.
-	UpdateSourceState(context.Context, string, SourceState) error
+	// state. If a ResourceVersion is specified via the
+	// EventSourceStateUpdateOptions parameter and it does not match the Event's
+	// current ResourceVersion, implementations MUST return a *meta.ErrConflict.
+	UpdateSourceState(
+		context.Context,
+		string,
+		SourceState,
+		EventSourceStateUpdateOptions,
+	) error
 	// UpdateSummary updates the opaque, Worker-specific summary of work performed
 	// by the Worker and its Jobs.
 	UpdateSummary(context.Context, string, EventSummary) error
@@ -299,29 +543,55 @@ type EventsService interface {
 	// Implementations MUST only cancel events whose Workers have not already
 	// reached a terminal state. If the specified Event's Worker has already
 	// reached a terminal state, implementations MUST return a *meta.ErrConflict.
-	Cancel(context.Context, string) error
+	// Implementations MUST record the provided reason and the principal found in
+	// the context.Context in the Worker's status.
+	Cancel(context.Context, string, EventCancelOptions) error
 	// CancelMany cancels multiple Events specified by the EventsSelector
 	// parameter. Implementations MUST only cancel events whose Workers have not
-	// already reached a terminal state.
+	// already reached a terminal state. Implementations MUST record the provided
+	// reason and the principal found in the context.Context in each Worker's
+	// status. If a dry run is requested, implementations MUST return the Events
+	// that would be canceled without canceling them.
 	CancelMany(
 		context.Context,
 		EventsSelector,
+		EventCancelManyOptions,
 	) (CancelManyEventsResult, error)
 	// Delete unconditionally deletes a single Event specified by its identifier.
 	// If no such event is found, implementations MUST return a *meta.ErrNotFound
 	// error.
-	Delete(context.Context, string) error
+	Delete(context.Context, string, EventDeleteOptions) error
 	// DeleteMany unconditionally deletes multiple Events specified by the
-	// EventsSelector parameter.
+	// EventsSelector parameter. If a dry run is requested, implementations MUST
+	// return the Events that would be deleted without deleting them.
 	DeleteMany(
 		context.Context,
 		EventsSelector,
+		EventDeleteManyOptions,
 	) (DeleteManyEventsResult, error)
 	// Retry copies an Event, including Worker configuration and Jobs, and
 	// creates a new Event from this information.  Where possible, job results
 	// are inherited and the job not re-scheduled, for example when a job has
-	// succeeded and does not make use of a shared workspace.
-	Retry(context.Context, string) (Event, error)
+	// succeeded and does not make use of a shared workspace. If
+	// EventRetryOptions specifies a Job to retry from, implementations MUST NOT
+	// inherit that Job or any Job following it and MUST return a
+	// *meta.ErrBadRequest error if the original Event has no such Job.
+	Retry(context.Context, string, EventRetryOptions) (Event, error)
+	// RetryMany retries multiple Events specified by the EventsSelector
+	// parameter, exactly as Retry would retry each of them individually.
+	// Implementations MUST only select Events whose Workers have reached a
+	// terminal phase. Failure to retry any individual Event MUST NOT prevent the
+	// others from being retried and MUST be reported in the result. If more than
+	// maxCopyManySelectionSize Events are selected, implementations MUST return
+	// a *meta.ErrBadRequest error without retrying any of them.
+	RetryMany(context.Context, EventsSelector) (RetryManyEventsResult, error)
+	// CloneMany clones multiple Events specified by the EventsSelector
+	// parameter, exactly as Clone would clone each of them individually. Failure
+	// to clone any individual Event MUST NOT prevent the others from being
+	// cloned and MUST be reported in the result. If more than
+	// maxCopyManySelectionSize Events are selected, implementations MUST return
+	// a *meta.ErrBadRequest error without cloning any of them.
+	CloneMany(context.Context, EventsSelector) (CloneManyEventsResult, error)
 }
 
 type eventsService struct {
@@ -329,8 +599,10 @@ type eventsService struct {
 	projectAuthorize    ProjectAuthorizeFn
 	projectsStore       ProjectsStore
 	eventsStore         EventsStore
+	gatewaysStore       GatewaysStore
 	logsStore           CoolLogsStore
 	substrate           Substrate
+	auditRecorder       AuditRecorder
 	createSingleEventFn func(context.Context, Project, Event) (Event, error)
 }
 
@@ -340,16 +612,20 @@ func NewEventsService(
 	projectAuthorize ProjectAuthorizeFn,
 	projectsStore ProjectsStore,
 	eventsStore EventsStore,
+	gatewaysStore GatewaysStore,
 	logsStore CoolLogsStore,
 	substrate Substrate,
+	auditRecorder AuditRecorder,
 ) EventsService {
 	e := &eventsService{
 		authorize:        authorizeFn,
 		projectAuthorize: projectAuthorize,
 		projectsStore:    projectsStore,
 		eventsStore:      eventsStore,
+		gatewaysStore:    gatewaysStore,
 		logsStore:        logsStore,
 		substrate:        substrate,
+		auditRecorder:    auditRecorder,
 	}
 	e.createSingleEventFn = e.createSingleEvent
 	return e
@@ -361,6 +637,130 @@ func (e *eventsService) Create(
 ) (EventList, error) {
 	events := EventList{}
 
+	if err := e.authorizeCreate(ctx, event); err != nil {
+		return events, err
+	}
+
+	now := time.Now().UTC()
+	event.Created = &now
+
+	if gateway, ok := PrincipalFromContext(ctx).(*Gateway); ok {
+		if err := e.gatewaysStore.UpdateLastSeen(ctx, gateway.ID, now); err != nil {
+			return events, errors.Wrapf(
+				err,
+				"error updating last seen time of gateway %q in store",
+				gateway.ID,
+			)
+		}
+	}
+
+	if event.ProjectID != "" {
+		// There's only one possible subscriber, so there's no need to look up
+		// any others.
+		project, err := e.projectsStore.Get(ctx, event.ProjectID)
+		if err != nil {
+			return events, errors.Wrapf(
+				err,
+				"error retrieving project %q from store",
+				event.ProjectID,
+			)
+		}
+
+		if !project.IsSubscribedTo(event) {
+			return events, nil
+		}
+
+		if event.Inputs, err = project.ResolveEventInputs(event); err != nil {
+			return events, err
+		}
+
+		evt, err := e.createSingleEventFn(ctx, project, event)
+		events.Items = []Event{evt}
+		return events, err
+	}
+
+	candidates, err := e.projectsStore.ListSubscribers(ctx, event)
+	if err != nil {
+		return events, errors.Wrap(
+			err,
+			"error retrieving subscribed projects from store",
+		)
+	}
+	// The store only narrows candidates by source and type. Types, qualifiers,
+	// and labels may be glob patterns and subscriptions may specify CEL filters,
+	// so the remaining criteria are evaluated here.
+	subscribers := ProjectList{}
+	for _, project := range candidates.Items {
+		if project.IsSubscribedTo(event) {
+			subscribers.Items = append(subscribers.Items, project)
+		}
+	}
+
+	// If we get to here, no project ID is specified, so we iterate over all
+	// subscribed projects in the list and create a discrete event for each.
+	// Each subscriber declares its own inputs, so a subscriber whose inputs
+	// cannot be resolved is skipped without affecting any other subscriber.
+	// Inputs are resolved for every subscriber before any event is created so
+	// that, if NO subscriber's inputs can be resolved, the caller can be told
+	// why.
+	resolvedSubscribers := make([]Project, 0, len(subscribers.Items))
+	inputs := make([]map[string]string, 0, len(subscribers.Items))
+	var inputErrs []string
+	for _, project := range subscribers.Items {
+		projectInputs, err := project.ResolveEventInputs(event)
+		if err != nil {
+			log.Println(
+				errors.Wrapf(
+					err,
+					"not creating event for subscribed project %q",
+					project.ID,
+				),
+			)
+			inputErrs = append(inputErrs, inputErrorDetails(err)...)
+			continue
+		}
+		resolvedSubscribers = append(resolvedSubscribers, project)
+		inputs = append(inputs, projectInputs)
+	}
+	if len(resolvedSubscribers) == 0 && len(inputErrs) > 0 {
+		return events, &meta.ErrBadRequest{
+			Reason:  "Invalid inputs for every subscribed project",
+			Details: inputErrs,
+		}
+	}
+	events.Items = make([]Event, len(resolvedSubscribers))
+	for i, project := range resolvedSubscribers {
+		event.ProjectID = project.ID
+		event.Inputs = inputs[i]
+		evt, err := e.createSingleEventFn(ctx, project, event)
+		if err != nil {
+			return events, err
+		}
+		events.Items[i] = evt
+	}
+	return events, nil
+}
+
+// inputErrorDetails returns error details, each prefixed with the Project it
+// applies to, from the error returned by Project.ResolveEventInputs().
+func inputErrorDetails(err error) []string {
+	badReqErr, ok := err.(*meta.ErrBadRequest)
+	if !ok {
+		return []string{err.Error()}
+	}
+	details := make([]string, len(badReqErr.Details))
+	for i, detail := range badReqErr.Details {
+		details[i] = fmt.Sprintf("%s: %s", badReqErr.Reason, detail)
+	}
+	return details
+}
+
+// authorizeCreate returns an error if the principal associated with the
+// provided context is not permitted to create the provided Event.
+func (e *eventsService) authorizeCreate(
+	ctx context.Context,
+	event Event,
+) error {
 	if event.ProjectID == "" {
 		// This event doesn't reference a discrete project and is instead going to
 		// be matched to all subscribing projects, so the only access requirement is
@@ -372,7 +772,7 @@ func (e *eventsService) Create(
 			RoleEventCreator,
 			event.Source,
 		); err != nil {
-			return events, err
+			return err
 		}
 	} else {
 		// This event references a discrete project, so the access requirement is
@@ -391,53 +791,103 @@ func (e *eventsService) Create(
 				RoleEventCreator,
 				event.Source,
 			); err != nil {
-				return events, err
+				return err
 			}
+		} else if event.Labels[RetryLabelKey] != "" ||
+			event.Labels[CloneLabelKey] != "" {
+			// A retry or clone copies an existing event, including its source. Any
+			// project user may retry or clone the project's events, even if their
+			// source is owned by a Gateway.
+			return nil
 		}
 	}
-
-	now := time.Now().UTC()
-	event.Created = &now
-
-	subscribers, err := e.projectsStore.ListSubscribers(ctx, event)
+	// Regardless of any other permissions the principal may have, if a new
+	// event's source is owned by a Gateway, only that Gateway may create events
+	// from that source.
+	gateway, err := e.gatewaysStore.GetBySource(ctx, event.Source)
 	if err != nil {
-		return events, errors.Wrap(
+		if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+			return nil
+		}
+		return errors.Wrapf(
 			err,
-			"error retrieving subscribed projects from store",
+			"error retrieving gateway owning source %q from store",
+			event.Source,
 		)
 	}
+	if principal, ok :=
+		PrincipalFromContext(ctx).(*Gateway); !ok || principal.ID != gateway.ID {
+		return &meta.ErrAuthorization{
+			Reason: fmt.Sprintf(
+				"Events from source %q may only be created by gateway %q.",
+				event.Source,
+				gateway.ID,
+			),
+		}
+	}
+	return nil
+}
+
+func (e *eventsService) CreateDryRun(
+	ctx context.Context,
+	event Event,
+) (EventCreateDryRunResult, error) {
+	result := EventCreateDryRunResult{}
 
+	if err := e.authorizeCreate(ctx, event); err != nil {
+		return result, err
+	}
+
+	var candidates []Project
 	if event.ProjectID != "" {
 		project, err := e.projectsStore.Get(ctx, event.ProjectID)
 		if err != nil {
-			return events, errors.Wrapf(
+			return result, errors.Wrapf(
 				err,
 				"error retrieving project %q from store",
 				event.ProjectID,
 			)
 		}
-
-		if !subscribers.Contains(project) {
-			return events, nil
+		candidates = []Project{project}
+	} else {
+		// Unlike Create, which narrows candidates as far as the store allows, a dry
+		// run considers every Project subscribed to the Event's source so that a
+		// mismatch on any other criterion, including type, can be reported.
+		projects, err := e.projectsStore.ListBySource(ctx, event.Source)
+		if err != nil {
+			return result, errors.Wrap(
+				err,
+				"error retrieving subscribed projects from store",
+			)
 		}
-
-		evt, err := e.createSingleEventFn(ctx, project, event)
-		events.Items = []Event{evt}
-		return events, err
+		candidates = projects.Items
 	}
 
-	// If we get to here, no project ID is specified, so we iterate over all
-	// subscribed projects in the list and create a discrete event for each.
-	events.Items = make([]Event, len(subscribers.Items))
-	for i, project := range subscribers.Items {
-		event.ProjectID = project.ID
-		evt, err := e.createSingleEventFn(ctx, project, event)
-		if err != nil {
-			return events, err
+	for _, project := range candidates {
+		nonSubscriber := NonSubscriber{ProjectID: project.ID}
+		subscribed := false
+		for i, subscription := range project.Spec.EventSubscriptions {
+			reasons := subscription.Mismatches(event)
+			if len(reasons) == 0 {
+				subscribed = true
+				break
+			}
+			nonSubscriber.Subscriptions = append(
+				nonSubscriber.Subscriptions,
+				SubscriptionMismatch{
+					Index:   i,
+					Reasons: reasons,
+				},
+			)
+		}
+		if subscribed {
+			result.Subscribers = append(result.Subscribers, project.ID)
+		} else {
+			result.NonSubscribers = append(result.NonSubscribers, nonSubscriber)
 		}
-		events.Items[i] = evt
 	}
-	return events, nil
+
+	return result, nil
 }
 
 func (e *eventsService) createSingleEvent(
@@ -449,12 +899,15 @@ func (e *eventsService) createSingleEvent(
 	event.ID = uuid.NewV4().String()
 
 	jobs := []Job{}
+	var retryJobs []string
 	workerSpec := project.Spec.WorkerTemplate
 	// If the event is a retry of another, defer to the Worker.Spec on the event
-	// itself, as well any pre-selected Jobs eligible for inheriting.
+	// itself, as well any pre-selected Jobs eligible for inheriting and the
+	// names of those to be executed again.
 	if event.Labels != nil && event.Labels[RetryLabelKey] != "" {
 		workerSpec = event.Worker.Spec
 		jobs = event.Worker.Jobs
+		retryJobs = event.Worker.RetryJobs
 	}
 
 	if workerSpec.WorkspaceSize == "" {
@@ -488,11 +941,15 @@ func (e *eventsService) createSingleEvent(
 	}
 
 	event.Worker = Worker{
-		Jobs: jobs,
-		Spec: workerSpec,
+		Jobs:      jobs,
+		RetryJobs: retryJobs,
+		Spec:      workerSpec,
 		Status: WorkerStatus{
 			Phase: WorkerPhasePending,
 		},
+		Timeline: []PhaseTransition{
+			newPhaseTransition(ctx, string(WorkerPhasePending), ""),
+		},
 	}
 
 	// Persist the Event
@@ -541,6 +998,38 @@ func (e *eventsService) List(
 	return events, nil
 }
 
+func (e *eventsService) Stats(
+	ctx context.Context,
+	opts EventStatsOptions,
+) (EventStats, error) {
+	if err := e.authorize(ctx, RoleReader, ""); err != nil {
+		return EventStats{}, err
+	}
+
+	opts = opts.applyDefaults(time.Now().UTC())
+	if err := opts.validate(); err != nil {
+		return EventStats{}, err
+	}
+
+	groups, err := e.eventsStore.Stats(ctx, opts)
+	if err != nil {
+		return EventStats{}, errors.Wrap(
+			err,
+			"error aggregating event statistics in store",
+		)
+	}
+	stats := EventStats{
+		GroupBy: opts.GroupBy,
+		Since:   opts.Since,
+		Until:   opts.Until,
+		Groups:  groups,
+	}
+	if opts.GroupBy == EventStatsGroupingTime {
+		stats.BucketSize = opts.BucketSize.String()
+	}
+	return stats, nil
+}
+
 func (e *eventsService) Get(
 	ctx context.Context,
 	id string,
@@ -604,6 +1093,20 @@ func (e *eventsService) Clone(
 	if err != nil {
 		return Event{}, err
 	}
+	// If the Project no longer subscribes to the original Event, no clone was
+	// created
+	if len(events.Items) == 0 {
+		return Event{}, &meta.ErrConflict{
+			Type: EventKind,
+			ID:   id,
+			Reason: fmt.Sprintf(
+				"Event %q was not cloned because project %q is not subscribed "+
+					"to it",
+				id,
+				event.ProjectID,
+			),
+		}
+	}
 
 	return events.Items[0], nil
 }
@@ -612,6 +1115,7 @@ func (e *eventsService) UpdateSourceState(
 	ctx context.Context,
 	id string,
 	sourceState SourceState,
+	opts EventSourceStateUpdateOptions,
 ) error {
 	event, err := e.eventsStore.Get(ctx, id)
 	if err != nil {
@@ -622,7 +1126,12 @@ func (e *eventsService) UpdateSourceState(
 		return err
 	}
 
-	err = e.eventsStore.UpdateSourceState(ctx, id, sourceState)
+	err = e.eventsStore.UpdateSourceState(
+		ctx,
+		id,
+		sourceState,
+		opts.ResourceVersion,
+	)
 	return errors.Wrapf(
 		err,
 		"error updating source state of event %q in store",
@@ -646,7 +1155,11 @@ func (e *eventsService) UpdateSummary(
 	)
 }
 
-func (e *eventsService) Cancel(ctx context.Context, id string) error {
+func (e *eventsService) Cancel(
+	ctx context.Context,
+	id string,
+	opts EventCancelOptions,
+) error {
 	event, err := e.eventsStore.Get(ctx, id)
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving event %q from store", id)
@@ -666,7 +1179,11 @@ func (e *eventsService) Cancel(ctx context.Context, id string) error {
 		)
 	}
 
-	if err = e.eventsStore.Cancel(ctx, id); err != nil {
+	if err = e.eventsStore.Cancel(
+		ctx,
+		id,
+		newPhaseTransition(ctx, "", opts.Reason),
+	); err != nil {
 		return errors.Wrapf(err, "error canceling event %q in store", id)
 	}
 
@@ -684,6 +1201,7 @@ func (e *eventsService) Cancel(ctx context.Context, id string) error {
 func (e *eventsService) CancelMany(
 	ctx context.Context,
 	selector EventsSelector,
+	opts EventCancelManyOptions,
 ) (CancelManyEventsResult, error) {
 	result := CancelManyEventsResult{}
 
@@ -708,6 +1226,29 @@ func (e *eventsService) CancelMany(
 		}
 	}
 
+	if opts.DryRun {
+		// Only Events that are pending, starting, or running can be canceled, so
+		// narrow the selector accordingly before previewing.
+		previewSelector := selector
+		previewSelector.WorkerPhases = nil
+		for _, phase := range selector.WorkerPhases {
+			if phase == WorkerPhasePending ||
+				phase == WorkerPhaseStarting ||
+				phase == WorkerPhaseRunning {
+				previewSelector.WorkerPhases =
+					append(previewSelector.WorkerPhases, phase)
+			}
+		}
+		result.DryRun = true
+		if len(previewSelector.WorkerPhases) == 0 {
+			return result, nil
+		}
+		var err error
+		result.Count, result.SampleIDs, err =
+			e.sampleEvents(ctx, previewSelector)
+		return result, err
+	}
+
 	project, err := e.projectsStore.Get(ctx, selector.ProjectID)
 	if err != nil {
 		return result, errors.Wrapf(
@@ -717,7 +1258,11 @@ func (e *eventsService) CancelMany(
 		)
 	}
 
-	eventCh, affectedCount, err := e.eventsStore.CancelMany(ctx, selector)
+	eventCh, affectedCount, err := e.eventsStore.CancelMany(
+		ctx,
+		selector,
+		newPhaseTransition(ctx, "", opts.Reason),
+	)
 	if err != nil {
 		return result, errors.Wrap(err, "error canceling events in store")
 	}
@@ -749,7 +1294,11 @@ func (e *eventsService) CancelMany(
 	return result, nil
 }
 
-func (e *eventsService) Delete(ctx context.Context, id string) error {
+func (e *eventsService) Delete(
+	ctx context.Context,
+	id string,
+	opts EventDeleteOptions,
+) error {
 	event, err := e.eventsStore.Get(ctx, id)
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving event %q from store", id)
@@ -769,6 +1318,9 @@ func (e *eventsService) Delete(ctx context.Context, id string) error {
 		)
 	}
 
+	// Deleted Events leave nothing behind in the store to record who deleted
+	// them or why. That is recorded, along with the reason, in the audit log by
+	// the audited EventsService that wraps this one.
 	if err = e.eventsStore.Delete(ctx, id); err != nil {
 		return errors.Wrapf(err, "error deleting event %q from store", id)
 	}
@@ -787,6 +1339,7 @@ func (e *eventsService) Delete(ctx context.Context, id string) error {
 func (e *eventsService) DeleteMany(
 	ctx context.Context,
 	selector EventsSelector,
+	opts EventDeleteManyOptions,
 ) (DeleteManyEventsResult, error) {
 	result := DeleteManyEventsResult{}
 
@@ -814,6 +1367,13 @@ func (e *eventsService) DeleteMany(
 		}
 	}
 
+	if opts.DryRun {
+		result.DryRun = true
+		var err error
+		result.Count, result.SampleIDs, err = e.sampleEvents(ctx, selector)
+		return result, err
+	}
+
 	project, err := e.projectsStore.Get(ctx, selector.ProjectID)
 	if err != nil {
 		return result, errors.Wrapf(
@@ -837,6 +1397,22 @@ func (e *eventsService) DeleteMany(
 	for i := 0; i < concurrency; i++ {
 		go func() {
 			for event := range eventCh {
+				// Deleted Events leave nothing behind in the store to record who
+				// deleted them or why, so each is recorded in the audit log where it
+				// can be found by the Event's ID. The audited EventsService that wraps
+				// this one only records a summary of the whole operation.
+				e.auditRecorder.Record(
+					ctx,
+					"events.deleteMany",
+					AuditTarget{
+						Type:      EventKind,
+						ID:        event.ID,
+						ProjectID: event.ProjectID,
+						Details:   reasonDetails(opts.Reason),
+					},
+					nil,
+				)
+
 				if err := e.substrate.DeleteWorkerAndJobs(
 					context.Background(), // deliberately not using ctx
 					project,
@@ -869,6 +1445,7 @@ func (e *eventsService) DeleteMany(
 func (e *eventsService) Retry(
 	ctx context.Context,
 	id string,
+	opts EventRetryOptions,
 ) (Event, error) {
 	// No authz call here as we'll defer to the checks in e.Create() invoked
 	// below
@@ -905,11 +1482,46 @@ func (e *eventsService) Retry(
 	}
 	retry.Labels[RetryLabelKey] = id
 
+	// Execution resumes from the specified job or, by default, from the first
+	// job that didn't succeed. Only jobs that precede it are eligible for
+	// inheritance.
+	fromJobIndex := len(retry.Worker.Jobs)
+	if opts.FromJob != "" {
+		fromJobIndex = -1
+		for i, job := range retry.Worker.Jobs {
+			if job.Name == opts.FromJob {
+				fromJobIndex = i
+				break
+			}
+		}
+		if fromJobIndex < 0 {
+			return Event{}, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					"Event %q has no job named %q to retry from.",
+					id,
+					opts.FromJob,
+				),
+			}
+		}
+	} else {
+		for i, job := range retry.Worker.Jobs {
+			if job.Status == nil || job.Status.Phase != JobPhaseSucceeded {
+				fromJobIndex = i
+				break
+			}
+		}
+	}
+	if fromJobIndex < len(retry.Worker.Jobs) {
+		retry.Labels[RetryFromJobLabelKey] = retry.Worker.Jobs[fromJobIndex].Name
+	}
+
 	// Only inherit jobs which have succeeded and do not require a shared
-	// workspace
+	// workspace. All others are recorded as jobs to be executed again.
 	var jobs []Job
-	for _, job := range retry.Worker.Jobs {
-		if job.Status.Phase == JobPhaseSucceeded && !job.UsesWorkspace() {
+	var retryJobs []string
+	for i, job := range retry.Worker.Jobs {
+		if i < fromJobIndex && job.Status != nil &&
+			job.Status.Phase == JobPhaseSucceeded && !job.UsesWorkspace() {
 			// Capture event ID for tracing original logs.  Note that it may
 			// already be set (e.g. via a retry of another retry), in which case
 			// we do not want to override it.
@@ -917,18 +1529,205 @@ func (e *eventsService) Retry(
 				job.Status.LogsEventID = id
 			}
 			jobs = append(jobs, job)
+			continue
 		}
+		retryJobs = append(retryJobs, job.Name)
 	}
 	retry.Worker.Jobs = jobs
+	retry.Worker.RetryJobs = retryJobs
 
 	events, err := e.Create(ctx, retry)
 	if err != nil {
 		return Event{}, err
 	}
+	// If the Project no longer subscribes to the original Event, no retry was
+	// created
+	if len(events.Items) == 0 {
+		return Event{}, &meta.ErrConflict{
+			Type: EventKind,
+			ID:   id,
+			Reason: fmt.Sprintf(
+				"Event %q was not retried because project %q is not subscribed "+
+					"to it",
+				id,
+				event.ProjectID,
+			),
+		}
+	}
 
 	return events.Items[0], nil
 }
 
+func (e *eventsService) RetryMany(
+	ctx context.Context,
+	selector EventsSelector,
+) (RetryManyEventsResult, error) {
+	result := RetryManyEventsResult{}
+
+	if err := e.validateManySelector(ctx, selector, "retry"); err != nil {
+		return result, err
+	}
+
+	// Refuse requests that select events whose workers may still be running
+	for _, phase := range selector.WorkerPhases {
+		if !phase.IsTerminal() {
+			return result, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					"Requests to retry multiple events may only select events with "+
+						"workers in a terminal phase; %q is non-terminal.",
+					phase,
+				),
+			}
+		}
+	}
+
+	ids, err := e.selectEventIDs(ctx, selector, "retry")
+	if err != nil {
+		return result, err
+	}
+
+	for _, id := range ids {
+		item := EventCopyResult{SourceEventID: id}
+		if event, err := e.Retry(ctx, id, EventRetryOptions{}); err != nil {
+			item.Error = err.Error()
+			result.FailedCount++
+		} else {
+			item.EventID = event.ID
+			result.Count++
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+func (e *eventsService) CloneMany(
+	ctx context.Context,
+	selector EventsSelector,
+) (CloneManyEventsResult, error) {
+	result := CloneManyEventsResult{}
+
+	if err := e.validateManySelector(ctx, selector, "clone"); err != nil {
+		return result, err
+	}
+
+	ids, err := e.selectEventIDs(ctx, selector, "clone")
+	if err != nil {
+		return result, err
+	}
+
+	for _, id := range ids {
+		item := EventCopyResult{SourceEventID: id}
+		if event, err := e.Clone(ctx, id); err != nil {
+			item.Error = err.Error()
+			result.FailedCount++
+		} else {
+			item.EventID = event.ID
+			result.Count++
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// validateManySelector applies the same rules to mass retry and clone
+// operations that CancelMany applies to mass cancellation. Requests must be
+// qualified by project and by worker phase(s) and the principal must be a
+// project user.
+func (e *eventsService) validateManySelector(
+	ctx context.Context,
+	selector EventsSelector,
+	operation string,
+) error {
+	// Refuse requests not qualified by project
+	if selector.ProjectID == "" {
+		return &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				"Requests to %s multiple events must be qualified by project.",
+				operation,
+			),
+		}
+	}
+
+	if err :=
+		e.projectAuthorize(ctx, selector.ProjectID, RoleProjectUser); err != nil {
+		return err
+	}
+
+	// Refuse requests not qualified by worker phases
+	if len(selector.WorkerPhases) == 0 {
+		return &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				"Requests to %s multiple events must be qualified by worker "+
+					"phase(s).",
+				operation,
+			),
+		}
+	}
+
+	return nil
+}
+
+// selectEventIDs returns the IDs of all Events matching the provided selector.
+// IDs are collected up front so that Events created while retrying or cloning
+// the selected Events are never themselves selected. If more than
+// maxCopyManySelectionSize Events match, a *meta.ErrBadRequest is returned
+// instead.
+func (e *eventsService) selectEventIDs(
+	ctx context.Context,
+	selector EventsSelector,
+	operation string,
+) ([]string, error) {
+	events, err := e.eventsStore.List(
+		ctx,
+		selector,
+		meta.ListOptions{Limit: maxCopyManySelectionSize},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving events from store")
+	}
+	if events.Continue != "" || events.RemainingItemCount > 0 {
+		return nil, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				"Requests to %s multiple events may select no more than %d "+
+					"events; this request selects %d. Narrow the selection and try "+
+					"again.",
+				operation,
+				maxCopyManySelectionSize,
+				int64(len(events.Items))+events.RemainingItemCount,
+			),
+		}
+	}
+	ids := make([]string, len(events.Items))
+	for i, event := range events.Items {
+		ids[i] = event.ID
+	}
+	return ids, nil
+}
+
+// sampleEvents returns the number of Events matching the provided
+// EventsSelector along with the IDs of up to maxDryRunSampleSize of them,
+// newest first.
+func (e *eventsService) sampleEvents(
+	ctx context.Context,
+	selector EventsSelector,
+) (int64, []string, error) {
+	events, err := e.eventsStore.List(
+		ctx,
+		selector,
+		meta.ListOptions{Limit: maxDryRunSampleSize},
+	)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "error retrieving events from store")
+	}
+	ids := make([]string, len(events.Items))
+	for i, event := range events.Items {
+		ids[i] = event.ID
+	}
+	return int64(len(ids)) + events.RemainingItemCount, ids, nil
+}
+
 // EventsStore is an interface for components that implement Event persistence
 // concerns.
 type EventsStore interface {
@@ -944,6 +1743,11 @@ type EventsStore interface {
 		EventsSelector,
 		meta.ListOptions,
 	) (EventList, error)
+	// Stats aggregates statistics about Events in the underlying data store in
+	// accordance with the provided EventStatsOptions. Implementations MAY assume
+	// the options have been validated and have had defaults applied by the
+	// caller.
+	Stats(context.Context, EventStatsOptions) ([]EventStatsGroup, error)
 	// Get retrieves a single Event from the underlying data store. If the
 	// specified Event does not exist, implementations MUST return a
 	// *meta.ErrNotFound error.
@@ -953,9 +1757,12 @@ type EventsStore interface {
 	// implementations MUST return a *meta.ErrNotFound error.
 	GetByHashedWorkerToken(context.Context, string) (Event, error)
 	// UpdateSourceState updates source-specific (e.g. gateway-specific) Event
-	// state. Implementations MAY assume the Event's existence has been
-	// pre-confirmed by the caller.
-	UpdateSourceState(context.Context, string, SourceState) error
+	// state and increments the Event's ResourceVersion. If the provided
+	// resource version is non-zero and does not match the Event's current
+	// ResourceVersion, implementations MUST return a *meta.ErrConflict.
+	// Implementations MAY assume the Event's existence has been pre-confirmed by
+	// the caller.
+	UpdateSourceState(context.Context, string, SourceState, int64) error
 	// UpdateSummary updates the opaque, Worker-specific Event summary.
 	UpdateSummary(context.Context, string, EventSummary) error
 	// Cancel updates the specified Event in the underlying data store to reflect
@@ -963,14 +1770,22 @@ type EventsStore interface {
 	// has been pre-confirmed by the caller. Implementations MUST only cancel
 	// events whose Workers have not already reached a terminal state. If the
 	// specified Event's Worker has already reached a terminal state,
-	// implementations MUST return a *meta.ErrConflict.
-	Cancel(context.Context, string) error
+	// implementations MUST return a *meta.ErrConflict. Implementations MUST
+	// append the provided PhaseTransition to the timelines of the Worker and any
+	// affected Jobs, replacing its Phase with the phase each actually
+	// transitioned to, and MUST record its Reason and Principal in the Worker's
+	// status.
+	Cancel(context.Context, string, PhaseTransition) error
 	// CancelMany updates multiple Events specified by the EventsSelector
 	// parameter in the underlying data store to reflect that they have been
 	// canceled. Implementations MUST only cancel events whose Workers have not
 	// already reached a terminal state and MUST return the total number of
-	// canceled events.
-	CancelMany(context.Context, EventsSelector) (<-chan Event, int64, error)
+	// canceled events. Timelines are to be updated as they are by Cancel.
+	CancelMany(
+		context.Context,
+		EventsSelector,
+		PhaseTransition,
+	) (<-chan Event, int64, error)
 	// Delete unconditionally deletes the specified Event from the underlying data
 	// store. If the specified Event does not exist, implementations MUST
 	// return a *meta.ErrNotFound error.
@@ -982,4 +1797,27 @@ type EventsStore interface {
 	// DeleteByProjectID unconditionally deletes all Events associated with the
 	// specified project.
 	DeleteByProjectID(context.Context, string) error
+	// ListLogsMigrationCandidates retrieves, oldest first, up to the specified
+	// number of Events whose Workers reached a terminal phase before the
+	// specified cutoff, whose logs have not been marked as migrated, and that
+	// are not claimed for the migration of their logs.
+	ListLogsMigrationCandidates(
+		ctx context.Context,
+		cutoff time.Time,
+		limit int64,
+	) ([]Event, error)
+	// ClaimLogsMigration claims the specified Event for the migration of its
+	// logs by the specified claimant until the specified time. A claim already
+	// held by the same claimant is renewed. Implementations MUST return false if
+	// the Event's logs have been marked as migrated or if another claimant
+	// holds a claim that has not yet expired.
+	ClaimLogsMigration(
+		ctx context.Context,
+		id string,
+		claimant string,
+		until time.Time,
+	) (bool, error)
+	// MarkLogsMigrated records that the specified Event's logs have been
+	// migrated and releases any claim on the Event.
+	MarkLogsMigrated(ctx context.Context, id string) error
 }
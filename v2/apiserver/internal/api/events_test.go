@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
@@ -34,29 +35,91 @@ func TestDeleteManyEventsResultMarshalJSON(t *testing.T) {
 	)
 }
 
+func TestRetryManyEventsResultMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(
+		t,
+		&RetryManyEventsResult{},
+		"RetryManyEventsResult",
+	)
+}
+
+func TestCloneManyEventsResultMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(
+		t,
+		&CloneManyEventsResult{},
+		"CloneManyEventsResult",
+	)
+}
+
+func TestEventCreateDryRunResultMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(
+		t,
+		&EventCreateDryRunResult{},
+		"EventCreateDryRunResult",
+	)
+}
+
 func TestNewEventsService(t *testing.T) {
 	projectsStore := &mockProjectsStore{}
 	eventsStore := &mockEventsStore{}
+	gatewaysStore := &mockGatewaysStore{}
 	logsStore := &mockLogsStore{}
 	substrate := &mockSubstrate{}
+	auditRecorder := &mockAuditRecorder{}
 	svc, ok := NewEventsService(
 		alwaysAuthorize,
 		alwaysProjectAuthorize,
 		projectsStore,
 		eventsStore,
+		gatewaysStore,
 		logsStore,
 		substrate,
+		auditRecorder,
 	).(*eventsService)
 	require.True(t, ok)
 	require.NotNil(t, svc.authorize)
 	require.Same(t, projectsStore, svc.projectsStore)
 	require.Same(t, eventsStore, svc.eventsStore)
+	require.Same(t, gatewaysStore, svc.gatewaysStore)
 	require.Same(t, substrate, svc.substrate)
+	require.Same(t, auditRecorder, svc.auditRecorder)
 }
 
 func TestEventsServiceCreate(t *testing.T) {
+	inputsProject := Project{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "blue-book",
+		},
+		Spec: ProjectSpec{
+			EventSubscriptions: []EventSubscription{
+				{
+					Source: "brigade.sh/cli",
+					Types:  []string{"deploy"},
+				},
+			},
+			EventInputs: []EventInputs{
+				{
+					Source: "brigade.sh/cli",
+					Type:   "deploy",
+					Inputs: []EventInput{
+						{
+							Name:     "environment",
+							Type:     EventInputTypeEnum,
+							Required: true,
+							Options:  []string{"dev", "prod"},
+						},
+						{
+							Name:    "version",
+							Default: "latest",
+						},
+					},
+				},
+			},
+		},
+	}
 	testCases := []struct {
 		name       string
+		principal  interface{}
 		event      Event
 		service    EventsService
 		assertions func(EventList, error)
@@ -72,49 +135,80 @@ func TestEventsServiceCreate(t *testing.T) {
 			},
 		},
 		{
-			name: "create single event for specified project; error getting " +
-				"project from store",
+			name: "error retrieving gateway owning source",
 			event: Event{
-				ProjectID: "blue-book",
+				Source: "github-gateway",
 			},
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, errors.New("projects store error")
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, errors.New("something went wrong")
 					},
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{}, nil
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving gateway owning source",
+				)
+			},
+		},
+		{
+			name: "source owned by a gateway other than the principal",
+			event: Event{
+				Source: "github-gateway",
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "github",
+							},
+						}, nil
 					},
 				},
 			},
 			assertions: func(_ EventList, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error retrieving project")
-				require.Contains(t, err.Error(), "projects store error")
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+				require.Contains(t, err.Error(), "github")
 			},
 		},
 		{
-			name: "create single event for specified but not subscribed project",
+			name: "retry of gateway-owned event by project user",
 			event: Event{
 				ProjectID: "blue-book",
+				Source:    "github-gateway",
+				Type:      "push",
+				Labels: map[string]string{
+					RetryLabelKey: "tony",
+				},
 			},
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						require.Fail(t, "gateway ownership should not have been checked")
+						return Gateway{}, nil
+					},
+				},
 				projectsStore: &mockProjectsStore{
 					GetFn: func(context.Context, string) (Project, error) {
 						return Project{
 							ObjectMeta: meta.ObjectMeta{
 								ID: "blue-book",
 							},
-						}, nil
-					},
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{
-								{
-									ObjectMeta: meta.ObjectMeta{
-										ID: "orange-book",
+							Spec: ProjectSpec{
+								EventSubscriptions: []EventSubscription{
+									{
+										Source: "github-gateway",
+										Types:  []string{"push"},
 									},
 								},
 							},
@@ -122,40 +216,47 @@ func TestEventsServiceCreate(t *testing.T) {
 					},
 				},
 				createSingleEventFn: func(
-					context.Context,
-					Project,
-					Event,
+					_ context.Context,
+					_ Project,
+					event Event,
 				) (Event, error) {
-					return Event{}, nil
+					return event, nil
 				},
 			},
 			assertions: func(events EventList, err error) {
 				require.NoError(t, err)
-				require.Len(t, events.Items, 0)
+				require.Len(t, events.Items, 1)
 			},
 		},
 		{
-			name: "create single event for specified and subscribed project; " +
-				"failure",
+			name: "clone of gateway-owned event by project user",
 			event: Event{
 				ProjectID: "blue-book",
+				Source:    "github-gateway",
+				Type:      "push",
+				Labels: map[string]string{
+					CloneLabelKey: "tony",
+				},
 			},
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						require.Fail(t, "gateway ownership should not have been checked")
+						return Gateway{}, nil
+					},
+				},
 				projectsStore: &mockProjectsStore{
 					GetFn: func(context.Context, string) (Project, error) {
 						return Project{
 							ObjectMeta: meta.ObjectMeta{
 								ID: "blue-book",
 							},
-						}, nil
-					},
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{
-								{
-									ObjectMeta: meta.ObjectMeta{
-										ID: "blue-book",
+							Spec: ProjectSpec{
+								EventSubscriptions: []EventSubscription{
+									{
+										Source: "github-gateway",
+										Types:  []string{"push"},
 									},
 								},
 							},
@@ -163,45 +264,114 @@ func TestEventsServiceCreate(t *testing.T) {
 					},
 				},
 				createSingleEventFn: func(
-					context.Context,
-					Project,
-					Event,
+					_ context.Context,
+					_ Project,
+					event Event,
 				) (Event, error) {
-					return Event{}, errors.New("error creating single event")
+					return event, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
+		{
+			name: "retry of gateway-owned event by another gateway",
+			principal: &Gateway{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "gitlab",
+				},
+			},
+			event: Event{
+				ProjectID: "blue-book",
+				Source:    "github-gateway",
+				Labels: map[string]string{
+					RetryLabelKey: "tony",
+				},
+			},
+			service: &eventsService{
+				authorize:        alwaysAuthorize,
+				projectAuthorize: neverProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "github",
+							},
+						}, nil
+					},
 				},
 			},
 			assertions: func(_ EventList, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error creating single event")
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+				require.Contains(t, err.Error(), "github")
 			},
 		},
 		{
-			name: "create single event for specified and subscribed project" +
-				"success",
+			name: "principal is owning gateway; error updating last seen",
+			principal: &Gateway{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "github",
+				},
+			},
 			event: Event{
-				ProjectID: "blue-book",
+				Source: "github-gateway",
 			},
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{
 							ObjectMeta: meta.ObjectMeta{
-								ID: "blue-book",
+								ID: "github",
 							},
 						}, nil
 					},
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{
-								{
-									ObjectMeta: meta.ObjectMeta{
-										ID: "blue-book",
-									},
-								},
+					UpdateLastSeenFn: func(context.Context, string, time.Time) error {
+						return errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error updating last seen time")
+			},
+		},
+		{
+			name: "principal is owning gateway; success",
+			principal: &Gateway{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "github",
+				},
+			},
+			event: Event{
+				Source: "github-gateway",
+				Type:   "push",
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "github",
 							},
 						}, nil
 					},
+					UpdateLastSeenFn: func(context.Context, string, time.Time) error {
+						return nil
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 1), nil
+					},
 				},
 				createSingleEventFn: func(
 					context.Context,
@@ -217,51 +387,48 @@ func TestEventsServiceCreate(t *testing.T) {
 			},
 		},
 		{
-			name: "create multiple events for subscribed projects; failure " +
-				"getting subscribed projects",
+			name: "create single event for specified project; error getting " +
+				"project from store",
 			event: Event{
-				Source: "github-gateway",
-				Type:   "push",
-				Qualifiers: Qualifiers{
-					"repo": "github.com/foo/bar",
-				},
+				ProjectID: "blue-book",
 			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{}, errors.New(
-							"error getting subscribed projects",
-						)
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("projects store error")
 					},
 				},
 			},
 			assertions: func(_ EventList, err error) {
 				require.Error(t, err)
-				require.Contains(
-					t,
-					err.Error(),
-					"error retrieving subscribed projects from store",
-				)
-				require.Contains(t, err.Error(), "error getting subscribed projects")
+				require.Contains(t, err.Error(), "error retrieving project")
+				require.Contains(t, err.Error(), "projects store error")
 			},
 		},
 		{
-			name: "create multiple events for subscribed projects; create single " +
-				"event failure ",
+			name: "create single event for specified but not subscribed project",
 			event: Event{
-				Source: "github-gateway",
-				Type:   "push",
-				Qualifiers: Qualifiers{
-					"repo": "github.com/foo/bar",
-				},
+				ProjectID: "blue-book",
 			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "blue-book",
+							},
 						}, nil
 					},
 				},
@@ -270,29 +437,40 @@ func TestEventsServiceCreate(t *testing.T) {
 					Project,
 					Event,
 				) (Event, error) {
-					return Event{}, errors.New("error creating single event")
+					return Event{}, nil
 				},
 			},
-			assertions: func(_ EventList, err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error creating single event")
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 0)
 			},
 		},
 		{
-			name: "create multiple events for subscribed projects; success",
+			name: "create single event for specified and subscribed project; " +
+				"failure",
 			event: Event{
-				Source: "github-gateway",
-				Type:   "push",
-				Qualifiers: Qualifiers{
-					"repo": "github.com/foo/bar",
-				},
+				ProjectID: "blue-book",
 			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "blue-book",
+							},
+							Spec: ProjectSpec{
+								EventSubscriptions: []EventSubscription{
+									{
+										Types: []string{"*"},
+									},
+								},
+							},
 						}, nil
 					},
 				},
@@ -301,169 +479,1138 @@ func TestEventsServiceCreate(t *testing.T) {
 					Project,
 					Event,
 				) (Event, error) {
-					return Event{}, nil
+					return Event{}, errors.New("error creating single event")
 				},
 			},
-			assertions: func(events EventList, err error) {
-				require.NoError(t, err)
-				require.Len(t, events.Items, 2)
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error creating single event")
 			},
 		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			events, err :=
-				testCase.service.Create(context.Background(), testCase.event)
+		{
+			name: "create single event for specified and subscribed project" +
+				"success",
+			event: Event{
+				ProjectID: "blue-book",
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "blue-book",
+							},
+							Spec: ProjectSpec{
+								EventSubscriptions: []EventSubscription{
+									{
+										Types: []string{"*"},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					return Event{}, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
+		{
+			name: "create single event for specified and subscribed project; " +
+				"invalid inputs",
+			event: Event{
+				ProjectID: "blue-book",
+				Source:    "brigade.sh/cli",
+				Type:      "deploy",
+				Inputs: map[string]string{
+					"environment": "staging",
+				},
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return inputsProject, nil
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(
+					t,
+					err.(*meta.ErrBadRequest).Details,
+					`input "environment": "staging" is not one of the permitted `+
+						`values ["dev" "prod"]`,
+				)
+			},
+		},
+		{
+			name: "create single event for specified and subscribed project; " +
+				"valid inputs",
+			event: Event{
+				ProjectID: "blue-book",
+				Source:    "brigade.sh/cli",
+				Type:      "deploy",
+				Inputs: map[string]string{
+					"environment": "prod",
+				},
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return inputsProject, nil
+					},
+				},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					require.Equal(
+						t,
+						map[string]string{
+							"environment": "prod",
+							"version":     "latest",
+						},
+						event.Inputs,
+					)
+					return event, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
+		{
+			name: "create multiple events for subscribed projects; one " +
+				"project's inputs cannot be resolved",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "deploy",
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{
+								inputsProject,
+								{
+									ObjectMeta: meta.ObjectMeta{
+										ID: "orange-book",
+									},
+									Spec: ProjectSpec{
+										EventSubscriptions: inputsProject.Spec.EventSubscriptions,
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				createSingleEventFn: func(
+					_ context.Context,
+					project Project,
+					event Event,
+				) (Event, error) {
+					require.Equal(t, "orange-book", project.ID)
+					return event, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+				require.Equal(t, "orange-book", events.Items[0].ProjectID)
+			},
+		},
+		{
+			name: "create multiple events for subscribed projects; no " +
+				"project's inputs can be resolved",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "deploy",
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
+						return ProjectList{
+							Items: []Project{inputsProject},
+						}, nil
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`Invalid inputs for project "blue-book": input "environment" ` +
+							"is required",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "create multiple events for subscribed projects; failure " +
+				"getting subscribed projects",
+			event: Event{
+				Source: "github-gateway",
+				Type:   "push",
+				Qualifiers: Qualifiers{
+					"repo": "github.com/foo/bar",
+				},
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
+						return ProjectList{}, errors.New(
+							"error getting subscribed projects",
+						)
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving subscribed projects from store",
+				)
+				require.Contains(t, err.Error(), "error getting subscribed projects")
+			},
+		},
+		{
+			name: "create multiple events for subscribed projects; create single " +
+				"event failure ",
+			event: Event{
+				Source: "github-gateway",
+				Type:   "push",
+				Qualifiers: Qualifiers{
+					"repo": "github.com/foo/bar",
+				},
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
+					},
+				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					return Event{}, errors.New("error creating single event")
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error creating single event")
+			},
+		},
+		{
+			name: "create multiple events for subscribed projects; success",
+			event: Event{
+				Source: "github-gateway",
+				Type:   "push",
+				Qualifiers: Qualifiers{
+					"repo": "github.com/foo/bar",
+				},
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
+					},
+				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					return Event{}, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 2)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			events, err := testCase.service.Create(
+				ContextWithPrincipal(context.Background(), testCase.principal),
+				testCase.event,
+			)
 			testCase.assertions(events, err)
 		})
 	}
 }
 
-func TestEventsServiceCreateSingleEvent(t *testing.T) {
-	testProject := Project{}
-	testEvent := Event{
-		Git: &GitDetails{
-			CloneURL: "github.com/foo/bar.git",
-			Commit:   "123456789",
-			Ref:      "dev",
+func TestEventsServiceCreateDryRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		event      Event
+		service    EventsService
+		assertions func(EventCreateDryRunResult, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(_ EventCreateDryRunResult, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting specified project from store",
+			event: Event{
+				ProjectID: "blue-book",
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("projects store error")
+					},
+				},
+			},
+			assertions: func(_ EventCreateDryRunResult, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error retrieving project")
+				require.Contains(t, err.Error(), "projects store error")
+			},
+		},
+		{
+			name: "specified project is not subscribed",
+			event: Event{
+				ProjectID: "blue-book",
+				Source:    "brigade.sh/cli",
+				Type:      "exec",
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "blue-book",
+							},
+							Spec: ProjectSpec{
+								EventSubscriptions: []EventSubscription{
+									{
+										Source: "brigade.sh/github",
+										Types:  []string{"*"},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			assertions: func(result EventCreateDryRunResult, err error) {
+				require.NoError(t, err)
+				require.Empty(t, result.Subscribers)
+				require.Len(t, result.NonSubscribers, 1)
+				nonSubscriber := result.NonSubscribers[0]
+				require.Equal(t, "blue-book", nonSubscriber.ProjectID)
+				require.Len(t, nonSubscriber.Subscriptions, 1)
+				require.Equal(t, 0, nonSubscriber.Subscriptions[0].Index)
+				require.Equal(
+					t,
+					[]string{`source "brigade.sh/cli" does not match "brigade.sh/github"`},
+					nonSubscriber.Subscriptions[0].Reasons,
+				)
+			},
+		},
+		{
+			name: "error listing subscribers",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListBySourceFn: func(context.Context, string) (ProjectList, error) {
+						return ProjectList{}, errors.New("projects store error")
+					},
+				},
+			},
+			assertions: func(_ EventCreateDryRunResult, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving subscribed projects",
+				)
+				require.Contains(t, err.Error(), "projects store error")
+			},
+		},
+		{
+			name: "success",
+			event: Event{
+				Source: "brigade.sh/cli",
+				Type:   "exec",
+			},
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListBySourceFn: func(
+						_ context.Context,
+						source string,
+					) (ProjectList, error) {
+						require.Equal(t, "brigade.sh/cli", source)
+						// orange-book subscribes to the same source, but a different
+						// type, so it must still be reported as a non-subscriber
+						return ProjectList{
+							Items: []Project{
+								{
+									ObjectMeta: meta.ObjectMeta{
+										ID: "blue-book",
+									},
+									Spec: ProjectSpec{
+										EventSubscriptions: []EventSubscription{
+											{
+												Source: "brigade.sh/cli",
+												Types:  []string{"exec"},
+											},
+										},
+									},
+								},
+								{
+									ObjectMeta: meta.ObjectMeta{
+										ID: "orange-book",
+									},
+									Spec: ProjectSpec{
+										EventSubscriptions: []EventSubscription{
+											{
+												Source: "brigade.sh/cli",
+												Types:  []string{"push"},
+											},
+										},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					CreateFn: func(context.Context, Event) error {
+						require.Fail(t, "dry run should not store any events")
+						return nil
+					},
+				},
+			},
+			assertions: func(result EventCreateDryRunResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"blue-book"}, result.Subscribers)
+				require.Len(t, result.NonSubscribers, 1)
+				nonSubscriber := result.NonSubscribers[0]
+				require.Equal(t, "orange-book", nonSubscriber.ProjectID)
+				require.Len(t, nonSubscriber.Subscriptions, 1)
+				require.Equal(
+					t,
+					[]string{`type "exec" does not match any of [push]`},
+					nonSubscriber.Subscriptions[0].Reasons,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err :=
+				testCase.service.CreateDryRun(context.Background(), testCase.event)
+			testCase.assertions(result, err)
+		})
+	}
+}
+
+func TestEventsServiceCreateSingleEvent(t *testing.T) {
+	testProject := Project{}
+	testEvent := Event{
+		Git: &GitDetails{
+			CloneURL: "github.com/foo/bar.git",
+			Commit:   "123456789",
+			Ref:      "dev",
+		},
+	}
+	testEventRetryLabel := map[string]string{
+		RetryLabelKey: "1234567",
+	}
+	testCases := []struct {
+		name        string
+		eventLabels map[string]string
+		worker      Worker
+		service     *eventsService
+		assertions  func(Event, error)
+	}{
+		{
+			name: "error creating event in store",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					CreateFn: func(context.Context, Event) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ Event, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error storing new event")
+				require.Contains(t, err.Error(), "store error")
+			},
+		},
+		{
+			name: "error scheduling worker in substrate",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					CreateFn: func(context.Context, Event) error {
+						return nil
+					},
+				},
+				substrate: &mockSubstrate{
+					ScheduleWorkerFn: func(context.Context, Event) error {
+						return errors.New("substrate error")
+					},
+				},
+			},
+			assertions: func(_ Event, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error scheduling event")
+				require.Contains(t, err.Error(), "substrate error")
+			},
+		},
+		{
+			name:        "event retry - inherit worker spec and job",
+			eventLabels: testEventRetryLabel,
+			worker: Worker{
+				Spec: WorkerSpec{
+					DefaultConfigFiles: map[string]string{
+						"defaultConfig": "myConfig",
+					},
+				},
+				Jobs: []Job{
+					{
+						Name: "foo",
+					},
+				},
+				RetryJobs: []string{"bar"},
+			},
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					CreateFn: func(context.Context, Event) error {
+						return nil
+					},
+				},
+				substrate: &mockSubstrate{
+					ScheduleWorkerFn: func(context.Context, Event) error {
+						return nil
+					},
+				},
+			},
+			assertions: func(event Event, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, len(event.Worker.Jobs))
+				require.Equal(t, Job{Name: "foo"}, event.Worker.Jobs[0])
+				require.Equal(t, []string{"bar"}, event.Worker.RetryJobs)
+				require.Equal(
+					t,
+					map[string]string{"defaultConfig": "myConfig"},
+					event.Worker.Spec.DefaultConfigFiles,
+				)
+			},
+		},
+		{
+			name: "success",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					CreateFn: func(context.Context, Event) error {
+						return nil
+					},
+				},
+				substrate: &mockSubstrate{
+					ScheduleWorkerFn: func(context.Context, Event) error {
+						return nil
+					},
+				},
+			},
+			assertions: func(event Event, err error) {
+				require.NoError(t, err)
+				// Make sure the Event looks like what we expect-- i.e. ID generated,
+				// default applied, etc.
+				require.NotEmpty(t, event.ID)
+				require.Equal(t, defaultWorkspaceSize, event.Worker.Spec.WorkspaceSize)
+				require.NotNil(t, event.Worker.Spec.Git)
+				require.Equal(t, testEvent.Git.CloneURL, event.Worker.Spec.Git.CloneURL)
+				require.Equal(t, testEvent.Git.Commit, event.Worker.Spec.Git.Commit)
+				require.Equal(t, testEvent.Git.Ref, event.Worker.Spec.Git.Ref)
+				require.Equal(t, LogLevelInfo, event.Worker.Spec.LogLevel)
+				require.Equal(t, WorkerPhasePending, event.Worker.Status.Phase)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testEvent.Labels = testCase.eventLabels
+			testEvent.Worker = testCase.worker
+			event, err := testCase.service.createSingleEvent(
+				context.Background(),
+				testProject,
+				testEvent,
+			)
+			testCase.assertions(event, err)
+		})
+	}
+}
+
+func TestEventsServiceList(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    EventsService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting events from store",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{}, errors.New("error listing events")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error listing events")
+				require.Contains(t, err.Error(), "error retrieving events from store")
+			},
+		},
+		{
+			name: "success",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.service.List(
+				context.Background(),
+				EventsSelector{},
+				meta.ListOptions{},
+			)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestEventsServiceStats(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    EventsService
+		opts       EventStatsOptions
+		assertions func(EventStats, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(_ EventStats, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "invalid options",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+			},
+			opts: EventStatsOptions{
+				GroupBy: "COLOR",
+			},
+			assertions: func(_ EventStats, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+			},
+		},
+		{
+			name: "error aggregating statistics in store",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					StatsFn: func(
+						context.Context,
+						EventStatsOptions,
+					) ([]EventStatsGroup, error) {
+						return nil, errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(_ EventStats, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(
+					t,
+					err.Error(),
+					"error aggregating event statistics in store",
+				)
+			},
+		},
+		{
+			name: "success",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					StatsFn: func(
+						_ context.Context,
+						opts EventStatsOptions,
+					) ([]EventStatsGroup, error) {
+						require.Equal(t, EventStatsGroupingTime, opts.GroupBy)
+						require.Equal(t, time.Hour, opts.BucketSize)
+						require.False(t, opts.Since.IsZero())
+						require.False(t, opts.Until.IsZero())
+						return []EventStatsGroup{{Total: 1}}, nil
+					},
+				},
+			},
+			opts: EventStatsOptions{
+				GroupBy: EventStatsGroupingTime,
+			},
+			assertions: func(stats EventStats, err error) {
+				require.NoError(t, err)
+				require.Equal(t, EventStatsGroupingTime, stats.GroupBy)
+				require.Equal(t, "1h0m0s", stats.BucketSize)
+				require.Len(t, stats.Groups, 1)
+			},
 		},
 	}
-	testEventRetryLabel := map[string]string{
-		RetryLabelKey: "1234567",
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.Stats(context.Background(), testCase.opts),
+			)
+		})
 	}
+}
+
+func TestEventsServiceGet(t *testing.T) {
 	testCases := []struct {
-		name        string
-		eventLabels map[string]string
-		worker      Worker
-		service     *eventsService
-		assertions  func(Event, error)
+		name       string
+		service    EventsService
+		assertions func(error)
 	}{
 		{
-			name: "error creating event in store",
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting event from store",
 			service: &eventsService{
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
-					CreateFn: func(context.Context, Event) error {
-						return errors.New("store error")
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, errors.New("error getting event")
 					},
 				},
 			},
-			assertions: func(_ Event, err error) {
+			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error storing new event")
-				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error getting event")
+				require.Contains(t, err.Error(), "error retrieving event")
 			},
 		},
 		{
-			name: "error scheduling worker in substrate",
+			name: "success",
 			service: &eventsService{
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
-					CreateFn: func(context.Context, Event) error {
-						return nil
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.service.Get(context.Background(), "foobar")
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestEventsServiceGetByWorkerToken(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    EventsService
+		assertions func(error)
+	}{
+		{
+			name: "error getting event from store",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					GetByHashedWorkerTokenFn: func(
+						context.Context,
+						string,
+					) (Event, error) {
+						return Event{}, errors.New("error getting event")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting event")
+				require.Contains(t, err.Error(), "error retrieving event")
+			},
+		},
+		{
+			name: "success",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					GetByHashedWorkerTokenFn: func(
+						context.Context,
+						string,
+					) (Event, error) {
+						return Event{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.service.GetByWorkerToken(
+				context.Background(),
+				"foobar",
+			)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestEventsServiceClone(t *testing.T) {
+	testEventID := "123456789"
+	testCases := []struct {
+		name       string
+		service    EventsService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting event from store",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, errors.New("error getting event")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting event")
+				require.Contains(t, err.Error(), "error retrieving event")
+			},
+		},
+		{
+			name: "error creating cloned event",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
 					},
 				},
-				substrate: &mockSubstrate{
-					ScheduleWorkerFn: func(context.Context, Event) error {
-						return errors.New("substrate error")
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
 					},
 				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
+					},
+				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					return Event{}, errors.New("something went wrong")
+				},
 			},
-			assertions: func(_ Event, err error) {
+			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error scheduling event")
-				require.Contains(t, err.Error(), "substrate error")
+				require.Contains(t, err.Error(), "something went wrong")
 			},
 		},
 		{
-			name:        "event retry - inherit worker spec and job",
-			eventLabels: testEventRetryLabel,
-			worker: Worker{
-				Spec: WorkerSpec{
-					DefaultConfigFiles: map[string]string{
-						"defaultConfig": "myConfig",
-					},
-				},
-				Jobs: []Job{
-					{
-						Name: "foo",
-					},
-				},
-			},
+			name: "project no longer subscribed",
 			service: &eventsService{
+				authorize:        alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					CreateFn: func(context.Context, Event) error {
-						return nil
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							ProjectID: "italian",
+							Source:    "eventsource",
+							Type:      "eventtype",
+						}, nil
 					},
 				},
-				substrate: &mockSubstrate{
-					ScheduleWorkerFn: func(context.Context, Event) error {
-						return nil
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						// No subscriptions
+						return Project{}, nil
 					},
 				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					require.Fail(t, "createSingleEventFn should not have been called")
+					return Event{}, nil
+				},
 			},
-			assertions: func(event Event, err error) {
-				require.NoError(t, err)
-				require.Equal(t, 1, len(event.Worker.Jobs))
-				require.Equal(t, Job{Name: "foo"}, event.Worker.Jobs[0])
-				require.Equal(
-					t,
-					map[string]string{"defaultConfig": "myConfig"},
-					event.Worker.Spec.DefaultConfigFiles,
-				)
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrConflict{}, err)
+				require.Contains(t, err.Error(), "not subscribed")
 			},
 		},
 		{
 			name: "success",
 			service: &eventsService{
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
-					CreateFn: func(context.Context, Event) error {
-						return nil
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							Source: "eventsource",
+							Type:   "eventtype",
+							Worker: Worker{
+								Spec: WorkerSpec{
+									DefaultConfigFiles: map[string]string{
+										"brigade.js": "test",
+									},
+								},
+							},
+						}, nil
 					},
 				},
-				substrate: &mockSubstrate{
-					ScheduleWorkerFn: func(context.Context, Event) error {
-						return nil
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
 					},
 				},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to see a label for tracing purposes
+					require.Contains(t, event.Labels, CloneLabelKey)
+					// Event details like source and type should be carried over
+					require.Equal(t, "eventsource", event.Source)
+					require.Equal(t, "eventtype", event.Type)
+					// But Worker config should not
+					require.Empty(t, event.Worker.Spec)
+					return Event{}, nil
+				},
 			},
-			assertions: func(event Event, err error) {
+			assertions: func(err error) {
 				require.NoError(t, err)
-				// Make sure the Event looks like what we expect-- i.e. ID generated,
-				// default applied, etc.
-				require.NotEmpty(t, event.ID)
-				require.Equal(t, defaultWorkspaceSize, event.Worker.Spec.WorkspaceSize)
-				require.NotNil(t, event.Worker.Spec.Git)
-				require.Equal(t, testEvent.Git.CloneURL, event.Worker.Spec.Git.CloneURL)
-				require.Equal(t, testEvent.Git.Commit, event.Worker.Spec.Git.Commit)
-				require.Equal(t, testEvent.Git.Ref, event.Worker.Spec.Git.Ref)
-				require.Equal(t, LogLevelInfo, event.Worker.Spec.LogLevel)
-				require.Equal(t, WorkerPhasePending, event.Worker.Status.Phase)
 			},
 		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			testEvent.Labels = testCase.eventLabels
-			testEvent.Worker = testCase.worker
-			event, err := testCase.service.createSingleEvent(
+			_, err := testCase.service.Clone(
 				context.Background(),
-				testProject,
-				testEvent,
+				testEventID,
 			)
-			testCase.assertions(event, err)
+			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceList(t *testing.T) {
+func TestEventsServiceUpdateSourceState(t *testing.T) {
+	testEventID := "123456789"
 	testCases := []struct {
 		name       string
 		service    EventsService
 		assertions func(error)
 	}{
+		{
+			name: "error getting event from store",
+			service: &eventsService{
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, errors.New("error getting event")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting event")
+				require.Contains(t, err.Error(), "error retrieving event")
+			},
+		},
 		{
 			name: "unauthorized",
 			service: &eventsService{
 				authorize: neverAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
@@ -471,23 +1618,27 @@ func TestEventsServiceList(t *testing.T) {
 			},
 		},
 		{
-			name: "error getting events from store",
+			name: "error updating source state in store",
 			service: &eventsService{
 				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
-					ListFn: func(
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+					UpdateSourceStateFn: func(
 						context.Context,
-						EventsSelector,
-						meta.ListOptions,
-					) (EventList, error) {
-						return EventList{}, errors.New("error listing events")
+						string,
+						SourceState,
+						int64,
+					) error {
+						return errors.New("something went wrong")
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error listing events")
-				require.Contains(t, err.Error(), "error retrieving events from store")
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error updating source state of event")
 			},
 		},
 		{
@@ -495,12 +1646,17 @@ func TestEventsServiceList(t *testing.T) {
 			service: &eventsService{
 				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
-					ListFn: func(
-						context.Context,
-						EventsSelector,
-						meta.ListOptions,
-					) (EventList, error) {
-						return EventList{}, nil
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+					UpdateSourceStateFn: func(
+						_ context.Context,
+						_ string,
+						_ SourceState,
+						resourceVersion int64,
+					) error {
+						require.Equal(t, int64(3), resourceVersion)
+						return nil
 					},
 				},
 			},
@@ -511,17 +1667,21 @@ func TestEventsServiceList(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.service.List(
+			err := testCase.service.UpdateSourceState(
 				context.Background(),
-				EventsSelector{},
-				meta.ListOptions{},
+				testEventID,
+				SourceState{},
+				EventSourceStateUpdateOptions{
+					ResourceVersion: 3,
+				},
 			)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceGet(t *testing.T) {
+func TestEventsServiceUpdateSummary(t *testing.T) {
+	testEventID := "123456789"
 	testCases := []struct {
 		name       string
 		service    EventsService
@@ -538,19 +1698,26 @@ func TestEventsServiceGet(t *testing.T) {
 			},
 		},
 		{
-			name: "error getting event from store",
+			name: "error updating summary in store",
 			service: &eventsService{
 				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, errors.New("error getting event")
+						return Event{}, nil
+					},
+					UpdateSummaryFn: func(
+						context.Context,
+						string,
+						EventSummary,
+					) error {
+						return errors.New("something went wrong")
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error getting event")
-				require.Contains(t, err.Error(), "error retrieving event")
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error updating summary of event")
 			},
 		},
 		{
@@ -561,6 +1728,13 @@ func TestEventsServiceGet(t *testing.T) {
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
+					UpdateSummaryFn: func(
+						context.Context,
+						string,
+						EventSummary,
+					) error {
+						return nil
+					},
 				},
 			},
 			assertions: func(err error) {
@@ -570,170 +1744,155 @@ func TestEventsServiceGet(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.service.Get(context.Background(), "foobar")
+			err := testCase.service.UpdateSummary(
+				context.Background(),
+				testEventID,
+				EventSummary{},
+			)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceGetByWorkerToken(t *testing.T) {
+func TestEventsServiceCancel(t *testing.T) {
+	const testEventID = "123456789"
 	testCases := []struct {
 		name       string
 		service    EventsService
 		assertions func(error)
 	}{
 		{
-			name: "error getting event from store",
+			name: "error retrieving event from store",
 			service: &eventsService{
 				eventsStore: &mockEventsStore{
-					GetByHashedWorkerTokenFn: func(
-						context.Context,
-						string,
-					) (Event, error) {
-						return Event{}, errors.New("error getting event")
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, errors.New("events store error")
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error getting event")
 				require.Contains(t, err.Error(), "error retrieving event")
+				require.Contains(t, err.Error(), "events store error")
 			},
 		},
 		{
-			name: "success",
+			name: "unauthorized",
 			service: &eventsService{
+				projectAuthorize: neverProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					GetByHashedWorkerTokenFn: func(
-						context.Context,
-						string,
-					) (Event, error) {
+					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
 				},
 			},
 			assertions: func(err error) {
-				require.NoError(t, err)
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
 			},
 		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.service.GetByWorkerToken(
-				context.Background(),
-				"foobar",
-			)
-			testCase.assertions(err)
-		})
-	}
-}
-
-func TestEventsServiceClone(t *testing.T) {
-	testEventID := "123456789"
-	testCases := []struct {
-		name       string
-		service    EventsService
-		assertions func(error)
-	}{
 		{
-			name: "unauthorized",
+			name: "error retrieving project from store",
 			service: &eventsService{
-				authorize: neverAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
 				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("projects store error")
+					},
+				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.IsType(t, &meta.ErrAuthorization{}, err)
+				require.Contains(t, err.Error(), "error retrieving project")
+				require.Contains(t, err.Error(), "projects store error")
 			},
 		},
 		{
-			name: "error getting event from store",
+			name: "error canceling event in store",
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, errors.New("error getting event")
+						return Event{}, nil
+					},
+					CancelFn: func(context.Context, string, PhaseTransition) error {
+						return errors.New("events store error")
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error getting event")
-				require.Contains(t, err.Error(), "error retrieving event")
+				require.Contains(t, err.Error(), "error canceling event")
+				require.Contains(t, err.Error(), "events store error")
 			},
 		},
 		{
-			name: "error creating cloned event",
+			name: "error deleting event from substrate",
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
+					CancelFn: func(context.Context, string, PhaseTransition) error {
+						return nil
+					},
 				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
 					},
 				},
-				createSingleEventFn: func(
-					context.Context,
-					Project,
-					Event,
-				) (Event, error) {
-					return Event{}, errors.New("something went wrong")
+				substrate: &mockSubstrate{
+					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
+						return errors.New("substrate error")
+					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error deleting event")
+				require.Contains(t, err.Error(), "worker and jobs")
+				require.Contains(t, err.Error(), "from the substrate")
+				require.Contains(t, err.Error(), "substrate error")
 			},
 		},
 		{
 			name: "success",
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Source: "eventsource",
-							Type:   "eventtype",
-							Worker: Worker{
-								Spec: WorkerSpec{
-									DefaultConfigFiles: map[string]string{
-										"brigade.js": "test",
-									},
-								},
-							},
-						}, nil
+						return Event{}, nil
+					},
+					CancelFn: func(
+						_ context.Context,
+						_ string,
+						transition PhaseTransition,
+					) error {
+						require.Equal(t, "no longer needed", transition.Reason)
+						return nil
 					},
 				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
 					},
 				},
-				createSingleEventFn: func(
-					_ context.Context,
-					_ Project,
-					event Event,
-				) (Event, error) {
-					// We expect to see a label for tracing purposes
-					require.Contains(t, event.Labels, CloneLabelKey)
-					// Event details like source and type should be carried over
-					require.Equal(t, "eventsource", event.Source)
-					require.Equal(t, "eventtype", event.Type)
-					// But Worker config should not
-					require.Empty(t, event.Worker.Spec)
-					return Event{}, nil
+				substrate: &mockSubstrate{
+					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
+						return nil
+					},
 				},
 			},
 			assertions: func(err error) {
@@ -743,46 +1902,48 @@ func TestEventsServiceClone(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.service.Clone(
+			err := testCase.service.Cancel(
 				context.Background(),
 				testEventID,
+				EventCancelOptions{
+					Reason: "no longer needed",
+				},
 			)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceUpdateSourceState(t *testing.T) {
-	testEventID := "123456789"
+func TestEventsServiceCancelMany(t *testing.T) {
 	testCases := []struct {
 		name       string
+		selector   EventsSelector
+		opts       EventCancelManyOptions
 		service    EventsService
 		assertions func(error)
 	}{
 		{
-			name: "error getting event from store",
-			service: &eventsService{
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, errors.New("error getting event")
-					},
-				},
-			},
+			name:     "request not qualified by project",
+			selector: EventsSelector{},
+			service:  &eventsService{},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error getting event")
-				require.Contains(t, err.Error(), "error retrieving event")
+				ebr, ok := err.(*meta.ErrBadRequest)
+				require.True(t, ok)
+				require.Equal(
+					t,
+					ebr.Reason,
+					"Requests to cancel multiple events must be qualified by project.",
+				)
 			},
 		},
 		{
 			name: "unauthorized",
+			selector: EventsSelector{
+				ProjectID: "blue-book",
+			},
 			service: &eventsService{
-				authorize: neverAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-				},
+				projectAuthorize: neverProjectAuthorize,
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
@@ -790,42 +1951,54 @@ func TestEventsServiceUpdateSourceState(t *testing.T) {
 			},
 		},
 		{
-			name: "error updating source state in store",
+			name: "request not qualified by worker phase",
+			selector: EventsSelector{
+				ProjectID: "blue-book",
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					UpdateSourceStateFn: func(
-						context.Context,
-						string,
-						SourceState,
-					) error {
-						return errors.New("something went wrong")
-					},
-				},
+				projectAuthorize: alwaysProjectAuthorize,
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "something went wrong")
-				require.Contains(t, err.Error(), "error updating source state of event")
+				ebr, ok := err.(*meta.ErrBadRequest)
+				require.True(t, ok)
+				require.Equal(
+					t,
+					ebr.Reason,
+					"Requests to cancel multiple events must be qualified by "+
+						"worker phase(s).",
+				)
 			},
 		},
 		{
-			name: "success",
+			name: "dry run",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed, WorkerPhaseRunning},
+			},
+			opts: EventCancelManyOptions{
+				DryRun: true,
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					UpdateSourceStateFn: func(
-						context.Context,
-						string,
-						SourceState,
-					) error {
-						return nil
+					ListFn: func(
+						_ context.Context,
+						selector EventsSelector,
+						opts meta.ListOptions,
+					) (EventList, error) {
+						require.Equal(
+							t,
+							[]WorkerPhase{WorkerPhaseRunning},
+							selector.WorkerPhases,
+						)
+						// Only a sample of events should be retrieved
+						require.Equal(t, int64(maxDryRunSampleSize), opts.Limit)
+						return EventList{
+							Items: []Event{
+								{ObjectMeta: meta.ObjectMeta{ID: "foo"}},
+							},
+						}, nil
 					},
 				},
 			},
@@ -833,73 +2006,77 @@ func TestEventsServiceUpdateSourceState(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			err := testCase.service.UpdateSourceState(
-				context.Background(),
-				testEventID,
-				SourceState{},
-			)
-			testCase.assertions(err)
-		})
-	}
-}
-
-func TestEventsServiceUpdateSummary(t *testing.T) {
-	testEventID := "123456789"
-	testCases := []struct {
-		name       string
-		service    EventsService
-		assertions func(error)
-	}{
 		{
-			name: "unauthorized",
+			name: "error getting project from store",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
 			service: &eventsService{
-				authorize: neverAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("error getting project")
+					},
+				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.IsType(t, &meta.ErrAuthorization{}, err)
+				require.Contains(t, err.Error(), "error retrieving project")
+				require.Contains(t, err.Error(), "error getting project")
 			},
 		},
 		{
-			name: "error updating summary in store",
+			name: "error canceling events in store",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
 					},
-					UpdateSummaryFn: func(
+				},
+				eventsStore: &mockEventsStore{
+					CancelManyFn: func(
 						context.Context,
-						string,
-						EventSummary,
-					) error {
-						return errors.New("something went wrong")
+						EventsSelector,
+						PhaseTransition,
+					) (<-chan Event, int64, error) {
+						return nil, 0, errors.New("events store error")
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "something went wrong")
-				require.Contains(t, err.Error(), "error updating summary of event")
+				require.Contains(t, err.Error(), "error canceling events in store")
+				require.Contains(t, err.Error(), "events store error")
 			},
 		},
 		{
 			name: "success",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
 					},
-					UpdateSummaryFn: func(
+				},
+				eventsStore: &mockEventsStore{
+					CancelManyFn: func(
 						context.Context,
-						string,
-						EventSummary,
-					) error {
-						return nil
+						EventsSelector,
+						PhaseTransition,
+					) (<-chan Event, int64, error) {
+						eventCh := make(chan Event)
+						defer close(eventCh)
+						return eventCh, 0, nil
 					},
 				},
 			},
@@ -910,17 +2087,18 @@ func TestEventsServiceUpdateSummary(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			err := testCase.service.UpdateSummary(
-				context.Background(),
-				testEventID,
-				EventSummary{},
-			)
+			_, err :=
+				testCase.service.CancelMany(
+					context.Background(),
+					testCase.selector,
+					testCase.opts,
+				)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceCancel(t *testing.T) {
+func TestEventsServiceDelete(t *testing.T) {
 	const testEventID = "123456789"
 	testCases := []struct {
 		name       string
@@ -979,14 +2157,14 @@ func TestEventsServiceCancel(t *testing.T) {
 			},
 		},
 		{
-			name: "error canceling event in store",
+			name: "error deleting event from store",
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
-					CancelFn: func(context.Context, string) error {
+					DeleteFn: func(context.Context, string) error {
 						return errors.New("events store error")
 					},
 				},
@@ -998,7 +2176,7 @@ func TestEventsServiceCancel(t *testing.T) {
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error canceling event")
+				require.Contains(t, err.Error(), "error deleting event")
 				require.Contains(t, err.Error(), "events store error")
 			},
 		},
@@ -1010,7 +2188,7 @@ func TestEventsServiceCancel(t *testing.T) {
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
-					CancelFn: func(context.Context, string) error {
+					DeleteFn: func(context.Context, string) error {
 						return nil
 					},
 				},
@@ -1033,6 +2211,39 @@ func TestEventsServiceCancel(t *testing.T) {
 				require.Contains(t, err.Error(), "substrate error")
 			},
 		},
+		{
+			name: "error deleting event logs",
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, nil
+					},
+					DeleteFn: func(context.Context, string) error {
+						return nil
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				logsStore: &mockLogsStore{
+					DeleteEventLogsFn: func(context.Context, string) error {
+						return errors.New("error deleting logs")
+					},
+				},
+				substrate: &mockSubstrate{
+					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
+						return nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error deleting logs")
+			},
+		},
 		{
 			name: "success",
 			service: &eventsService{
@@ -1041,7 +2252,7 @@ func TestEventsServiceCancel(t *testing.T) {
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{}, nil
 					},
-					CancelFn: func(context.Context, string) error {
+					DeleteFn: func(context.Context, string) error {
 						return nil
 					},
 				},
@@ -1050,6 +2261,11 @@ func TestEventsServiceCancel(t *testing.T) {
 						return Project{}, nil
 					},
 				},
+				logsStore: &mockLogsStore{
+					DeleteEventLogsFn: func(context.Context, string) error {
+						return nil
+					},
+				},
 				substrate: &mockSubstrate{
 					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
 						return nil
@@ -1063,16 +2279,21 @@ func TestEventsServiceCancel(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			err := testCase.service.Cancel(context.Background(), testEventID)
+			err := testCase.service.Delete(
+				context.Background(),
+				testEventID,
+				EventDeleteOptions{},
+			)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceCancelMany(t *testing.T) {
+func TestEventsServiceDeleteMany(t *testing.T) {
 	testCases := []struct {
 		name       string
 		selector   EventsSelector
+		opts       EventDeleteManyOptions
 		service    EventsService
 		assertions func(error)
 	}{
@@ -1087,7 +2308,7 @@ func TestEventsServiceCancelMany(t *testing.T) {
 				require.Equal(
 					t,
 					ebr.Reason,
-					"Requests to cancel multiple events must be qualified by project.",
+					"Requests to delete multiple events must be qualified by project.",
 				)
 			},
 		},
@@ -1119,11 +2340,47 @@ func TestEventsServiceCancelMany(t *testing.T) {
 				require.Equal(
 					t,
 					ebr.Reason,
-					"Requests to cancel multiple events must be qualified by "+
+					"Requests to delete multiple events must be qualified by "+
 						"worker phase(s).",
 				)
 			},
 		},
+		{
+			name: "dry run",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
+			opts: EventDeleteManyOptions{
+				DryRun: true,
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				eventsStore: &mockEventsStore{
+					ListFn: func(
+						_ context.Context,
+						selector EventsSelector,
+						opts meta.ListOptions,
+					) (EventList, error) {
+						require.Equal(
+							t,
+							[]WorkerPhase{WorkerPhaseFailed},
+							selector.WorkerPhases,
+						)
+						// Only a sample of events should be retrieved
+						require.Equal(t, int64(maxDryRunSampleSize), opts.Limit)
+						return EventList{
+							Items: []Event{
+								{ObjectMeta: meta.ObjectMeta{ID: "foo"}},
+							},
+						}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "error getting project from store",
 			selector: EventsSelector{
@@ -1145,7 +2402,7 @@ func TestEventsServiceCancelMany(t *testing.T) {
 			},
 		},
 		{
-			name: "error canceling events in store",
+			name: "error deleting events from store",
 			selector: EventsSelector{
 				ProjectID:    "blue-book",
 				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
@@ -1158,7 +2415,7 @@ func TestEventsServiceCancelMany(t *testing.T) {
 					},
 				},
 				eventsStore: &mockEventsStore{
-					CancelManyFn: func(
+					DeleteManyFn: func(
 						context.Context,
 						EventsSelector,
 					) (<-chan Event, int64, error) {
@@ -1168,220 +2425,667 @@ func TestEventsServiceCancelMany(t *testing.T) {
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error canceling events in store")
-				require.Contains(t, err.Error(), "events store error")
+				require.Contains(t, err.Error(), "error deleting events from store")
+				require.Contains(t, err.Error(), "events store error")
+			},
+		},
+		{
+			name: "success",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					DeleteManyFn: func(
+						context.Context,
+						EventsSelector,
+					) (<-chan Event, int64, error) {
+						eventCh := make(chan Event)
+						defer close(eventCh)
+						return eventCh, 0, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err :=
+				testCase.service.DeleteMany(
+					context.Background(),
+					testCase.selector,
+					testCase.opts,
+				)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestEventsServiceDeleteManyRecordsDeletedEvents(t *testing.T) {
+	cleanedUp := make(chan struct{})
+	var records []AuditTarget
+	svc := &eventsService{
+		projectAuthorize: alwaysProjectAuthorize,
+		projectsStore: &mockProjectsStore{
+			GetFn: func(context.Context, string) (Project, error) {
+				return Project{}, nil
+			},
+		},
+		eventsStore: &mockEventsStore{
+			DeleteManyFn: func(
+				context.Context,
+				EventsSelector,
+			) (<-chan Event, int64, error) {
+				eventCh := make(chan Event, 1)
+				eventCh <- Event{
+					ObjectMeta: meta.ObjectMeta{ID: "tony"},
+					ProjectID:  "blue-book",
+				}
+				close(eventCh)
+				return eventCh, 1, nil
+			},
+		},
+		substrate: &mockSubstrate{
+			DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
+				return nil
+			},
+		},
+		logsStore: &mockLogsStore{
+			DeleteEventLogsFn: func(context.Context, string) error {
+				close(cleanedUp)
+				return nil
+			},
+		},
+		auditRecorder: &mockAuditRecorder{
+			RecordFn: func(
+				_ context.Context,
+				action string,
+				target AuditTarget,
+				err error,
+			) {
+				require.Equal(t, "events.deleteMany", action)
+				require.NoError(t, err)
+				records = append(records, target)
+			},
+		},
+	}
+	result, err := svc.DeleteMany(
+		context.Background(),
+		EventsSelector{
+			ProjectID:    "blue-book",
+			WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+		},
+		EventDeleteManyOptions{
+			Reason: "cleaning up",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Count)
+	<-cleanedUp
+	require.Equal(
+		t,
+		[]AuditTarget{
+			{
+				Type:      EventKind,
+				ID:        "tony",
+				ProjectID: "blue-book",
+				Details: map[string]string{
+					"reason": "cleaning up",
+				},
+			},
+		},
+		records,
+	)
+}
+
+func TestEventsServiceRetry(t *testing.T) {
+	testEventID := "123456789"
+	testCases := []struct {
+		name       string
+		opts       EventRetryOptions
+		service    EventsService
+		assertions func(error)
+	}{
+		{
+			name: "error getting event from store",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{}, errors.New("error getting event")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting event")
+				require.Contains(t, err.Error(), "error retrieving event")
+			},
+		},
+		{
+			name: "unauthorized",
+			service: &eventsService{
+				authorize: neverAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "original event worker has non-terminal phase",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseStarting,
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"non-terminal and may not yet be retried",
+				)
+			},
+		},
+		{
+			name: "error creating retry event",
+			service: &eventsService{
+				authorize: alwaysAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+							},
+						}, nil
+					},
+				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
+					},
+				},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					return Event{}, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
 			},
 		},
 		{
-			name: "success",
-			selector: EventsSelector{
-				ProjectID:    "blue-book",
-				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
-			},
+			name: "project no longer subscribed",
 			service: &eventsService{
+				authorize:        alwaysAuthorize,
 				projectAuthorize: alwaysProjectAuthorize,
+				eventsStore: &mockEventsStore{
+					GetFn: func(context.Context, string) (Event, error) {
+						return Event{
+							ProjectID: "italian",
+							Source:    "eventsource",
+							Type:      "eventtype",
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+							},
+						}, nil
+					},
+				},
 				projectsStore: &mockProjectsStore{
 					GetFn: func(context.Context, string) (Project, error) {
+						// No subscriptions
 						return Project{}, nil
 					},
 				},
-				eventsStore: &mockEventsStore{
-					CancelManyFn: func(
-						context.Context,
-						EventsSelector,
-					) (<-chan Event, int64, error) {
-						eventCh := make(chan Event)
-						defer close(eventCh)
-						return eventCh, 0, nil
-					},
+				createSingleEventFn: func(
+					context.Context,
+					Project,
+					Event,
+				) (Event, error) {
+					require.Fail(t, "createSingleEventFn should not have been called")
+					return Event{}, nil
 				},
 			},
 			assertions: func(err error) {
-				require.NoError(t, err)
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrConflict{}, err)
+				require.Contains(t, err.Error(), "not subscribed")
 			},
 		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			_, err :=
-				testCase.service.CancelMany(context.Background(), testCase.selector)
-			testCase.assertions(err)
-		})
-	}
-}
-
-func TestEventsServiceDelete(t *testing.T) {
-	const testEventID = "123456789"
-	testCases := []struct {
-		name       string
-		service    EventsService
-		assertions func(error)
-	}{
 		{
-			name: "error retrieving event from store",
+			name: "inherit job",
 			service: &eventsService{
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, errors.New("events store error")
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseSucceeded,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+								},
+							},
+						}, nil
 					},
 				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
+					},
+				},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to inherit one job
+					require.Equal(t, 1, len(event.Worker.Jobs))
+					// We expect to see the job's LogsEventID field match the event ID
+					require.Equal(t, testEventID, event.Worker.Jobs[0].Status.LogsEventID)
+					return Event{}, nil
+				},
 			},
 			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error retrieving event")
-				require.Contains(t, err.Error(), "events store error")
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "unauthorized",
+			name: "inherit job - logs ID already exists",
 			service: &eventsService{
-				projectAuthorize: neverProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseSucceeded,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Status: &JobStatus{
+											Phase:       JobPhaseSucceeded,
+											LogsEventID: "abcdefgh",
+										},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
 					},
 				},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to inherit one job
+					require.Equal(t, 1, len(event.Worker.Jobs))
+					// We expect to see the job's original LogsEventID field retained
+					require.Equal(t, "abcdefgh", event.Worker.Jobs[0].Status.LogsEventID)
+					return Event{}, nil
+				},
 			},
 			assertions: func(err error) {
-				require.Error(t, err)
-				require.IsType(t, &meta.ErrAuthorization{}, err)
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "error retrieving project from store",
+			name: "do not inherit job",
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseSucceeded,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Spec: JobSpec{
+											PrimaryContainer: JobContainerSpec{
+												WorkspaceMountPath: "/workspace",
+											},
+										},
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+								},
+							},
+						}, nil
+					},
+				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
 					},
 				},
 				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, errors.New("projects store error")
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
 					},
 				},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to inherit no jobs
+					require.Equal(t, 0, len(event.Worker.Jobs))
+					// We expect the job to be executed again, but since every job
+					// succeeded, there's no job to retry from
+					require.Equal(t, []string{"foo"}, event.Worker.RetryJobs)
+					require.NotContains(t, event.Labels, RetryFromJobLabelKey)
+					return Event{}, nil
+				},
 			},
 			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error retrieving project")
-				require.Contains(t, err.Error(), "projects store error")
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "error deleting event from store",
+			name: "retry from nonexistent job",
+			opts: EventRetryOptions{
+				FromJob: "bat",
+			},
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					DeleteFn: func(context.Context, string) error {
-						return errors.New("events store error")
-					},
-				},
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+								},
+							},
+						}, nil
 					},
 				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error deleting event")
-				require.Contains(t, err.Error(), "events store error")
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), `no job named "bat"`)
 			},
 		},
 		{
-			name: "error deleting event from substrate",
+			name: "retry from job",
+			opts: EventRetryOptions{
+				FromJob: "bar",
+			},
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					DeleteFn: func(context.Context, string) error {
-						return nil
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+									{
+										Name: "bar",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+									{
+										Name: "bat",
+										Status: &JobStatus{
+											Phase: JobPhaseFailed,
+										},
+									},
+								},
+							},
+						}, nil
 					},
 				},
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
 					},
 				},
-				substrate: &mockSubstrate{
-					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
-						return errors.New("substrate error")
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 1), nil
 					},
 				},
-			},
-			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error deleting event")
-				require.Contains(t, err.Error(), "worker and jobs")
-				require.Contains(t, err.Error(), "from the substrate")
-				require.Contains(t, err.Error(), "substrate error")
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to inherit only the job preceding "bar"
+					require.Len(t, event.Worker.Jobs, 1)
+					require.Equal(t, "foo", event.Worker.Jobs[0].Name)
+					require.Equal(t, testEventID, event.Worker.Jobs[0].Status.LogsEventID)
+					// We expect the job to retry from to be recorded
+					require.Equal(t, "bar", event.Labels[RetryFromJobLabelKey])
+					// We expect the jobs to be executed again to be recorded
+					require.Equal(t, []string{"bar", "bat"}, event.Worker.RetryJobs)
+					return event, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
 			},
 		},
 		{
-			name: "error deleting event logs",
+			name: "retry from first job that did not succeed by default",
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					DeleteFn: func(context.Context, string) error {
-						return nil
+						return Event{
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+								Jobs: []Job{
+									{
+										Name: "foo",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+									{
+										Name: "bar",
+										Status: &JobStatus{
+											Phase: JobPhaseFailed,
+										},
+									},
+									{
+										Name: "bat",
+										Status: &JobStatus{
+											Phase: JobPhaseSucceeded,
+										},
+									},
+								},
+							},
+						}, nil
 					},
 				},
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
 					},
 				},
-				logsStore: &mockLogsStore{
-					DeleteEventLogsFn: func(context.Context, string) error {
-						return errors.New("error deleting logs")
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 1), nil
 					},
 				},
-				substrate: &mockSubstrate{
-					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
-						return nil
-					},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to inherit only the job preceding the failed one, even
+					// though a later job succeeded
+					require.Len(t, event.Worker.Jobs, 1)
+					require.Equal(t, "foo", event.Worker.Jobs[0].Name)
+					require.Equal(t, "bar", event.Labels[RetryFromJobLabelKey])
+					require.Equal(t, []string{"bar", "bat"}, event.Worker.RetryJobs)
+					return event, nil
 				},
 			},
 			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error deleting logs")
+				require.NoError(t, err)
 			},
 		},
 		{
 			name: "success",
 			service: &eventsService{
-				projectAuthorize: alwaysProjectAuthorize,
+				authorize: alwaysAuthorize,
 				eventsStore: &mockEventsStore{
 					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, nil
-					},
-					DeleteFn: func(context.Context, string) error {
-						return nil
+						return Event{
+							Source: "eventsource",
+							Type:   "eventtype",
+							Worker: Worker{
+								Status: WorkerStatus{
+									Phase: WorkerPhaseFailed,
+								},
+								Spec: WorkerSpec{
+									DefaultConfigFiles: map[string]string{
+										"brigade.js": "test",
+									},
+								},
+							},
+						}, nil
 					},
 				},
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
 					},
 				},
-				logsStore: &mockLogsStore{
-					DeleteEventLogsFn: func(context.Context, string) error {
-						return nil
+				projectsStore: &mockProjectsStore{
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 2), nil
 					},
 				},
-				substrate: &mockSubstrate{
-					DeleteWorkerAndJobsFn: func(context.Context, Project, Event) error {
-						return nil
-					},
+				createSingleEventFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+				) (Event, error) {
+					// We expect to see a label for tracing purposes
+					require.Contains(t, event.Labels, RetryLabelKey)
+					// Event details like source and type should be carried over
+					require.Equal(t, "eventsource", event.Source)
+					require.Equal(t, "eventtype", event.Type)
+					// As well as Worker config
+					require.NotEmpty(t, event.Worker.Spec)
+					require.Equal(
+						t,
+						map[string]string{"brigade.js": "test"},
+						event.Worker.Spec.DefaultConfigFiles,
+					)
+					return Event{}, nil
 				},
 			},
 			assertions: func(err error) {
@@ -1391,31 +3095,35 @@ func TestEventsServiceDelete(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			err := testCase.service.Delete(context.Background(), testEventID)
+			_, err := testCase.service.Retry(
+				context.Background(),
+				testEventID,
+				testCase.opts,
+			)
 			testCase.assertions(err)
 		})
 	}
 }
 
-func TestEventsServiceDeleteMany(t *testing.T) {
+func TestEventsServiceRetryMany(t *testing.T) {
 	testCases := []struct {
 		name       string
 		selector   EventsSelector
 		service    EventsService
-		assertions func(error)
+		assertions func(RetryManyEventsResult, error)
 	}{
 		{
 			name:     "request not qualified by project",
 			selector: EventsSelector{},
 			service:  &eventsService{},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
 				ebr, ok := err.(*meta.ErrBadRequest)
 				require.True(t, ok)
 				require.Equal(
 					t,
+					"Requests to retry multiple events must be qualified by project.",
 					ebr.Reason,
-					"Requests to delete multiple events must be qualified by project.",
 				)
 			},
 		},
@@ -1427,7 +3135,7 @@ func TestEventsServiceDeleteMany(t *testing.T) {
 			service: &eventsService{
 				projectAuthorize: neverProjectAuthorize,
 			},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
 				require.IsType(t, &meta.ErrAuthorization{}, err)
 			},
@@ -1440,236 +3148,136 @@ func TestEventsServiceDeleteMany(t *testing.T) {
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
 			},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
 				ebr, ok := err.(*meta.ErrBadRequest)
 				require.True(t, ok)
 				require.Equal(
 					t,
+					"Requests to retry multiple events must be qualified by worker "+
+						"phase(s).",
 					ebr.Reason,
-					"Requests to delete multiple events must be qualified by "+
-						"worker phase(s).",
 				)
 			},
 		},
 		{
-			name: "error getting project from store",
+			name: "request selects non-terminal worker phase",
 			selector: EventsSelector{
 				ProjectID:    "blue-book",
-				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed, WorkerPhaseRunning},
 			},
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, errors.New("error getting project")
-					},
-				},
 			},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error retrieving project")
-				require.Contains(t, err.Error(), "error getting project")
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "is non-terminal")
 			},
 		},
 		{
-			name: "error deleting events from store",
+			name: "error listing events from store",
 			selector: EventsSelector{
 				ProjectID:    "blue-book",
 				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
 			},
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
-					},
-				},
 				eventsStore: &mockEventsStore{
-					DeleteManyFn: func(
+					ListFn: func(
 						context.Context,
 						EventsSelector,
-					) (<-chan Event, int64, error) {
-						return nil, 0, errors.New("events store error")
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{}, errors.New("events store error")
 					},
 				},
 			},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error deleting events from store")
+				require.Contains(t, err.Error(), "error retrieving events from store")
 				require.Contains(t, err.Error(), "events store error")
 			},
 		},
 		{
-			name: "success",
+			name: "selection too large",
 			selector: EventsSelector{
 				ProjectID:    "blue-book",
 				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
 			},
 			service: &eventsService{
 				projectAuthorize: alwaysProjectAuthorize,
-				projectsStore: &mockProjectsStore{
-					GetFn: func(context.Context, string) (Project, error) {
-						return Project{}, nil
-					},
-				},
 				eventsStore: &mockEventsStore{
-					DeleteManyFn: func(
+					ListFn: func(
 						context.Context,
 						EventsSelector,
-					) (<-chan Event, int64, error) {
-						eventCh := make(chan Event)
-						defer close(eventCh)
-						return eventCh, 0, nil
-					},
-				},
-			},
-			assertions: func(err error) {
-				require.NoError(t, err)
-			},
-		},
-	}
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			_, err :=
-				testCase.service.DeleteMany(context.Background(), testCase.selector)
-			testCase.assertions(err)
-		})
-	}
-}
-
-func TestEventsServiceRetry(t *testing.T) {
-	testEventID := "123456789"
-	testCases := []struct {
-		name       string
-		service    EventsService
-		assertions func(error)
-	}{
-		{
-			name: "error getting event from store",
-			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{}, errors.New("error getting event")
-					},
-				},
-			},
-			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "error getting event")
-				require.Contains(t, err.Error(), "error retrieving event")
-			},
-		},
-		{
-			name: "unauthorized",
-			service: &eventsService{
-				authorize: neverAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseFailed,
-								},
-							},
-						}, nil
-					},
-				},
-			},
-			assertions: func(err error) {
-				require.Error(t, err)
-				require.IsType(t, &meta.ErrAuthorization{}, err)
-			},
-		},
-		{
-			name: "original event worker has non-terminal phase",
-			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseStarting,
-								},
-							},
-						}, nil
-					},
-				},
-			},
-			assertions: func(err error) {
-				require.Error(t, err)
-				require.Contains(
-					t,
-					err.Error(),
-					"non-terminal and may not yet be retried",
-				)
-			},
-		},
-		{
-			name: "error creating retry event",
-			service: &eventsService{
-				authorize: alwaysAuthorize,
-				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseFailed,
-								},
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{
+							ListMeta: meta.ListMeta{
+								Continue:           "foo",
+								RemainingItemCount: 1,
 							},
+							Items: make([]Event, maxCopyManySelectionSize),
 						}, nil
 					},
 				},
-				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
-					},
-				},
-				createSingleEventFn: func(
-					context.Context,
-					Project,
-					Event,
-				) (Event, error) {
-					return Event{}, errors.New("something went wrong")
-				},
 			},
-			assertions: func(err error) {
+			assertions: func(_ RetryManyEventsResult, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "something went wrong")
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "this request selects 101")
 			},
 		},
 		{
-			name: "inherit job",
+			name: "partial success",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseFailed},
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				authorize:        alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
+					ListFn: func(
+						_ context.Context,
+						_ EventsSelector,
+						opts meta.ListOptions,
+					) (EventList, error) {
+						require.Equal(t, int64(maxCopyManySelectionSize), opts.Limit)
+						return EventList{
+							Items: []Event{
+								{ObjectMeta: meta.ObjectMeta{ID: "foo"}},
+								{ObjectMeta: meta.ObjectMeta{ID: "bar"}},
+							},
+						}, nil
+					},
+					GetFn: func(_ context.Context, id string) (Event, error) {
+						if id == "bar" {
+							return Event{}, errors.New("error getting event")
+						}
 						return Event{
+							Source: "eventsource",
+							Type:   "eventtype",
 							Worker: Worker{
 								Status: WorkerStatus{
-									Phase: WorkerPhaseSucceeded,
-								},
-								Jobs: []Job{
-									{
-										Name: "foo",
-										Status: &JobStatus{
-											Phase: JobPhaseSucceeded,
-										},
-									},
+									Phase: WorkerPhaseFailed,
 								},
 							},
 						}, nil
 					},
 				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 1), nil
 					},
 				},
 				createSingleEventFn: func(
@@ -1677,140 +3285,169 @@ func TestEventsServiceRetry(t *testing.T) {
 					_ Project,
 					event Event,
 				) (Event, error) {
-					// We expect to inherit one job
-					require.Equal(t, 1, len(event.Worker.Jobs))
-					// We expect to see the job's LogsEventID field match the event ID
-					require.Equal(t, testEventID, event.Worker.Jobs[0].Status.LogsEventID)
-					return Event{}, nil
+					require.Equal(t, "foo", event.Labels[RetryLabelKey])
+					event.ID = "retried-foo"
+					return event, nil
 				},
 			},
-			assertions: func(err error) {
+			assertions: func(result RetryManyEventsResult, err error) {
 				require.NoError(t, err)
+				require.Equal(t, int64(1), result.Count)
+				require.Equal(t, int64(1), result.FailedCount)
+				require.Len(t, result.Items, 2)
+				require.Equal(
+					t,
+					EventCopyResult{
+						SourceEventID: "foo",
+						EventID:       "retried-foo",
+					},
+					result.Items[0],
+				)
+				require.Equal(t, "bar", result.Items[1].SourceEventID)
+				require.Empty(t, result.Items[1].EventID)
+				require.Contains(t, result.Items[1].Error, "error getting event")
 			},
 		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := testCase.service.RetryMany(
+				context.Background(),
+				testCase.selector,
+			)
+			testCase.assertions(result, err)
+		})
+	}
+}
+
+func TestEventsServiceCloneMany(t *testing.T) {
+	testCases := []struct {
+		name       string
+		selector   EventsSelector
+		service    EventsService
+		assertions func(CloneManyEventsResult, error)
+	}{
 		{
-			name: "inherit job - logs ID already exists",
+			name:     "request not qualified by project",
+			selector: EventsSelector{},
+			service:  &eventsService{},
+			assertions: func(_ CloneManyEventsResult, err error) {
+				require.Error(t, err)
+				ebr, ok := err.(*meta.ErrBadRequest)
+				require.True(t, ok)
+				require.Equal(
+					t,
+					"Requests to clone multiple events must be qualified by project.",
+					ebr.Reason,
+				)
+			},
+		},
+		{
+			name: "unauthorized",
+			selector: EventsSelector{
+				ProjectID: "blue-book",
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: neverProjectAuthorize,
+			},
+			assertions: func(_ CloneManyEventsResult, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error listing events from store",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseRunning},
+			},
+			service: &eventsService{
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseSucceeded,
-								},
-								Jobs: []Job{
-									{
-										Name: "foo",
-										Status: &JobStatus{
-											Phase:       JobPhaseSucceeded,
-											LogsEventID: "abcdefgh",
-										},
-									},
-								},
-							},
-						}, nil
-					},
-				},
-				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{}, errors.New("events store error")
 					},
 				},
-				createSingleEventFn: func(
-					_ context.Context,
-					_ Project,
-					event Event,
-				) (Event, error) {
-					// We expect to inherit one job
-					require.Equal(t, 1, len(event.Worker.Jobs))
-					// We expect to see the job's original LogsEventID field retained
-					require.Equal(t, "abcdefgh", event.Worker.Jobs[0].Status.LogsEventID)
-					return Event{}, nil
-				},
 			},
-			assertions: func(err error) {
-				require.NoError(t, err)
+			assertions: func(_ CloneManyEventsResult, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error retrieving events from store")
+				require.Contains(t, err.Error(), "events store error")
 			},
 		},
 		{
-			name: "do not inherit job",
+			name: "selection too large",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseRunning},
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
-					GetFn: func(context.Context, string) (Event, error) {
-						return Event{
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseSucceeded,
-								},
-								Jobs: []Job{
-									{
-										Name: "foo",
-										Spec: JobSpec{
-											PrimaryContainer: JobContainerSpec{
-												WorkspaceMountPath: "/workspace",
-											},
-										},
-										Status: &JobStatus{
-											Phase: JobPhaseSucceeded,
-										},
-									},
-								},
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{
+							ListMeta: meta.ListMeta{
+								Continue:           "foo",
+								RemainingItemCount: 1,
 							},
+							Items: make([]Event, maxCopyManySelectionSize),
 						}, nil
 					},
 				},
-				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
-					},
-				},
-				createSingleEventFn: func(
-					_ context.Context,
-					_ Project,
-					event Event,
-				) (Event, error) {
-					// We expect to inherit no jobs
-					require.Equal(t, 0, len(event.Worker.Jobs))
-					return Event{}, nil
-				},
 			},
-			assertions: func(err error) {
-				require.NoError(t, err)
+			assertions: func(_ CloneManyEventsResult, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "this request selects 101")
 			},
 		},
 		{
 			name: "success",
+			selector: EventsSelector{
+				ProjectID:    "blue-book",
+				WorkerPhases: []WorkerPhase{WorkerPhaseRunning},
+			},
 			service: &eventsService{
-				authorize: alwaysAuthorize,
+				authorize:        alwaysAuthorize,
+				projectAuthorize: alwaysProjectAuthorize,
 				eventsStore: &mockEventsStore{
+					ListFn: func(
+						context.Context,
+						EventsSelector,
+						meta.ListOptions,
+					) (EventList, error) {
+						return EventList{
+							Items: []Event{
+								{ObjectMeta: meta.ObjectMeta{ID: "foo"}},
+							},
+						}, nil
+					},
 					GetFn: func(context.Context, string) (Event, error) {
 						return Event{
 							Source: "eventsource",
 							Type:   "eventtype",
-							Worker: Worker{
-								Status: WorkerStatus{
-									Phase: WorkerPhaseFailed,
-								},
-								Spec: WorkerSpec{
-									DefaultConfigFiles: map[string]string{
-										"brigade.js": "test",
-									},
-								},
-							},
 						}, nil
 					},
 				},
+				gatewaysStore: &mockGatewaysStore{
+					GetBySourceFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, &meta.ErrNotFound{}
+					},
+				},
 				projectsStore: &mockProjectsStore{
-					ListSubscribersFn: func(context.Context, Event) (ProjectList, error) {
-						return ProjectList{
-							Items: []Project{{}, {}},
-						}, nil
+					ListSubscribersFn: func(
+						_ context.Context,
+						event Event,
+					) (ProjectList, error) {
+						return subscribedProjects(event, 1), nil
 					},
 				},
 				createSingleEventFn: func(
@@ -1818,33 +3455,35 @@ func TestEventsServiceRetry(t *testing.T) {
 					_ Project,
 					event Event,
 				) (Event, error) {
-					// We expect to see a label for tracing purposes
-					require.Contains(t, event.Labels, RetryLabelKey)
-					// Event details like source and type should be carried over
-					require.Equal(t, "eventsource", event.Source)
-					require.Equal(t, "eventtype", event.Type)
-					// As well as Worker config
-					require.NotEmpty(t, event.Worker.Spec)
-					require.Equal(
-						t,
-						map[string]string{"brigade.js": "test"},
-						event.Worker.Spec.DefaultConfigFiles,
-					)
-					return Event{}, nil
+					require.Equal(t, "foo", event.Labels[CloneLabelKey])
+					event.ID = "cloned-foo"
+					return event, nil
 				},
 			},
-			assertions: func(err error) {
+			assertions: func(result CloneManyEventsResult, err error) {
 				require.NoError(t, err)
+				require.Equal(t, int64(1), result.Count)
+				require.Zero(t, result.FailedCount)
+				require.Equal(
+					t,
+					[]EventCopyResult{
+						{
+							SourceEventID: "foo",
+							EventID:       "cloned-foo",
+						},
+					},
+					result.Items,
+				)
 			},
 		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.service.Retry(
+			result, err := testCase.service.CloneMany(
 				context.Background(),
-				testEventID,
+				testCase.selector,
 			)
-			testCase.assertions(err)
+			testCase.assertions(result, err)
 		})
 	}
 }
@@ -1856,21 +3495,38 @@ type mockEventsStore struct {
 		EventsSelector,
 		meta.ListOptions,
 	) (EventList, error)
+	StatsFn func(
+		context.Context,
+		EventStatsOptions,
+	) ([]EventStatsGroup, error)
 	GetFn                    func(context.Context, string) (Event, error)
 	GetByHashedWorkerTokenFn func(context.Context, string) (Event, error)
-	UpdateSourceStateFn      func(context.Context, string, SourceState) error
+	UpdateSourceStateFn      func(context.Context, string, SourceState, int64) error
 	UpdateSummaryFn          func(context.Context, string, EventSummary) error
-	CancelFn                 func(context.Context, string) error
+	CancelFn                 func(context.Context, string, PhaseTransition) error
 	CancelManyFn             func(
 		context.Context,
 		EventsSelector,
+		PhaseTransition,
 	) (<-chan Event, int64, error)
 	DeleteFn     func(context.Context, string) error
 	DeleteManyFn func(
 		context.Context,
 		EventsSelector,
 	) (<-chan Event, int64, error)
-	DeleteByProjectIDFn func(context.Context, string) error
+	DeleteByProjectIDFn           func(context.Context, string) error
+	ListLogsMigrationCandidatesFn func(
+		context.Context,
+		time.Time,
+		int64,
+	) ([]Event, error)
+	ClaimLogsMigrationFn func(
+		context.Context,
+		string,
+		string,
+		time.Time,
+	) (bool, error)
+	MarkLogsMigratedFn func(context.Context, string) error
 }
 
 func (m *mockEventsStore) Create(ctx context.Context, event Event) error {
@@ -1885,6 +3541,13 @@ func (m *mockEventsStore) List(
 	return m.ListFn(ctx, selector, opts)
 }
 
+func (m *mockEventsStore) Stats(
+	ctx context.Context,
+	opts EventStatsOptions,
+) ([]EventStatsGroup, error) {
+	return m.StatsFn(ctx, opts)
+}
+
 func (m *mockEventsStore) Get(ctx context.Context, id string) (Event, error) {
 	return m.GetFn(ctx, id)
 }
@@ -1900,8 +3563,9 @@ func (m *mockEventsStore) UpdateSourceState(
 	ctx context.Context,
 	id string,
 	sourceState SourceState,
+	resourceVersion int64,
 ) error {
-	return m.UpdateSourceStateFn(ctx, id, sourceState)
+	return m.UpdateSourceStateFn(ctx, id, sourceState, resourceVersion)
 }
 
 func (m *mockEventsStore) UpdateSummary(
@@ -1912,15 +3576,20 @@ func (m *mockEventsStore) UpdateSummary(
 	return m.UpdateSummaryFn(ctx, id, summary)
 }
 
-func (m *mockEventsStore) Cancel(ctx context.Context, id string) error {
-	return m.CancelFn(ctx, id)
+func (m *mockEventsStore) Cancel(
+	ctx context.Context,
+	id string,
+	transition PhaseTransition,
+) error {
+	return m.CancelFn(ctx, id, transition)
 }
 
 func (m *mockEventsStore) CancelMany(
 	ctx context.Context,
 	selector EventsSelector,
+	transition PhaseTransition,
 ) (<-chan Event, int64, error) {
-	return m.CancelManyFn(ctx, selector)
+	return m.CancelManyFn(ctx, selector, transition)
 }
 
 func (m *mockEventsStore) Delete(ctx context.Context, id string) error {
@@ -1940,3 +3609,27 @@ func (m *mockEventsStore) DeleteByProjectID(
 ) error {
 	return m.DeleteByProjectIDFn(ctx, projectID)
 }
+
+func (m *mockEventsStore) ListLogsMigrationCandidates(
+	ctx context.Context,
+	cutoff time.Time,
+	limit int64,
+) ([]Event, error) {
+	return m.ListLogsMigrationCandidatesFn(ctx, cutoff, limit)
+}
+
+func (m *mockEventsStore) ClaimLogsMigration(
+	ctx context.Context,
+	id string,
+	claimant string,
+	until time.Time,
+) (bool, error) {
+	return m.ClaimLogsMigrationFn(ctx, id, claimant, until)
+}
+
+func (m *mockEventsStore) MarkLogsMigrated(
+	ctx context.Context,
+	id string,
+) error {
+	return m.MarkLogsMigratedFn(ctx, id)
+}
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/brigadecore/brigade-foundations/crypto"
+	libCrypto "github.com/brigadecore/brigade/v2/apiserver/internal/lib/crypto"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+)
+
+// GatewayKind represents the canonical Gateway kind string
+const GatewayKind = "Gateway"
+
+// Gateway represents a component that creates Events from some external
+// source. Each Gateway exclusively owns a single Event source. Once a Gateway
+// owns a source, no other principal may create Events from that source.
+type Gateway struct {
+	// ObjectMeta encapsulates Gateway metadata.
+	meta.ObjectMeta `json:"metadata" bson:",inline"`
+	// Description is a natural language description of the Gateway's purpose.
+	Description string `json:"description" bson:"description"`
+	// Source is the Event source owned by the Gateway. It is unique across all
+	// Gateways.
+	Source string `json:"source" bson:"source"`
+	// HashedToken is a secure, one-way hash of the Gateway's token.
+	HashedToken string `json:"-" bson:"hashedToken"`
+	// LastSeen indicates when the Gateway last created an Event. If this field's
+	// value is nil, the Gateway has never created an Event.
+	LastSeen *time.Time `json:"lastSeen,omitempty" bson:"lastSeen"`
+}
+
+// MarshalJSON amends Gateway instances with type metadata.
+func (g Gateway) MarshalJSON() ([]byte, error) {
+	type Alias Gateway
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       GatewayKind,
+			},
+			Alias: (Alias)(g),
+		},
+	)
+}
+
+// RoleAssignments returns the Gateway's RoleAssignments. A Gateway is
+// implicitly permitted to create Events from the source it owns and nothing
+// else.
+func (g *Gateway) RoleAssignments() []RoleAssignment {
+	return []RoleAssignment{
+		{
+			Role:  RoleEventCreator,
+			Scope: g.Source,
+		},
+	}
+}
+
+// GatewayList is an ordered and pageable list of Gateways.
+type GatewayList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of Gateways.
+	Items []Gateway `json:"items,omitempty"`
+}
+
+// MarshalJSON amends GatewayList instances with type metadata.
+func (g GatewayList) MarshalJSON() ([]byte, error) {
+	type Alias GatewayList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "GatewayList",
+			},
+			Alias: (Alias)(g),
+		},
+	)
+}
+
+// GatewaysService is the specialized interface for managing Gateways. It's
+// decoupled from underlying technology choices (e.g. data store) to keep
+// business logic reusable and consistent while the underlying tech stack
+// remains free to change.
+type GatewaysService interface {
+	// Create creates a new Gateway. If a Gateway having the same ID or owning the
+	// same source already exists, implementations MUST return a
+	// *meta.ErrConflict error.
+	Create(context.Context, Gateway) (Token, error)
+	// List retrieves a GatewayList.
+	List(context.Context, meta.ListOptions) (GatewayList, error)
+	// Get retrieves a single Gateway specified by its identifier. If the
+	// specified Gateway does not exist, implementations MUST return a
+	// *meta.ErrNotFound error.
+	Get(context.Context, string) (Gateway, error)
+	// GetByToken retrieves a single Gateway specified by token. If no such
+	// Gateway exists, implementations MUST return a *meta.ErrNotFound error.
+	GetByToken(context.Context, string) (Gateway, error)
+	// Update updates an existing Gateway's description and source. If the
+	// specified Gateway does not exist, implementations MUST return a
+	// *meta.ErrNotFound error. If another Gateway already owns the specified
+	// source, implementations MUST return a *meta.ErrConflict error.
+	Update(context.Context, Gateway) error
+	// Delete removes a single Gateway specified by its identifier.
+	Delete(context.Context, string) error
+}
+
+type gatewaysService struct {
+	authorize     AuthorizeFn
+	gatewaysStore GatewaysStore
+}
+
+// NewGatewaysService returns a specialized interface for managing Gateways.
+func NewGatewaysService(
+	authorizeFn AuthorizeFn,
+	gatewaysStore GatewaysStore,
+) GatewaysService {
+	return &gatewaysService{
+		authorize:     authorizeFn,
+		gatewaysStore: gatewaysStore,
+	}
+}
+
+func (g *gatewaysService) Create(
+	ctx context.Context,
+	gateway Gateway,
+) (Token, error) {
+	token := Token{}
+
+	if err := g.authorize(ctx, RoleAdmin, ""); err != nil {
+		return token, err
+	}
+
+	token.Value = libCrypto.NewToken(256)
+	now := time.Now().UTC()
+	gateway.Created = &now
+	gateway.HashedToken = crypto.Hash("", token.Value)
+	gateway.LastSeen = nil
+	if err := g.gatewaysStore.Create(ctx, gateway); err != nil {
+		return token, errors.Wrapf(
+			err,
+			"error storing new gateway %q",
+			gateway.ID,
+		)
+	}
+	return token, nil
+}
+
+func (g *gatewaysService) List(
+	ctx context.Context,
+	opts meta.ListOptions,
+) (GatewayList, error) {
+	if err := g.authorize(ctx, RoleReader, ""); err != nil {
+		return GatewayList{}, err
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	gateways, err := g.gatewaysStore.List(ctx, opts)
+	if err != nil {
+		return gateways, errors.Wrap(err, "error retrieving gateways from store")
+	}
+	return gateways, nil
+}
+
+func (g *gatewaysService) Get(ctx context.Context, id string) (Gateway, error) {
+	if err := g.authorize(ctx, RoleReader, ""); err != nil {
+		return Gateway{}, err
+	}
+
+	gateway, err := g.gatewaysStore.Get(ctx, id)
+	if err != nil {
+		return gateway, errors.Wrapf(
+			err,
+			"error retrieving gateway %q from store",
+			id,
+		)
+	}
+	return gateway, nil
+}
+
+func (g *gatewaysService) GetByToken(
+	ctx context.Context,
+	token string,
+) (Gateway, error) {
+	// No authz requirements here because this is is never invoked at the explicit
+	// request of an end user; rather it is invoked only by the system itself.
+
+	gateway, err := g.gatewaysStore.GetByHashedToken(
+		ctx,
+		crypto.Hash("", token),
+	)
+	if err != nil {
+		return gateway, errors.Wrap(
+			err,
+			"error retrieving gateway from store by token",
+		)
+	}
+	return gateway, nil
+}
+
+func (g *gatewaysService) Update(ctx context.Context, gateway Gateway) error {
+	if err := g.authorize(ctx, RoleAdmin, ""); err != nil {
+		return err
+	}
+
+	if err := g.gatewaysStore.Update(ctx, gateway); err != nil {
+		return errors.Wrapf(
+			err,
+			"error updating gateway %q in store",
+			gateway.ID,
+		)
+	}
+	return nil
+}
+
+func (g *gatewaysService) Delete(ctx context.Context, id string) error {
+	if err := g.authorize(ctx, RoleAdmin, ""); err != nil {
+		return err
+	}
+
+	if err := g.gatewaysStore.Delete(ctx, id); err != nil {
+		return errors.Wrapf(err, "error deleting gateway %q from store", id)
+	}
+	return nil
+}
+
+// GatewaysStore is an interface for components that implement Gateway
+// persistence concerns.
+type GatewaysStore interface {
+	// Create persists a new Gateway in the underlying data store. If a Gateway
+	// having the same ID or owning the same source already exists,
+	// implementations MUST return a *meta.ErrConflict error.
+	Create(context.Context, Gateway) error
+	// List retrieves a GatewayList from the underlying data store, with its
+	// Items (Gateways) ordered by ID.
+	List(context.Context, meta.ListOptions) (GatewayList, error)
+	// Get retrieves a single Gateway from the underlying data store. If the
+	// specified Gateway does not exist, implementations MUST return a
+	// *meta.ErrNotFound error.
+	Get(context.Context, string) (Gateway, error)
+	// GetByHashedToken retrieves a single Gateway having the provided hashed
+	// token from the underlying data store. If no such Gateway exists,
+	// implementations MUST return a *meta.ErrNotFound error.
+	GetByHashedToken(context.Context, string) (Gateway, error)
+	// GetBySource retrieves the single Gateway that owns the provided source
+	// from the underlying data store. If no such Gateway exists,
+	// implementations MUST return a *meta.ErrNotFound error.
+	GetBySource(context.Context, string) (Gateway, error)
+	// Update updates the description and source of the specified Gateway in the
+	// underlying data store. If the specified Gateway does not exist,
+	// implementations MUST return a *meta.ErrNotFound error. If another Gateway
+	// already owns the specified source, implementations MUST return a
+	// *meta.ErrConflict error.
+	Update(context.Context, Gateway) error
+	// UpdateLastSeen records, in the underlying data store, the time at which
+	// the specified Gateway last created an Event. If the specified Gateway does
+	// not exist, implementations MUST return a *meta.ErrNotFound error.
+	UpdateLastSeen(ctx context.Context, id string, lastSeen time.Time) error
+	// Delete deletes the specified Gateway. If no Gateway having the given
+	// identifier is found, implementations MUST return a *meta.ErrNotFound
+	// error.
+	Delete(context.Context, string) error
+}
@@ -0,0 +1,444 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, Gateway{}, GatewayKind)
+}
+
+func TestGatewayListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, GatewayList{}, "GatewayList")
+}
+
+func TestGatewayRoleAssignments(t *testing.T) {
+	gateway := &Gateway{
+		Source: "example.org/example-gateway",
+	}
+	require.Equal(
+		t,
+		[]RoleAssignment{
+			{
+				Role:  RoleEventCreator,
+				Scope: "example.org/example-gateway",
+			},
+		},
+		gateway.RoleAssignments(),
+	)
+}
+
+func TestNewGatewaysService(t *testing.T) {
+	gatewaysStore := &mockGatewaysStore{}
+	svc, ok := NewGatewaysService(
+		alwaysAuthorize,
+		gatewaysStore,
+	).(*gatewaysService)
+	require.True(t, ok)
+	require.NotNil(t, svc.authorize)
+	require.Same(t, gatewaysStore, svc.gatewaysStore)
+}
+
+func TestGatewaysServiceCreate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(Token, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &gatewaysService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error creating gateway in store",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					CreateFn: func(context.Context, Gateway) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error storing new gateway")
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					CreateFn: func(_ context.Context, gateway Gateway) error {
+						require.NotNil(t, gateway.Created)
+						require.NotEmpty(t, gateway.HashedToken)
+						require.Nil(t, gateway.LastSeen)
+						return nil
+					},
+				},
+			},
+			assertions: func(token Token, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, token.Value)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now := time.Now().UTC()
+			token, err := testCase.service.Create(
+				context.Background(),
+				Gateway{
+					LastSeen: &now,
+				},
+			)
+			testCase.assertions(token, err)
+		})
+	}
+}
+
+func TestGatewaysServiceList(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &gatewaysService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting gateways from store",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					ListFn: func(
+						context.Context,
+						meta.ListOptions,
+					) (GatewayList, error) {
+						return GatewayList{}, errors.New("error listing gateways")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error listing gateways")
+				require.Contains(t, err.Error(), "error retrieving gateways from store")
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					ListFn: func(
+						_ context.Context,
+						opts meta.ListOptions,
+					) (GatewayList, error) {
+						require.Equal(t, int64(20), opts.Limit)
+						return GatewayList{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err :=
+				testCase.service.List(context.Background(), meta.ListOptions{})
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysServiceGet(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &gatewaysService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting gateway from store",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, errors.New("error getting gateway")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting gateway")
+				require.Contains(t, err.Error(), "error retrieving gateway")
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					GetFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.service.Get(context.Background(), "github")
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysServiceGetByToken(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(error)
+	}{
+		{
+			name: "error getting gateway from store",
+			service: &gatewaysService{
+				gatewaysStore: &mockGatewaysStore{
+					GetByHashedTokenFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, errors.New("error getting gateway")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting gateway")
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving gateway from store by token",
+				)
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				gatewaysStore: &mockGatewaysStore{
+					GetByHashedTokenFn: func(context.Context, string) (Gateway, error) {
+						return Gateway{}, nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err :=
+				testCase.service.GetByToken(context.Background(), "abcdefg")
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysServiceUpdate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &gatewaysService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error updating gateway in store",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					UpdateFn: func(context.Context, Gateway) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error updating gateway")
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					UpdateFn: func(context.Context, Gateway) error {
+						return nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.service.Update(context.Background(), Gateway{})
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysServiceDelete(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    GatewaysService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &gatewaysService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error deleting gateway from store",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					DeleteFn: func(context.Context, string) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error deleting gateway")
+			},
+		},
+		{
+			name: "success",
+			service: &gatewaysService{
+				authorize: alwaysAuthorize,
+				gatewaysStore: &mockGatewaysStore{
+					DeleteFn: func(context.Context, string) error {
+						return nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.service.Delete(context.Background(), "github")
+			testCase.assertions(err)
+		})
+	}
+}
+
+type mockGatewaysStore struct {
+	CreateFn           func(context.Context, Gateway) error
+	ListFn             func(context.Context, meta.ListOptions) (GatewayList, error)
+	GetFn              func(context.Context, string) (Gateway, error)
+	GetByHashedTokenFn func(context.Context, string) (Gateway, error)
+	GetBySourceFn      func(context.Context, string) (Gateway, error)
+	UpdateFn           func(context.Context, Gateway) error
+	UpdateLastSeenFn   func(context.Context, string, time.Time) error
+	DeleteFn           func(context.Context, string) error
+}
+
+func (m *mockGatewaysStore) Create(ctx context.Context, gateway Gateway) error {
+	return m.CreateFn(ctx, gateway)
+}
+
+func (m *mockGatewaysStore) List(
+	ctx context.Context,
+	opts meta.ListOptions,
+) (GatewayList, error) {
+	return m.ListFn(ctx, opts)
+}
+
+func (m *mockGatewaysStore) Get(ctx context.Context, id string) (Gateway, error) {
+	return m.GetFn(ctx, id)
+}
+
+func (m *mockGatewaysStore) GetByHashedToken(
+	ctx context.Context,
+	hashedToken string,
+) (Gateway, error) {
+	return m.GetByHashedTokenFn(ctx, hashedToken)
+}
+
+func (m *mockGatewaysStore) GetBySource(
+	ctx context.Context,
+	source string,
+) (Gateway, error) {
+	return m.GetBySourceFn(ctx, source)
+}
+
+func (m *mockGatewaysStore) Update(ctx context.Context, gateway Gateway) error {
+	return m.UpdateFn(ctx, gateway)
+}
+
+func (m *mockGatewaysStore) UpdateLastSeen(
+	ctx context.Context,
+	id string,
+	lastSeen time.Time,
+) error {
+	return m.UpdateLastSeenFn(ctx, id, lastSeen)
+}
+
+func (m *mockGatewaysStore) Delete(ctx context.Context, id string) error {
+	return m.DeleteFn(ctx, id)
+}
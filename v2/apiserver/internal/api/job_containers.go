@@ -0,0 +1,248 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+// reservedJobContainerNames are the names of containers Brigade itself may add
+// to a Job's pod. Containers declared in a JobSpec may not use these names.
+var reservedJobContainerNames = map[string]struct{}{
+	"vcs":              {},
+	"readiness-waiter": {},
+}
+
+// JobInitContainerSpec amends the JobContainerSpec type with a name, since,
+// unlike sidecar containers, init containers are declared in an ordered list.
+type JobInitContainerSpec struct {
+	// Name is the init container's name. It must be unique among all of a Job's
+	// containers.
+	Name string `json:"name" bson:"name"`
+	// JobContainerSpec encapsulates Job-specific specifications for an OCI
+	// container.
+	JobContainerSpec `json:",inline" bson:",inline"`
+}
+
+// ReadinessProbe describes how to determine whether a sidecar container is
+// ready. Exactly one of Exec, TCPSocket, or HTTPGet must be non-nil.
+type ReadinessProbe struct {
+	// Exec specifies a command to execute within the sidecar container. The
+	// sidecar is ready once the command exits with a zero exit code.
+	Exec *ExecProbe `json:"exec,omitempty" bson:"exec,omitempty"`
+	// TCPSocket specifies a port on which the sidecar container accepts TCP
+	// connections. The sidecar is ready once a connection can be opened.
+	TCPSocket *TCPSocketProbe `json:"tcpSocket,omitempty" bson:"tcpSocket,omitempty"` // nolint: lll
+	// HTTPGet specifies an HTTP endpoint exposed by the sidecar container. The
+	// sidecar is ready once a GET request to that endpoint returns a status code
+	// greater than or equal to 200 and less than 400.
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty" bson:"httpGet,omitempty"`
+	// InitialDelaySeconds specifies the number of seconds to wait after the
+	// sidecar container has started before probing it for the first time.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty" bson:"initialDelaySeconds,omitempty"` // nolint: lll
+	// PeriodSeconds specifies the number of seconds to wait between attempts to
+	// probe the sidecar container. When zero, the default is 5 seconds.
+	PeriodSeconds int32 `json:"periodSeconds,omitempty" bson:"periodSeconds,omitempty"` // nolint: lll
+	// TimeoutSeconds specifies the number of seconds after which a single
+	// attempt to probe the sidecar container times out. When zero, the default
+	// is 1 second.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty" bson:"timeoutSeconds,omitempty"` // nolint: lll
+	// FailureThreshold specifies the number of consecutive failed attempts to
+	// probe the sidecar container after which the sidecar is considered to have
+	// failed to become ready, in which case the Job fails. When zero, the default
+	// is 12.
+	FailureThreshold int32 `json:"failureThreshold,omitempty" bson:"failureThreshold,omitempty"` // nolint: lll
+}
+
+// ExecProbe describes a command-based readiness probe.
+type ExecProbe struct {
+	// Command is the command to execute within the container. Only the first
+	// element is the actual command. Subsequent elements are treated as
+	// arguments. The command is not run in a shell.
+	Command []string `json:"command" bson:"command"`
+}
+
+// TCPSocketProbe describes a TCP-based readiness probe.
+type TCPSocketProbe struct {
+	// Port is the port on which the container accepts TCP connections.
+	Port int32 `json:"port" bson:"port"`
+}
+
+// HTTPGetProbe describes an HTTP-based readiness probe.
+type HTTPGetProbe struct {
+	// Path is the path of the HTTP endpoint. When empty, "/" is assumed.
+	Path string `json:"path,omitempty" bson:"path,omitempty"`
+	// Port is the port on which the container accepts HTTP requests.
+	Port int32 `json:"port" bson:"port"`
+}
+
+// validateJobContainers returns a *meta.ErrBadRequest if any of the provided
+// JobSpec's init containers or readiness probes is invalid, or if any two of
+// the Job's containers share a name.
+func validateJobContainers(jobName string, jobSpec JobSpec) error {
+	var details []string
+	names := map[string]struct{}{
+		jobName: {},
+	}
+	if jobSpec.PrimaryContainer.ReadinessProbe != nil {
+		details = append(
+			details,
+			"primaryContainer.readinessProbe: readiness probes are applicable only "+
+				"to sidecar containers",
+		)
+	}
+	for i, initContainer := range jobSpec.InitContainers {
+		field := fmt.Sprintf("initContainers[%d]", i)
+		if _, ok := reservedJobContainerNames[initContainer.Name]; ok {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s.name: container name %q is reserved",
+					field,
+					initContainer.Name,
+				),
+			)
+		} else if _, ok := names[initContainer.Name]; ok {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s.name: duplicate container name %q",
+					field,
+					initContainer.Name,
+				),
+			)
+		}
+		names[initContainer.Name] = struct{}{}
+		if initContainer.ReadinessProbe != nil {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s.readinessProbe: readiness probes are applicable only to "+
+						"sidecar containers",
+					field,
+				),
+			)
+		}
+	}
+	sidecarNames := make([]string, 0, len(jobSpec.SidecarContainers))
+	for name := range jobSpec.SidecarContainers {
+		sidecarNames = append(sidecarNames, name)
+	}
+	sort.Strings(sidecarNames)
+	for _, name := range sidecarNames {
+		field := fmt.Sprintf("sidecarContainers.%s", name)
+		if _, ok := reservedJobContainerNames[name]; ok {
+			details = append(
+				details,
+				fmt.Sprintf("%s: container name %q is reserved", field, name),
+			)
+		} else if _, ok := names[name]; ok {
+			details = append(
+				details,
+				fmt.Sprintf("%s: duplicate container name %q", field, name),
+			)
+		}
+		names[name] = struct{}{}
+		probe := jobSpec.SidecarContainers[name].ReadinessProbe
+		if probe == nil {
+			continue
+		}
+		field = fmt.Sprintf("%s.readinessProbe", field)
+		if jobSpec.Host != nil && jobSpec.Host.OS == OSFamilyWindows {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s: readiness probes are not supported for Windows jobs",
+					field,
+				),
+			)
+		}
+		var handlers int
+		if probe.Exec != nil {
+			handlers++
+			if len(probe.Exec.Command) == 0 {
+				details = append(
+					details,
+					fmt.Sprintf("%s.exec.command: must not be empty", field),
+				)
+			}
+		}
+		if probe.TCPSocket != nil {
+			handlers++
+			if !isValidPort(probe.TCPSocket.Port) {
+				details = append(
+					details,
+					fmt.Sprintf("%s.tcpSocket.port: invalid port", field),
+				)
+			}
+		}
+		if probe.HTTPGet != nil {
+			handlers++
+			if !isValidPort(probe.HTTPGet.Port) {
+				details = append(
+					details,
+					fmt.Sprintf("%s.httpGet.port: invalid port", field),
+				)
+			}
+		}
+		if handlers != 1 {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s: exactly one of exec, tcpSocket, or httpGet must be specified",
+					field,
+				),
+			)
+		}
+		if probe.InitialDelaySeconds < 0 || probe.PeriodSeconds < 0 ||
+			probe.TimeoutSeconds < 0 || probe.FailureThreshold < 0 {
+			details = append(
+				details,
+				fmt.Sprintf("%s: durations and thresholds must not be negative", field),
+			)
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Job contains one or more invalid container specifications",
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// getJobContainerFields returns the field names of all of the provided
+// JobSpec's containers, in a deterministic order, along with a map of those
+// field names to the containers' specifications. This is useful for validating
+// all of a Job's containers alike.
+func getJobContainerFields(
+	jobSpec JobSpec,
+) ([]string, map[string]JobContainerSpec) {
+	containers := map[string]JobContainerSpec{
+		"primaryContainer": jobSpec.PrimaryContainer,
+	}
+	containerFields := []string{"primaryContainer"}
+	for i, initContainer := range jobSpec.InitContainers {
+		field := fmt.Sprintf("initContainers[%d]", i)
+		containers[field] = initContainer.JobContainerSpec
+		containerFields = append(containerFields, field)
+	}
+	sidecarNames := make([]string, 0, len(jobSpec.SidecarContainers))
+	for name := range jobSpec.SidecarContainers {
+		sidecarNames = append(sidecarNames, name)
+	}
+	sort.Strings(sidecarNames)
+	for _, name := range sidecarNames {
+		field := fmt.Sprintf("sidecarContainers.%s", name)
+		containers[field] = jobSpec.SidecarContainers[name]
+		containerFields = append(containerFields, field)
+	}
+	return containerFields, containers
+}
+
+// isValidPort returns a bool indicating whether the provided port number is
+// within the range of valid TCP ports.
+func isValidPort(port int32) bool {
+	return port > 0 && port <= 65535
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJobContainers(t *testing.T) {
+	const testJobName = "italian"
+	testCases := []struct {
+		name       string
+		jobSpec    JobSpec
+		assertions func(error)
+	}{
+		{
+			name: "no init containers or readiness probes",
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "readiness probe on primary container",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					ReadinessProbe: &ReadinessProbe{
+						TCPSocket: &TCPSocketProbe{
+							Port: 8080,
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"primaryContainer.readinessProbe: readiness probes are " +
+							"applicable only to sidecar containers",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "reserved and duplicate init container names",
+			jobSpec: JobSpec{
+				InitContainers: []JobInitContainerSpec{
+					{
+						Name: "vcs",
+					},
+					{
+						Name: testJobName,
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`initContainers[0].name: container name "vcs" is reserved`,
+						`initContainers[1].name: duplicate container name "italian"`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "sidecar name duplicates init container name",
+			jobSpec: JobSpec{
+				InitContainers: []JobInitContainerSpec{
+					{
+						Name: "helper",
+					},
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"helper": {},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`sidecarContainers.helper: duplicate container name "helper"`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "readiness probe without handler",
+			jobSpec: JobSpec{
+				SidecarContainers: map[string]JobContainerSpec{
+					"db": {
+						ReadinessProbe: &ReadinessProbe{},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"sidecarContainers.db.readinessProbe: exactly one of exec, " +
+							"tcpSocket, or httpGet must be specified",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "invalid readiness probe",
+			jobSpec: JobSpec{
+				Host: &JobHost{
+					OS: OSFamilyWindows,
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"db": {
+						ReadinessProbe: &ReadinessProbe{
+							Exec:          &ExecProbe{},
+							PeriodSeconds: -1,
+						},
+					},
+					"web": {
+						ReadinessProbe: &ReadinessProbe{
+							HTTPGet: &HTTPGetProbe{
+								Port: 70000,
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"sidecarContainers.db.readinessProbe: readiness probes are not " +
+							"supported for Windows jobs",
+						"sidecarContainers.db.readinessProbe.exec.command: must not be " +
+							"empty",
+						"sidecarContainers.db.readinessProbe: durations and thresholds " +
+							"must not be negative",
+						"sidecarContainers.web.readinessProbe: readiness probes are not " +
+							"supported for Windows jobs",
+						"sidecarContainers.web.readinessProbe.httpGet.port: invalid port",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid init containers and readiness probes",
+			jobSpec: JobSpec{
+				InitContainers: []JobInitContainerSpec{
+					{
+						Name: "setup",
+					},
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"db": {
+						ReadinessProbe: &ReadinessProbe{
+							TCPSocket: &TCPSocketProbe{
+								Port: 5432,
+							},
+						},
+					},
+					"web": {
+						ReadinessProbe: &ReadinessProbe{
+							HTTPGet: &HTTPGetProbe{
+								Path: "/healthz",
+								Port: 8080,
+							},
+							FailureThreshold: 3,
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateJobContainers(testJobName, testCase.jobSpec))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+// SecretRef makes the value of one of a Project's secrets available to one of
+// a Job's containers, either as an environment variable or as a file. Unlike
+// values in a container's Environment, the secret's value is never included in
+// the Job's specification. It is resolved by the substrate when the Job's
+// containers are created. Exactly one of EnvVar or Path must be non-empty.
+type SecretRef struct {
+	// Key is the key of the Project secret.
+	Key string `json:"key" bson:"key"`
+	// EnvVar is the name of the environment variable that should contain the
+	// Project secret's value.
+	EnvVar string `json:"envVar,omitempty" bson:"envVar,omitempty"`
+	// Path is the absolute path, in the OCI container's file system, of a
+	// read-only file that should contain the Project secret's value.
+	Path string `json:"path,omitempty" bson:"path,omitempty"`
+}
+
+// validateJobSecretRefs returns a *meta.ErrBadRequest if any of the secret
+// references of the provided JobSpec's containers is invalid.
+func validateJobSecretRefs(jobSpec JobSpec) error {
+	var details []string
+	containerFields, containers := getJobContainerFields(jobSpec)
+	for _, containerField := range containerFields {
+		container := containers[containerField]
+		envVars := map[string]struct{}{}
+		for envVar := range container.Environment {
+			envVars[envVar] = struct{}{}
+		}
+		paths := map[string]struct{}{}
+		if container.WorkspaceMountPath != "" {
+			paths[container.WorkspaceMountPath] = struct{}{}
+		}
+		if container.SourceMountPath != "" {
+			paths[container.SourceMountPath] = struct{}{}
+		}
+		for _, mount := range container.VolumeMounts {
+			paths[mount.MountPath] = struct{}{}
+		}
+		for i, secretRef := range container.SecretRefs {
+			field := fmt.Sprintf("%s.secretRefs[%d]", containerField, i)
+			if secretRef.Key == "" {
+				details = append(
+					details,
+					fmt.Sprintf("%s.key: must not be empty", field),
+				)
+			}
+			if (secretRef.EnvVar == "") == (secretRef.Path == "") {
+				details = append(
+					details,
+					fmt.Sprintf("%s: exactly one of envVar or path must be specified", field),
+				)
+				continue
+			}
+			if secretRef.EnvVar != "" {
+				if _, ok := envVars[secretRef.EnvVar]; ok {
+					details = append(
+						details,
+						fmt.Sprintf(
+							"%s.envVar: environment variable %q is already in use",
+							field,
+							secretRef.EnvVar,
+						),
+					)
+				}
+				envVars[secretRef.EnvVar] = struct{}{}
+				continue
+			}
+			if !path.IsAbs(secretRef.Path) {
+				details = append(
+					details,
+					fmt.Sprintf("%s.path: must be an absolute path", field),
+				)
+				continue
+			}
+			if _, ok := paths[secretRef.Path]; ok {
+				details = append(
+					details,
+					fmt.Sprintf(
+						"%s.path: path %q is already in use",
+						field,
+						secretRef.Path,
+					),
+				)
+			}
+			paths[secretRef.Path] = struct{}{}
+		}
+	}
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Job contains one or more invalid secret references",
+			Details: details,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJobSecretRefs(t *testing.T) {
+	testCases := []struct {
+		name       string
+		jobSpec    JobSpec
+		assertions func(error)
+	}{
+		{
+			name: "no secret refs",
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "missing key",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					SecretRefs: []SecretRef{
+						{
+							EnvVar: "API_TOKEN",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"primaryContainer.secretRefs[0].key: must not be empty",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "neither env var nor path",
+			jobSpec: JobSpec{
+				InitContainers: []JobInitContainerSpec{
+					{
+						Name: "setup",
+						JobContainerSpec: JobContainerSpec{
+							SecretRefs: []SecretRef{
+								{
+									Key: "apiToken",
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"initContainers[0].secretRefs[0]: exactly one of envVar or path " +
+							"must be specified",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "both env var and path",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					SecretRefs: []SecretRef{
+						{
+							Key:    "apiToken",
+							EnvVar: "API_TOKEN",
+							Path:   "/var/secrets/api-token",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Len(t, err.(*meta.ErrBadRequest).Details, 1)
+			},
+		},
+		{
+			name: "env var already in use",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					ContainerSpec: ContainerSpec{
+						Environment: map[string]string{
+							"API_TOKEN": "foo",
+						},
+					},
+					SecretRefs: []SecretRef{
+						{
+							Key:    "apiToken",
+							EnvVar: "API_TOKEN",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"primaryContainer.secretRefs[0].envVar: environment variable " +
+							`"API_TOKEN" is already in use`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "invalid paths",
+			jobSpec: JobSpec{
+				SidecarContainers: map[string]JobContainerSpec{
+					"helper": {
+						WorkspaceMountPath: "/var/workspace",
+						SecretRefs: []SecretRef{
+							{
+								Key:  "apiToken",
+								Path: "api-token",
+							},
+							{
+								Key:  "apiToken",
+								Path: "/var/workspace",
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"sidecarContainers.helper.secretRefs[0].path: must be an " +
+							"absolute path",
+						"sidecarContainers.helper.secretRefs[1].path: path " +
+							`"/var/workspace" is already in use`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid secret refs",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					SecretRefs: []SecretRef{
+						{
+							Key:    "apiToken",
+							EnvVar: "API_TOKEN",
+						},
+						{
+							Key:  "apiToken",
+							Path: "/var/secrets/api-token",
+						},
+					},
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"helper": {
+						SecretRefs: []SecretRef{
+							{
+								Key:    "apiToken",
+								EnvVar: "API_TOKEN",
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateJobSecretRefs(testCase.jobSpec))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+// reservedJobVolumeNames are the names of volumes Brigade itself may add to a
+// Job's pod. Volumes declared in a JobSpec may not use these names.
+var reservedJobVolumeNames = map[string]struct{}{
+	"workspace":        {},
+	"event":            {},
+	"vcs":              {},
+	"readiness-waiter": {},
+	"secret-refs":      {},
+}
+
+// StorageMedium represents the type of storage backing an EmptyDirVolume.
+type StorageMedium string
+
+const (
+	// StorageMediumDefault represents storage backed by whatever medium backs
+	// the substrate node's own file system.
+	StorageMediumDefault StorageMedium = ""
+	// StorageMediumMemory represents storage backed by RAM (tmpfs). Files
+	// written to such a volume count against the memory available to the Job.
+	StorageMediumMemory StorageMedium = "Memory"
+)
+
+// JobVolume represents a volume that may be mounted into any of a Job's
+// containers. Exactly one of its fields must be non-nil.
+type JobVolume struct {
+	// EmptyDir specifies an initially empty scratch directory that exists for
+	// the lifetime of the Job. This is useful for sharing files between a Job's
+	// primary container and its sidecars.
+	EmptyDir *EmptyDirVolume `json:"emptyDir,omitempty" bson:"emptyDir,omitempty"` // nolint: lll
+	// ProjectSecret specifies that some or all of the Project's secrets should
+	// be made available as files.
+	ProjectSecret *ProjectSecretVolume `json:"projectSecret,omitempty" bson:"projectSecret,omitempty"` // nolint: lll
+	// ConfigFiles specifies files whose contents are provided inline.
+	ConfigFiles *ConfigFilesVolume `json:"configFiles,omitempty" bson:"configFiles,omitempty"` // nolint: lll
+}
+
+// EmptyDirVolume represents an initially empty scratch directory that exists
+// for the lifetime of a Job.
+type EmptyDirVolume struct {
+	// Medium specifies the type of storage backing the directory. When empty,
+	// the substrate node's default medium is used.
+	Medium StorageMedium `json:"medium,omitempty" bson:"medium,omitempty"`
+	// SizeLimit optionally specifies the maximum amount of storage the
+	// directory may use, expressed as a quantity such as "500Mi" or "1Gi".
+	SizeLimit string `json:"sizeLimit,omitempty" bson:"sizeLimit,omitempty"`
+}
+
+// ProjectSecretVolume represents a volume containing a Project's secrets as
+// files.
+type ProjectSecretVolume struct {
+	// Items optionally specifies which of the Project's secrets should be
+	// included in the volume and the relative paths at which they should appear.
+	// When empty, every one of the Project's secrets is included, each in a file
+	// named after the secret's key.
+	Items []ProjectSecretItem `json:"items,omitempty" bson:"items,omitempty"`
+}
+
+// ProjectSecretItem maps a single Project secret to a file.
+type ProjectSecretItem struct {
+	// Key is the key of the Project secret.
+	Key string `json:"key" bson:"key"`
+	// Path is the path, relative to the volume's mount path, of the file that
+	// should contain the Project secret's value.
+	Path string `json:"path" bson:"path"`
+}
+
+// ConfigFilesVolume represents a volume containing files whose contents are
+// provided inline. Since a Job's specification is readable by anyone with read
+// access to its Event, these files should not contain sensitive information.
+// Use a ProjectSecretVolume for that instead.
+type ConfigFilesVolume struct {
+	// Files specifies the files that should appear in the volume.
+	Files []ConfigFile `json:"files" bson:"files"`
+}
+
+// ConfigFile represents a single file in a ConfigFilesVolume.
+type ConfigFile struct {
+	// Path is the path of the file, relative to the volume's mount path.
+	Path string `json:"path" bson:"path"`
+	// Content is the content of the file.
+	Content string `json:"content" bson:"content"`
+}
+
+// JobVolumeMount represents the mounting of one of a Job's volumes into one of
+// its containers.
+type JobVolumeMount struct {
+	// Name is the name of the volume to mount. It must match the name of one of
+	// the volumes declared in the JobSpec.
+	Name string `json:"name" bson:"name"`
+	// MountPath is the path in the OCI container's file system where the volume
+	// should be mounted.
+	MountPath string `json:"mountPath" bson:"mountPath"`
+	// ReadOnly indicates whether the volume should be mounted read-only.
+	ReadOnly bool `json:"readOnly,omitempty" bson:"readOnly,omitempty"`
+}
+
+// validateJobVolumes returns a *meta.ErrBadRequest if any of the volumes
+// declared in the provided JobSpec, or any of the volume mounts of its
+// containers, is invalid.
+func validateJobVolumes(jobSpec JobSpec) error {
+	var details []string
+	volumeNames := make([]string, 0, len(jobSpec.Volumes))
+	for name := range jobSpec.Volumes {
+		volumeNames = append(volumeNames, name)
+	}
+	sort.Strings(volumeNames)
+	for _, name := range volumeNames {
+		volume := jobSpec.Volumes[name]
+		field := fmt.Sprintf("volumes.%s", name)
+		if _, ok := reservedJobVolumeNames[name]; ok {
+			details = append(
+				details,
+				fmt.Sprintf("%s: volume name %q is reserved", field, name),
+			)
+		}
+		var sources int
+		if volume.EmptyDir != nil {
+			sources++
+			switch volume.EmptyDir.Medium {
+			case StorageMediumDefault, StorageMediumMemory:
+			default:
+				details = append(
+					details,
+					fmt.Sprintf(
+						"%s.emptyDir.medium: unrecognized medium %q",
+						field,
+						volume.EmptyDir.Medium,
+					),
+				)
+			}
+		}
+		if volume.ProjectSecret != nil {
+			sources++
+			for i, item := range volume.ProjectSecret.Items {
+				itemField := fmt.Sprintf("%s.projectSecret.items[%d]", field, i)
+				if item.Key == "" {
+					details = append(
+						details,
+						fmt.Sprintf("%s.key: must not be empty", itemField),
+					)
+				}
+				if problem := validateVolumeFilePath(item.Path); problem != "" {
+					details = append(
+						details,
+						fmt.Sprintf("%s.path: %s", itemField, problem),
+					)
+				}
+			}
+		}
+		if volume.ConfigFiles != nil {
+			sources++
+			if len(volume.ConfigFiles.Files) == 0 {
+				details = append(
+					details,
+					fmt.Sprintf("%s.configFiles.files: must not be empty", field),
+				)
+			}
+			paths := map[string]struct{}{}
+			for i, file := range volume.ConfigFiles.Files {
+				fileField := fmt.Sprintf("%s.configFiles.files[%d].path", field, i)
+				if problem := validateVolumeFilePath(file.Path); problem != "" {
+					details = append(details, fmt.Sprintf("%s: %s", fileField, problem))
+				} else if _, ok := paths[file.Path]; ok {
+					details = append(
+						details,
+						fmt.Sprintf("%s: duplicate path %q", fileField, file.Path),
+					)
+				}
+				paths[file.Path] = struct{}{}
+			}
+		}
+		if sources != 1 {
+			details = append(
+				details,
+				fmt.Sprintf(
+					"%s: exactly one of emptyDir, projectSecret, or configFiles must "+
+						"be specified",
+					field,
+				),
+			)
+		}
+	}
+
+	containerFields, containers := getJobContainerFields(jobSpec)
+	for _, containerField := range containerFields {
+		container := containers[containerField]
+		mountPaths := map[string]struct{}{}
+		if container.WorkspaceMountPath != "" {
+			mountPaths[container.WorkspaceMountPath] = struct{}{}
+		}
+		if container.SourceMountPath != "" {
+			mountPaths[container.SourceMountPath] = struct{}{}
+		}
+		for i, mount := range container.VolumeMounts {
+			field := fmt.Sprintf("%s.volumeMounts[%d]", containerField, i)
+			if _, ok := jobSpec.Volumes[mount.Name]; !ok {
+				details = append(
+					details,
+					fmt.Sprintf("%s.name: no volume named %q", field, mount.Name),
+				)
+			}
+			if mount.MountPath == "" {
+				details = append(
+					details,
+					fmt.Sprintf("%s.mountPath: must not be empty", field),
+				)
+				continue
+			}
+			if _, ok := mountPaths[mount.MountPath]; ok {
+				details = append(
+					details,
+					fmt.Sprintf(
+						"%s.mountPath: path %q is already in use",
+						field,
+						mount.MountPath,
+					),
+				)
+			}
+			mountPaths[mount.MountPath] = struct{}{}
+		}
+	}
+
+	if len(details) > 0 {
+		return &meta.ErrBadRequest{
+			Reason:  "Job contains one or more invalid volumes or volume mounts",
+			Details: details,
+		}
+	}
+	return nil
+}
+
+// validateVolumeFilePath returns a description of the problem if the provided
+// path is not suitable for a file within a volume, i.e. is empty, absolute, or
+// refers to a location outside the volume. Otherwise, it returns an empty
+// string.
+func validateVolumeFilePath(filePath string) string {
+	if filePath == "" {
+		return "must not be empty"
+	}
+	if path.IsAbs(filePath) {
+		return "must be a relative path"
+	}
+	for _, element := range strings.Split(filePath, "/") {
+		if element == ".." {
+			return `must not contain ".."`
+		}
+	}
+	return ""
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJobVolumes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		jobSpec    JobSpec
+		assertions func(error)
+	}{
+		{
+			name: "no volumes",
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "reserved volume name",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"workspace": {
+						EmptyDir: &EmptyDirVolume{},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`volumes.workspace: volume name "workspace" is reserved`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "no volume source",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"scratch": {},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"volumes.scratch: exactly one of emptyDir, projectSecret, or " +
+							"configFiles must be specified",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "multiple volume sources",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"scratch": {
+						EmptyDir:      &EmptyDirVolume{},
+						ProjectSecret: &ProjectSecretVolume{},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Len(t, err.(*meta.ErrBadRequest).Details, 1)
+			},
+		},
+		{
+			name: "unrecognized medium",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"scratch": {
+						EmptyDir: &EmptyDirVolume{
+							Medium: "Floppy",
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`volumes.scratch.emptyDir.medium: unrecognized medium "Floppy"`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "invalid project secret items",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"secrets": {
+						ProjectSecret: &ProjectSecretVolume{
+							Items: []ProjectSecretItem{
+								{
+									Path: "token",
+								},
+								{
+									Key:  "password",
+									Path: "/etc/password",
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						"volumes.secrets.projectSecret.items[0].key: must not be empty",
+						"volumes.secrets.projectSecret.items[1].path: must be a " +
+							"relative path",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "no config files",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"config": {
+						ConfigFiles: &ConfigFilesVolume{},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{"volumes.config.configFiles.files: must not be empty"},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "invalid config file paths",
+			jobSpec: JobSpec{
+				Volumes: map[string]JobVolume{
+					"config": {
+						ConfigFiles: &ConfigFilesVolume{
+							Files: []ConfigFile{
+								{
+									Path: "app.yaml",
+								},
+								{
+									Path: "app.yaml",
+								},
+								{
+									Path: "../app.yaml",
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`volumes.config.configFiles.files[1].path: duplicate path ` +
+							`"app.yaml"`,
+						`volumes.config.configFiles.files[2].path: must not contain ".."`,
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "invalid volume mounts",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					WorkspaceMountPath: "/var/workspace",
+					VolumeMounts: []JobVolumeMount{
+						{
+							Name:      "scratch",
+							MountPath: "/var/workspace",
+						},
+					},
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"helper": {
+						VolumeMounts: []JobVolumeMount{
+							{
+								Name:      "nonexistent",
+								MountPath: "/var/nonexistent",
+							},
+							{
+								Name: "scratch",
+							},
+						},
+					},
+				},
+				Volumes: map[string]JobVolume{
+					"scratch": {
+						EmptyDir: &EmptyDirVolume{},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Equal(
+					t,
+					[]string{
+						`primaryContainer.volumeMounts[0].mountPath: path ` +
+							`"/var/workspace" is already in use`,
+						`sidecarContainers.helper.volumeMounts[0].name: no volume ` +
+							`named "nonexistent"`,
+						"sidecarContainers.helper.volumeMounts[1].mountPath: must not " +
+							"be empty",
+					},
+					err.(*meta.ErrBadRequest).Details,
+				)
+			},
+		},
+		{
+			name: "valid volumes and volume mounts",
+			jobSpec: JobSpec{
+				PrimaryContainer: JobContainerSpec{
+					VolumeMounts: []JobVolumeMount{
+						{
+							Name:      "scratch",
+							MountPath: "/var/scratch",
+						},
+						{
+							Name:      "secrets",
+							MountPath: "/var/secrets",
+							ReadOnly:  true,
+						},
+					},
+				},
+				SidecarContainers: map[string]JobContainerSpec{
+					"helper": {
+						VolumeMounts: []JobVolumeMount{
+							{
+								Name:      "scratch",
+								MountPath: "/var/scratch",
+							},
+							{
+								Name:      "config",
+								MountPath: "/etc/helper",
+							},
+						},
+					},
+				},
+				Volumes: map[string]JobVolume{
+					"scratch": {
+						EmptyDir: &EmptyDirVolume{
+							Medium:    StorageMediumMemory,
+							SizeLimit: "64Mi",
+						},
+					},
+					"secrets": {
+						ProjectSecret: &ProjectSecretVolume{
+							Items: []ProjectSecretItem{
+								{
+									Key:  "token",
+									Path: "auth/token",
+								},
+							},
+						},
+					},
+					"config": {
+						ConfigFiles: &ConfigFilesVolume{
+							Files: []ConfigFile{
+								{
+									Path:    "helper.yaml",
+									Content: "verbose: true",
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateJobVolumes(testCase.jobSpec))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
@@ -93,6 +94,9 @@ type Job struct {
 	Spec JobSpec `json:"spec" bson:"spec"`
 	// Status contains details of the Job's current state.
 	Status *JobStatus `json:"status" bson:"status"`
+	// Timeline is a chronological record of every phase the Job has
+	// transitioned through.
+	Timeline []PhaseTransition `json:"timeline,omitempty" bson:"timeline,omitempty"` // nolint: lll
 }
 
 // UsesWorkspace returns a boolean value indicating whether or not the job
@@ -101,6 +105,11 @@ func (j Job) UsesWorkspace() bool {
 	if j.Spec.PrimaryContainer.WorkspaceMountPath != "" {
 		return true
 	}
+	for _, initContainer := range j.Spec.InitContainers {
+		if initContainer.WorkspaceMountPath != "" {
+			return true
+		}
+	}
 	for _, sidecarContainer := range j.Spec.SidecarContainers {
 		if sidecarContainer.WorkspaceMountPath != "" {
 			return true
@@ -109,20 +118,54 @@ func (j Job) UsesWorkspace() bool {
 	return false
 }
 
+// containerNames returns the names of all the Job's containers from which logs
+// may be streamed. The "vcs" container, if present, is listed first, followed
+// by any init containers in the order they run, the primary container, and
+// then any sidecars, ordered by name.
+func (j Job) containerNames() []string {
+	names := []string{}
+	usesSource := j.Spec.PrimaryContainer.SourceMountPath != ""
+	initNames := make([]string, len(j.Spec.InitContainers))
+	for i, initContainer := range j.Spec.InitContainers {
+		if initContainer.SourceMountPath != "" {
+			usesSource = true
+		}
+		initNames[i] = initContainer.Name
+	}
+	sidecarNames := make([]string, 0, len(j.Spec.SidecarContainers))
+	for name, sidecarContainer := range j.Spec.SidecarContainers {
+		if sidecarContainer.SourceMountPath != "" {
+			usesSource = true
+		}
+		sidecarNames = append(sidecarNames, name)
+	}
+	if usesSource {
+		names = append(names, "vcs")
+	}
+	names = append(names, initNames...)
+	names = append(names, j.Name)
+	sort.Strings(sidecarNames)
+	return append(names, sidecarNames...)
+}
+
 // JobSpec is the technical blueprint for a Job.
 type JobSpec struct {
 	// PrimaryContainer specifies the details of an OCI container that forms the
 	// cornerstone of the Job. Job success or failure is tied to completion and
 	// exit code of this container.
 	PrimaryContainer JobContainerSpec `json:"primaryContainer" bson:"primaryContainer"` // nolint: lll
+	// InitContainers specifies the details of OCI containers that run to
+	// completion, one at a time and in the order specified, before any of the
+	// Job's other containers are started. If any of them fails, the Job fails.
+	InitContainers []JobInitContainerSpec `json:"initContainers,omitempty" bson:"initContainers,omitempty"` // nolint: lll
 	// SidecarContainers specifies the details of supplemental, "sidecar"
 	// containers. Their completion and exit code do not directly impact Job
-	// status. Brigade does not understand dependencies between a Job's multiple
-	// containers and cannot enforce any specific startup or shutdown order. When
-	// such dependencies exist (for instance, a primary container than cannot
-	// proceed with a suite of tests until a database is launched and READY in a
-	// sidecar container), then logic within those containers must account for
-	// these constraints.
+	// status. Sidecar containers are started before the primary container. When
+	// the primary container depends on a sidecar (for instance, a primary
+	// container that cannot proceed with a suite of tests until a database is
+	// launched and READY in a sidecar container), that sidecar should specify a
+	// ReadinessProbe. The primary container is then not started until the
+	// sidecar is ready. Brigade does not enforce any shutdown order.
 	SidecarContainers map[string]JobContainerSpec `json:"sidecarContainers,omitempty" bson:"sidecarContainers,omitempty"` // nolint: lll
 	// TimeoutDuration specifies the time duration that must elapse before a
 	// running Job should be considered to have timed out. This duration string
@@ -135,6 +178,10 @@ type JobSpec struct {
 	// non-default operating system (i.e. Windows) or specific hardware (e.g. a
 	// GPU.)
 	Host *JobHost `json:"host,omitempty" bson:"host,omitempty"`
+	// Volumes specifies, by name, volumes in addition to the shared workspace
+	// and source code that may be mounted into any of the Job's containers using
+	// each container's VolumeMounts.
+	Volumes map[string]JobVolume `json:"volumes,omitempty" bson:"volumes,omitempty"` // nolint: lll
 }
 
 func (js JobSpec) EqualTo(js2 JobSpec) bool {
@@ -145,6 +192,18 @@ func (js JobSpec) EqualTo(js2 JobSpec) bool {
 	js.PrimaryContainer, js2.PrimaryContainer =
 		JobContainerSpec{}, JobContainerSpec{}
 
+	// Compare InitContainers; if equivalent, nil out
+	if len(js.InitContainers) != len(js2.InitContainers) {
+		return false
+	}
+	for i, initContainer := range js.InitContainers {
+		if initContainer.Name != js2.InitContainers[i].Name ||
+			!initContainer.EqualTo(js2.InitContainers[i].JobContainerSpec) {
+			return false
+		}
+	}
+	js.InitContainers, js2.InitContainers = nil, nil
+
 	// Compare SidecarContainers maps; if equivalent, nil out
 	if len(js.SidecarContainers) != len(js2.SidecarContainers) {
 		return false
@@ -191,6 +250,18 @@ type JobContainerSpec struct {
 	// for the container, but that may be disallowed by Project-level
 	// configuration.
 	Privileged bool `json:"privileged" bson:"privileged"`
+	// VolumeMounts specifies which of the volumes declared in the JobSpec should
+	// be mounted into the OCI container and where.
+	VolumeMounts []JobVolumeMount `json:"volumeMounts,omitempty" bson:"volumeMounts,omitempty"` // nolint: lll
+	// SecretRefs specifies which of the Project's secrets should be made
+	// available to the OCI container and how. Unlike values in Environment, the
+	// values of these secrets are never included in the Job's specification.
+	SecretRefs []SecretRef `json:"secretRefs,omitempty" bson:"secretRefs,omitempty"` // nolint: lll
+	// ReadinessProbe optionally specifies how to determine whether a sidecar
+	// container is ready. When specified, the Job's primary container is not
+	// started until the sidecar is ready, and the Job fails if the sidecar does
+	// not become ready. This is applicable only to sidecar containers.
+	ReadinessProbe *ReadinessProbe `json:"readinessProbe,omitempty" bson:"readinessProbe,omitempty"` // nolint: lll
 	// UseHostDockerSocket indicates whether the OCI container should mount the
 	// host's Docker socket into its own file system. This is commonly used to
 	// effect "Docker-out-of-Docker" ("DooD") scenarios wherein one of a Job's OCI
@@ -261,6 +332,16 @@ type JobStatus struct {
 	// This is useful for looking up logs for an inherited job associated with
 	// retry events.
 	LogsEventID string `json:"logsEventID,omitempty" bson:"logsEventID,omitempty"`
+	// Reason is an optional, human-readable explanation of why the Job timed
+	// out or failed, e.g. because a sidecar container never became ready.
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// JobTimeoutOptions represents useful, optional settings for timing out a Job.
+type JobTimeoutOptions struct {
+	// Reason is an optional, human-readable explanation of why the Job is being
+	// timed out.
+	Reason string
 }
 
 // JobsService is the specialized interface for managing Jobs. It's
@@ -311,7 +392,12 @@ type JobsService interface {
 	Cleanup(ctx context.Context, eventID, jobName string) error
 	// Timeout updates a Job's status to indicate it has timed out and proceeds
 	// to cleanup Job-related resources from the substrate.
-	Timeout(ctx context.Context, eventID, jobName string) error
+	Timeout(
+		ctx context.Context,
+		eventID string,
+		jobName string,
+		opts JobTimeoutOptions,
+	) error
 }
 
 type jobsService struct {
@@ -397,6 +483,14 @@ func (j *jobsService) Create(
 	var useWorkspace = job.Spec.PrimaryContainer.WorkspaceMountPath != ""
 	var usePrivileged = job.Spec.PrimaryContainer.Privileged
 	// var useDockerSocket = job.Spec.PrimaryContainer.UseHostDockerSocket
+	for _, initContainer := range job.Spec.InitContainers {
+		if initContainer.WorkspaceMountPath != "" {
+			useWorkspace = true
+		}
+		if initContainer.Privileged {
+			usePrivileged = true
+		}
+	}
 	for _, sidecarContainer := range job.Spec.SidecarContainers {
 		if sidecarContainer.WorkspaceMountPath != "" {
 			useWorkspace = true
@@ -437,6 +531,16 @@ func (j *jobsService) Create(
 		}
 	}
 
+	if err = validateJobContainers(job.Name, job.Spec); err != nil {
+		return err
+	}
+	if err = validateJobVolumes(job.Spec); err != nil {
+		return err
+	}
+	if err = validateJobSecretRefs(job.Spec); err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
 	job.Created = &now
 
@@ -444,6 +548,9 @@ func (j *jobsService) Create(
 	job.Status = &JobStatus{
 		Phase: JobPhasePending,
 	}
+	job.Timeline = []PhaseTransition{
+		newPhaseTransition(ctx, string(JobPhasePending), ""),
+	}
 
 	project, err := j.projectsStore.Get(ctx, event.ProjectID)
 	if err != nil {
@@ -463,6 +570,18 @@ func (j *jobsService) Create(
 	for k := range job.Spec.PrimaryContainer.Environment {
 		jobCopy.Spec.PrimaryContainer.Environment[k] = "*** REDACTED ***"
 	}
+	// This needs to be a NEW slice, otherwise as we mess with it, we're messing
+	// with the original since slices are references.
+	jobCopy.Spec.InitContainers =
+		make([]JobInitContainerSpec, len(job.Spec.InitContainers))
+	for i, initContainer := range job.Spec.InitContainers {
+		environment := map[string]string{}
+		for k := range initContainer.Environment {
+			environment[k] = "*** REDACTED ***"
+		}
+		initContainer.Environment = environment
+		jobCopy.Spec.InitContainers[i] = initContainer
+	}
 	// This needs to be a NEW map, otherwise as we mess with it, we're messing
 	// with the original since maps are references.
 	jobCopy.Spec.SidecarContainers = map[string]JobContainerSpec{}
@@ -557,6 +676,10 @@ func (j *jobsService) Start(
 		JobStatus{
 			Phase: JobPhaseStarting,
 		},
+		&PhaseTransition{
+			Phase: string(JobPhaseStarting),
+			Time:  time.Now().UTC(),
+		},
 	); err != nil {
 		return errors.Wrapf(
 			err,
@@ -667,7 +790,7 @@ func (j *jobsService) UpdateStatus(
 		return errors.Wrapf(err, "error retrieving event %q from store", eventID)
 	}
 
-	return j.updateStatus(ctx, event, jobName, status)
+	return j.updateStatus(ctx, event, jobName, status, status.Reason)
 }
 
 func (j *jobsService) Cleanup(
@@ -691,6 +814,7 @@ func (j *jobsService) Timeout(
 	ctx context.Context,
 	eventID string,
 	jobName string,
+	opts JobTimeoutOptions,
 ) error {
 	if err := j.authorize(ctx, RoleObserver, ""); err != nil {
 		return err
@@ -713,8 +837,10 @@ func (j *jobsService) Timeout(
 	status := *job.Status
 	status.Phase = JobPhaseTimedOut
 	status.Ended = &now
+	status.Reason = opts.Reason
 
-	if err := j.updateStatus(ctx, event, jobName, status); err != nil {
+	if err :=
+		j.updateStatus(ctx, event, jobName, status, opts.Reason); err != nil {
 		return errors.Wrapf(
 			err,
 			"error updating status for event %q job %q",
@@ -733,6 +859,7 @@ func (j *jobsService) updateStatus(
 	event Event,
 	jobName string,
 	status JobStatus,
+	reason string,
 ) error {
 	job, ok := event.Worker.Job(jobName)
 	if !ok {
@@ -755,12 +882,20 @@ func (j *jobsService) updateStatus(
 		}
 	}
 
+	// Only record a transition if the phase has actually changed
+	var transition *PhaseTransition
+	if status.Phase != job.Status.Phase {
+		t := newPhaseTransition(ctx, string(status.Phase), reason)
+		transition = &t
+	}
+
 	return errors.Wrapf(
 		j.jobsStore.UpdateStatus(
 			ctx,
 			event.ID,
 			jobName,
 			status,
+			transition,
 		),
 		"error updating status of event %q worker job %q in store",
 		event.ID,
@@ -808,11 +943,13 @@ type JobsStore interface {
 	Create(ctx context.Context, eventID string, job Job) error
 	// UpdateStatus updates the status of the specified Job in the underlying data
 	// store. If the specified job is not found, implementations MUST return a
-	// *meta.ErrNotFound error.
+	// *meta.ErrNotFound error. If the provided PhaseTransition is non-nil,
+	// implementations MUST also append it to the Job's timeline.
 	UpdateStatus(
 		ctx context.Context,
 		eventID string,
 		jobName string,
 		status JobStatus,
+		transition *PhaseTransition,
 	) error
 }
@@ -781,6 +781,7 @@ func TestJobsServiceStart(t *testing.T) {
 						context.Context,
 						string, string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return errors.New("something went wrong")
 					},
@@ -822,6 +823,7 @@ func TestJobsServiceStart(t *testing.T) {
 						context.Context,
 						string, string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return nil
 					},
@@ -868,6 +870,7 @@ func TestJobsServiceStart(t *testing.T) {
 						context.Context,
 						string, string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return nil
 					},
@@ -1154,6 +1157,7 @@ func TestJobsServiceUpdateStatus(t *testing.T) {
 						string,
 						string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return errors.New("something went wrong")
 					},
@@ -1191,6 +1195,7 @@ func TestJobsServiceUpdateStatus(t *testing.T) {
 						string,
 						string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						require.Fail(
 							t,
@@ -1235,6 +1240,7 @@ func TestJobsServiceUpdateStatus(t *testing.T) {
 						string,
 						string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return nil
 					},
@@ -1467,6 +1473,7 @@ func TestJobsServiceTimeout(t *testing.T) {
 						string,
 						string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return errors.New("something went wrong")
 					},
@@ -1493,6 +1500,7 @@ func TestJobsServiceTimeout(t *testing.T) {
 						string,
 						string,
 						JobStatus,
+						*PhaseTransition,
 					) error {
 						return nil
 					},
@@ -1529,6 +1537,7 @@ func TestJobsServiceTimeout(t *testing.T) {
 						_ string,
 						_ string,
 						status JobStatus,
+						_ *PhaseTransition,
 					) error {
 						require.Equal(t, JobPhaseTimedOut, status.Phase)
 						require.Equal(t, &testStartedTime, status.Started)
@@ -1558,6 +1567,7 @@ func TestJobsServiceTimeout(t *testing.T) {
 				context.Background(),
 				testEventID,
 				testJobName,
+				JobTimeoutOptions{},
 			)
 			testCase.assertions(err)
 		})
@@ -1659,6 +1669,7 @@ type mockJobsStore struct {
 		eventID string,
 		jobName string,
 		status JobStatus,
+		transition *PhaseTransition,
 	) error
 }
 
@@ -1675,6 +1686,25 @@ func (m *mockJobsStore) UpdateStatus(
 	eventID string,
 	jobName string,
 	status JobStatus,
+	transition *PhaseTransition,
 ) error {
-	return m.UpdateStatusFn(ctx, eventID, jobName, status)
+	return m.UpdateStatusFn(ctx, eventID, jobName, status, transition)
+}
+
+func TestJobContainerNames(t *testing.T) {
+	job := Job{
+		Name: "italian",
+		Spec: JobSpec{
+			SidecarContainers: map[string]JobContainerSpec{
+				"ziti":   {},
+				"penne":  {SourceMountPath: "/src"},
+				"rigati": {},
+			},
+		},
+	}
+	require.Equal(
+		t,
+		[]string{"vcs", "italian", "penne", "rigati", "ziti"},
+		job.containerNames(),
+	)
 }
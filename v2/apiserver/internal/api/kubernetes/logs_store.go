@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/brigadecore/brigade-foundations/retries"
@@ -15,13 +16,34 @@ import (
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// podLogStreams maps each api.LogStream to the value Kubernetes expects for the
+// "stream" parameter of a pod log request.
+var podLogStreams = map[api.LogStream]string{
+	api.LogStreamStdout: "Stdout",
+	api.LogStreamStderr: "Stderr",
+}
+
+// LogsStoreConfig encapsulates configuration options for the Kubernetes-based
+// implementation of the api.LogsStore interface.
+type LogsStoreConfig struct {
+	// SplitStreams indicates whether the Kubernetes cluster supports retrieving
+	// a container's stdout and stderr separately (i.e. whether the
+	// PodLogsQuerySplitStreams feature is enabled). When true, log entries are
+	// tagged with the stream they were written to and may be filtered by stream.
+	// When false, log entries' streams are unknown and they cannot be filtered
+	// by stream.
+	SplitStreams bool
+}
+
 // logsStore is a Kubernetes-based implementation of the api.LogsStore
 // interface.
 type logsStore struct {
 	kubeClient kubernetes.Interface
+	config     LogsStoreConfig
 }
 
 // NewLogsStore returns a Kubernetes-based implementation of the api.LogsStore
@@ -33,9 +55,13 @@ type logsStore struct {
 // Callers should be prepared to fall back on another implementation of the
 // api.LogsStore interface, with the assumption that by the time a Worker's or
 // Job's pod has been deleted, all of its logs have been aggregated and stored.
-func NewLogsStore(kubeClient kubernetes.Interface) api.LogsStore {
+func NewLogsStore(
+	kubeClient kubernetes.Interface,
+	config LogsStoreConfig,
+) api.LogsStore {
 	return &logsStore{
 		kubeClient: kubeClient,
+		config:     config,
 	}
 }
 
@@ -48,14 +74,150 @@ func (l *logsStore) StreamLogs(
 ) (<-chan api.LogEntry, error) {
 	podName := podNameFromSelector(event.ID, selector)
 
+	podLogOpts := &v1.PodLogOptions{
+		Container:  selector.Container,
+		Timestamps: true,
+		Follow:     opts.Follow,
+	}
+	if opts.TailLines > 0 {
+		podLogOpts.TailLines = &opts.TailLines
+	}
+	if opts.Since != nil {
+		podLogOpts.SinceTime = &metav1.Time{Time: *opts.Since}
+	}
+	// Kubernetes offers no equivalent to opts.Until, so that is handled below as
+	// log lines are read.
+
+	if !l.config.SplitStreams {
+		if opts.Stream != "" {
+			return nil, &meta.ErrBadRequest{
+				Reason: "Logs of running workers and jobs cannot be filtered by " +
+					"stream because this Brigade installation is not configured to " +
+					"retrieve them separately. Logs can be filtered by stream once " +
+					"the worker or job has completed.",
+			}
+		}
+		podLogs, err := l.openPodLogs(ctx, project, podName, podLogOpts, "")
+		if err != nil {
+			return nil, err
+		}
+		logEntryCh := make(chan api.LogEntry)
+		go func() {
+			defer close(logEntryCh)
+			sendLogEntries(ctx, podLogs, "", opts.Until, nil, logEntryCh)
+		}()
+		return logEntryCh, nil
+	}
+
+	streams := []api.LogStream{api.LogStreamStdout, api.LogStreamStderr}
+	if opts.Stream != "" {
+		streams = []api.LogStream{opts.Stream}
+	}
+
+	// Kubernetes doesn't permit tailing an individual stream, so when tailing,
+	// each stream is read from the beginning and the chronologically merged
+	// lines are passed through a buffer holding only the most recent
+	// opts.TailLines of them. Those are sent before any new lines are followed.
+	// The same merging is needed for correctly ordered output whenever lines
+	// aren't being followed, but since no lines are dropped in that case, merged
+	// lines are sent as soon as they're read.
+	readBacklog := !opts.Follow || opts.TailLines > 0
+	podLogOpts.TailLines = nil
+	podLogOpts.Follow = !readBacklog
+	podLogs, err := l.openAllPodLogs(ctx, project, podName, podLogOpts, streams)
+	if err != nil {
+		return nil, err
+	}
+
+	logEntryCh := make(chan api.LogEntry)
+	go func() {
+		defer close(logEntryCh)
+		if !readBacklog {
+			fanInLogEntries(ctx, podLogs, streams, opts.Until, nil, logEntryCh)
+			return
+		}
+		if opts.TailLines == 0 {
+			mergeLogEntries(ctx, podLogs, streams, opts.Until, logEntryCh)
+			return
+		}
+		merged := make(chan api.LogEntry)
+		go func() {
+			defer close(merged)
+			mergeLogEntries(ctx, podLogs, streams, opts.Until, merged)
+		}()
+		tail := newLogEntryRing(opts.TailLines)
+		for logEntry := range merged {
+			tail.add(logEntry)
+		}
+		backlog := tail.entries()
+		for _, logEntry := range backlog {
+			select {
+			case logEntryCh <- logEntry:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if !opts.Follow {
+			return
+		}
+		// Follow new lines, picking up where the backlog left off
+		var after *time.Time
+		if len(backlog) > 0 {
+			after = backlog[len(backlog)-1].Time
+		}
+		if after != nil {
+			podLogOpts.SinceTime = &metav1.Time{Time: *after}
+		}
+		podLogOpts.Follow = true
+		if podLogs, err =
+			l.openAllPodLogs(ctx, project, podName, podLogOpts, streams); err != nil {
+			api.ReportLogStreamError(ctx, err)
+			return
+		}
+		fanInLogEntries(ctx, podLogs, streams, opts.Until, after, logEntryCh)
+	}()
+	return logEntryCh, nil
+}
+
+// openAllPodLogs opens the logs of the specified pod once for each of the
+// specified streams. If any cannot be opened, all that were opened are closed.
+func (l *logsStore) openAllPodLogs(
+	ctx context.Context,
+	project api.Project,
+	podName string,
+	podLogOpts *v1.PodLogOptions,
+	streams []api.LogStream,
+) ([]io.ReadCloser, error) {
+	podLogs := make([]io.ReadCloser, 0, len(streams))
+	for _, stream := range streams {
+		streamLogs, err := l.openPodLogs(ctx, project, podName, podLogOpts, stream)
+		if err != nil {
+			for _, openedLogs := range podLogs {
+				openedLogs.Close() // nolint: errcheck
+			}
+			return nil, err
+		}
+		podLogs = append(podLogs, streamLogs)
+	}
+	return podLogs, nil
+}
+
+// openPodLogs opens the logs of the specified pod. If a stream is specified,
+// only lines written to that stream are included.
+func (l *logsStore) openPodLogs(
+	ctx context.Context,
+	project api.Project,
+	podName string,
+	podLogOpts *v1.PodLogOptions,
+	stream api.LogStream,
+) (io.ReadCloser, error) {
 	req := l.kubeClient.CoreV1().Pods(project.Kubernetes.Namespace).GetLogs(
 		podName,
-		&v1.PodLogOptions{
-			Container:  selector.Container,
-			Timestamps: true,
-			Follow:     opts.Follow,
-		},
+		podLogOpts,
 	)
+	if stream != "" {
+		req = req.Param("stream", podLogStreams[stream])
+	}
 
 	// The LogsService only would have called us for a Worker or Job that has
 	// already moved past the PENDING and STARTING phases. So at this point, the
@@ -104,46 +266,198 @@ func (l *logsStore) StreamLogs(
 	); err != nil {
 		return nil, err
 	}
+	return podLogs, nil
+}
 
-	logEntryCh := make(chan api.LogEntry)
+// fanInLogEntries concurrently reads log entries from each of the provided
+// pod logs, each of which contains lines written to the corresponding stream,
+// and sends them over the provided channel in the order they arrive. It
+// returns once all the pod logs have been read.
+func fanInLogEntries(
+	ctx context.Context,
+	podLogs []io.ReadCloser,
+	streams []api.LogStream,
+	until *time.Time,
+	after *time.Time,
+	logEntryCh chan<- api.LogEntry,
+) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(podLogs))
+	for i := range podLogs {
+		go func(i int) {
+			defer wg.Done()
+			sendLogEntries(ctx, podLogs[i], streams[i], until, after, logEntryCh)
+		}(i)
+	}
+	wg.Wait()
+}
 
-	go func() {
-		defer podLogs.Close()
-		defer close(logEntryCh)
-		buffer := bufio.NewReader(podLogs)
-		for {
-			logEntry := api.LogEntry{}
-			logLine, err := buffer.ReadString('\n')
-			if err == io.EOF {
-				break
-			}
-			if len(logLine) == 0 {
+// mergeLogEntries concurrently reads log entries from each of the provided
+// pod logs, each of which contains lines written to the corresponding stream,
+// and sends them over the provided channel in chronological order. Since the
+// lines of each individual stream are already in chronological order, only one
+// unsent entry per stream is held at any time. Entries with no timestamp are
+// sent as soon as they're read. It returns once all the pod logs have been read
+// or the context is canceled.
+func mergeLogEntries(
+	ctx context.Context,
+	podLogs []io.ReadCloser,
+	streams []api.LogStream,
+	until *time.Time,
+	logEntryCh chan<- api.LogEntry,
+) {
+	streamChs := make([]chan api.LogEntry, len(podLogs))
+	for i := range podLogs {
+		streamChs[i] = make(chan api.LogEntry)
+		go func(i int) {
+			defer close(streamChs[i])
+			sendLogEntries(ctx, podLogs[i], streams[i], until, nil, streamChs[i])
+		}(i)
+	}
+	heads := make([]*api.LogEntry, len(streamChs))
+	open := len(streamChs)
+	for {
+		// Make sure we're holding the next entry from every stream that isn't
+		// exhausted
+		for i, streamCh := range streamChs {
+			if heads[i] != nil || streamCh == nil {
 				continue
 			}
-			// The last character should be a newline that we don't want, so let's
-			// remove that
-			logLine = logLine[:len(logLine)-1]
-			logLineParts := strings.SplitN(logLine, " ", 2)
-			if len(logLineParts) == 2 {
-				timeStr := logLineParts[0]
-				t, err := time.Parse(time.RFC3339, timeStr)
-				if err == nil {
-					logEntry.Time = &t
-				}
-				logEntry.Message = logLineParts[1]
-			} else {
-				logEntry.Message = logLine
-			}
 			select {
-			case logEntryCh <- logEntry:
+			case logEntry, ok := <-streamCh:
+				if !ok {
+					streamChs[i] = nil
+					open--
+					continue
+				}
+				heads[i] = &logEntry
 			case <-ctx.Done():
 				return
 			}
 		}
-		podLogs.Close()
-	}()
+		if open == 0 {
+			return
+		}
+		next := -1
+		for i, head := range heads {
+			if head == nil {
+				continue
+			}
+			if head.Time == nil {
+				next = i
+				break
+			}
+			if next == -1 || head.Time.Before(*heads[next].Time) {
+				next = i
+			}
+		}
+		select {
+		case logEntryCh <- *heads[next]:
+			heads[next] = nil
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-	return logEntryCh, nil
+// logEntryRing is a fixed-capacity buffer that retains only the most recent
+// log entries added to it.
+type logEntryRing struct {
+	capacity int64
+	buffer   []api.LogEntry
+	next     int64
+}
+
+// newLogEntryRing returns a logEntryRing that retains at most the specified
+// number of log entries. Storage is allocated as entries are added so that a
+// large capacity costs nothing until it is actually used.
+func newLogEntryRing(capacity int64) *logEntryRing {
+	return &logEntryRing{
+		capacity: capacity,
+	}
+}
+
+// add adds the provided log entry to the ring, evicting the oldest entry if the
+// ring is already full.
+func (l *logEntryRing) add(logEntry api.LogEntry) {
+	if int64(len(l.buffer)) < l.capacity {
+		l.buffer = append(l.buffer, logEntry)
+		return
+	}
+	l.buffer[l.next] = logEntry
+	l.next = (l.next + 1) % l.capacity
+}
+
+// entries returns the log entries in the ring, oldest first.
+func (l *logEntryRing) entries() []api.LogEntry {
+	entries := make([]api.LogEntry, 0, len(l.buffer))
+	entries = append(entries, l.buffer[l.next:]...)
+	return append(entries, l.buffer[:l.next]...)
+}
+
+// sendLogEntries reads log entries from the provided pod logs, tags them with
+// the specified stream, and sends them over the provided channel until the
+// logs are exhausted, a line written at or after until is encountered, or the
+// context is canceled. Lines written at or before after, if specified, are
+// skipped. The pod logs are closed before returning.
+func sendLogEntries(
+	ctx context.Context,
+	podLogs io.ReadCloser,
+	stream api.LogStream,
+	until *time.Time,
+	after *time.Time,
+	logEntryCh chan<- api.LogEntry,
+) {
+	defer podLogs.Close()
+	buffer := bufio.NewReader(podLogs)
+	for {
+		logLine, err := buffer.ReadString('\n')
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				api.ReportLogStreamError(
+					ctx,
+					errors.Wrap(err, "error reading pod logs"),
+				)
+			}
+			return
+		}
+		logEntry := logEntryFromLine(logLine)
+		logEntry.Stream = stream
+		if logEntry.Time != nil {
+			if until != nil && !logEntry.Time.Before(*until) {
+				return
+			}
+			if after != nil && !logEntry.Time.After(*after) {
+				continue
+			}
+		}
+		select {
+		case logEntryCh <- logEntry:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// logEntryFromLine returns an api.LogEntry parsed from a newline-terminated
+// line of timestamped pod logs.
+func logEntryFromLine(logLine string) api.LogEntry {
+	logEntry := api.LogEntry{}
+	// The last character should be a newline that we don't want, so let's
+	// remove that
+	logLine = logLine[:len(logLine)-1]
+	logLineParts := strings.SplitN(logLine, " ", 2)
+	if len(logLineParts) == 2 {
+		timeStr := logLineParts[0]
+		t, err := time.Parse(time.RFC3339, timeStr)
+		if err == nil {
+			logEntry.Time = &t
+		}
+		logEntry.Message = logLineParts[1]
+	} else {
+		logEntry.Message = logLine
+	}
+	return logEntry
 }
 
 func podNameFromSelector(eventID string, selector api.LogsSelector) string {
@@ -1,7 +1,12 @@
 package kubernetes
 
 import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	myk8s "github.com/brigadecore/brigade/v2/internal/kubernetes"
@@ -11,9 +16,11 @@ import (
 
 func TestNewLogsStore(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
-	store, ok := NewLogsStore(kubeClient).(*logsStore)
+	config := LogsStoreConfig{SplitStreams: true}
+	store, ok := NewLogsStore(kubeClient, config).(*logsStore)
 	require.True(t, ok)
 	require.Same(t, kubeClient, store.kubeClient)
+	require.Equal(t, config, store.config)
 }
 
 // TODO: This is very difficult, if not impossible, to test in isolation because
@@ -25,6 +32,122 @@ func TestLogStoreStreamLogs(t *testing.T) {
 	// require.Fail(t, "test me")
 }
 
+func TestMergeLogEntries(t *testing.T) {
+	podLogs := []io.ReadCloser{
+		ioutil.NopCloser(strings.NewReader(
+			"2021-01-01T00:00:00Z out 1\n" +
+				"2021-01-01T00:00:02Z out 2\n" +
+				"2021-01-01T00:00:05Z out 3\n",
+		)),
+		ioutil.NopCloser(strings.NewReader(
+			"2021-01-01T00:00:01Z err 1\n" +
+				"untimestamped\n" +
+				"2021-01-01T00:00:03Z err 2\n" +
+				"2021-01-01T00:00:04Z err 3\n",
+		)),
+	}
+	until := time.Date(2021, time.January, 1, 0, 0, 4, 0, time.UTC)
+	logEntryCh := make(chan api.LogEntry)
+	go func() {
+		defer close(logEntryCh)
+		mergeLogEntries(
+			context.Background(),
+			podLogs,
+			[]api.LogStream{api.LogStreamStdout, api.LogStreamStderr},
+			&until,
+			logEntryCh,
+		)
+	}()
+	messages := []string{}
+	for logEntry := range logEntryCh {
+		messages = append(messages, string(logEntry.Stream)+": "+logEntry.Message)
+	}
+	require.Equal(
+		t,
+		[]string{
+			"stdout: out 1",
+			"stderr: err 1",
+			"stderr: untimestamped",
+			"stdout: out 2",
+			"stderr: err 2",
+		},
+		messages,
+	)
+}
+
+func TestLogEntryRing(t *testing.T) {
+	testCases := []struct {
+		name             string
+		count            int
+		expectedMessages []string
+	}{
+		{
+			name:             "ring not full",
+			count:            2,
+			expectedMessages: []string{"0", "1"},
+		},
+		{
+			name:             "ring full",
+			count:            3,
+			expectedMessages: []string{"0", "1", "2"},
+		},
+		{
+			name:             "ring wrapped",
+			count:            7,
+			expectedMessages: []string{"4", "5", "6"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ring := newLogEntryRing(3)
+			for i := 0; i < testCase.count; i++ {
+				ring.add(api.LogEntry{Message: string(rune('0' + i))})
+			}
+			messages := []string{}
+			for _, logEntry := range ring.entries() {
+				messages = append(messages, logEntry.Message)
+			}
+			require.Equal(t, testCase.expectedMessages, messages)
+		})
+	}
+}
+
+func TestLogEntryFromLine(t *testing.T) {
+	testCases := []struct {
+		name             string
+		logLine          string
+		expectedLogEntry func() api.LogEntry
+	}{
+		{
+			name:    "timestamped line",
+			logLine: "2021-01-01T00:00:00Z hello world\n",
+			expectedLogEntry: func() api.LogEntry {
+				t := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+				return api.LogEntry{
+					Time:    &t,
+					Message: "hello world",
+				}
+			},
+		},
+		{
+			name:    "line without timestamp",
+			logLine: "hello\n",
+			expectedLogEntry: func() api.LogEntry {
+				return api.LogEntry{Message: "hello"}
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expectedLogEntry(),
+				logEntryFromLine(testCase.logLine),
+			)
+		})
+	}
+}
+
 func TestPodNameFromSelector(t *testing.T) {
 	const testEventID = "123456789"
 	const testJobName = "italian"
@@ -80,6 +80,27 @@ func (s *secretsStore) List(
 	return secrets, nil
 }
 
+func (s *secretsStore) Values(
+	ctx context.Context,
+	project api.Project,
+) ([]string, error) {
+	k8sSecret, err := s.kubeClient.CoreV1().Secrets(
+		project.Kubernetes.Namespace,
+	).Get(ctx, "project-secrets", metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error retrieving secret \"project-secrets\" in namespace %q",
+			project.Kubernetes.Namespace,
+		)
+	}
+	values := make([]string, 0, len(k8sSecret.Data))
+	for _, value := range k8sSecret.Data {
+		values = append(values, string(value))
+	}
+	return values, nil
+}
+
 func (s *secretsStore) Set(
 	ctx context.Context,
 	project api.Project,
@@ -106,6 +106,49 @@ func TestSecretsStoreList(t *testing.T) {
 	}
 }
 
+func TestSecretsStoreValues(t *testing.T) {
+	const testNamespace = "foo"
+	testProject := api.Project{
+		Kubernetes: &api.KubernetesDetails{
+			Namespace: testNamespace,
+		},
+	}
+
+	t.Run("error getting kubernetes secret", func(t *testing.T) {
+		s := &secretsStore{
+			// We'll force an error simply by having the secret not exist
+			kubeClient: fake.NewSimpleClientset(),
+		}
+		_, err := s.Values(context.Background(), testProject)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error retrieving secret")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset()
+		_, err := kubeClient.CoreV1().Secrets(testNamespace).Create(
+			context.Background(),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "project-secrets",
+				},
+				Data: map[string][]byte{
+					"foo": []byte("bar"),
+					"bat": []byte("baz"),
+				},
+			},
+			metav1.CreateOptions{},
+		)
+		require.NoError(t, err)
+		s := &secretsStore{
+			kubeClient: kubeClient,
+		}
+		values, err := s.Values(context.Background(), testProject)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"bar", "baz"}, values)
+	})
+}
+
 func TestSecretsStoreSet(t *testing.T) {
 	const testNamespace = "foo"
 	const testKey = "foo"
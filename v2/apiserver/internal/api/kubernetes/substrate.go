@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
 	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
@@ -20,6 +22,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// readinessWaiterMountPath is the path at which the volume containing the
+// readiness waiter is mounted into a Job's containers.
+const readinessWaiterMountPath = "/brigade/readiness-waiter"
+
 var runningPodsSelector = fields.Set(
 	map[string]string{
 		"status.phase": string(corev1.PodRunning),
@@ -64,6 +70,14 @@ type SubstrateConfig struct {
 	// GitInitializerWindowsImagePullPolicy is the ImagePullPolicy that will be
 	// used (when applicable) for the Windows-based git initializer.
 	GitInitializerWindowsImagePullPolicy api.ImagePullPolicy
+	// ReadinessWaiterImage is the name of the OCI image that will be used (when
+	// applicable) to delay the start of a Job's primary container until its
+	// sidecars are ready. The expected format is
+	// [REGISTRY/][ORG/]IMAGE_NAME[:TAG].
+	ReadinessWaiterImage string
+	// ReadinessWaiterImagePullPolicy is the ImagePullPolicy that will be used
+	// (when applicable) for the readiness waiter.
+	ReadinessWaiterImagePullPolicy api.ImagePullPolicy
 	// DefaultWorkerImage is the name of the OCI image that will be used for the
 	// Worker pod's container[0] if none is specified in a Project's
 	// configuration. The expected format is [REGISTRY/][ORG/]IMAGE_NAME[:TAG].
@@ -418,19 +432,23 @@ func (s *substrate) StartWorker(
 	event api.Event,
 	token string,
 ) error {
-	projectSecretsSecret, err := s.kubeClient.CoreV1().Secrets(
-		project.Kubernetes.Namespace,
-	).Get(ctx, "project-secrets", metav1.GetOptions{})
-	if err != nil {
-		return errors.Wrapf(
-			err,
-			"error finding secret \"project-secrets\" in namespace %q",
-			project.Kubernetes.Namespace,
-		)
-	}
 	secrets := map[string]string{}
-	for key, value := range projectSecretsSecret.Data {
-		secrets[key] = string(value)
+	// Unless the Project's policies prohibit it, the Worker gets to see all the
+	// Project's secrets
+	if !hidesSecretsFromWorker(project) {
+		projectSecretsSecret, err := s.kubeClient.CoreV1().Secrets(
+			project.Kubernetes.Namespace,
+		).Get(ctx, "project-secrets", metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(
+				err,
+				"error finding secret \"project-secrets\" in namespace %q",
+				project.Kubernetes.Namespace,
+			)
+		}
+		for key, value := range projectSecretsSecret.Data {
+			secrets[key] = string(value)
+		}
 	}
 
 	type proj struct {
@@ -460,6 +478,7 @@ func (s *substrate) StartWorker(
 			ShortTitle string            `json:"shortTitle"`
 			LongTitle  string            `json:"longTitle"`
 			Payload    string            `json:"payload"`
+			Inputs     map[string]string `json:"inputs,omitempty"`
 			Worker     worker            `json:"worker"`
 		}{
 			ID: event.ID,
@@ -475,6 +494,7 @@ func (s *substrate) StartWorker(
 			ShortTitle: event.ShortTitle,
 			LongTitle:  event.LongTitle,
 			Payload:    event.Payload,
+			Inputs:     event.Inputs,
 			Worker: worker{
 				APIAddress:           s.config.APIAddress,
 				APIToken:             token,
@@ -947,6 +967,7 @@ func (s *substrate) createWorkerPod(
 				ImagePullPolicy: corev1.PullPolicy(
 					s.config.GitInitializerImagePullPolicy,
 				),
+				Env:          getGitCredentialsEnv(project),
 				VolumeMounts: volumeMounts,
 			},
 		}
@@ -1059,11 +1080,24 @@ func (s *substrate) createJobSecret(
 	for k, v := range jobSpec.PrimaryContainer.Environment {
 		jobSecret.StringData[fmt.Sprintf("%s.%s", jobName, k)] = v
 	}
+	for _, initContainer := range jobSpec.InitContainers {
+		for k, v := range initContainer.Environment {
+			jobSecret.StringData[fmt.Sprintf("%s.%s", initContainer.Name, k)] = v
+		}
+	}
 	for sidecarName, sidecareSpec := range jobSpec.SidecarContainers {
 		for k, v := range sidecareSpec.Environment {
 			jobSecret.StringData[fmt.Sprintf("%s.%s", sidecarName, k)] = v
 		}
 	}
+	for volumeName, volume := range jobSpec.Volumes {
+		if volume.ConfigFiles == nil {
+			continue
+		}
+		for i, file := range volume.ConfigFiles.Files {
+			jobSecret.StringData[configFileSecretKey(volumeName, i)] = file.Content
+		}
+	}
 
 	secretsClient := s.kubeClient.CoreV1().Secrets(project.Kubernetes.Namespace)
 	if _, err := secretsClient.Create(
@@ -1094,9 +1128,24 @@ func (s *substrate) createJobPod(
 	//   1. Use shared workspace
 	//   2. Use source code from git
 	//   3. Mount the host's Docker socket
+	//   4. Need to be probed for readiness
+	//   5. Reference secrets as files
 	var useWorkspace = jobSpec.PrimaryContainer.WorkspaceMountPath != ""
 	var useSource = jobSpec.PrimaryContainer.SourceMountPath != ""
 	// var useDockerSocket = jobSpec.PrimaryContainer.UseHostDockerSocket
+	var useReadinessWaiter bool
+	var useSecretRefFiles = usesSecretRefFiles(jobSpec.PrimaryContainer)
+	for _, initContainer := range jobSpec.InitContainers {
+		if initContainer.WorkspaceMountPath != "" {
+			useWorkspace = true
+		}
+		if initContainer.SourceMountPath != "" {
+			useSource = true
+		}
+		if usesSecretRefFiles(initContainer.JobContainerSpec) {
+			useSecretRefFiles = true
+		}
+	}
 	for _, sidecarContainer := range jobSpec.SidecarContainers {
 		if sidecarContainer.WorkspaceMountPath != "" {
 			useWorkspace = true
@@ -1104,9 +1153,15 @@ func (s *substrate) createJobPod(
 		if sidecarContainer.SourceMountPath != "" {
 			useSource = true
 		}
+		if usesSecretRefFiles(sidecarContainer) {
+			useSecretRefFiles = true
+		}
 		// if sidecarContainer.UseHostDockerSocket {
 		// 	useDockerSocket = true
 		// }
+		if sidecarContainer.ReadinessProbe != nil {
+			useReadinessWaiter = true
+		}
 	}
 
 	imagePullSecrets := []corev1.LocalObjectReference{}
@@ -1169,6 +1224,40 @@ func (s *substrate) createJobPod(
 	// 		},
 	// 	)
 	// }
+	jobVolumes, err := getVolumesFromSpec(event.ID, jobName, jobSpec)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error creating volumes for event %q job %q",
+			event.ID,
+			jobName,
+		)
+	}
+	volumes = append(volumes, jobVolumes...)
+	if useSecretRefFiles {
+		volumes = append(
+			volumes,
+			corev1.Volume{
+				Name: "secret-refs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: "project-secrets",
+					},
+				},
+			},
+		)
+	}
+	if useReadinessWaiter {
+		volumes = append(
+			volumes,
+			corev1.Volume{
+				Name: "readiness-waiter",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		)
+	}
 
 	initContainers := []corev1.Container{}
 	if useSource &&
@@ -1186,6 +1275,7 @@ func (s *substrate) createJobPod(
 				Name:            "vcs",
 				Image:           gitInitializerImage,
 				ImagePullPolicy: corev1.PullPolicy(gitInitializerImagePullPolicy),
+				Env:             getGitCredentialsEnv(project),
 				VolumeMounts: []corev1.VolumeMount{
 					{
 						Name:      myk8s.LabelKeyEvent,
@@ -1201,30 +1291,89 @@ func (s *substrate) createJobPod(
 		}
 	}
 
-	// This slice is big enough to hold the primary container AND all (if any)
-	// sidecar containers.
-	containers := make([]corev1.Container, len(jobSpec.SidecarContainers)+1)
+	if useReadinessWaiter {
+		initContainers = append(
+			initContainers,
+			corev1.Container{
+				Name:  "readiness-waiter",
+				Image: s.config.ReadinessWaiterImage,
+				ImagePullPolicy: corev1.PullPolicy(
+					s.config.ReadinessWaiterImagePullPolicy,
+				),
+				Args: []string{"install", readinessWaiterMountPath},
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "readiness-waiter",
+						MountPath: readinessWaiterMountPath,
+					},
+				},
+			},
+		)
+	}
 
-	// The primary container will be the 0 container in this list.
-	containers[0] = getContainerFromSpec(
-		event.ID,
-		jobName,
-		jobName,
-		jobSpec.PrimaryContainer,
-	)
+	// User-specified init containers run after Brigade's own, in the order
+	// specified.
+	for _, initContainerSpec := range jobSpec.InitContainers {
+		initContainers = append(
+			initContainers,
+			getContainerFromSpec(
+				event.ID,
+				jobName,
+				initContainerSpec.Name,
+				initContainerSpec.JobContainerSpec,
+			),
+		)
+	}
 
-	// Now add all the sidecars...
-	i := 1
-	for sidecarName, sidecarSpec := range jobSpec.SidecarContainers {
-		containers[i] = getContainerFromSpec(
+	// This slice is big enough to hold all (if any) sidecar containers AND the
+	// primary container.
+	containers := make([]corev1.Container, 0, len(jobSpec.SidecarContainers)+1)
+
+	// Kubernetes starts a pod's containers one at a time, in order, and won't
+	// start the next container until the post-start hook of the previous one has
+	// completed. So the sidecars are added first, in order by name, and any
+	// sidecar with a readiness probe is given a post-start hook that waits for
+	// the sidecar to be ready. This ensures the primary container, which is added
+	// last, does not start until all such sidecars are ready.
+	sidecarNames := make([]string, 0, len(jobSpec.SidecarContainers))
+	for sidecarName := range jobSpec.SidecarContainers {
+		sidecarNames = append(sidecarNames, sidecarName)
+	}
+	sort.Strings(sidecarNames)
+	for _, sidecarName := range sidecarNames {
+		sidecarSpec := jobSpec.SidecarContainers[sidecarName]
+		sidecar := getContainerFromSpec(
 			event.ID,
 			jobName,
 			sidecarName,
 			sidecarSpec,
 		)
-		i++
+		if sidecarSpec.ReadinessProbe != nil {
+			if err =
+				addReadinessWaiter(&sidecar, *sidecarSpec.ReadinessProbe); err != nil {
+				return errors.Wrapf(
+					err,
+					"error adding readiness waiter to sidecar %q of event %q job %q",
+					sidecarName,
+					event.ID,
+					jobName,
+				)
+			}
+		}
+		containers = append(containers, sidecar)
 	}
 
+	// The primary container takes the job's name
+	containers = append(
+		containers,
+		getContainerFromSpec(
+			event.ID,
+			jobName,
+			jobName,
+			jobSpec.PrimaryContainer,
+		),
+	)
+
 	jobPod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      myk8s.JobPodName(event.ID, jobName),
@@ -1356,6 +1505,46 @@ func getContainerFromSpec(
 	// 		},
 	// 	)
 	// }
+	for _, volumeMount := range spec.VolumeMounts {
+		container.VolumeMounts = append(
+			container.VolumeMounts,
+			corev1.VolumeMount{
+				Name:      volumeMount.Name,
+				MountPath: volumeMount.MountPath,
+				ReadOnly:  volumeMount.ReadOnly,
+			},
+		)
+	}
+	// Referenced secrets are resolved directly from the Project's secrets, so
+	// their values never appear in the Job's own secret.
+	for _, secretRef := range spec.SecretRefs {
+		if secretRef.EnvVar != "" {
+			container.Env = append(
+				container.Env,
+				corev1.EnvVar{
+					Name: secretRef.EnvVar,
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "project-secrets",
+							},
+							Key: secretRef.Key,
+						},
+					},
+				},
+			)
+			continue
+		}
+		container.VolumeMounts = append(
+			container.VolumeMounts,
+			corev1.VolumeMount{
+				Name:      "secret-refs",
+				MountPath: secretRef.Path,
+				SubPath:   secretRef.Key,
+				ReadOnly:  true,
+			},
+		)
+	}
 	if spec.Privileged {
 		tru := true
 		container.SecurityContext = &corev1.SecurityContext{
@@ -1364,3 +1553,173 @@ func getContainerFromSpec(
 	}
 	return container
 }
+
+// usesSecretRefFiles returns a bool indicating whether the provided container
+// references any of the Project's secrets as files.
+func usesSecretRefFiles(spec api.JobContainerSpec) bool {
+	for _, secretRef := range spec.SecretRefs {
+		if secretRef.Path != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hidesSecretsFromWorker returns a bool indicating whether the provided
+// Project's policies require its secrets to be withheld from its Workers.
+func hidesSecretsFromWorker(project api.Project) bool {
+	return project.Spec.SecretPolicies != nil &&
+		project.Spec.SecretPolicies.HideFromWorker
+}
+
+// getGitCredentialsEnv returns environment variables that make the provided
+// Project's git credentials, if any, available to the git initializer directly
+// from the Project's secrets. This is only necessary when the Project's secrets
+// are hidden from its Workers, since the git initializer otherwise finds them
+// in the event.json file. When that is not the case, nil is returned.
+func getGitCredentialsEnv(project api.Project) []corev1.EnvVar {
+	if !hidesSecretsFromWorker(project) {
+		return nil
+	}
+	optional := true
+	return []corev1.EnvVar{
+		{
+			Name: "GIT_SSH_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "project-secrets",
+					},
+					Key:      "gitSSHKey",
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name: "GIT_SSH_KEY_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "project-secrets",
+					},
+					Key:      "gitSSHKeyPassword",
+					Optional: &optional,
+				},
+			},
+		},
+	}
+}
+
+// addReadinessWaiter adds a post-start hook to the provided container that
+// waits until the container is ready according to the provided probe. The hook
+// uses the readiness waiter installed to a shared volume by an init container.
+func addReadinessWaiter(
+	container *corev1.Container,
+	probe api.ReadinessProbe,
+) error {
+	probeJSON, err := json.Marshal(probe)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling readiness probe")
+	}
+	container.VolumeMounts = append(
+		container.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      "readiness-waiter",
+			MountPath: readinessWaiterMountPath,
+			ReadOnly:  true,
+		},
+	)
+	container.Lifecycle = &corev1.Lifecycle{
+		PostStart: &corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					path.Join(readinessWaiterMountPath, "readiness-waiter"),
+					"wait",
+					string(probeJSON),
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// getVolumesFromSpec returns pod volumes corresponding to the volumes declared
+// in the provided JobSpec, ordered by name. The contents of any config files
+// are expected to have been stored in the Job's secret by createJobSecret.
+func getVolumesFromSpec(
+	eventID string,
+	jobName string,
+	jobSpec api.JobSpec,
+) ([]corev1.Volume, error) {
+	volumeNames := make([]string, 0, len(jobSpec.Volumes))
+	for volumeName := range jobSpec.Volumes {
+		volumeNames = append(volumeNames, volumeName)
+	}
+	sort.Strings(volumeNames)
+	volumes := make([]corev1.Volume, 0, len(volumeNames))
+	for _, volumeName := range volumeNames {
+		jobVolume := jobSpec.Volumes[volumeName]
+		volume := corev1.Volume{
+			Name: volumeName,
+		}
+		switch {
+		case jobVolume.EmptyDir != nil:
+			volume.EmptyDir = &corev1.EmptyDirVolumeSource{
+				Medium: corev1.StorageMedium(jobVolume.EmptyDir.Medium),
+			}
+			if jobVolume.EmptyDir.SizeLimit != "" {
+				sizeLimit, err := resource.ParseQuantity(jobVolume.EmptyDir.SizeLimit)
+				if err != nil {
+					return nil, errors.Wrapf(
+						err,
+						"error parsing size limit %q for volume %q",
+						jobVolume.EmptyDir.SizeLimit,
+						volumeName,
+					)
+				}
+				volume.EmptyDir.SizeLimit = &sizeLimit
+			}
+		case jobVolume.ProjectSecret != nil:
+			volume.Secret = &corev1.SecretVolumeSource{
+				SecretName: "project-secrets",
+				Items: make(
+					[]corev1.KeyToPath,
+					len(jobVolume.ProjectSecret.Items),
+				),
+			}
+			for i, item := range jobVolume.ProjectSecret.Items {
+				volume.Secret.Items[i] = corev1.KeyToPath{
+					Key:  item.Key,
+					Path: item.Path,
+				}
+			}
+		case jobVolume.ConfigFiles != nil:
+			volume.Secret = &corev1.SecretVolumeSource{
+				SecretName: myk8s.JobSecretName(eventID, jobName),
+				Items: make(
+					[]corev1.KeyToPath,
+					len(jobVolume.ConfigFiles.Files),
+				),
+			}
+			for i, file := range jobVolume.ConfigFiles.Files {
+				volume.Secret.Items[i] = corev1.KeyToPath{
+					Key:  configFileSecretKey(volumeName, i),
+					Path: file.Path,
+				}
+			}
+		default:
+			return nil, errors.Errorf("volume %q has no source", volumeName)
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// configFileSecretKey returns the key under which the content of the config
+// file at the specified index of the specified volume is stored in a Job's
+// secret. Unlike the keys of environment variables, which are of the form
+// <container>.<variable>, these keys never contain a period, so the two can
+// never collide.
+func configFileSecretKey(volumeName string, index int) string {
+	return fmt.Sprintf("config-%s-%d", volumeName, index)
+}
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -621,10 +622,12 @@ func TestSubstrateScheduleWorker(t *testing.T) {
 func TestSubstrateStartWorker(t *testing.T) {
 	const testNamespace = "foo"
 	const testEventID = "12345"
+	var kubeClient kubernetes.Interface
 	testCases := []struct {
-		name       string
-		setup      func() api.Substrate
-		assertions func(error)
+		name           string
+		secretPolicies *api.SecretPolicies
+		setup          func() api.Substrate
+		assertions     func(error)
 	}{
 		{
 			name: "error getting project secret",
@@ -784,12 +787,60 @@ func TestSubstrateStartWorker(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "success with secrets hidden from worker",
+			secretPolicies: &api.SecretPolicies{
+				HideFromWorker: true,
+			},
+			setup: func() api.Substrate {
+				// Note there's no project secret. It shouldn't be needed.
+				kubeClient = fake.NewSimpleClientset()
+				return &substrate{
+					kubeClient: kubeClient,
+					createWorkspacePVCFn: func(
+						context.Context,
+						api.Project,
+						api.Event,
+					) error {
+						return nil
+					},
+					createWorkerPodFn: func(
+						context.Context,
+						api.Project,
+						api.Event,
+					) error {
+						return nil
+					},
+				}
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+				eventSecret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(
+					context.Background(),
+					myk8s.EventSecretName(testEventID),
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				event := struct {
+					Project struct {
+						Secrets map[string]string `json:"secrets"`
+					} `json:"project"`
+				}{}
+				err = json.Unmarshal(eventSecret.Data["event.json"], &event)
+				require.NoError(t, err)
+				require.NotNil(t, event.Project.Secrets)
+				require.Empty(t, event.Project.Secrets)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			err := testCase.setup().StartWorker(
 				context.Background(),
 				api.Project{
+					Spec: api.ProjectSpec{
+						SecretPolicies: testCase.secretPolicies,
+					},
 					Kubernetes: &api.KubernetesDetails{
 						Namespace: testNamespace,
 					},
@@ -1314,6 +1365,18 @@ func TestSubstrateCreateJobSecret(t *testing.T) {
 				},
 			},
 		},
+		InitContainers: []api.JobInitContainerSpec{
+			{
+				Name: "setup",
+				JobContainerSpec: api.JobContainerSpec{
+					ContainerSpec: api.ContainerSpec{
+						Environment: map[string]string{
+							"QUX": "quux",
+						},
+					},
+				},
+			},
+		},
 		SidecarContainers: map[string]api.JobContainerSpec{
 			"helper": {
 				ContainerSpec: api.ContainerSpec{
@@ -1323,6 +1386,18 @@ func TestSubstrateCreateJobSecret(t *testing.T) {
 				},
 			},
 		},
+		Volumes: map[string]api.JobVolume{
+			"config": {
+				ConfigFiles: &api.ConfigFilesVolume{
+					Files: []api.ConfigFile{
+						{
+							Path:    "app.yaml",
+							Content: "verbose: true",
+						},
+					},
+				},
+			},
+		},
 	}
 	testCases := []struct {
 		name       string
@@ -1376,9 +1451,15 @@ func TestSubstrateCreateJobSecret(t *testing.T) {
 				val, ok := secret.StringData["italian.FOO"]
 				require.True(t, ok)
 				require.Equal(t, "bar", val)
+				val, ok = secret.StringData["setup.QUX"]
+				require.True(t, ok)
+				require.Equal(t, "quux", val)
 				val, ok = secret.StringData["helper.BAT"]
 				require.True(t, ok)
 				require.Equal(t, "baz", val)
+				val, ok = secret.StringData["config-config-0"]
+				require.True(t, ok)
+				require.Equal(t, "verbose: true", val)
 			},
 		},
 	}
@@ -1403,6 +1484,8 @@ func TestSubstrateCreateJobPod(t *testing.T) {
 		GitInitializerImagePullPolicy:        "IfNotPresent",
 		GitInitializerWindowsImage:           "brigadecore/brigade2-git-initializer-windows:v2.0.0", // nolint: lll
 		GitInitializerWindowsImagePullPolicy: "IfNotPresent",
+		ReadinessWaiterImage:                 "brigadecore/brigade2-readiness-waiter:v2.0.0", // nolint: lll
+		ReadinessWaiterImagePullPolicy:       "IfNotPresent",
 	}
 	testProject := api.Project{
 		Kubernetes: &api.KubernetesDetails{
@@ -1538,39 +1621,396 @@ func TestSubstrateCreateJobPod(t *testing.T) {
 				// Containers:
 				require.Len(t, pod.Spec.Containers, 2)
 				// Primary container:
-				require.Equal(t, testJobName, pod.Spec.Containers[0].Name)
-				require.Len(t, pod.Spec.Containers[0].Env, 1)
-				require.Equal(t, "FOO", pod.Spec.Containers[0].Env[0].Name)
-				require.Len(t, pod.Spec.Containers[0].VolumeMounts, 2)
+				require.Equal(t, testJobName, pod.Spec.Containers[1].Name)
+				require.Len(t, pod.Spec.Containers[1].Env, 1)
+				require.Equal(t, "FOO", pod.Spec.Containers[1].Env[0].Name)
+				require.Len(t, pod.Spec.Containers[1].VolumeMounts, 2)
 				require.Equal(
 					t,
 					"workspace",
-					pod.Spec.Containers[0].VolumeMounts[0].Name,
+					pod.Spec.Containers[1].VolumeMounts[0].Name,
 				)
-				require.Equal(t, "vcs", pod.Spec.Containers[0].VolumeMounts[1].Name)
+				require.Equal(t, "vcs", pod.Spec.Containers[1].VolumeMounts[1].Name)
 				// require.Equal(
 				// 	t,
 				// 	"docker-socket",
-				// 	pod.Spec.Containers[0].VolumeMounts[2].Name,
+				// 	pod.Spec.Containers[1].VolumeMounts[2].Name,
 				// )
 				// Sidecar container:
-				require.Equal(t, "helper", pod.Spec.Containers[1].Name)
-				require.Len(t, pod.Spec.Containers[1].Env, 1)
-				require.Equal(t, "BAT", pod.Spec.Containers[1].Env[0].Name)
-				require.Len(t, pod.Spec.Containers[1].VolumeMounts, 2)
+				require.Equal(t, "helper", pod.Spec.Containers[0].Name)
+				require.Len(t, pod.Spec.Containers[0].Env, 1)
+				require.Equal(t, "BAT", pod.Spec.Containers[0].Env[0].Name)
+				require.Len(t, pod.Spec.Containers[0].VolumeMounts, 2)
 				require.Equal(
 					t,
 					"workspace",
-					pod.Spec.Containers[1].VolumeMounts[0].Name,
+					pod.Spec.Containers[0].VolumeMounts[0].Name,
 				)
-				require.Equal(t, "vcs", pod.Spec.Containers[1].VolumeMounts[1].Name)
+				require.Equal(t, "vcs", pod.Spec.Containers[0].VolumeMounts[1].Name)
 				// require.Equal(
 				// 	t,
 				// 	"docker-socket",
-				// 	pod.Spec.Containers[1].VolumeMounts[2].Name,
+				// 	pod.Spec.Containers[0].VolumeMounts[2].Name,
 				// )
 			},
 		},
+		{
+			name: "error parsing volume size limit",
+			setup: func() *substrate {
+				return &substrate{
+					config:     testSubstrateConfig,
+					kubeClient: fake.NewSimpleClientset(),
+				}
+			},
+			jobSpec: func() api.JobSpec {
+				jobSpecCopy := testJobSpec
+				jobSpecCopy.Volumes = map[string]api.JobVolume{
+					"scratch": {
+						EmptyDir: &api.EmptyDirVolume{
+							SizeLimit: "a lot",
+						},
+					},
+				}
+				return jobSpecCopy
+			},
+			assertions: func(_ kubernetes.Interface, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing size limit")
+			},
+		},
+		{
+			name: "success with volumes",
+			setup: func() *substrate {
+				return &substrate{
+					config:     testSubstrateConfig,
+					kubeClient: fake.NewSimpleClientset(),
+				}
+			},
+			jobSpec: func() api.JobSpec {
+				return api.JobSpec{
+					PrimaryContainer: api.JobContainerSpec{
+						VolumeMounts: []api.JobVolumeMount{
+							{
+								Name:      "scratch",
+								MountPath: "/var/scratch",
+							},
+							{
+								Name:      "secrets",
+								MountPath: "/var/secrets",
+								ReadOnly:  true,
+							},
+							{
+								Name:      "config",
+								MountPath: "/etc/app",
+							},
+						},
+					},
+					SidecarContainers: map[string]api.JobContainerSpec{
+						"helper": {
+							VolumeMounts: []api.JobVolumeMount{
+								{
+									Name:      "scratch",
+									MountPath: "/var/scratch",
+								},
+							},
+						},
+					},
+					Volumes: map[string]api.JobVolume{
+						"scratch": {
+							EmptyDir: &api.EmptyDirVolume{
+								Medium:    api.StorageMediumMemory,
+								SizeLimit: "64Mi",
+							},
+						},
+						"secrets": {
+							ProjectSecret: &api.ProjectSecretVolume{
+								Items: []api.ProjectSecretItem{
+									{
+										Key:  "token",
+										Path: "auth/token",
+									},
+								},
+							},
+						},
+						"config": {
+							ConfigFiles: &api.ConfigFilesVolume{
+								Files: []api.ConfigFile{
+									{
+										Path:    "app.yaml",
+										Content: "verbose: true",
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+			assertions: func(kubeClient kubernetes.Interface, err error) {
+				require.NoError(t, err)
+				pod, err := kubeClient.CoreV1().Pods(
+					testProject.Kubernetes.Namespace,
+				).Get(
+					context.Background(),
+					myk8s.JobPodName(testEvent.ID, testJobName),
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				// Volumes are ordered by name:
+				require.Len(t, pod.Spec.Volumes, 3)
+				require.Equal(t, "config", pod.Spec.Volumes[0].Name)
+				require.NotNil(t, pod.Spec.Volumes[0].Secret)
+				require.Equal(
+					t,
+					myk8s.JobSecretName(testEvent.ID, testJobName),
+					pod.Spec.Volumes[0].Secret.SecretName,
+				)
+				require.Equal(
+					t,
+					[]corev1.KeyToPath{
+						{
+							Key:  "config-config-0",
+							Path: "app.yaml",
+						},
+					},
+					pod.Spec.Volumes[0].Secret.Items,
+				)
+				require.Equal(t, "scratch", pod.Spec.Volumes[1].Name)
+				require.NotNil(t, pod.Spec.Volumes[1].EmptyDir)
+				require.Equal(
+					t,
+					corev1.StorageMediumMemory,
+					pod.Spec.Volumes[1].EmptyDir.Medium,
+				)
+				require.Equal(
+					t,
+					"64Mi",
+					pod.Spec.Volumes[1].EmptyDir.SizeLimit.String(),
+				)
+				require.Equal(t, "secrets", pod.Spec.Volumes[2].Name)
+				require.NotNil(t, pod.Spec.Volumes[2].Secret)
+				require.Equal(
+					t,
+					"project-secrets",
+					pod.Spec.Volumes[2].Secret.SecretName,
+				)
+				require.Equal(
+					t,
+					[]corev1.KeyToPath{
+						{
+							Key:  "token",
+							Path: "auth/token",
+						},
+					},
+					pod.Spec.Volumes[2].Secret.Items,
+				)
+				// Volume mounts:
+				require.Equal(
+					t,
+					[]corev1.VolumeMount{
+						{
+							Name:      "scratch",
+							MountPath: "/var/scratch",
+						},
+						{
+							Name:      "secrets",
+							MountPath: "/var/secrets",
+							ReadOnly:  true,
+						},
+						{
+							Name:      "config",
+							MountPath: "/etc/app",
+						},
+					},
+					pod.Spec.Containers[1].VolumeMounts,
+				)
+				require.Equal(
+					t,
+					[]corev1.VolumeMount{
+						{
+							Name:      "scratch",
+							MountPath: "/var/scratch",
+						},
+					},
+					pod.Spec.Containers[0].VolumeMounts,
+				)
+			},
+		},
+		{
+			name: "success with init containers and readiness probes",
+			setup: func() *substrate {
+				return &substrate{
+					config:     testSubstrateConfig,
+					kubeClient: fake.NewSimpleClientset(),
+				}
+			},
+			jobSpec: func() api.JobSpec {
+				return api.JobSpec{
+					InitContainers: []api.JobInitContainerSpec{
+						{
+							Name: "setup",
+							JobContainerSpec: api.JobContainerSpec{
+								WorkspaceMountPath: "/var/workspace",
+							},
+						},
+					},
+					SidecarContainers: map[string]api.JobContainerSpec{
+						"db": {
+							ReadinessProbe: &api.ReadinessProbe{
+								TCPSocket: &api.TCPSocketProbe{
+									Port: 5432,
+								},
+							},
+						},
+						"cache": {},
+					},
+				}
+			},
+			assertions: func(kubeClient kubernetes.Interface, err error) {
+				require.NoError(t, err)
+				pod, err := kubeClient.CoreV1().Pods(
+					testProject.Kubernetes.Namespace,
+				).Get(
+					context.Background(),
+					myk8s.JobPodName(testEvent.ID, testJobName),
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				// Volumes:
+				require.Len(t, pod.Spec.Volumes, 2)
+				require.Equal(t, "readiness-waiter", pod.Spec.Volumes[1].Name)
+				require.NotNil(t, pod.Spec.Volumes[1].EmptyDir)
+				// Init containers:
+				require.Len(t, pod.Spec.InitContainers, 2)
+				require.Equal(
+					t,
+					"readiness-waiter",
+					pod.Spec.InitContainers[0].Name,
+				)
+				require.Equal(
+					t,
+					testSubstrateConfig.ReadinessWaiterImage,
+					pod.Spec.InitContainers[0].Image,
+				)
+				require.Equal(
+					t,
+					[]string{"install", readinessWaiterMountPath},
+					pod.Spec.InitContainers[0].Args,
+				)
+				require.Equal(t, "setup", pod.Spec.InitContainers[1].Name)
+				require.Len(t, pod.Spec.InitContainers[1].VolumeMounts, 1)
+				require.Equal(
+					t,
+					"workspace",
+					pod.Spec.InitContainers[1].VolumeMounts[0].Name,
+				)
+				// Sidecars come first, in order by name, and the primary container
+				// comes last:
+				require.Len(t, pod.Spec.Containers, 3)
+				require.Equal(t, "cache", pod.Spec.Containers[0].Name)
+				require.Nil(t, pod.Spec.Containers[0].Lifecycle)
+				require.Equal(t, "db", pod.Spec.Containers[1].Name)
+				require.Equal(
+					t,
+					[]corev1.VolumeMount{
+						{
+							Name:      "readiness-waiter",
+							MountPath: readinessWaiterMountPath,
+							ReadOnly:  true,
+						},
+					},
+					pod.Spec.Containers[1].VolumeMounts,
+				)
+				require.NotNil(t, pod.Spec.Containers[1].Lifecycle)
+				require.NotNil(t, pod.Spec.Containers[1].Lifecycle.PostStart)
+				require.Equal(
+					t,
+					[]string{
+						readinessWaiterMountPath + "/readiness-waiter",
+						"wait",
+						`{"tcpSocket":{"port":5432}}`,
+					},
+					pod.Spec.Containers[1].Lifecycle.PostStart.Exec.Command,
+				)
+				require.Equal(t, testJobName, pod.Spec.Containers[2].Name)
+			},
+		},
+		{
+			name: "success with secret refs",
+			setup: func() *substrate {
+				return &substrate{
+					config:     testSubstrateConfig,
+					kubeClient: fake.NewSimpleClientset(),
+				}
+			},
+			jobSpec: func() api.JobSpec {
+				return api.JobSpec{
+					PrimaryContainer: api.JobContainerSpec{
+						SecretRefs: []api.SecretRef{
+							{
+								Key:    "apiToken",
+								EnvVar: "API_TOKEN",
+							},
+							{
+								Key:  "tlsCert",
+								Path: "/etc/tls/cert.pem",
+							},
+						},
+					},
+				}
+			},
+			assertions: func(kubeClient kubernetes.Interface, err error) {
+				require.NoError(t, err)
+				pod, err := kubeClient.CoreV1().Pods(
+					testProject.Kubernetes.Namespace,
+				).Get(
+					context.Background(),
+					myk8s.JobPodName(testEvent.ID, testJobName),
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				// Volumes:
+				require.Len(t, pod.Spec.Volumes, 1)
+				require.Equal(t, "secret-refs", pod.Spec.Volumes[0].Name)
+				require.NotNil(t, pod.Spec.Volumes[0].Secret)
+				require.Equal(
+					t,
+					"project-secrets",
+					pod.Spec.Volumes[0].Secret.SecretName,
+				)
+				// Environment variables are resolved directly from the project's
+				// secrets:
+				require.Equal(
+					t,
+					[]corev1.EnvVar{
+						{
+							Name: "API_TOKEN",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "project-secrets",
+									},
+									Key: "apiToken",
+								},
+							},
+						},
+					},
+					pod.Spec.Containers[0].Env,
+				)
+				// And so are files:
+				require.Equal(
+					t,
+					[]corev1.VolumeMount{
+						{
+							Name:      "secret-refs",
+							MountPath: "/etc/tls/cert.pem",
+							SubPath:   "tlsCert",
+							ReadOnly:  true,
+						},
+					},
+					pod.Spec.Containers[0].VolumeMounts,
+				)
+			},
+		},
 		{
 			name: "success with windows",
 			setup: func() *substrate {
@@ -1865,6 +2305,49 @@ func TestSubstrateCreatePodWithNodeSelectorAndToleration(t *testing.T) {
 	}
 }
 
+func TestGetGitCredentialsEnv(t *testing.T) {
+	testCases := []struct {
+		name       string
+		project    api.Project
+		assertions func([]corev1.EnvVar)
+	}{
+		{
+			name: "secrets not hidden from worker",
+			assertions: func(env []corev1.EnvVar) {
+				require.Nil(t, env)
+			},
+		},
+		{
+			name: "secrets hidden from worker",
+			project: api.Project{
+				Spec: api.ProjectSpec{
+					SecretPolicies: &api.SecretPolicies{
+						HideFromWorker: true,
+					},
+				},
+			},
+			assertions: func(env []corev1.EnvVar) {
+				require.Len(t, env, 2)
+				require.Equal(t, "GIT_SSH_KEY", env[0].Name)
+				require.Equal(t, "gitSSHKey", env[0].ValueFrom.SecretKeyRef.Key)
+				require.True(t, *env[0].ValueFrom.SecretKeyRef.Optional)
+				require.Equal(t, "GIT_SSH_KEY_PASSWORD", env[1].Name)
+				require.Equal(
+					t,
+					"gitSSHKeyPassword",
+					env[1].ValueFrom.SecretKeyRef.Key,
+				)
+				require.True(t, *env[1].ValueFrom.SecretKeyRef.Optional)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(getGitCredentialsEnv(testCase.project))
+		})
+	}
+}
+
 func TestGenerateNewNamespace(t *testing.T) {
 	namespace := generateNewNamespace()
 	tokens := strings.SplitN(namespace, "-", 2)
@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	myk8s "github.com/brigadecore/brigade/v2/internal/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// webhookSecretsSecretName is the name of the Kubernetes secret, in each
+// Project's namespace, that holds the HMAC secrets of all that Project's
+// Webhooks, keyed by Webhook ID. This is deliberately separate from the
+// "project-secrets" secret so that these values are never made available to
+// Workers or Jobs.
+const webhookSecretsSecretName = "webhook-secrets"
+
+// webhookSecretsStore is a Kubernetes-based implementation of the
+// api.WebhookSecretsStore interface.
+type webhookSecretsStore struct {
+	kubeClient kubernetes.Interface
+}
+
+// NewWebhookSecretsStore returns a Kubernetes-based implementation of the
+// api.WebhookSecretsStore interface.
+func NewWebhookSecretsStore(
+	kubeClient kubernetes.Interface,
+) api.WebhookSecretsStore {
+	return &webhookSecretsStore{
+		kubeClient: kubeClient,
+	}
+}
+
+func (w *webhookSecretsStore) Get(
+	ctx context.Context,
+	project api.Project,
+	webhookID string,
+) (string, error) {
+	k8sSecret, err := w.kubeClient.CoreV1().Secrets(
+		project.Kubernetes.Namespace,
+	).Get(ctx, webhookSecretsSecretName, metav1.GetOptions{})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return "", errors.Wrapf(
+			err,
+			"error retrieving secret %q in namespace %q",
+			webhookSecretsSecretName,
+			project.Kubernetes.Namespace,
+		)
+	}
+	if err == nil {
+		if value, ok := k8sSecret.Data[webhookID]; ok {
+			return string(value), nil
+		}
+	}
+	return "", &meta.ErrNotFound{
+		Type: api.WebhookKind,
+		ID:   webhookID,
+	}
+}
+
+func (w *webhookSecretsStore) Set(
+	ctx context.Context,
+	project api.Project,
+	webhookID string,
+	secret string,
+) error {
+	patch := struct {
+		Data map[string]string `json:"data"`
+	}{
+		Data: map[string]string{
+			webhookID: base64.StdEncoding.EncodeToString([]byte(secret)),
+		},
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error marshaling patch for project %q webhook secrets in namespace %q",
+			project.ID,
+			project.Kubernetes.Namespace,
+		)
+	}
+	_, err = w.kubeClient.CoreV1().Secrets(project.Kubernetes.Namespace).Patch(
+		ctx,
+		webhookSecretsSecretName,
+		types.StrategicMergePatchType,
+		patchBytes,
+		metav1.PatchOptions{},
+	)
+	if err == nil {
+		return nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		return errors.Wrapf(
+			err,
+			"error patching project %q webhook secrets in namespace %q",
+			project.ID,
+			project.Kubernetes.Namespace,
+		)
+	}
+	// This is the Project's first Webhook that needs a secret
+	if _, err = w.kubeClient.CoreV1().Secrets(
+		project.Kubernetes.Namespace,
+	).Create(
+		ctx,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: webhookSecretsSecretName,
+				Labels: map[string]string{
+					myk8s.LabelComponent: myk8s.LabelKeyWebhookSecrets,
+					myk8s.LabelProject:   project.ID,
+				},
+			},
+			Type: myk8s.SecretTypeWebhookSecrets,
+			Data: map[string][]byte{
+				webhookID: []byte(secret),
+			},
+		},
+		metav1.CreateOptions{},
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error creating secret %q in namespace %q",
+			webhookSecretsSecretName,
+			project.Kubernetes.Namespace,
+		)
+	}
+	return nil
+}
+
+func (w *webhookSecretsStore) Unset(
+	ctx context.Context,
+	project api.Project,
+	webhookID string,
+) error {
+	// As with project secrets, patching to remove a key that doesn't exist is an
+	// error, so we have a peek first.
+	k8sSecret, err := w.kubeClient.CoreV1().Secrets(
+		project.Kubernetes.Namespace,
+	).Get(ctx, webhookSecretsSecretName, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(
+			err,
+			"error retrieving secret %q in namespace %q",
+			webhookSecretsSecretName,
+			project.Kubernetes.Namespace,
+		)
+	}
+	if _, ok := k8sSecret.Data[webhookID]; !ok {
+		return nil
+	}
+	patch := []struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+	}{
+		{
+			Op:   "remove",
+			Path: fmt.Sprintf("/data/%s", webhookID),
+		},
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error marshaling patch for project %q webhook secrets in namespace %q",
+			project.ID,
+			project.Kubernetes.Namespace,
+		)
+	}
+	if _, err := w.kubeClient.CoreV1().Secrets(
+		project.Kubernetes.Namespace,
+	).Patch(
+		ctx,
+		webhookSecretsSecretName,
+		types.JSONPatchType,
+		patchBytes,
+		metav1.PatchOptions{},
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error patching project %q webhook secrets in namespace %q",
+			project.ID,
+			project.Kubernetes.Namespace,
+		)
+	}
+	return nil
+}
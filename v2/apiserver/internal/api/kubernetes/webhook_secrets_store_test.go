@@ -0,0 +1,229 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewWebhookSecretsStore(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	w, ok := NewWebhookSecretsStore(kubeClient).(*webhookSecretsStore)
+	require.True(t, ok)
+	require.Same(t, kubeClient, w.kubeClient)
+}
+
+func TestWebhookSecretsStoreGet(t *testing.T) {
+	const testNamespace = "foo"
+	testProject := api.Project{
+		Kubernetes: &api.KubernetesDetails{
+			Namespace: testNamespace,
+		},
+	}
+	testCases := []struct {
+		name       string
+		setup      func() *fake.Clientset
+		assertions func(string, error)
+	}{
+		{
+			name: "kubernetes secret does not exist",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset()
+			},
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrNotFound{}, err)
+			},
+		},
+		{
+			name: "webhook has no secret",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      webhookSecretsSecretName,
+							Namespace: testNamespace,
+						},
+					},
+				)
+			},
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrNotFound{}, err)
+			},
+		},
+		{
+			name: "success",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      webhookSecretsSecretName,
+							Namespace: testNamespace,
+						},
+						Data: map[string][]byte{
+							"bar": []byte("abcdefg"),
+						},
+					},
+				)
+			},
+			assertions: func(secret string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "abcdefg", secret)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhookSecretsStore{
+				kubeClient: testCase.setup(),
+			}
+			testCase.assertions(
+				store.Get(context.Background(), testProject, "bar"),
+			)
+		})
+	}
+}
+
+func TestWebhookSecretsStoreSet(t *testing.T) {
+	const testNamespace = "foo"
+	testProject := api.Project{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "italian",
+		},
+		Kubernetes: &api.KubernetesDetails{
+			Namespace: testNamespace,
+		},
+	}
+	testCases := []struct {
+		name       string
+		setup      func() *fake.Clientset
+		assertions func(*fake.Clientset, error)
+	}{
+		{
+			name: "kubernetes secret does not exist",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset()
+			},
+			assertions: func(kubeClient *fake.Clientset, err error) {
+				require.NoError(t, err)
+				k8sSecret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(
+					context.Background(),
+					webhookSecretsSecretName,
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				require.Equal(t, "abcdefg", string(k8sSecret.Data["bar"]))
+				require.Equal(t, "italian", k8sSecret.Labels["brigade.sh/project"])
+			},
+		},
+		{
+			name: "kubernetes secret exists",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      webhookSecretsSecretName,
+							Namespace: testNamespace,
+						},
+						Data: map[string][]byte{
+							"bat": []byte("baz"),
+						},
+					},
+				)
+			},
+			assertions: func(kubeClient *fake.Clientset, err error) {
+				require.NoError(t, err)
+				k8sSecret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(
+					context.Background(),
+					webhookSecretsSecretName,
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				require.Len(t, k8sSecret.Data, 2)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			kubeClient := testCase.setup()
+			store := &webhookSecretsStore{
+				kubeClient: kubeClient,
+			}
+			testCase.assertions(
+				kubeClient,
+				store.Set(context.Background(), testProject, "bar", "abcdefg"),
+			)
+		})
+	}
+}
+
+func TestWebhookSecretsStoreUnset(t *testing.T) {
+	const testNamespace = "foo"
+	testProject := api.Project{
+		Kubernetes: &api.KubernetesDetails{
+			Namespace: testNamespace,
+		},
+	}
+	testCases := []struct {
+		name       string
+		setup      func() *fake.Clientset
+		assertions func(*fake.Clientset, error)
+	}{
+		{
+			name: "kubernetes secret does not exist",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset()
+			},
+			assertions: func(_ *fake.Clientset, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "success",
+			setup: func() *fake.Clientset {
+				return fake.NewSimpleClientset(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      webhookSecretsSecretName,
+							Namespace: testNamespace,
+						},
+						Data: map[string][]byte{
+							"bar": []byte("abcdefg"),
+							"bat": []byte("baz"),
+						},
+					},
+				)
+			},
+			assertions: func(kubeClient *fake.Clientset, err error) {
+				require.NoError(t, err)
+				k8sSecret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(
+					context.Background(),
+					webhookSecretsSecretName,
+					metav1.GetOptions{},
+				)
+				require.NoError(t, err)
+				require.Len(t, k8sSecret.Data, 1)
+				require.Contains(t, k8sSecret.Data, "bat")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			kubeClient := testCase.setup()
+			store := &webhookSecretsStore{
+				kubeClient: kubeClient,
+			}
+			testCase.assertions(
+				kubeClient,
+				store.Unset(context.Background(), testProject, "bar"),
+			)
+		})
+	}
+}
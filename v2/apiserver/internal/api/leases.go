@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// LeasesStore is an interface for components that implement lease persistence
+// concerns. A lease permits only one of many API server replicas at a time to
+// perform some periodic task.
+type LeasesStore interface {
+	// Acquire attempts to acquire the specified lease on behalf of the specified
+	// holder until the specified time. A lease already held by the same holder is
+	// renewed. Implementations MUST return false if the lease is held by another
+	// holder and has not yet expired.
+	Acquire(
+		ctx context.Context,
+		name string,
+		holder string,
+		until time.Time,
+	) (bool, error)
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/brigadecore/brigade-foundations/retries"
@@ -24,6 +29,11 @@ type LogsSelector struct {
 	// presume logs are desired from a container having the same name as the
 	// selected Worker or Job.
 	Container string
+	// All specifies that logs should be streamed from every container of the
+	// Worker and of every Job that has started. It is mutually exclusive with Job
+	// and Container. Entries from all containers are interleaved and each is
+	// tagged with the Job and Container it came from.
+	All bool
 }
 
 // LogStreamOptions represents useful options for streaming logs from some
@@ -34,14 +44,63 @@ type LogStreamOptions struct {
 	// until closed by the client (true), continuing to send new lines as they
 	// become available.
 	Follow bool `json:"follow"`
+	// TailLines, if greater than zero, specifies that only the specified number
+	// of most recent lines available when the stream is opened should be sent,
+	// followed by any new lines if Follow is true.
+	TailLines int64 `json:"tailLines,omitempty"`
+	// Stream, if non-empty, specifies that only lines written to the specified
+	// stream should be sent.
+	Stream LogStream `json:"stream,omitempty"`
+	// Since, if non-nil, specifies that only lines written at or after the
+	// specified time should be sent.
+	Since *time.Time `json:"since,omitempty"`
+	// Until, if non-nil, specifies that only lines written before the specified
+	// time should be sent. The stream concludes once such a line is encountered,
+	// even if Follow is true.
+	Until *time.Time `json:"until,omitempty"`
+	// Unredacted specifies that the values of the Project's Secrets should NOT
+	// be redacted from the logs. Only admins may request this.
+	Unredacted bool `json:"unredacted,omitempty"`
 }
 
+// LogStream represents one of the standard streams an OCI container writes
+// its output to.
+type LogStream string
+
+const (
+	// LogStreamStdout represents a container's standard output.
+	LogStreamStdout LogStream = "stdout"
+	// LogStreamStderr represents a container's standard error.
+	LogStreamStderr LogStream = "stderr"
+)
+
+// logLevelKeys are the keys, in order of preference, under which the level
+// of a structured (JSON) log line is commonly found.
+var logLevelKeys = []string{"level", "lvl", "severity"}
+
 // LogEntry represents one line of output from an OCI container.
 type LogEntry struct {
 	// Time is the time the line was written.
 	Time *time.Time `json:"time,omitempty" bson:"time,omitempty"`
 	// Message is a single line of log output from an OCI container.
 	Message string `json:"message,omitempty" bson:"log,omitempty"`
+	// Stream is the stream the line was written to. It is empty if that could
+	// not be determined.
+	Stream LogStream `json:"stream,omitempty" bson:"stream,omitempty"`
+	// Level is the level of a line that is a JSON object having a "level",
+	// "lvl", or "severity" field. It is derived from the Message and is
+	// normalized to lower case.
+	Level string `json:"level,omitempty" bson:"-"`
+	// Fields are the fields, other than the level, of a line that is a JSON
+	// object. They are derived from the Message.
+	Fields map[string]interface{} `json:"fields,omitempty" bson:"-"`
+	// Job is the name of the Job whose container wrote the line. It is empty for
+	// lines written by the Worker's containers. It is only guaranteed to be set
+	// when logs are streamed from all containers.
+	Job string `json:"job,omitempty" bson:"job,omitempty"`
+	// Container is the name of the container that wrote the line. It is only
+	// guaranteed to be set when logs are streamed from all containers.
+	Container string `json:"container,omitempty" bson:"container,omitempty"`
 }
 
 // MarshalJSON amends LogEntry instances with type metadata so that clients do
@@ -62,6 +121,132 @@ func (l LogEntry) MarshalJSON() ([]byte, error) {
 	)
 }
 
+// LogsArchiveFile represents the logs of a single container of an Event's
+// Worker or of one of its Jobs, as they are to be included in an archive of
+// all of the Event's logs.
+type LogsArchiveFile struct {
+	// Job is the name of the Job whose container wrote the logs. It is empty for
+	// logs written by the Worker's containers.
+	Job string
+	// Container is the name of the container that wrote the logs.
+	Container string
+	// Open opens a stream of the container's log entries. They're sent over the
+	// returned channel in the order they were written and the channel is closed
+	// after the last entry. Streams are opened only when needed so that an
+	// archive never holds more than one open at a time. If the container turns
+	// out to have no logs, a *meta.ErrNotFound error is returned. Errors
+	// encountered after the stream was opened are reported to any
+	// LogStreamErrorHandler carried by the provided context.
+	Open func(ctx context.Context) (<-chan LogEntry, error)
+}
+
+type logStreamErrorHandlerContextKey struct{}
+
+// ContextWithLogStreamErrorHandler returns a context.Context that has been
+// augmented with a function to be invoked whenever a LogsStore encounters an
+// error after it has already returned a stream of log entries. Such an error
+// ends the stream early, so this permits a caller to distinguish a stream that
+// was cut short from one that was complete. The handler may be invoked
+// concurrently.
+func ContextWithLogStreamErrorHandler(
+	ctx context.Context,
+	handler func(error),
+) context.Context {
+	return context.WithValue(ctx, logStreamErrorHandlerContextKey{}, handler)
+}
+
+// ReportLogStreamError logs the provided error, which ended a stream of log
+// entries early, and passes it to the handler, if any, carried by the provided
+// context.Context. LogsStore implementations should use this in place of
+// merely logging such errors.
+func ReportLogStreamError(ctx context.Context, err error) {
+	log.Println(err)
+	if handler, ok :=
+		ctx.Value(logStreamErrorHandlerContextKey{}).(func(error)); ok {
+		handler(err)
+	}
+}
+
+const (
+	// redactedSecret is what replaces the value of any project secret found in
+	// log output.
+	redactedSecret = "***"
+	// minRedactedSecretLength is the length of the shortest value (or line of a
+	// multi-line value) of a project secret that is redacted from log output.
+	// Shorter values, such as "true" or a port number, are too likely to occur
+	// by chance. Redacting them would mangle unrelated output and, by starring
+	// out every occurrence, reveal the value anyway.
+	minRedactedSecretLength = 6
+)
+
+// LogsSearchSelector represents useful criteria for selecting log entries
+// when searching a Project's logs. Exactly one of Text or Regex must be
+// specified. All other criteria are optional.
+type LogsSearchSelector struct {
+	// Text specifies words or phrases that selected log entries must contain.
+	// Matching uses the data store's full text search, so words are matched
+	// regardless of case or word ending.
+	Text string
+	// Regex specifies a regular expression that selected log entries must
+	// match.
+	Regex string
+	// Job specifies that only log entries written by containers of the Job
+	// with the specified name should be selected.
+	Job string
+	// Container specifies that only log entries written by containers with the
+	// specified name should be selected.
+	Container string
+	// WrittenSince specifies that only log entries written at or after the
+	// specified time should be selected. This bounds when individual lines were
+	// written, not when the Events that wrote them were created.
+	WrittenSince *time.Time
+	// WrittenUntil specifies that only log entries written before the specified
+	// time should be selected. This bounds when individual lines were written,
+	// not when the Events that wrote them were created.
+	WrittenUntil *time.Time
+}
+
+// LogSearchResult represents one line of output that matched a log search,
+// along with the Event, Job, and container that wrote it.
+type LogSearchResult struct {
+	// Time is the time the line was written.
+	Time *time.Time `json:"time,omitempty" bson:"time,omitempty"`
+	// EventID is the identifier of the Event whose Worker or Job wrote the line.
+	EventID string `json:"eventID,omitempty" bson:"event,omitempty"`
+	// Job is the name of the Job whose container wrote the line. It is empty for
+	// lines written by the Worker's containers.
+	Job string `json:"job,omitempty" bson:"job,omitempty"`
+	// Container is the name of the container that wrote the line.
+	Container string `json:"container,omitempty" bson:"container,omitempty"`
+	// Message is a single line of log output from an OCI container.
+	Message string `json:"message,omitempty" bson:"log,omitempty"`
+}
+
+// LogSearchResultList is an ordered and pageable list of LogSearchResults.
+type LogSearchResultList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of LogSearchResults.
+	Items []LogSearchResult `json:"items,omitempty"`
+}
+
+// MarshalJSON amends LogSearchResultList instances with type metadata.
+func (l LogSearchResultList) MarshalJSON() ([]byte, error) {
+	type Alias LogSearchResultList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "LogSearchResultList",
+			},
+			Alias: (Alias)(l),
+		},
+	)
+}
+
 // LogsService is the specialized interface for accessing logs. It's
 // decoupled from underlying technology choices (e.g. data store, message bus,
 // etc.) to keep business logic reusable and consistent while the underlying
@@ -78,6 +263,25 @@ type LogsService interface {
 		selector LogsSelector,
 		opts LogStreamOptions,
 	) (<-chan LogEntry, error)
+	// Archive returns one LogsArchiveFile for each container of an Event's
+	// Worker and of every Job that has started, from which a downloadable
+	// archive of all the Event's logs can be assembled. The logs of Jobs
+	// inherited from another Event are included. No logs are streamed until a
+	// LogsArchiveFile is opened. If the specified Event does not exist,
+	// implementations MUST return a *meta.ErrNotFound error.
+	Archive(ctx context.Context, eventID string) ([]LogsArchiveFile, error)
+	// Search returns a LogSearchResultList, with its Items (LogSearchResults)
+	// ordered by time, newest first, of log entries written by any Worker or Job
+	// belonging to the specified Project. Criteria for which log entries should
+	// be retrieved are specified using the LogsSearchSelector parameter. Only
+	// the LogsSearchStore is searched, so the logs of Events that have been
+	// migrated to another store by a LogsMigrator are never included.
+	Search(
+		ctx context.Context,
+		projectID string,
+		selector LogsSearchSelector,
+		opts meta.ListOptions,
+	) (LogSearchResultList, error)
 }
 
 type logsService struct {
@@ -85,8 +289,10 @@ type logsService struct {
 	projectAuthorize ProjectAuthorizeFn
 	projectsStore    ProjectsStore
 	eventsStore      EventsStore
+	secretsStore     SecretsStore
 	warmLogsStore    LogsStore
 	coolLogsStore    LogsStore
+	logsSearchStore  LogsSearchStore
 }
 
 // NewLogsService returns a specialized interface for accessing logs.
@@ -95,16 +301,20 @@ func NewLogsService(
 	projectAuthorize ProjectAuthorizeFn,
 	projectsStore ProjectsStore,
 	eventsStore EventsStore,
+	secretsStore SecretsStore,
 	warmLogsStore LogsStore,
 	coolLogsStore LogsStore,
+	logsSearchStore LogsSearchStore,
 ) LogsService {
 	return &logsService{
 		authorize:        authorize,
 		projectAuthorize: projectAuthorize,
 		projectsStore:    projectsStore,
 		eventsStore:      eventsStore,
+		secretsStore:     secretsStore,
 		warmLogsStore:    warmLogsStore,
 		coolLogsStore:    coolLogsStore,
+		logsSearchStore:  logsSearchStore,
 	}
 }
 
@@ -115,13 +325,21 @@ func (l *logsService) Stream(
 	selector LogsSelector,
 	opts LogStreamOptions,
 ) (<-chan LogEntry, error) {
-	// Set defaults on the selector
-	if selector.Job == "" { // If a job isn't specified, then we want worker logs
+	if selector.All && (selector.Job != "" || selector.Container != "") {
+		return nil, &meta.ErrBadRequest{
+			Reason: "Logs from all containers may not be requested together with " +
+				"logs from a specific job or container.",
+		}
+	}
+
+	// Set defaults on the selector unless logs from all containers are wanted
+	if !selector.All && selector.Job == "" {
+		// If a job isn't specified, then we want worker logs
 		if selector.Container == "" {
 			// If a container isn't specified, we want the one named "worker"
 			selector.Container = myk8s.LabelKeyWorker
 		}
-	} else { // A job was specified, so we want job logs
+	} else if !selector.All { // A job was specified, so we want job logs
 		if selector.Container == "" {
 			// If a container isn't specified, we want the primary container's logs.
 			// The primary container has the same name as the job itself.
@@ -129,31 +347,43 @@ func (l *logsService) Stream(
 		}
 	}
 
+	if opts.TailLines < 0 {
+		return nil, &meta.ErrBadRequest{
+			Reason: "The number of lines to tail must not be negative.",
+		}
+	}
+	if opts.Stream != "" &&
+		opts.Stream != LogStreamStdout &&
+		opts.Stream != LogStreamStderr {
+		return nil, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf("Invalid log stream %q.", opts.Stream),
+		}
+	}
+	if opts.Since != nil && opts.Until != nil && !opts.Since.Before(*opts.Until) {
+		return nil, &meta.ErrBadRequest{
+			Reason: "The start of the requested time range must precede its end.",
+		}
+	}
+
 	event, err := l.eventsStore.Get(ctx, eventID)
 	if err != nil {
 		return nil,
 			errors.Wrapf(err, "error retrieving event %q from store", eventID)
 	}
 
-	// Throughout the service layer, we typically only require RoleReader() to
-	// authorize read-only operations of any kind. In the case of logs, however,
-	// there's just too much possibility of secrets bleeding into the logs, not
-	// due to any fault of Brigade's but because of some end-user misstep. So, out
-	// of an abundance of caution, we raise the bar a little on this one read-only
-	// operation and require the principal to be a project user in order to stream
-	// logs.
-	err = l.projectAuthorize(ctx, event.ProjectID, RoleProjectUser)
-	if err != nil {
-		// We also permit access by the event's worker
-		err = l.authorize(ctx, RoleWorker, event.ID)
+	if err = l.authorizeEventLogs(ctx, event); err != nil {
+		return nil, err
 	}
-	if err != nil {
-		// We also permit access by the creator of the event. This enables smarter
-		// gateways to send logs "upstream" if appropriate.
-		err = l.authorize(ctx, RoleEventCreator, event.Source)
+
+	// Only admins may see logs without the values of project secrets redacted
+	if opts.Unredacted {
+		if err = l.authorize(ctx, RoleAdmin, ""); err != nil {
+			return nil, err
+		}
 	}
-	if err != nil {
-		return nil, err
+
+	if selector.All {
+		return l.streamAll(ctx, event, opts)
 	}
 
 	var containerFound bool
@@ -188,6 +418,16 @@ func (l *logsService) Stream(
 			containerFound = job.Spec.PrimaryContainer.SourceMountPath != ""
 			if !containerFound {
 				// If we get to here, the primary container didn't use source, so check
+				// if any of the init containers do.
+				for _, initContainer := range job.Spec.InitContainers {
+					if initContainer.SourceMountPath != "" {
+						containerFound = true
+						break
+					}
+				}
+			}
+			if !containerFound {
+				// If we get to here, no init container used source either, so check
 				// if any of the sidecars do.
 				for _, containerSpec := range job.Spec.SidecarContainers {
 					if containerSpec.SourceMountPath != "" {
@@ -199,7 +439,14 @@ func (l *logsService) Stream(
 		} else {
 			// If we get to here, the container name didn't match the job name (which
 			// is also the name of the primary container) and it wasn't "vcs" either.
-			// Just loop through the sidecars to see if such a container exists.
+			// Just loop through the init containers and sidecars to see if such a
+			// container exists.
+			for _, initContainer := range job.Spec.InitContainers {
+				if initContainer.Name == selector.Container {
+					containerFound = true
+					break
+				}
+			}
 			for containerName := range job.Spec.SidecarContainers {
 				if containerName == selector.Container {
 					containerFound = true
@@ -250,6 +497,11 @@ func (l *logsService) Stream(
 			)
 	}
 
+	redactor, err := l.secretsRedactor(ctx, project, opts.Unredacted)
+	if err != nil {
+		return nil, err
+	}
+
 	// Wait for the target Worker or Job to move past PENDING and STARTING phases
 	if err = retries.ManageRetries(
 		ctx,
@@ -281,6 +533,22 @@ func (l *logsService) Stream(
 		return nil, err
 	}
 
+	logCh, err := l.streamFromStores(ctx, project, event, selector, opts)
+	if err != nil {
+		return nil, err
+	}
+	return parseLogEntries(ctx, redactLogEntries(ctx, logCh, redactor)), nil
+}
+
+// streamFromStores streams logs from the warmLogsStore, falling back to the
+// coolLogsStore if the warmLogsStore cannot find them.
+func (l *logsService) streamFromStores(
+	ctx context.Context,
+	project Project,
+	event Event,
+	selector LogsSelector,
+	opts LogStreamOptions,
+) (<-chan LogEntry, error) {
 	logCh, err := l.warmLogsStore.StreamLogs(ctx, project, event, selector, opts)
 	if err != nil {
 		// If the issue is simply that the warmLogsStore couldn't find the logs
@@ -294,6 +562,603 @@ func (l *logsService) Stream(
 	return logCh, err
 }
 
+// streamAll streams logs from every container of the Event's Worker and of
+// every Job that has started, tagging each entry with its Job and Container.
+// Unless following, entries are interleaved in chronological order. When
+// following, entries are sent as soon as they arrive from any container.
+func (l *logsService) streamAll(
+	ctx context.Context,
+	event Event,
+	opts LogStreamOptions,
+) (<-chan LogEntry, error) {
+	project, err := l.projectsStore.Get(ctx, event.ProjectID)
+	if err != nil {
+		return nil,
+			errors.Wrapf(
+				err,
+				"error retrieving project %q from store",
+				event.ProjectID,
+			)
+	}
+
+	redactor, err := l.secretsRedactor(ctx, project, opts.Unredacted)
+	if err != nil {
+		return nil, err
+	}
+
+	containerStreams, err := l.containerLogStreams(ctx, project, event, opts)
+	if err != nil {
+		return nil, err
+	}
+	logChs := make([]<-chan LogEntry, len(containerStreams))
+	for i, containerStream := range containerStreams {
+		logChs[i] =
+			tagLogEntries(ctx, containerStream.logCh, containerStream.selector)
+	}
+
+	var logCh <-chan LogEntry
+	if opts.Follow {
+		logCh = fanInLogEntries(ctx, logChs)
+	} else {
+		logCh = mergeLogEntries(ctx, logChs)
+	}
+	return parseLogEntries(ctx, redactLogEntries(ctx, logCh, redactor)), nil
+}
+
+func (l *logsService) Archive(
+	ctx context.Context,
+	eventID string,
+) ([]LogsArchiveFile, error) {
+	event, err := l.eventsStore.Get(ctx, eventID)
+	if err != nil {
+		return nil,
+			errors.Wrapf(err, "error retrieving event %q from store", eventID)
+	}
+
+	if err = l.authorizeEventLogs(ctx, event); err != nil {
+		return nil, err
+	}
+
+	project, err := l.projectsStore.Get(ctx, event.ProjectID)
+	if err != nil {
+		return nil,
+			errors.Wrapf(
+				err,
+				"error retrieving project %q from store",
+				event.ProjectID,
+			)
+	}
+
+	redactor, err := l.secretsRedactor(ctx, project, false)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := l.containerLogSources(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]LogsArchiveFile, len(sources))
+	for i := range sources {
+		source := sources[i]
+		files[i] = LogsArchiveFile{
+			Job:       source.selector.Job,
+			Container: source.selector.Container,
+			Open: func(ctx context.Context) (<-chan LogEntry, error) {
+				logCh, err := l.streamFromStores(
+					ctx,
+					project,
+					source.event,
+					source.selector,
+					LogStreamOptions{},
+				)
+				if err != nil {
+					return nil, err
+				}
+				return redactLogEntries(ctx, logCh, redactor), nil
+			},
+		}
+	}
+	return files, nil
+}
+
+func (l *logsService) Search(
+	ctx context.Context,
+	projectID string,
+	selector LogsSearchSelector,
+	opts meta.ListOptions,
+) (LogSearchResultList, error) {
+	if (selector.Text == "") == (selector.Regex == "") {
+		return LogSearchResultList{}, &meta.ErrBadRequest{
+			Reason: "Exactly one of text or a regular expression must be " +
+				"specified when searching logs.",
+		}
+	}
+	var regex *regexp.Regexp
+	if selector.Regex != "" {
+		var err error
+		if regex, err = regexp.Compile(selector.Regex); err != nil {
+			return LogSearchResultList{}, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					"Invalid regular expression %q: %s",
+					selector.Regex,
+					err,
+				),
+			}
+		}
+	}
+	if selector.WrittenSince != nil && selector.WrittenUntil != nil &&
+		!selector.WrittenSince.Before(*selector.WrittenUntil) {
+		return LogSearchResultList{}, &meta.ErrBadRequest{
+			Reason: "The start of the requested time range must precede its end.",
+		}
+	}
+
+	// As with streaming, the possibility of secrets bleeding into the logs means
+	// we require the principal to be a project user in order to search logs.
+	if err := l.projectAuthorize(ctx, projectID, RoleProjectUser); err != nil {
+		return LogSearchResultList{}, err
+	}
+
+	project, err := l.projectsStore.Get(ctx, projectID)
+	if err != nil {
+		return LogSearchResultList{}, errors.Wrapf(
+			err,
+			"error retrieving project %q from store",
+			projectID,
+		)
+	}
+
+	redactor, err := l.secretsRedactor(ctx, project, false)
+	if err != nil {
+		return LogSearchResultList{}, err
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	results, err := l.logsSearchStore.SearchLogs(ctx, projectID, selector, opts)
+	if err != nil {
+		return results, errors.Wrapf(
+			err,
+			"error searching logs of project %q in store",
+			projectID,
+		)
+	}
+	if redactor == nil {
+		return results, nil
+	}
+	// The store searched the logs as they were written, secrets and all. If
+	// lines that matched only because of a secret were returned, anyone able to
+	// search could confirm a secret's value one character at a time. So lines
+	// from which a secret was redacted are kept only if the redacted line still
+	// matches. This means a page may hold fewer results than the limit.
+	items := make([]LogSearchResult, 0, len(results.Items))
+	for _, item := range results.Items {
+		redacted := redactor.Replace(item.Message)
+		if redacted != item.Message &&
+			!logMessageMatches(redacted, selector.Text, regex) {
+			continue
+		}
+		item.Message = redacted
+		items = append(items, item)
+	}
+	results.Items = items
+	return results, nil
+}
+
+// logMessageMatches returns a bool indicating whether the provided log message
+// matches the provided regular expression or, if that is nil, the provided
+// text. Like the data store's full text search, text matches if the message
+// contains, regardless of case, every quoted phrase or, if there are none, any
+// one of the words of the text. Words preceded by a minus sign are excluded by
+// the data store and are ignored here. Word endings are not considered, so
+// this may reject a message the data store matched by stem.
+func logMessageMatches(
+	message string,
+	text string,
+	regex *regexp.Regexp,
+) bool {
+	if regex != nil {
+		return regex.MatchString(message)
+	}
+	message = strings.ToLower(message)
+	// Splitting on quotes leaves phrases at odd indices
+	parts := strings.Split(strings.ToLower(text), `"`)
+	var hasPhrases bool
+	for i := 1; i < len(parts); i += 2 {
+		if phrase := strings.TrimSpace(parts[i]); phrase != "" {
+			if !strings.Contains(message, phrase) {
+				return false
+			}
+			hasPhrases = true
+		}
+	}
+	if hasPhrases {
+		return true
+	}
+	for i := 0; i < len(parts); i += 2 {
+		for _, word := range strings.Fields(parts[i]) {
+			if !strings.HasPrefix(word, "-") && strings.Contains(message, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containerLogStream is a stream of the logs of a single container.
+type containerLogStream struct {
+	// selector identifies the Job, if any, and container that wrote the logs.
+	selector LogsSelector
+	logCh    <-chan LogEntry
+}
+
+// containerLogSource identifies a single container whose logs are to be
+// streamed and the Event they belong to.
+type containerLogSource struct {
+	// event is the Event the logs belong to. For a Job inherited from another
+	// Event, this is that other Event.
+	event Event
+	// selector identifies the Job, if any, and container that wrote the logs.
+	selector LogsSelector
+}
+
+// containerLogSources returns a containerLogSource for every container of the
+// Event's Worker and of every Job that has started. The logs of Jobs inherited
+// from another Event belong to that Event. Jobs inherited from an Event that no
+// longer exists are left out.
+func (l *logsService) containerLogSources(
+	ctx context.Context,
+	event Event,
+) ([]containerLogSource, error) {
+	sources := []containerLogSource{}
+
+	if event.Worker.Status.Phase != WorkerPhasePending &&
+		event.Worker.Status.Phase != WorkerPhaseStarting {
+		for _, container := range event.Worker.containerNames() {
+			sources = append(
+				sources,
+				containerLogSource{
+					event:    event,
+					selector: LogsSelector{Container: container},
+				},
+			)
+		}
+	}
+
+	for _, job := range event.Worker.Jobs {
+		if job.Status == nil ||
+			job.Status.Phase == JobPhasePending ||
+			job.Status.Phase == JobPhaseStarting {
+			continue
+		}
+		// Inherited jobs' logs belong to the event they were inherited from
+		logsEvent := event
+		if job.Status.LogsEventID != "" {
+			var err error
+			if logsEvent, err =
+				l.eventsStore.Get(ctx, job.Status.LogsEventID); err != nil {
+				if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+					continue
+				}
+				return nil, errors.Wrapf(
+					err,
+					"error retrieving logs for job %q",
+					job.Name,
+				)
+			}
+		}
+		for _, container := range job.containerNames() {
+			sources = append(
+				sources,
+				containerLogSource{
+					event: logsEvent,
+					selector: LogsSelector{
+						Job:       job.Name,
+						Container: container,
+					},
+				},
+			)
+		}
+	}
+
+	return sources, nil
+}
+
+// containerLogStreams opens a stream of the logs of every container of the
+// Event's Worker and of every Job that has started. The logs of Jobs inherited
+// from another Event are streamed from that Event. Containers that have no
+// logs are left out.
+func (l *logsService) containerLogStreams(
+	ctx context.Context,
+	project Project,
+	event Event,
+	opts LogStreamOptions,
+) ([]containerLogStream, error) {
+	sources, err := l.containerLogSources(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	containerStreams := []containerLogStream{}
+	for _, source := range sources {
+		logCh, err := l.streamFromStores(
+			ctx,
+			project,
+			source.event,
+			source.selector,
+			opts,
+		)
+		if err != nil {
+			// A container that has no logs is simply left out
+			if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+				continue
+			}
+			return nil, errors.Wrapf(
+				err,
+				"error streaming logs from container %q",
+				source.selector.Container,
+			)
+		}
+		containerStreams = append(
+			containerStreams,
+			containerLogStream{
+				selector: source.selector,
+				logCh:    logCh,
+			},
+		)
+	}
+	return containerStreams, nil
+}
+
+// authorizeEventLogs returns an error if the principal is not permitted to
+// access the specified Event's logs.
+func (l *logsService) authorizeEventLogs(
+	ctx context.Context,
+	event Event,
+) error {
+	// Throughout the service layer, we typically only require RoleReader() to
+	// authorize read-only operations of any kind. In the case of logs, however,
+	// there's just too much possibility of secrets bleeding into the logs, not
+	// due to any fault of Brigade's but because of some end-user misstep. So, out
+	// of an abundance of caution, we raise the bar a little on this one read-only
+	// operation and require the principal to be a project user in order to
+	// access logs.
+	err := l.projectAuthorize(ctx, event.ProjectID, RoleProjectUser)
+	if err != nil {
+		// We also permit access by the event's worker
+		err = l.authorize(ctx, RoleWorker, event.ID)
+	}
+	if err != nil {
+		// We also permit access by the creator of the event. This enables smarter
+		// gateways to send logs "upstream" if appropriate.
+		err = l.authorize(ctx, RoleEventCreator, event.Source)
+	}
+	return err
+}
+
+// secretsRedactor returns a *strings.Replacer that replaces any occurrence of
+// the values of the specified Project's Secrets with redactedSecret. Values
+// shorter than minRedactedSecretLength are not redacted. If unredacted is true
+// or the Project has no Secrets long enough to redact, nil is returned.
+func (l *logsService) secretsRedactor(
+	ctx context.Context,
+	project Project,
+	unredacted bool,
+) (*strings.Replacer, error) {
+	if unredacted {
+		return nil, nil
+	}
+	values, err := l.secretsStore.Values(ctx, project)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error retrieving secrets for project %q from store",
+			project.ID,
+		)
+	}
+	// Each log entry is a single line, so each line of a multi-line value (e.g.
+	// a private key) is redacted on its own.
+	toRedact := []string{}
+	for _, value := range values {
+		for _, line := range strings.Split(value, "\n") {
+			if line = strings.TrimSpace(line); len(line) >= minRedactedSecretLength {
+				toRedact = append(toRedact, line)
+			}
+		}
+	}
+	if len(toRedact) == 0 {
+		return nil, nil
+	}
+	// The replacer tries values in the order they're given, so longer values go
+	// first. This way, a value that contains another is redacted in its
+	// entirety.
+	sort.Slice(toRedact, func(i, j int) bool {
+		return len(toRedact[i]) > len(toRedact[j])
+	})
+	oldNew := make([]string, 0, 2*len(toRedact))
+	for _, value := range toRedact {
+		oldNew = append(oldNew, value, redactedSecret)
+	}
+	return strings.NewReplacer(oldNew...), nil
+}
+
+// redactLogEntries returns a channel over which all entries received from the
+// provided channel are sent after the provided redactor has been applied to
+// their messages. If the redactor is nil, the provided channel is returned.
+func redactLogEntries(
+	ctx context.Context,
+	logCh <-chan LogEntry,
+	redactor *strings.Replacer,
+) <-chan LogEntry {
+	if redactor == nil {
+		return logCh
+	}
+	redactedCh := make(chan LogEntry)
+	go func() {
+		defer close(redactedCh)
+		for logEntry := range logCh {
+			logEntry.Message = redactor.Replace(logEntry.Message)
+			select {
+			case redactedCh <- logEntry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return redactedCh
+}
+
+// parseLogEntries returns a channel over which all entries received from the
+// provided channel are sent after the level and fields of any that are
+// structured (JSON) log lines have been extracted from their messages.
+func parseLogEntries(
+	ctx context.Context,
+	logCh <-chan LogEntry,
+) <-chan LogEntry {
+	parsedCh := make(chan LogEntry)
+	go func() {
+		defer close(parsedCh)
+		for logEntry := range logCh {
+			parseLogEntry(&logEntry)
+			select {
+			case parsedCh <- logEntry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return parsedCh
+}
+
+// parseLogEntry sets the Level and Fields of the provided LogEntry if its
+// Message is a JSON object. Otherwise, the LogEntry is left unchanged.
+func parseLogEntry(logEntry *LogEntry) {
+	message := strings.TrimSpace(logEntry.Message)
+	if !strings.HasPrefix(message, "{") {
+		return
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(message), &fields); err != nil {
+		return
+	}
+	for _, key := range logLevelKeys {
+		if level, ok := fields[key].(string); ok {
+			logEntry.Level = strings.ToLower(level)
+			delete(fields, key)
+			break
+		}
+	}
+	if len(fields) > 0 {
+		logEntry.Fields = fields
+	}
+}
+
+// tagLogEntries returns a channel over which all entries received from the
+// provided channel are sent after being tagged with the Job and Container
+// specified by the provided selector.
+func tagLogEntries(
+	ctx context.Context,
+	logCh <-chan LogEntry,
+	selector LogsSelector,
+) <-chan LogEntry {
+	taggedCh := make(chan LogEntry)
+	go func() {
+		defer close(taggedCh)
+		for logEntry := range logCh {
+			logEntry.Job = selector.Job
+			logEntry.Container = selector.Container
+			select {
+			case taggedCh <- logEntry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return taggedCh
+}
+
+// fanInLogEntries returns a channel over which all entries received from any
+// of the provided channels are sent in the order they arrive.
+func fanInLogEntries(
+	ctx context.Context,
+	logChs []<-chan LogEntry,
+) <-chan LogEntry {
+	mergedCh := make(chan LogEntry)
+	wg := sync.WaitGroup{}
+	wg.Add(len(logChs))
+	for _, logCh := range logChs {
+		go func(logCh <-chan LogEntry) {
+			defer wg.Done()
+			for logEntry := range logCh {
+				select {
+				case mergedCh <- logEntry:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(logCh)
+	}
+	go func() {
+		wg.Wait()
+		close(mergedCh)
+	}()
+	return mergedCh
+}
+
+// mergeLogEntries returns a channel over which all entries received from the
+// provided channels are sent in chronological order. Each of the provided
+// channels must itself deliver entries in chronological order and must
+// eventually be closed. Entries without a time are sent as soon as they are
+// received.
+func mergeLogEntries(
+	ctx context.Context,
+	logChs []<-chan LogEntry,
+) <-chan LogEntry {
+	mergedCh := make(chan LogEntry)
+	go func() {
+		defer close(mergedCh)
+		// heads holds the next entry from each channel that has not yet been sent
+		heads := make([]*LogEntry, len(logChs))
+		next := func(i int) {
+			if logEntry, ok := <-logChs[i]; ok {
+				heads[i] = &logEntry
+			} else {
+				heads[i] = nil
+			}
+		}
+		for i := range logChs {
+			next(i)
+		}
+		for {
+			earliest := -1
+			for i, head := range heads {
+				if head == nil {
+					continue
+				}
+				if earliest < 0 || head.Time == nil ||
+					(heads[earliest].Time != nil &&
+						head.Time.Before(*heads[earliest].Time)) {
+					earliest = i
+				}
+				if head.Time == nil {
+					break
+				}
+			}
+			if earliest < 0 {
+				return
+			}
+			select {
+			case mergedCh <- *heads[earliest]:
+			case <-ctx.Done():
+				return
+			}
+			next(earliest)
+		}
+	}()
+	return mergedCh
+}
+
 // LogsStore is an interface for components that implement Log persistence
 // concerns.
 type LogsStore interface {
@@ -325,3 +1190,18 @@ type CoolLogsStore interface {
 	// DeleteProjectLogs deletes all logs associated with the provided project.
 	DeleteProjectLogs(ctx context.Context, id string) error
 }
+
+// LogsSearchStore is an interface for components that implement searching of
+// persisted logs.
+type LogsSearchStore interface {
+	// SearchLogs retrieves a LogSearchResultList from the underlying data store,
+	// with its Items (LogSearchResults) ordered by time, newest first. Only log
+	// entries belonging to the specified Project and matching the provided
+	// LogsSearchSelector are included.
+	SearchLogs(
+		ctx context.Context,
+		projectID string,
+		selector LogsSearchSelector,
+		opts meta.ListOptions,
+	) (LogSearchResultList, error)
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LogsExportStore is an interface for "cool" log stores from which the logs of
+// individual containers can be exported so that they may be migrated to
+// another store.
+type LogsExportStore interface {
+	CoolLogsStore
+
+	// ExportLogs invokes the provided function once for each log entry written
+	// by the container specified by the Event and LogsSelector, in the order the
+	// entries were written. Exporting stops at the first error, whether it was
+	// encountered while retrieving log entries or returned by the provided
+	// function, and that error is returned.
+	ExportLogs(
+		ctx context.Context,
+		event Event,
+		selector LogsSelector,
+		fn func(LogEntry) error,
+	) error
+}
+
+// LogsWriter is an interface for components that write the logs of a single
+// container to a LogsImportStore.
+type LogsWriter interface {
+	// Write writes the provided log entry.
+	Write(LogEntry) error
+	// Close writes any buffered log entries and records that all of the
+	// container's logs have been written. Logs for which Close has not been
+	// invoked may be incomplete and implementations MUST NOT treat them as
+	// authoritative.
+	Close() error
+}
+
+// LogsImportStore is an interface for "cool" log stores that logs can be
+// migrated into from a LogsExportStore.
+type LogsImportStore interface {
+	CoolLogsStore
+
+	// HasEventLogs returns a bool indicating whether the import of all the
+	// specified Event's logs has been completed.
+	HasEventLogs(ctx context.Context, eventID string) (bool, error)
+	// ImportLogs returns a LogsWriter for importing the logs of the container
+	// specified by the Event and LogsSelector. Any previously, partially
+	// imported logs for the same container are discarded, so callers MUST NOT
+	// import the logs of the same container concurrently.
+	ImportLogs(
+		ctx context.Context,
+		event Event,
+		selector LogsSelector,
+	) (LogsWriter, error)
+	// CompleteEventLogs records that the import of all the specified Event's
+	// logs has been completed.
+	CompleteEventLogs(ctx context.Context, event Event) error
+}
+
+// LogsMigratorConfig encapsulates configuration for the LogsMigrator.
+type LogsMigratorConfig struct {
+	// Interval specifies how frequently logs are migrated.
+	Interval time.Duration
+	// GracePeriod specifies how long after an Event's Worker reaches a terminal
+	// phase its logs are migrated. This allows time for the last of the Event's
+	// log entries to be forwarded to the source store. Since logs are removed
+	// from the source store once migrated and only the source store can be
+	// searched, this also determines how long an Event's logs remain
+	// searchable.
+	GracePeriod time.Duration
+	// LeaseDuration specifies how long a LogsMigrator's claim on an Event lasts.
+	// Every API server replica runs a LogsMigrator, and the claim keeps any
+	// other from migrating the same Event's logs at the same time. The claim is
+	// renewed before each of the Event's containers is migrated, so this only
+	// needs to exceed the time it takes to migrate a single container's logs.
+	LeaseDuration time.Duration
+}
+
+// LogsMigrator is an interface for a component that moves the logs of Events
+// whose Workers have reached a terminal phase from one "cool" log store to
+// another.
+type LogsMigrator interface {
+	// Run periodically migrates logs until the provided context is canceled.
+	Run(context.Context)
+	// Migrate migrates the logs of all eligible Events once and returns the
+	// number of Events whose logs were migrated.
+	Migrate(context.Context) (int, error)
+}
+
+type logsMigrator struct {
+	eventsStore EventsStore
+	source      LogsExportStore
+	destination LogsImportStore
+	config      LogsMigratorConfig
+	// claimant uniquely identifies this LogsMigrator when claiming Events.
+	claimant string
+}
+
+// NewLogsMigrator returns an implementation of the LogsMigrator interface that
+// migrates logs from the provided LogsExportStore to the provided
+// LogsImportStore.
+func NewLogsMigrator(
+	eventsStore EventsStore,
+	source LogsExportStore,
+	destination LogsImportStore,
+	config LogsMigratorConfig,
+) LogsMigrator {
+	return &logsMigrator{
+		eventsStore: eventsStore,
+		source:      source,
+		destination: destination,
+		config:      config,
+		claimant:    uuid.NewV4().String(),
+	}
+}
+
+func (l *logsMigrator) Run(ctx context.Context) {
+	ticker := time.NewTicker(l.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := l.Migrate(ctx)
+			if err != nil {
+				log.Println(errors.Wrap(err, "error migrating logs"))
+			}
+			if count > 0 {
+				log.Printf("migrated logs for %d events", count)
+			}
+		}
+	}
+}
+
+func (l *logsMigrator) Migrate(ctx context.Context) (int, error) {
+	var count int
+	cutoff := time.Now().UTC().Add(-l.config.GracePeriod)
+	for {
+		// Events whose logs are migrated are marked as such and Events that could
+		// not be claimed or migrated remain claimed until their claims expire, so
+		// neither is listed again and this terminates.
+		events, err :=
+			l.eventsStore.ListLogsMigrationCandidates(ctx, cutoff, 100)
+		if err != nil {
+			return count, errors.Wrap(err, "error retrieving events from store")
+		}
+		if len(events) == 0 {
+			return count, nil
+		}
+		for _, event := range events {
+			// A problem with one Event's logs shouldn't hold up the migration of
+			// every other Event's logs, so errors are only logged.
+			migrated, err := l.migrateEvent(ctx, event)
+			if err != nil {
+				log.Println(err)
+			} else if migrated {
+				count++
+			}
+		}
+	}
+}
+
+// migrateEvent claims the provided Event and migrates all its logs, unless
+// another LogsMigrator has claimed it. It returns a bool indicating whether
+// logs were migrated.
+func (l *logsMigrator) migrateEvent(
+	ctx context.Context,
+	event Event,
+) (bool, error) {
+	claimed, err := l.claim(ctx, event)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	// The logs may already have been imported by an attempt that failed
+	// afterwards, or by a version of Brigade that did not mark migrated Events.
+	imported, err := l.destination.HasEventLogs(ctx, event.ID)
+	if err != nil {
+		return false, errors.Wrapf(
+			err,
+			"error checking whether logs for event %q were migrated",
+			event.ID,
+		)
+	}
+
+	if !imported {
+		selectors := []LogsSelector{}
+		if event.Worker.Status.Started != nil {
+			for _, container := range event.Worker.containerNames() {
+				selectors = append(selectors, LogsSelector{Container: container})
+			}
+		}
+		for _, job := range event.Worker.Jobs {
+			// Inherited jobs' logs belong to the event they were inherited from and
+			// are migrated along with that event's logs.
+			if job.Status == nil ||
+				job.Status.Phase == JobPhasePending ||
+				job.Status.Phase == JobPhaseStarting ||
+				job.Status.LogsEventID != "" {
+				continue
+			}
+			for _, container := range job.containerNames() {
+				selectors = append(
+					selectors,
+					LogsSelector{
+						Job:       job.Name,
+						Container: container,
+					},
+				)
+			}
+		}
+
+		for _, selector := range selectors {
+			// Renewing the claim before each container keeps it from expiring while
+			// a large Event is migrated.
+			if claimed, err = l.claim(ctx, event); err != nil {
+				return false, err
+			}
+			if !claimed {
+				return false, errors.Errorf(
+					"lost claim on event %q while migrating its logs",
+					event.ID,
+				)
+			}
+			if err = l.migrateContainer(ctx, event, selector); err != nil {
+				return false, err
+			}
+		}
+		if err = l.destination.CompleteEventLogs(ctx, event); err != nil {
+			return false, errors.Wrapf(
+				err,
+				"error completing migration of logs for event %q",
+				event.ID,
+			)
+		}
+	}
+
+	// Only now that the logs are safely in the destination store can they be
+	// deleted from the source store.
+	if err = l.source.DeleteEventLogs(ctx, event.ID); err != nil {
+		return false, errors.Wrapf(
+			err,
+			"error deleting migrated logs for event %q from source store",
+			event.ID,
+		)
+	}
+	if err = l.eventsStore.MarkLogsMigrated(ctx, event.ID); err != nil {
+		return false, err
+	}
+	return !imported, nil
+}
+
+// claim claims, or renews this LogsMigrator's claim on, the provided Event.
+// It returns a bool indicating whether the Event is claimed by this
+// LogsMigrator.
+func (l *logsMigrator) claim(ctx context.Context, event Event) (bool, error) {
+	claimed, err := l.eventsStore.ClaimLogsMigration(
+		ctx,
+		event.ID,
+		l.claimant,
+		time.Now().UTC().Add(l.config.LeaseDuration),
+	)
+	return claimed, errors.Wrapf(
+		err,
+		"error claiming event %q for logs migration",
+		event.ID,
+	)
+}
+
+// migrateContainer migrates the logs of the container specified by the
+// provided Event and LogsSelector.
+func (l *logsMigrator) migrateContainer(
+	ctx context.Context,
+	event Event,
+	selector LogsSelector,
+) error {
+	writer, err := l.destination.ImportLogs(ctx, event, selector)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error importing logs for event %q job %q container %q",
+			event.ID,
+			selector.Job,
+			selector.Container,
+		)
+	}
+	if err = l.source.ExportLogs(ctx, event, selector, writer.Write); err != nil {
+		return errors.Wrapf(
+			err,
+			"error exporting logs for event %q job %q container %q",
+			event.ID,
+			selector.Job,
+			selector.Container,
+		)
+	}
+	return errors.Wrapf(
+		writer.Close(),
+		"error importing logs for event %q job %q container %q",
+		event.ID,
+		selector.Job,
+		selector.Container,
+	)
+}
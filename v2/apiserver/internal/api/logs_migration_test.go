@@ -0,0 +1,417 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogsMigrator(t *testing.T) {
+	eventsStore := &mockEventsStore{}
+	source := &mockLogsExportStore{}
+	destination := &mockLogsImportStore{}
+	config := LogsMigratorConfig{
+		Interval:      time.Minute,
+		GracePeriod:   time.Minute,
+		LeaseDuration: time.Minute,
+	}
+	migrator, ok := NewLogsMigrator(
+		eventsStore,
+		source,
+		destination,
+		config,
+	).(*logsMigrator)
+	require.True(t, ok)
+	require.Same(t, eventsStore, migrator.eventsStore)
+	require.Same(t, source, migrator.source)
+	require.Same(t, destination, migrator.destination)
+	require.Equal(t, config, migrator.config)
+	require.NotEmpty(t, migrator.claimant)
+}
+
+func TestLogsMigratorMigrate(t *testing.T) {
+	longAgo := time.Now().UTC().Add(-time.Hour)
+	// listOnce returns a function that lists the provided Events the first time
+	// it is invoked and no Events thereafter, as the store would once the Events
+	// are migrated or claimed.
+	listOnce := func(
+		events ...Event,
+	) func(context.Context, time.Time, int64) ([]Event, error) {
+		return func(context.Context, time.Time, int64) ([]Event, error) {
+			defer func() { events = nil }()
+			return events, nil
+		}
+	}
+	testEvent := Event{
+		ObjectMeta: meta.ObjectMeta{ID: "tony"},
+		Worker: Worker{
+			Spec: WorkerSpec{Git: &GitConfig{}},
+			Status: WorkerStatus{
+				Started: &longAgo,
+				Ended:   &longAgo,
+			},
+			Jobs: []Job{
+				{
+					Name:   "foo",
+					Status: &JobStatus{Phase: JobPhaseSucceeded},
+				},
+				{
+					// Never started
+					Name:   "bar",
+					Status: &JobStatus{Phase: JobPhasePending},
+				},
+				{
+					// Inherited from another event
+					Name: "baz",
+					Status: &JobStatus{
+						Phase:       JobPhaseSucceeded,
+						LogsEventID: "carmela",
+					},
+				},
+			},
+		},
+	}
+	alwaysClaim := func(
+		context.Context,
+		string,
+		string,
+		time.Time,
+	) (bool, error) {
+		return true, nil
+	}
+	type record struct {
+		imported []LogsSelector
+		deleted  []string
+		marked   []string
+	}
+	testCases := []struct {
+		name       string
+		migrator   func(*record) *logsMigrator
+		assertions func(count int, err error, r record)
+	}{
+		{
+			name: "error listing events",
+			migrator: func(*record) *logsMigrator {
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: func(
+							context.Context,
+							time.Time,
+							int64,
+						) ([]Event, error) {
+							return nil, errors.New("something went wrong")
+						},
+					},
+				}
+			},
+			assertions: func(_ int, err error, _ record) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error retrieving events")
+			},
+		},
+		{
+			name: "event claimed by another migrator",
+			migrator: func(*record) *logsMigrator {
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: listOnce(testEvent),
+						ClaimLogsMigrationFn: func(
+							context.Context,
+							string,
+							string,
+							time.Time,
+						) (bool, error) {
+							return false, nil
+						},
+					},
+				}
+			},
+			assertions: func(count int, err error, r record) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				require.Empty(t, r.deleted)
+				require.Empty(t, r.marked)
+			},
+		},
+		{
+			name: "logs already imported",
+			migrator: func(r *record) *logsMigrator {
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: listOnce(testEvent),
+						ClaimLogsMigrationFn:          alwaysClaim,
+						MarkLogsMigratedFn: func(_ context.Context, id string) error {
+							r.marked = append(r.marked, id)
+							return nil
+						},
+					},
+					source: &mockLogsExportStore{
+						mockLogsStore: mockLogsStore{
+							DeleteEventLogsFn: func(_ context.Context, id string) error {
+								r.deleted = append(r.deleted, id)
+								return nil
+							},
+						},
+					},
+					destination: &mockLogsImportStore{
+						HasEventLogsFn: func(context.Context, string) (bool, error) {
+							return true, nil
+						},
+					},
+				}
+			},
+			assertions: func(count int, err error, r record) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				// The event should have been tidied up without importing again
+				require.Empty(t, r.imported)
+				require.Equal(t, []string{"tony"}, r.deleted)
+				require.Equal(t, []string{"tony"}, r.marked)
+			},
+		},
+		{
+			name: "error exporting logs",
+			migrator: func(r *record) *logsMigrator {
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: listOnce(testEvent),
+						ClaimLogsMigrationFn:          alwaysClaim,
+					},
+					source: &mockLogsExportStore{
+						ExportLogsFn: func(
+							context.Context,
+							Event,
+							LogsSelector,
+							func(LogEntry) error,
+						) error {
+							return errors.New("something went wrong")
+						},
+					},
+					destination: &mockLogsImportStore{
+						HasEventLogsFn: func(context.Context, string) (bool, error) {
+							return false, nil
+						},
+						ImportLogsFn: func(
+							context.Context,
+							Event,
+							LogsSelector,
+						) (LogsWriter, error) {
+							return &mockLogsWriter{}, nil
+						},
+					},
+				}
+			},
+			assertions: func(count int, err error, r record) {
+				// The error is only logged
+				require.NoError(t, err)
+				require.Zero(t, count)
+				// Logs that weren't migrated must not be deleted
+				require.Empty(t, r.deleted)
+				require.Empty(t, r.marked)
+			},
+		},
+		{
+			name: "claim lost while migrating",
+			migrator: func(r *record) *logsMigrator {
+				var claims int
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: listOnce(testEvent),
+						ClaimLogsMigrationFn: func(
+							context.Context,
+							string,
+							string,
+							time.Time,
+						) (bool, error) {
+							claims++
+							return claims < 3, nil
+						},
+					},
+					source: &mockLogsExportStore{
+						ExportLogsFn: func(
+							context.Context,
+							Event,
+							LogsSelector,
+							func(LogEntry) error,
+						) error {
+							return nil
+						},
+					},
+					destination: &mockLogsImportStore{
+						HasEventLogsFn: func(context.Context, string) (bool, error) {
+							return false, nil
+						},
+						ImportLogsFn: func(
+							_ context.Context,
+							_ Event,
+							selector LogsSelector,
+						) (LogsWriter, error) {
+							r.imported = append(r.imported, selector)
+							return &mockLogsWriter{}, nil
+						},
+					},
+				}
+			},
+			assertions: func(count int, err error, r record) {
+				require.NoError(t, err)
+				require.Zero(t, count)
+				// Migration should have stopped after the first container
+				require.Equal(t, []LogsSelector{{Container: "vcs"}}, r.imported)
+				require.Empty(t, r.deleted)
+				require.Empty(t, r.marked)
+			},
+		},
+		{
+			name: "success",
+			migrator: func(r *record) *logsMigrator {
+				now := time.Now().UTC()
+				return &logsMigrator{
+					eventsStore: &mockEventsStore{
+						ListLogsMigrationCandidatesFn: listOnce(testEvent),
+						ClaimLogsMigrationFn: func(
+							_ context.Context,
+							id string,
+							claimant string,
+							until time.Time,
+						) (bool, error) {
+							require.Equal(t, "tony", id)
+							require.Equal(t, "tester", claimant)
+							require.True(t, until.After(time.Now()))
+							return true, nil
+						},
+						MarkLogsMigratedFn: func(_ context.Context, id string) error {
+							r.marked = append(r.marked, id)
+							return nil
+						},
+					},
+					source: &mockLogsExportStore{
+						ExportLogsFn: func(
+							_ context.Context,
+							_ Event,
+							_ LogsSelector,
+							fn func(LogEntry) error,
+						) error {
+							return fn(LogEntry{Time: &now, Message: "hello"})
+						},
+						mockLogsStore: mockLogsStore{
+							DeleteEventLogsFn: func(_ context.Context, id string) error {
+								r.deleted = append(r.deleted, id)
+								return nil
+							},
+						},
+					},
+					destination: &mockLogsImportStore{
+						HasEventLogsFn: func(context.Context, string) (bool, error) {
+							return false, nil
+						},
+						ImportLogsFn: func(
+							_ context.Context,
+							_ Event,
+							selector LogsSelector,
+						) (LogsWriter, error) {
+							r.imported = append(r.imported, selector)
+							return &mockLogsWriter{}, nil
+						},
+						CompleteEventLogsFn: func(context.Context, Event) error {
+							return nil
+						},
+					},
+					config:   LogsMigratorConfig{LeaseDuration: time.Minute},
+					claimant: "tester",
+				}
+			},
+			assertions: func(count int, err error, r record) {
+				require.NoError(t, err)
+				require.Equal(t, 1, count)
+				require.Equal(
+					t,
+					[]LogsSelector{
+						{Container: "vcs"},
+						{Container: "worker"},
+						{Job: "foo", Container: "foo"},
+					},
+					r.imported,
+				)
+				require.Equal(t, []string{"tony"}, r.deleted)
+				require.Equal(t, []string{"tony"}, r.marked)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := record{}
+			count, err := testCase.migrator(&r).Migrate(context.Background())
+			testCase.assertions(count, err, r)
+		})
+	}
+}
+
+type mockLogsExportStore struct {
+	mockLogsStore
+	ExportLogsFn func(
+		ctx context.Context,
+		event Event,
+		selector LogsSelector,
+		fn func(LogEntry) error,
+	) error
+}
+
+func (m *mockLogsExportStore) ExportLogs(
+	ctx context.Context,
+	event Event,
+	selector LogsSelector,
+	fn func(LogEntry) error,
+) error {
+	return m.ExportLogsFn(ctx, event, selector, fn)
+}
+
+type mockLogsImportStore struct {
+	mockLogsStore
+	HasEventLogsFn func(ctx context.Context, eventID string) (bool, error)
+	ImportLogsFn   func(
+		ctx context.Context,
+		event Event,
+		selector LogsSelector,
+	) (LogsWriter, error)
+	CompleteEventLogsFn func(ctx context.Context, event Event) error
+}
+
+func (m *mockLogsImportStore) HasEventLogs(
+	ctx context.Context,
+	eventID string,
+) (bool, error) {
+	return m.HasEventLogsFn(ctx, eventID)
+}
+
+func (m *mockLogsImportStore) ImportLogs(
+	ctx context.Context,
+	event Event,
+	selector LogsSelector,
+) (LogsWriter, error) {
+	return m.ImportLogsFn(ctx, event, selector)
+}
+
+func (m *mockLogsImportStore) CompleteEventLogs(
+	ctx context.Context,
+	event Event,
+) error {
+	return m.CompleteEventLogsFn(ctx, event)
+}
+
+type mockLogsWriter struct {
+	entries []LogEntry
+}
+
+func (m *mockLogsWriter) Write(logEntry LogEntry) error {
+	m.entries = append(m.entries, logEntry)
+	return nil
+}
+
+func (m *mockLogsWriter) Close() error {
+	return nil
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
@@ -14,35 +17,101 @@ func TestLogEntryMarshalJSON(t *testing.T) {
 	metaTesting.RequireAPIVersionAndType(t, &LogEntry{}, "LogEntry")
 }
 
+func TestLogSearchResultListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(
+		t,
+		&LogSearchResultList{},
+		"LogSearchResultList",
+	)
+}
+
 func TestLogsService(t *testing.T) {
 	projectsStore := &mockProjectsStore{}
 	eventsStore := &mockEventsStore{}
+	secretsStore := &mockSecretsStore{}
 	warmLogsStore := &mockLogsStore{}
 	coolLogsStore := &mockLogsStore{}
+	logsSearchStore := &mockLogsSearchStore{}
 	svc, ok := NewLogsService(
 		alwaysAuthorize,
 		alwaysProjectAuthorize,
 		projectsStore,
 		eventsStore,
+		secretsStore,
 		warmLogsStore,
 		coolLogsStore,
+		logsSearchStore,
 	).(*logsService)
 	require.True(t, ok)
 	require.NotNil(t, svc.projectAuthorize)
 	require.Same(t, projectsStore, svc.projectsStore)
 	require.Same(t, eventsStore, svc.eventsStore)
+	require.Same(t, secretsStore, svc.secretsStore)
 	require.Same(t, warmLogsStore, svc.warmLogsStore)
 	require.Same(t, coolLogsStore, svc.coolLogsStore)
+	require.Same(t, logsSearchStore, svc.logsSearchStore)
 }
 
 func TestLogsServiceStream(t *testing.T) {
 	const testEventID = "123456789"
+	testTime := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name       string
 		service    LogsService
 		selector   LogsSelector
+		opts       LogStreamOptions
 		assertions func(<-chan LogEntry, error)
 	}{
+		{
+			name: "all containers with specific job",
+			selector: LogsSelector{
+				All: true,
+				Job: "foo",
+			},
+			service: &logsService{},
+			assertions: func(_ <-chan LogEntry, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "may not be requested together")
+			},
+		},
+		{
+			name:    "negative tail lines",
+			service: &logsService{},
+			opts: LogStreamOptions{
+				TailLines: -1,
+			},
+			assertions: func(_ <-chan LogEntry, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "must not be negative")
+			},
+		},
+		{
+			name:    "invalid stream",
+			service: &logsService{},
+			opts: LogStreamOptions{
+				Stream: "stdin",
+			},
+			assertions: func(_ <-chan LogEntry, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "Invalid log stream")
+			},
+		},
+		{
+			name:    "since not before until",
+			service: &logsService{},
+			opts: LogStreamOptions{
+				Since: &testTime,
+				Until: &testTime,
+			},
+			assertions: func(_ <-chan LogEntry, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "must precede its end")
+			},
+		},
 		{
 			name:     "error retrieving event from store",
 			selector: LogsSelector{},
@@ -264,6 +333,11 @@ func TestLogsServiceStream(t *testing.T) {
 						return Project{}, nil
 					},
 				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return nil, nil
+					},
+				},
 				warmLogsStore: &mockLogsStore{
 					StreamLogsFn: func(
 						context.Context,
@@ -308,6 +382,11 @@ func TestLogsServiceStream(t *testing.T) {
 						return Project{}, nil
 					},
 				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return nil, nil
+					},
+				},
 				warmLogsStore: &mockLogsStore{
 					StreamLogsFn: func(
 						context.Context,
@@ -340,6 +419,11 @@ func TestLogsServiceStream(t *testing.T) {
 						return Project{}, nil
 					},
 				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return nil, nil
+					},
+				},
 				warmLogsStore: &mockLogsStore{
 					StreamLogsFn: func(
 						context.Context,
@@ -384,6 +468,11 @@ func TestLogsServiceStream(t *testing.T) {
 						return Project{}, nil
 					},
 				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return nil, nil
+					},
+				},
 				warmLogsStore: &mockLogsStore{
 					StreamLogsFn: func(
 						context.Context,
@@ -419,13 +508,751 @@ func TestLogsServiceStream(t *testing.T) {
 				context.Background(),
 				testEventID,
 				testCase.selector,
-				LogStreamOptions{},
+				testCase.opts,
 			)
 			testCase.assertions(logCh, err)
 		})
 	}
 }
 
+func TestLogsServiceStreamRedaction(t *testing.T) {
+	newService := func(
+		authorize AuthorizeFn,
+		valuesFn func(context.Context, Project) ([]string, error),
+	) *logsService {
+		return &logsService{
+			authorize:        authorize,
+			projectAuthorize: alwaysProjectAuthorize,
+			eventsStore: &mockEventsStore{
+				GetFn: func(context.Context, string) (Event, error) {
+					return Event{}, nil
+				},
+			},
+			projectsStore: &mockProjectsStore{
+				GetFn: func(context.Context, string) (Project, error) {
+					return Project{}, nil
+				},
+			},
+			secretsStore: &mockSecretsStore{
+				ValuesFn: valuesFn,
+			},
+			warmLogsStore: &mockLogsStore{
+				StreamLogsFn: func(
+					context.Context,
+					Project,
+					Event,
+					LogsSelector,
+					LogStreamOptions,
+				) (<-chan LogEntry, error) {
+					logCh := make(chan LogEntry, 2)
+					logCh <- LogEntry{Message: "password is hunter2, token is abc123"}
+					logCh <- LogEntry{Message: "-----BEGIN KEY----- MIIEow"}
+					close(logCh)
+					return logCh, nil
+				},
+			},
+		}
+	}
+	secretValues := func(context.Context, Project) ([]string, error) {
+		return []string{
+			"hunter2",
+			"abc",
+			"abc123",
+			// Too short to redact
+			"ok",
+			"-----BEGIN KEY-----\nMIIEow\n-----END KEY-----\n",
+			"",
+		}, nil
+	}
+	readMessages := func(logCh <-chan LogEntry) []string {
+		messages := []string{}
+		for logEntry := range logCh {
+			messages = append(messages, logEntry.Message)
+		}
+		return messages
+	}
+
+	t.Run("error retrieving secret values", func(t *testing.T) {
+		_, err := newService(
+			alwaysAuthorize,
+			func(context.Context, Project) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+		).Stream(
+			context.Background(),
+			"123456789",
+			LogsSelector{},
+			LogStreamOptions{},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "something went wrong")
+		require.Contains(t, err.Error(), "error retrieving secrets")
+	})
+
+	t.Run("secret values are redacted", func(t *testing.T) {
+		logCh, err := newService(alwaysAuthorize, secretValues).Stream(
+			context.Background(),
+			"123456789",
+			LogsSelector{},
+			LogStreamOptions{},
+		)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]string{
+				"password is ***, token is ***",
+				"*** ***",
+			},
+			readMessages(logCh),
+		)
+	})
+
+	t.Run("unredacted logs requested by non-admin", func(t *testing.T) {
+		_, err := newService(neverAuthorize, secretValues).Stream(
+			context.Background(),
+			"123456789",
+			LogsSelector{},
+			LogStreamOptions{Unredacted: true},
+		)
+		require.IsType(t, &meta.ErrAuthorization{}, err)
+	})
+
+	t.Run("unredacted logs requested by admin", func(t *testing.T) {
+		logCh, err := newService(alwaysAuthorize, secretValues).Stream(
+			context.Background(),
+			"123456789",
+			LogsSelector{},
+			LogStreamOptions{Unredacted: true},
+		)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]string{
+				"password is hunter2, token is abc123",
+				"-----BEGIN KEY----- MIIEow",
+			},
+			readMessages(logCh),
+		)
+	})
+}
+
+func TestLogsServiceStreamAll(t *testing.T) {
+	t0 := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+	t2 := t0.Add(2 * time.Second)
+	t3 := t0.Add(3 * time.Second)
+	logEntries := map[string][]LogEntry{
+		"/worker": {
+			{Time: &t0, Message: "worker started"},
+			{Time: &t3, Message: "worker done"},
+		},
+		"foo/foo": {
+			{Time: &t1, Message: "foo running"},
+		},
+		"foo/bar": {
+			{Time: &t2, Message: "bar sidecar running"},
+		},
+	}
+	svc := &logsService{
+		projectAuthorize: alwaysProjectAuthorize,
+		eventsStore: &mockEventsStore{
+			GetFn: func(context.Context, string) (Event, error) {
+				return Event{
+					Worker: Worker{
+						Spec: WorkerSpec{
+							Git: &GitConfig{},
+						},
+						Status: WorkerStatus{
+							Phase: WorkerPhaseSucceeded,
+						},
+						Jobs: []Job{
+							{
+								Name: "foo",
+								Spec: JobSpec{
+									SidecarContainers: map[string]JobContainerSpec{
+										"bar": {},
+									},
+								},
+								Status: &JobStatus{
+									Phase: JobPhaseSucceeded,
+								},
+							},
+							{
+								Name: "bat",
+								Status: &JobStatus{
+									Phase: JobPhasePending,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		},
+		projectsStore: &mockProjectsStore{
+			GetFn: func(context.Context, string) (Project, error) {
+				return Project{}, nil
+			},
+		},
+		secretsStore: &mockSecretsStore{
+			ValuesFn: func(context.Context, Project) ([]string, error) {
+				return nil, nil
+			},
+		},
+		warmLogsStore: &mockLogsStore{
+			StreamLogsFn: func(
+				_ context.Context,
+				_ Project,
+				_ Event,
+				selector LogsSelector,
+				_ LogStreamOptions,
+			) (<-chan LogEntry, error) {
+				require.NotEqual(t, "bat", selector.Job)
+				entries, ok :=
+					logEntries[fmt.Sprintf("%s/%s", selector.Job, selector.Container)]
+				if !ok {
+					return nil, &meta.ErrNotFound{}
+				}
+				logCh := make(chan LogEntry, len(entries))
+				for _, entry := range entries {
+					logCh <- entry
+				}
+				close(logCh)
+				return logCh, nil
+			},
+		},
+		coolLogsStore: &mockLogsStore{
+			StreamLogsFn: func(
+				context.Context,
+				Project,
+				Event,
+				LogsSelector,
+				LogStreamOptions,
+			) (<-chan LogEntry, error) {
+				return nil, &meta.ErrNotFound{}
+			},
+		},
+	}
+	logCh, err := svc.Stream(
+		context.Background(),
+		"123456789",
+		LogsSelector{All: true},
+		LogStreamOptions{},
+	)
+	require.NoError(t, err)
+	received := []LogEntry{}
+	for logEntry := range logCh {
+		received = append(received, logEntry)
+	}
+	require.Equal(
+		t,
+		[]LogEntry{
+			{Time: &t0, Message: "worker started", Container: "worker"},
+			{Time: &t1, Message: "foo running", Job: "foo", Container: "foo"},
+			{
+				Time:      &t2,
+				Message:   "bar sidecar running",
+				Job:       "foo",
+				Container: "bar",
+			},
+			{Time: &t3, Message: "worker done", Container: "worker"},
+		},
+		received,
+	)
+}
+
+func TestLogsServiceArchive(t *testing.T) {
+	t.Run("error retrieving event from store", func(t *testing.T) {
+		svc := &logsService{
+			eventsStore: &mockEventsStore{
+				GetFn: func(context.Context, string) (Event, error) {
+					return Event{}, errors.New("something went wrong")
+				},
+			},
+		}
+		_, err := svc.Archive(context.Background(), "tony")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "something went wrong")
+		require.Contains(t, err.Error(), "error retrieving event")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		svc := &logsService{
+			authorize:        neverAuthorize,
+			projectAuthorize: neverProjectAuthorize,
+			eventsStore: &mockEventsStore{
+				GetFn: func(context.Context, string) (Event, error) {
+					return Event{}, nil
+				},
+			},
+		}
+		_, err := svc.Archive(context.Background(), "tony")
+		require.Error(t, err)
+		require.IsType(t, &meta.ErrAuthorization{}, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		logEntries := map[string][]LogEntry{
+			"tony//worker":    {{Message: "worker says hunter2"}},
+			"tony/foo/foo":    {{Message: "foo running"}},
+			"carmela/bar/bar": {{Message: "bar ran before"}},
+		}
+		svc := &logsService{
+			projectAuthorize: alwaysProjectAuthorize,
+			eventsStore: &mockEventsStore{
+				GetFn: func(_ context.Context, id string) (Event, error) {
+					return Event{
+						ObjectMeta: meta.ObjectMeta{ID: id},
+						Worker: Worker{
+							Status: WorkerStatus{
+								Phase: WorkerPhaseSucceeded,
+							},
+							Jobs: []Job{
+								{
+									Name: "foo",
+									Status: &JobStatus{
+										Phase: JobPhaseSucceeded,
+									},
+								},
+								{
+									// Inherited from another event
+									Name: "bar",
+									Status: &JobStatus{
+										Phase:       JobPhaseSucceeded,
+										LogsEventID: "carmela",
+									},
+								},
+							},
+						},
+					}, nil
+				},
+			},
+			projectsStore: &mockProjectsStore{
+				GetFn: func(context.Context, string) (Project, error) {
+					return Project{}, nil
+				},
+			},
+			secretsStore: &mockSecretsStore{
+				ValuesFn: func(context.Context, Project) ([]string, error) {
+					return []string{"hunter2"}, nil
+				},
+			},
+			warmLogsStore: &mockLogsStore{
+				StreamLogsFn: func(
+					_ context.Context,
+					_ Project,
+					event Event,
+					selector LogsSelector,
+					_ LogStreamOptions,
+				) (<-chan LogEntry, error) {
+					entries, ok := logEntries[fmt.Sprintf(
+						"%s/%s/%s",
+						event.ID,
+						selector.Job,
+						selector.Container,
+					)]
+					if !ok {
+						return nil, &meta.ErrNotFound{}
+					}
+					logCh := make(chan LogEntry, len(entries))
+					for _, entry := range entries {
+						logCh <- entry
+					}
+					close(logCh)
+					return logCh, nil
+				},
+			},
+		}
+		files, err := svc.Archive(context.Background(), "tony")
+		require.NoError(t, err)
+		received := map[string][]string{}
+		for _, file := range files {
+			key := fmt.Sprintf("%s/%s", file.Job, file.Container)
+			logCh, err := file.Open(context.Background())
+			if _, ok := err.(*meta.ErrNotFound); ok {
+				continue
+			}
+			require.NoError(t, err)
+			received[key] = []string{}
+			for logEntry := range logCh {
+				received[key] = append(received[key], logEntry.Message)
+			}
+		}
+		require.Equal(
+			t,
+			map[string][]string{
+				"/worker": {"worker says ***"},
+				"foo/foo": {"foo running"},
+				"bar/bar": {"bar ran before"},
+			},
+			received,
+		)
+	})
+}
+
+func TestReportLogStreamError(t *testing.T) {
+	t.Run("no handler", func(t *testing.T) {
+		ReportLogStreamError(context.Background(), errors.New("oops"))
+	})
+	t.Run("handler", func(t *testing.T) {
+		var reported error
+		ctx := ContextWithLogStreamErrorHandler(
+			context.Background(),
+			func(err error) {
+				reported = err
+			},
+		)
+		ReportLogStreamError(ctx, errors.New("oops"))
+		require.EqualError(t, reported, "oops")
+	})
+}
+
+func TestParseLogEntry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		message          string
+		expectedLogEntry LogEntry
+	}{
+		{
+			name:             "unstructured",
+			message:          "hello world",
+			expectedLogEntry: LogEntry{Message: "hello world"},
+		},
+		{
+			name:             "invalid JSON",
+			message:          "{hello world",
+			expectedLogEntry: LogEntry{Message: "{hello world"},
+		},
+		{
+			name:    "structured with level",
+			message: `{"severity":"WARN","msg":"hello","count":2}`,
+			expectedLogEntry: LogEntry{
+				Message: `{"severity":"WARN","msg":"hello","count":2}`,
+				Level:   "warn",
+				Fields: map[string]interface{}{
+					"msg":   "hello",
+					"count": float64(2),
+				},
+			},
+		},
+		{
+			name:    "structured without level",
+			message: `{"msg":"hello"}`,
+			expectedLogEntry: LogEntry{
+				Message: `{"msg":"hello"}`,
+				Fields:  map[string]interface{}{"msg": "hello"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			logEntry := LogEntry{Message: testCase.message}
+			parseLogEntry(&logEntry)
+			require.Equal(t, testCase.expectedLogEntry, logEntry)
+		})
+	}
+}
+
+func TestLogsServiceSearch(t *testing.T) {
+	const testProjectID = "italian"
+	testSince := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	testUntil := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		service    LogsService
+		selector   LogsSearchSelector
+		opts       meta.ListOptions
+		assertions func(LogSearchResultList, error)
+	}{
+		{
+			name:    "neither text nor regex specified",
+			service: &logsService{},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "Exactly one of text")
+			},
+		},
+		{
+			name:    "both text and regex specified",
+			service: &logsService{},
+			selector: LogsSearchSelector{
+				Text:  "foo",
+				Regex: "foo",
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "Exactly one of text")
+			},
+		},
+		{
+			name:    "invalid regex",
+			service: &logsService{},
+			selector: LogsSearchSelector{
+				Regex: "foo(",
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "Invalid regular expression")
+			},
+		},
+		{
+			name:    "since does not precede until",
+			service: &logsService{},
+			selector: LogsSearchSelector{
+				Text:         "foo",
+				WrittenSince: &testSince,
+				WrittenUntil: &testUntil,
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+				require.Contains(t, err.Error(), "must precede its end")
+			},
+		},
+		{
+			name: "unauthorized",
+			service: &logsService{
+				projectAuthorize: neverProjectAuthorize,
+			},
+			selector: LogsSearchSelector{
+				Text: "foo",
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting project from store",
+			service: &logsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, &meta.ErrNotFound{}
+					},
+				},
+			},
+			selector: LogsSearchSelector{
+				Text: "foo",
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error retrieving project")
+			},
+		},
+		{
+			name: "error searching store",
+			service: &logsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return nil, nil
+					},
+				},
+				logsSearchStore: &mockLogsSearchStore{
+					SearchLogsFn: func(
+						context.Context,
+						string,
+						LogsSearchSelector,
+						meta.ListOptions,
+					) (LogSearchResultList, error) {
+						return LogSearchResultList{},
+							errors.New("something went wrong")
+					},
+				},
+			},
+			selector: LogsSearchSelector{
+				Text: "foo",
+			},
+			assertions: func(_ LogSearchResultList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error searching logs")
+			},
+		},
+		{
+			name: "success",
+			service: &logsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return []string{"hunter2"}, nil
+					},
+				},
+				logsSearchStore: &mockLogsSearchStore{
+					SearchLogsFn: func(
+						_ context.Context,
+						projectID string,
+						selector LogsSearchSelector,
+						opts meta.ListOptions,
+					) (LogSearchResultList, error) {
+						require.Equal(t, testProjectID, projectID)
+						require.Equal(t, "went (wrong|right)", selector.Regex)
+						// The default limit should have been applied
+						require.Equal(t, int64(20), opts.Limit)
+						return LogSearchResultList{
+							Items: []LogSearchResult{
+								{
+									EventID: "123456789",
+									Message: "something went wrong with hunter2",
+								},
+								{
+									EventID: "123456789",
+									Message: "password hunter2 went wrong",
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			selector: LogsSearchSelector{
+				Regex: "went (wrong|right)",
+			},
+			assertions: func(results LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Len(t, results.Items, 2)
+				// Secret values should have been redacted
+				require.Equal(
+					t,
+					"something went wrong with ***",
+					results.Items[0].Message,
+				)
+				require.Equal(
+					t,
+					"password *** went wrong",
+					results.Items[1].Message,
+				)
+			},
+		},
+		{
+			name: "results matching only because of a secret are dropped",
+			service: &logsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				secretsStore: &mockSecretsStore{
+					ValuesFn: func(context.Context, Project) ([]string, error) {
+						return []string{"hunter2"}, nil
+					},
+				},
+				logsSearchStore: &mockLogsSearchStore{
+					SearchLogsFn: func(
+						context.Context,
+						string,
+						LogsSearchSelector,
+						meta.ListOptions,
+					) (LogSearchResultList, error) {
+						return LogSearchResultList{
+							Items: []LogSearchResult{
+								{
+									Message: "password is hunter2",
+								},
+								{
+									Message: "password is hunt",
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			selector: LogsSearchSelector{
+				Regex: "is hunt",
+			},
+			assertions: func(results LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]LogSearchResult{
+						{
+							Message: "password is hunt",
+						},
+					},
+					results.Items,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results, err := testCase.service.Search(
+				context.Background(),
+				testProjectID,
+				testCase.selector,
+				testCase.opts,
+			)
+			testCase.assertions(results, err)
+		})
+	}
+}
+
+func TestLogMessageMatches(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+		text    string
+		regex   *regexp.Regexp
+		matches bool
+	}{
+		{
+			name:    "regex matches",
+			message: "panic: nil pointer",
+			regex:   regexp.MustCompile("panic: .*nil"),
+			matches: true,
+		},
+		{
+			name:    "regex does not match",
+			message: "panic: ***",
+			regex:   regexp.MustCompile("panic: .*nil"),
+		},
+		{
+			name:    "any word matches regardless of case",
+			message: "Connection Refused",
+			text:    "timeout refused",
+			matches: true,
+		},
+		{
+			name:    "no word matches",
+			message: "connection ***",
+			text:    "timeout refused",
+		},
+		{
+			name:    "negated words are ignored",
+			message: "connection refused",
+			text:    "-connection",
+		},
+		{
+			name:    "every phrase matches",
+			message: "connection refused by host",
+			text:    `"connection refused" "by host" timeout`,
+			matches: true,
+		},
+		{
+			name:    "a phrase does not match",
+			message: "connection refused by ***",
+			text:    `"connection refused" "by host"`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.matches,
+				logMessageMatches(testCase.message, testCase.text, testCase.regex),
+			)
+		})
+	}
+}
+
 type mockLogsStore struct {
 	StreamLogsFn func(
 		ctx context.Context,
@@ -469,3 +1296,63 @@ func (m *mockLogsStore) DeleteProjectLogs(
 ) error {
 	return m.DeleteProjectLogsFn(ctx, id)
 }
+
+type mockLogsSearchStore struct {
+	SearchLogsFn func(
+		ctx context.Context,
+		projectID string,
+		selector LogsSearchSelector,
+		opts meta.ListOptions,
+	) (LogSearchResultList, error)
+}
+
+func (m *mockLogsSearchStore) SearchLogs(
+	ctx context.Context,
+	projectID string,
+	selector LogsSearchSelector,
+	opts meta.ListOptions,
+) (LogSearchResultList, error) {
+	return m.SearchLogsFn(ctx, projectID, selector, opts)
+}
+
+type mockSecretsStore struct {
+	ListFn func(
+		ctx context.Context,
+		project Project,
+		opts meta.ListOptions,
+	) (SecretList, error)
+	ValuesFn func(ctx context.Context, project Project) ([]string, error)
+	SetFn    func(ctx context.Context, project Project, secret Secret) error
+	UnsetFn  func(ctx context.Context, project Project, key string) error
+}
+
+func (m *mockSecretsStore) List(
+	ctx context.Context,
+	project Project,
+	opts meta.ListOptions,
+) (SecretList, error) {
+	return m.ListFn(ctx, project, opts)
+}
+
+func (m *mockSecretsStore) Values(
+	ctx context.Context,
+	project Project,
+) ([]string, error) {
+	return m.ValuesFn(ctx, project)
+}
+
+func (m *mockSecretsStore) Set(
+	ctx context.Context,
+	project Project,
+	secret Secret,
+) error {
+	return m.SetFn(ctx, project, secret)
+}
+
+func (m *mockSecretsStore) Unset(
+	ctx context.Context,
+	project Project,
+	key string,
+) error {
+	return m.UnsetFn(ctx, project, key)
+}
@@ -0,0 +1,199 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditStore is a MongoDB-based implementation of the api.AuditStore
+// interface.
+type auditStore struct {
+	collection mongodb.Collection
+}
+
+// NewAuditStore returns a MongoDB-based implementation of the api.AuditStore
+// interface.
+func NewAuditStore(database *mongo.Database) (api.AuditStore, error) {
+	ctx, cancel :=
+		context.WithTimeout(context.Background(), createIndexTimeout)
+	defer cancel()
+	unique := true
+	// Set the default expiration value to 0 so that each record's 'expires'
+	// field is used to determine actual expiration
+	defaultExpiry := int32(0)
+	collection := database.Collection("audit")
+	if _, err := collection.Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.M{
+					"id": 1,
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+			{
+				Keys: bson.D{
+					{Key: "time", Value: -1},
+					{Key: "id", Value: 1},
+				},
+			},
+			{
+				Keys: bson.M{
+					"expires": 1,
+				},
+				Options: &options.IndexOptions{
+					ExpireAfterSeconds: &defaultExpiry,
+				},
+			},
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "error adding indexes to audit collection")
+	}
+	return &auditStore{
+		collection: collection,
+	}, nil
+}
+
+func (a *auditStore) Create(ctx context.Context, record api.AuditRecord) error {
+	if _, err := a.collection.InsertOne(ctx, record); err != nil {
+		return errors.Wrapf(err, "error inserting new audit record %q", record.ID)
+	}
+	return nil
+}
+
+func (a *auditStore) List(
+	ctx context.Context,
+	selector api.AuditRecordsSelector,
+	opts meta.ListOptions,
+) (api.AuditRecordList, error) {
+	records := api.AuditRecordList{}
+
+	criteria := bson.M{}
+	if selector.Action != "" {
+		criteria["action"] = selector.Action
+	}
+	if selector.TargetType != "" {
+		criteria["targetType"] = selector.TargetType
+	}
+	if selector.TargetID != "" {
+		criteria["targetID"] = selector.TargetID
+	}
+	if selector.ProjectID != "" {
+		criteria["projectID"] = selector.ProjectID
+	}
+	if selector.PrincipalType != "" {
+		criteria["principal.type"] = selector.PrincipalType
+	}
+	if selector.PrincipalID != "" {
+		criteria["principal.id"] = selector.PrincipalID
+	}
+	if selector.Result != "" {
+		criteria["result"] = selector.Result
+	}
+	if selector.Since != nil || selector.Until != nil {
+		timeCriteria := bson.M{}
+		if selector.Since != nil {
+			timeCriteria["$gte"] = *selector.Since
+		}
+		if selector.Until != nil {
+			timeCriteria["$lt"] = *selector.Until
+		}
+		criteria["time"] = timeCriteria
+	}
+	// The continue criteria are combined with the time criteria above using
+	// $and so that neither clobbers the other.
+	var continueCriteria bson.M
+	if opts.Continue != "" {
+		tokens := strings.Split(opts.Continue, ":")
+		if len(tokens) != 2 {
+			return records, errors.New("error parsing continue time")
+		}
+		continueTimeNano, err := strconv.ParseInt(tokens[0], 10, 64)
+		if err != nil {
+			return records, errors.Wrap(err, "error parsing continue time")
+		}
+		continueCriteria = auditRecordsAfter(
+			time.Unix(0, continueTimeNano).UTC(),
+			tokens[1],
+		)
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(
+		// bson.D preserves order, and we want to sort by time FIRST and id SECOND
+		bson.D{
+			{Key: "time", Value: -1},
+			{Key: "id", Value: 1},
+		},
+	)
+	findOptions.SetLimit(opts.Limit)
+	cur, err := a.collection.Find(
+		ctx,
+		withContinueCriteria(criteria, continueCriteria),
+		findOptions,
+	)
+	if err != nil {
+		return records, errors.Wrap(err, "error finding audit records")
+	}
+	if err := cur.All(ctx, &records.Items); err != nil {
+		return records, errors.Wrap(err, "error decoding audit records")
+	}
+
+	if int64(len(records.Items)) == opts.Limit {
+		continueTime := records.Items[opts.Limit-1].Time
+		continueID := records.Items[opts.Limit-1].ID
+		remaining, err := a.collection.CountDocuments(
+			ctx,
+			withContinueCriteria(
+				criteria,
+				auditRecordsAfter(continueTime, continueID),
+			),
+		)
+		if err != nil {
+			return records,
+				errors.Wrap(err, "error counting remaining audit records")
+		}
+		if remaining > 0 {
+			records.Continue =
+				fmt.Sprintf("%d:%s", continueTime.UnixNano(), continueID)
+			records.RemainingItemCount = remaining
+		}
+	}
+
+	return records, nil
+}
+
+// auditRecordsAfter returns criteria selecting audit records that sort after
+// the record with the specified time and ID.
+func auditRecordsAfter(continueTime time.Time, continueID string) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"time": continueTime, "id": bson.M{"$gt": continueID}},
+			{"time": bson.M{"$lt": continueTime}},
+		},
+	}
+}
+
+// withContinueCriteria returns the provided criteria combined with the
+// provided continue criteria, if any.
+func withContinueCriteria(criteria bson.M, continueCriteria bson.M) bson.M {
+	if continueCriteria == nil {
+		return criteria
+	}
+	return bson.M{
+		"$and": []bson.M{criteria, continueCriteria},
+	}
+}
@@ -0,0 +1,252 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestAuditStoreCreate(t *testing.T) {
+	testRecord := api.AuditRecord{
+		ID:     "123456789",
+		Action: "projects.create",
+	}
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error inserting new audit record")
+			},
+		},
+
+		{
+			name: "successful creation",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					record, ok := document.(api.AuditRecord)
+					require.True(t, ok)
+					require.Equal(t, testRecord, record)
+					return nil, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &auditStore{
+				collection: testCase.collection,
+			}
+			err := store.Create(context.Background(), testRecord)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestAuditStoreList(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	testRecord := api.AuditRecord{
+		ID:   "123456789",
+		Time: now,
+	}
+	testSince := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name       string
+		selector   api.AuditRecordsSelector
+		opts       meta.ListOptions
+		collection mongodb.Collection
+		assertions func(records api.AuditRecordList, err error)
+	}{
+
+		{
+			name: "invalid continue value",
+			opts: meta.ListOptions{
+				Continue: "foo",
+			},
+			assertions: func(_ api.AuditRecordList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing continue time")
+			},
+		},
+
+		{
+			name: "error finding audit records",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ api.AuditRecordList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding audit records")
+			},
+		},
+
+		{
+			name: "selector criteria are applied",
+			selector: api.AuditRecordsSelector{
+				Action:        "events.cancel",
+				ProjectID:     "italian",
+				PrincipalType: api.PrincipalTypeUser,
+				PrincipalID:   "tony@starkindustries.com",
+				Result:        api.AuditResultFailed,
+				Since:         &testSince,
+			},
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(t, "events.cancel", criteria["action"])
+					require.Equal(t, "italian", criteria["projectID"])
+					require.Equal(
+						t,
+						api.PrincipalTypeUser,
+						criteria["principal.type"],
+					)
+					require.Equal(
+						t,
+						"tony@starkindustries.com",
+						criteria["principal.id"],
+					)
+					require.Equal(t, api.AuditResultFailed, criteria["result"])
+					require.Equal(t, bson.M{"$gte": testSince}, criteria["time"])
+					cursor, err := mongoTesting.MockCursor(testRecord)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(records api.AuditRecordList, err error) {
+				require.NoError(t, err)
+				require.Len(t, records.Items, 1)
+			},
+		},
+
+		{
+			name: "audit records found; no more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testRecord)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(records api.AuditRecordList, err error) {
+				require.NoError(t, err)
+				require.Empty(t, records.Continue)
+				require.Zero(t, records.RemainingItemCount)
+				require.Len(t, records.Items, 1)
+				require.Equal(t, testRecord.ID, records.Items[0].ID)
+			},
+		},
+
+		{
+			name: "audit records found; more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testRecord)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 5, nil
+				},
+			},
+			assertions: func(records api.AuditRecordList, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					fmt.Sprintf("%d:%s", testRecord.Time.UnixNano(), testRecord.ID),
+					records.Continue,
+				)
+				require.Equal(t, int64(5), records.RemainingItemCount)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &auditStore{
+				collection: testCase.collection,
+			}
+			if testCase.opts.Limit == 0 {
+				testCase.opts.Limit = 1
+			}
+			records, err := store.List(
+				context.Background(),
+				testCase.selector,
+				testCase.opts,
+			)
+			testCase.assertions(records, err)
+		})
+	}
+}
@@ -1,5 +1,50 @@
 package mongodb
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
 
 const createIndexTimeout = time.Second * 5
+
+// resourceVersionConflict is used after an update conditioned on a resource's
+// version has matched no documents. It determines whether that is because the
+// resource (selected by the provided criteria) does not exist, in which case a
+// *meta.ErrNotFound is returned, or because the resource's version no longer
+// matches the expected version, in which case a *meta.ErrConflict is returned.
+func resourceVersionConflict(
+	ctx context.Context,
+	collection mongodb.Collection,
+	criteria bson.M,
+	kind string,
+	id string,
+	expectedVersion int64,
+) error {
+	count, err := collection.CountDocuments(ctx, criteria)
+	if err != nil {
+		return errors.Wrapf(err, "error counting %s %q", kind, id)
+	}
+	if count == 0 {
+		return &meta.ErrNotFound{
+			Type: kind,
+			ID:   id,
+		}
+	}
+	return &meta.ErrConflict{
+		Type: kind,
+		ID:   id,
+		Reason: fmt.Sprintf(
+			"%s %q has been modified since version %d was retrieved; retrieve "+
+				"it again and reapply the changes",
+			kind,
+			id,
+			expectedVersion,
+		),
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -42,6 +44,54 @@ func NewEventsStore(database *mongo.Database) (api.EventsStore, error) {
 					Unique: &unique,
 				},
 			},
+			{
+				Keys: bson.M{
+					"created": -1,
+				},
+			},
+			{
+				Keys: bson.D{
+					{Key: "projectID", Value: 1},
+					{Key: "created", Value: -1},
+				},
+			},
+			{
+				Keys: bson.M{
+					"git.commit": 1,
+				},
+			},
+			{
+				Keys: bson.M{
+					"git.ref": 1,
+				},
+			},
+			{
+				// This index supports text search over event titles and summaries.
+				// Note that MongoDB permits only one text index per collection.
+				Keys: bson.D{
+					{Key: "shortTitle", Value: "text"},
+					{Key: "longTitle", Value: "text"},
+					{Key: "summary", Value: "text"},
+				},
+			},
+			{
+				// This index supports the periodic pruning of each project's events
+				// by worker phase and age. bson.D is used because key order matters
+				// in a compound index.
+				Keys: bson.D{
+					{Key: "projectID", Value: 1},
+					{Key: "worker.status.phase", Value: 1},
+					{Key: "created", Value: -1},
+				},
+			},
+			{
+				// This index supports finding events whose logs have yet to be
+				// migrated from one log store to another.
+				Keys: bson.D{
+					{Key: "logsMigration.migrated", Value: 1},
+					{Key: "created", Value: 1},
+				},
+			},
 		},
 	); err != nil {
 		return nil, errors.Wrap(err, "error adding indexes to events collection")
@@ -57,6 +107,7 @@ func (e *eventsStore) Create(ctx context.Context, event api.Event) error {
 	if event.Worker.Jobs == nil {
 		event.Worker.Jobs = []api.Job{}
 	}
+	event.ResourceVersion = 1
 	if _, err := e.collection.InsertOne(ctx, event); err != nil {
 		return errors.Wrapf(err, "error inserting new event %q", event.ID)
 	}
@@ -98,6 +149,7 @@ func (e *eventsStore) List(
 			"$in": selector.WorkerPhases,
 		}
 	}
+	addSearchCriteria(criteria, selector)
 	if opts.Continue != "" {
 		tokens := strings.Split(opts.Continue, ":")
 		if len(tokens) != 2 {
@@ -154,6 +206,230 @@ func (e *eventsStore) List(
 	return events, nil
 }
 
+func (e *eventsStore) Stats(
+	ctx context.Context,
+	opts api.EventStatsOptions,
+) ([]api.EventStatsGroup, error) {
+	match := bson.M{
+		"deleted": bson.M{
+			"$exists": false, // Don't include logically deleted events
+		},
+		"created": bson.M{
+			"$gte": opts.Since,
+			"$lt":  opts.Until,
+		},
+	}
+	if opts.ProjectID != "" {
+		match["projectID"] = opts.ProjectID
+	}
+	groupKey := eventStatsGroupKey(opts)
+	aggregateOpts := options.Aggregate().SetAllowDiskUse(true)
+
+	// Aggregate Event-level statistics
+	group := bson.M{
+		"_id":   groupKey,
+		"total": bson.M{"$sum": 1},
+	}
+	phaseCounts := bson.M{}
+	for _, phase := range api.WorkerPhasesAll() {
+		group[string(phase)] = bson.M{
+			"$sum": bson.M{
+				"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$worker.status.phase", phase}},
+					1,
+					0,
+				},
+			},
+		}
+		phaseCounts[string(phase)] = fmt.Sprintf("$%s", phase)
+	}
+	cur, err := e.collection.Aggregate(
+		ctx,
+		bson.A{
+			bson.M{"$match": match},
+			bson.M{"$group": group},
+			bson.M{
+				"$project": bson.M{
+					"total":       1,
+					"phaseCounts": phaseCounts,
+				},
+			},
+			bson.M{
+				"$sort": bson.D{
+					{Key: "_id.projectID", Value: 1},
+					{Key: "_id.source", Value: 1},
+					{Key: "_id.type", Value: 1},
+					{Key: "_id.bucketStart", Value: 1},
+				},
+			},
+		},
+		aggregateOpts,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error aggregating event statistics")
+	}
+	eventResults := []eventStatsResult{}
+	if err = cur.All(ctx, &eventResults); err != nil {
+		return nil, errors.Wrap(err, "error decoding event statistics")
+	}
+
+	// Percentiles are estimated from histograms of durations because
+	// $percentile isn't available in all supported versions of MongoDB. Unlike
+	// the durations themselves, the number of histogram buckets per group is
+	// bounded, so this is safe no matter how many Events are in a group.
+	if cur, err = e.collection.Aggregate(
+		ctx,
+		bson.A{
+			bson.M{"$match": match},
+			bson.M{
+				"$project": bson.M{
+					"_id": 0,
+					"key": groupKey,
+					// These evaluate to null if the Worker hasn't started or ended
+					"durations": bson.A{
+						bson.M{
+							"metric": durationMetricQueueWait,
+							"millis": bson.M{
+								"$subtract": bson.A{"$worker.status.started", "$created"},
+							},
+						},
+						bson.M{
+							"metric": durationMetricRunDuration,
+							"millis": bson.M{
+								"$subtract": bson.A{
+									"$worker.status.ended",
+									"$worker.status.started",
+								},
+							},
+						},
+					},
+				},
+			},
+			bson.M{"$unwind": "$durations"},
+			bson.M{"$match": bson.M{"durations.millis": bson.M{"$ne": nil}}},
+			bson.M{
+				"$group": bson.M{
+					"_id": bson.M{
+						"key":    "$key",
+						"metric": "$durations.metric",
+						"bucket": durationHistogramBucket("$durations.millis"),
+					},
+					"count": bson.M{"$sum": 1},
+				},
+			},
+		},
+		aggregateOpts,
+	); err != nil {
+		return nil, errors.Wrap(err, "error aggregating event durations")
+	}
+	histogramResults := []durationHistogramResult{}
+	if err = cur.All(ctx, &histogramResults); err != nil {
+		return nil, errors.Wrap(err, "error decoding event durations")
+	}
+
+	// Aggregate Job-level statistics using the same grouping, further broken
+	// down by Job name
+	jobGroupKey := bson.M{"jobName": "$worker.jobs.name"}
+	for k, v := range groupKey {
+		jobGroupKey[k] = v
+	}
+	if cur, err = e.collection.Aggregate(
+		ctx,
+		bson.A{
+			bson.M{"$match": match},
+			bson.M{"$unwind": "$worker.jobs"},
+			bson.M{
+				"$match": bson.M{
+					"worker.jobs.status.phase": bson.M{
+						"$in": []api.JobPhase{
+							api.JobPhaseAborted,
+							api.JobPhaseCanceled,
+							api.JobPhaseFailed,
+							api.JobPhaseSchedulingFailed,
+							api.JobPhaseSucceeded,
+							api.JobPhaseTimedOut,
+						},
+					},
+				},
+			},
+			bson.M{
+				"$group": bson.M{
+					"_id":   jobGroupKey,
+					"total": bson.M{"$sum": 1},
+					"failed": bson.M{
+						"$sum": bson.M{
+							"$cond": bson.A{
+								bson.M{
+									"$ne": bson.A{
+										"$worker.jobs.status.phase",
+										api.JobPhaseSucceeded,
+									},
+								},
+								1,
+								0,
+							},
+						},
+					},
+				},
+			},
+		},
+		aggregateOpts,
+	); err != nil {
+		return nil, errors.Wrap(err, "error aggregating job statistics")
+	}
+	jobResults := []jobStatsResult{}
+	if err = cur.All(ctx, &jobResults); err != nil {
+		return nil, errors.Wrap(err, "error decoding job statistics")
+	}
+
+	histograms := map[string]map[string]durationHistogram{}
+	for _, result := range histogramResults {
+		key := result.Key.EventStatsKey.String()
+		if histograms[key] == nil {
+			histograms[key] = map[string]durationHistogram{}
+		}
+		if histograms[key][result.Key.Metric] == nil {
+			histograms[key][result.Key.Metric] = durationHistogram{}
+		}
+		histograms[key][result.Key.Metric][result.Key.Bucket] += result.Count
+	}
+
+	groups := make([]api.EventStatsGroup, len(eventResults))
+	groupsByKey := map[string]*api.EventStatsGroup{}
+	for i, result := range eventResults {
+		groups[i] = result.toEventStatsGroup()
+		metrics := histograms[result.Key.String()]
+		groups[i].QueueWait = metrics[durationMetricQueueWait].percentiles()
+		groups[i].RunDuration = metrics[durationMetricRunDuration].percentiles()
+		groupsByKey[result.Key.String()] = &groups[i]
+	}
+	for _, result := range jobResults {
+		group, ok := groupsByKey[result.Key.EventStatsKey.String()]
+		if !ok {
+			continue
+		}
+		group.JobFailureRates = append(
+			group.JobFailureRates,
+			api.JobFailureRate{
+				JobName:     result.Key.JobName,
+				Total:       result.Total,
+				Failed:      result.Failed,
+				FailureRate: float64(result.Failed) / float64(result.Total),
+			},
+		)
+	}
+	for i := range groups {
+		rates := groups[i].JobFailureRates
+		sort.Slice(rates, func(a, b int) bool {
+			if rates[a].FailureRate != rates[b].FailureRate {
+				return rates[a].FailureRate > rates[b].FailureRate
+			}
+			return rates[a].JobName < rates[b].JobName
+		})
+	}
+	return groups, nil
+}
+
 func (e *eventsStore) Get(
 	ctx context.Context,
 	id string,
@@ -211,19 +487,27 @@ func (e *eventsStore) UpdateSourceState(
 	ctx context.Context,
 	id string,
 	sourceState api.SourceState,
+	resourceVersion int64,
 ) error {
+	criteria := bson.M{
+		"id": id,
+		"deleted": bson.M{
+			"$exists": false, // Don't grab logically deleted events
+		},
+	}
+	if resourceVersion != 0 {
+		criteria["resourceVersion"] = resourceVersion
+	}
 	res, err := e.collection.UpdateOne(
 		ctx,
-		bson.M{
-			"id": id,
-			"deleted": bson.M{
-				"$exists": false, // Don't grab logically deleted events
-			},
-		},
+		criteria,
 		bson.M{
 			"$set": bson.M{
 				"sourceState": sourceState,
 			},
+			"$inc": bson.M{
+				"resourceVersion": 1,
+			},
 		},
 	)
 	if err != nil {
@@ -234,6 +518,19 @@ func (e *eventsStore) UpdateSourceState(
 		)
 	}
 	if res.MatchedCount == 0 {
+		if resourceVersion != 0 {
+			return resourceVersionConflict(
+				ctx,
+				e.collection,
+				bson.M{
+					"id":      id,
+					"deleted": bson.M{"$exists": false},
+				},
+				api.EventKind,
+				id,
+				resourceVersion,
+			)
+		}
 		return &meta.ErrNotFound{
 			Type: "Event",
 			ID:   id,
@@ -277,7 +574,11 @@ func (e *eventsStore) UpdateSummary(
 	return nil
 }
 
-func (e *eventsStore) Cancel(ctx context.Context, id string) error {
+func (e *eventsStore) Cancel(
+	ctx context.Context,
+	id string,
+	transition api.PhaseTransition,
+) error {
 	cancellationTime := time.Now().UTC()
 
 	res, err := e.collection.UpdateOne(
@@ -291,8 +592,16 @@ func (e *eventsStore) Cancel(ctx context.Context, id string) error {
 		},
 		bson.M{
 			"$set": bson.M{
-				"canceled":            cancellationTime,
-				"worker.status.phase": api.WorkerPhaseCanceled,
+				"canceled":                cancellationTime,
+				"worker.status.phase":     api.WorkerPhaseCanceled,
+				"worker.status.reason":    transition.Reason,
+				"worker.status.principal": transition.Principal,
+			},
+			"$push": bson.M{
+				"worker.timeline": transitionTo(
+					transition,
+					string(api.WorkerPhaseCanceled),
+				),
 			},
 		},
 	)
@@ -317,9 +626,25 @@ func (e *eventsStore) Cancel(ctx context.Context, id string) error {
 		bson.M{
 			"$set": bson.M{
 				"worker.status.phase":                           api.WorkerPhaseAborted, // nolint: lll
+				"worker.status.reason":                          transition.Reason,
+				"worker.status.principal":                       transition.Principal,
 				"worker.jobs.$[pending].status.phase":           api.JobPhaseCanceled,
 				"worker.jobs.$[startingOrRunning].status.phase": api.JobPhaseAborted,
 			},
+			"$push": bson.M{
+				"worker.timeline": transitionTo(
+					transition,
+					string(api.WorkerPhaseAborted),
+				),
+				"worker.jobs.$[pending].timeline": transitionTo(
+					transition,
+					string(api.JobPhaseCanceled),
+				),
+				"worker.jobs.$[startingOrRunning].timeline": transitionTo(
+					transition,
+					string(api.JobPhaseAborted),
+				),
+			},
 		},
 		&options.UpdateOptions{
 			ArrayFilters: &options.ArrayFilters{
@@ -361,6 +686,7 @@ func (e *eventsStore) Cancel(ctx context.Context, id string) error {
 func (e *eventsStore) CancelMany(
 	ctx context.Context,
 	selector api.EventsSelector,
+	transition api.PhaseTransition,
 ) (<-chan api.Event, int64, error) {
 	var affectedCount int64
 	// It only makes sense to cancel events that are in a pending, starting, or
@@ -400,6 +726,7 @@ func (e *eventsStore) CancelMany(
 	if selector.Source != "" {
 		criteria["source"] = selector.Source
 	}
+	addSearchCriteria(criteria, selector)
 	for k, v := range selector.SourceState {
 		criteria[fmt.Sprintf("sourceState.state.%s", k)] = v
 	}
@@ -414,8 +741,16 @@ func (e *eventsStore) CancelMany(
 			criteria,
 			bson.M{
 				"$set": bson.M{
-					"canceled":            cancellationTime,
-					"worker.status.phase": api.WorkerPhaseCanceled,
+					"canceled":                cancellationTime,
+					"worker.status.phase":     api.WorkerPhaseCanceled,
+					"worker.status.reason":    transition.Reason,
+					"worker.status.principal": transition.Principal,
+				},
+				"$push": bson.M{
+					"worker.timeline": transitionTo(
+						transition,
+						string(api.WorkerPhaseCanceled),
+					),
 				},
 			},
 		)
@@ -447,9 +782,25 @@ func (e *eventsStore) CancelMany(
 				"$set": bson.M{
 					"canceled":                                      cancellationTime,
 					"worker.status.phase":                           api.WorkerPhaseAborted,
+					"worker.status.reason":                          transition.Reason,
+					"worker.status.principal":                       transition.Principal,
 					"worker.jobs.$[pending].status.phase":           api.JobPhaseCanceled,
 					"worker.jobs.$[startingOrRunning].status.phase": api.JobPhaseAborted,
 				},
+				"$push": bson.M{
+					"worker.timeline": transitionTo(
+						transition,
+						string(api.WorkerPhaseAborted),
+					),
+					"worker.jobs.$[pending].timeline": transitionTo(
+						transition,
+						string(api.JobPhaseCanceled),
+					),
+					"worker.jobs.$[startingOrRunning].timeline": transitionTo(
+						transition,
+						string(api.JobPhaseAborted),
+					),
+				},
 			},
 			&options.UpdateOptions{
 				ArrayFilters: &options.ArrayFilters{
@@ -560,6 +911,7 @@ func (e *eventsStore) DeleteMany(
 			"$in": selector.WorkerPhases,
 		}
 	}
+	addSearchCriteria(criteria, selector)
 	result, err := e.collection.UpdateMany(
 		ctx,
 		criteria,
@@ -627,3 +979,327 @@ func (e *eventsStore) DeleteByProjectID(
 	)
 	return errors.Wrapf(err, "error deleting events for project %q", projectID)
 }
+
+func (e *eventsStore) ListLogsMigrationCandidates(
+	ctx context.Context,
+	cutoff time.Time,
+	limit int64,
+) ([]api.Event, error) {
+	criteria := bson.M{
+		"deleted": bson.M{
+			"$exists": false, // Don't grab logically deleted events
+		},
+		"worker.status.phase": bson.M{
+			"$in": []api.WorkerPhase{
+				api.WorkerPhaseAborted,
+				api.WorkerPhaseCanceled,
+				api.WorkerPhaseFailed,
+				api.WorkerPhaseSchedulingFailed,
+				api.WorkerPhaseSucceeded,
+				api.WorkerPhaseTimedOut,
+			},
+		},
+		"logsMigration.migrated": bson.M{
+			"$ne": true,
+		},
+		"$and": []bson.M{
+			{
+				// Workers that never started may have no end time, in which case the
+				// event's creation time is used instead.
+				"$or": []bson.M{
+					{"worker.status.ended": bson.M{"$lt": cutoff}},
+					{"worker.status.ended": nil, "created": bson.M{"$lt": cutoff}},
+				},
+			},
+			{
+				"$or": []bson.M{
+					{"logsMigration.claimedUntil": nil},
+					{"logsMigration.claimedUntil": bson.M{"$lt": time.Now().UTC()}},
+				},
+			},
+		},
+	}
+	findOptions := options.Find()
+	findOptions.SetSort(
+		bson.D{
+			{Key: "created", Value: 1},
+			{Key: "id", Value: 1},
+		},
+	)
+	findOptions.SetLimit(limit)
+	cur, err := e.collection.Find(ctx, criteria, findOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding events")
+	}
+	events := []api.Event{}
+	if err := cur.All(ctx, &events); err != nil {
+		return nil, errors.Wrap(err, "error decoding events")
+	}
+	return events, nil
+}
+
+func (e *eventsStore) ClaimLogsMigration(
+	ctx context.Context,
+	id string,
+	claimant string,
+	until time.Time,
+) (bool, error) {
+	res, err := e.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"id": id,
+			"logsMigration.migrated": bson.M{
+				"$ne": true,
+			},
+			"$or": []bson.M{
+				{"logsMigration.claimant": claimant},
+				{"logsMigration.claimedUntil": nil},
+				{"logsMigration.claimedUntil": bson.M{"$lt": time.Now().UTC()}},
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"logsMigration.claimant":     claimant,
+				"logsMigration.claimedUntil": until,
+			},
+		},
+	)
+	if err != nil {
+		return false, errors.Wrapf(
+			err,
+			"error claiming event %q for logs migration",
+			id,
+		)
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (e *eventsStore) MarkLogsMigrated(ctx context.Context, id string) error {
+	if _, err := e.collection.UpdateOne(
+		ctx,
+		bson.M{"id": id},
+		bson.M{
+			"$set": bson.M{
+				"logsMigration.migrated": true,
+			},
+			"$unset": bson.M{
+				"logsMigration.claimant":     1,
+				"logsMigration.claimedUntil": 1,
+			},
+		},
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error marking logs of event %q as migrated",
+			id,
+		)
+	}
+	return nil
+}
+
+// addSearchCriteria amends the provided criteria with any time range, git, or
+// text search criteria specified by the provided api.EventsSelector.
+func addSearchCriteria(criteria bson.M, selector api.EventsSelector) {
+	if selector.CreatedAfter != nil || selector.CreatedBefore != nil {
+		createdCriteria := bson.M{}
+		if selector.CreatedAfter != nil {
+			createdCriteria["$gt"] = *selector.CreatedAfter
+		}
+		if selector.CreatedBefore != nil {
+			createdCriteria["$lt"] = *selector.CreatedBefore
+		}
+		criteria["created"] = createdCriteria
+	}
+	if selector.Commit != "" {
+		criteria["git.commit"] = selector.Commit
+	}
+	if selector.Ref != "" {
+		criteria["git.ref"] = selector.Ref
+	}
+	if selector.Text != "" {
+		criteria["$text"] = bson.M{"$search": selector.Text}
+	}
+}
+
+// eventStatsGroupKey returns the _id expression used for grouping Events when
+// aggregating statistics in accordance with the provided options.
+func eventStatsGroupKey(opts api.EventStatsOptions) bson.M {
+	switch opts.GroupBy {
+	case api.EventStatsGroupingSourceType:
+		return bson.M{
+			"source": "$source",
+			"type":   "$type",
+		}
+	case api.EventStatsGroupingTime:
+		// Buckets are aligned to the beginning of the window
+		return bson.M{
+			"bucketStart": bson.M{
+				"$subtract": bson.A{
+					"$created",
+					bson.M{
+						"$mod": bson.A{
+							bson.M{"$subtract": bson.A{"$created", opts.Since}},
+							opts.BucketSize.Milliseconds(),
+						},
+					},
+				},
+			},
+		}
+	default:
+		return bson.M{"projectID": "$projectID"}
+	}
+}
+
+// eventStatsKey identifies a group of Events for which statistics have been
+// aggregated. Which fields are populated depends on how Events were grouped.
+type eventStatsKey struct {
+	ProjectID   string     `bson:"projectID,omitempty"`
+	Source      string     `bson:"source,omitempty"`
+	Type        string     `bson:"type,omitempty"`
+	BucketStart *time.Time `bson:"bucketStart,omitempty"`
+}
+
+// String returns a string representation of the key that is suitable for use
+// as a map key.
+func (e eventStatsKey) String() string {
+	var bucketStart int64
+	if e.BucketStart != nil {
+		bucketStart = e.BucketStart.UnixNano()
+	}
+	return fmt.Sprintf("%s|%s|%s|%d", e.ProjectID, e.Source, e.Type, bucketStart)
+}
+
+// eventStatsResult is a single document produced by aggregating Event-level
+// statistics.
+type eventStatsResult struct {
+	Key         eventStatsKey             `bson:"_id"`
+	Total       int64                     `bson:"total"`
+	PhaseCounts map[api.WorkerPhase]int64 `bson:"phaseCounts"`
+}
+
+func (e eventStatsResult) toEventStatsGroup() api.EventStatsGroup {
+	group := api.EventStatsGroup{
+		ProjectID:   e.Key.ProjectID,
+		Source:      e.Key.Source,
+		Type:        e.Key.Type,
+		BucketStart: e.Key.BucketStart,
+		Total:       e.Total,
+		PhaseCounts: map[api.WorkerPhase]int64{},
+	}
+	var terminal, succeeded int64
+	for phase, count := range e.PhaseCounts {
+		if count == 0 {
+			continue
+		}
+		group.PhaseCounts[phase] = count
+		if phase.IsTerminal() {
+			terminal += count
+		}
+		if phase == api.WorkerPhaseSucceeded {
+			succeeded = count
+		}
+	}
+	if terminal > 0 {
+		group.SuccessRate = float64(succeeded) / float64(terminal)
+	}
+	return group
+}
+
+// jobStatsResult is a single document produced by aggregating Job-level
+// statistics.
+type jobStatsResult struct {
+	Key struct {
+		EventStatsKey eventStatsKey `bson:",inline"`
+		JobName       string        `bson:"jobName"`
+	} `bson:"_id"`
+	Total  int64 `bson:"total"`
+	Failed int64 `bson:"failed"`
+}
+
+const (
+	durationMetricQueueWait   = "queueWait"
+	durationMetricRunDuration = "runDuration"
+
+	// durationHistogramBucketsPerDoubling determines the resolution of the
+	// histograms from which duration percentiles are estimated. Each bucket
+	// spans durations that differ by a factor of at most 2^(1/8), so estimates
+	// are within about 5% of the exact percentiles.
+	durationHistogramBucketsPerDoubling = 8
+)
+
+// durationHistogramBucket returns an expression that evaluates to the
+// histogram bucket for the duration, in milliseconds, found in the specified
+// field. Durations under a millisecond all fall into bucket -1.
+func durationHistogramBucket(millis string) bson.M {
+	return bson.M{
+		"$cond": bson.A{
+			bson.M{"$lt": bson.A{millis, 1}},
+			-1,
+			bson.M{
+				"$floor": bson.M{
+					"$multiply": bson.A{
+						bson.M{"$log": bson.A{millis, 2}},
+						durationHistogramBucketsPerDoubling,
+					},
+				},
+			},
+		},
+	}
+}
+
+// durationHistogramResult is a single document produced by aggregating
+// durations into histograms. Each represents one bucket of the histogram for
+// one metric of one group of Events.
+type durationHistogramResult struct {
+	Key struct {
+		EventStatsKey eventStatsKey `bson:"key"`
+		Metric        string        `bson:"metric"`
+		Bucket        int64         `bson:"bucket"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
+
+// durationHistogram maps histogram buckets to the number of durations in
+// each.
+type durationHistogram map[int64]int64
+
+// percentiles estimates percentiles of the durations in the histogram using
+// the nearest-rank method. Each is estimated as the midpoint of the bucket the
+// ranked duration fell into.
+func (d durationHistogram) percentiles() api.DurationPercentiles {
+	percentiles := api.DurationPercentiles{}
+	buckets := make([]int64, 0, len(d))
+	for bucket, count := range d {
+		buckets = append(buckets, bucket)
+		percentiles.Count += count
+	}
+	if percentiles.Count == 0 {
+		return percentiles
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	percentile := func(p float64) float64 {
+		rank := int64(math.Ceil(p * float64(percentiles.Count)))
+		var cumulative int64
+		for _, bucket := range buckets {
+			if cumulative += d[bucket]; cumulative >= rank {
+				return durationHistogramBucketSeconds(bucket)
+			}
+		}
+		return durationHistogramBucketSeconds(buckets[len(buckets)-1])
+	}
+	percentiles.P50Seconds = percentile(.50)
+	percentiles.P95Seconds = percentile(.95)
+	return percentiles
+}
+
+// durationHistogramBucketSeconds returns the midpoint, in seconds, of the
+// specified histogram bucket.
+func durationHistogramBucketSeconds(bucket int64) float64 {
+	if bucket < 0 {
+		return 0
+	}
+	return math.Pow(
+		2,
+		(float64(bucket)+.5)/durationHistogramBucketsPerDoubling,
+	) / 1000
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -86,6 +88,7 @@ func TestEventsStoreList(t *testing.T) {
 	}
 	testCases := []struct {
 		name        string
+		selector    api.EventsSelector
 		listOptions meta.ListOptions
 		collection  mongodb.Collection
 		assertions  func(events api.EventList, err error)
@@ -196,6 +199,51 @@ func TestEventsStoreList(t *testing.T) {
 				require.Equal(t, int64(5), events.RemainingItemCount)
 			},
 		},
+		{
+			name: "search criteria applied",
+			selector: api.EventsSelector{
+				CreatedAfter:  &now,
+				CreatedBefore: &now,
+				Commit:        "1234567",
+				Ref:           "refs/heads/main",
+				Text:          "deploy",
+			},
+			listOptions: meta.ListOptions{
+				Limit: 1,
+			},
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(
+						t,
+						bson.M{"$gt": now, "$lt": now},
+						criteria["created"],
+					)
+					require.Equal(t, "1234567", criteria["git.commit"])
+					require.Equal(t, "refs/heads/main", criteria["git.ref"])
+					require.Equal(t, bson.M{"$search": "deploy"}, criteria["$text"])
+					cursor, err := mongoTesting.MockCursor(testEvent)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(events api.EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -203,11 +251,11 @@ func TestEventsStoreList(t *testing.T) {
 			store := &eventsStore{
 				collection: testCase.collection,
 			}
+			selector := testCase.selector
+			selector.ProjectID = testProjectID
 			events, err := store.List(
 				context.Background(),
-				api.EventsSelector{
-					ProjectID: testProjectID,
-				},
+				selector,
 				testCase.listOptions,
 			)
 			testCase.assertions(events, err)
@@ -215,6 +263,258 @@ func TestEventsStoreList(t *testing.T) {
 	}
 }
 
+func TestEventsStoreStats(t *testing.T) {
+	until := time.Now().UTC()
+	opts := api.EventStatsOptions{
+		ProjectID: "blue-book",
+		GroupBy:   api.EventStatsGroupingProject,
+		Since:     until.Add(-time.Hour),
+		Until:     until,
+	}
+	eventResult := eventStatsResult{
+		PhaseCounts: map[api.WorkerPhase]int64{
+			api.WorkerPhaseFailed:    1,
+			api.WorkerPhaseRunning:   1,
+			api.WorkerPhaseSucceeded: 3,
+			api.WorkerPhaseUnknown:   0,
+		},
+		Total: 5,
+	}
+	eventResult.Key.ProjectID = "blue-book"
+	histogramResults := []interface{}{}
+	for metric, durations := range map[string][]float64{
+		durationMetricQueueWait:   {4000, 1000, 3000, 2000, 5000},
+		durationMetricRunDuration: {60000},
+	} {
+		for _, millis := range durations {
+			result := durationHistogramResult{Count: 1}
+			result.Key.EventStatsKey.ProjectID = "blue-book"
+			result.Key.Metric = metric
+			result.Key.Bucket = int64(
+				math.Floor(math.Log2(millis) * durationHistogramBucketsPerDoubling),
+			)
+			histogramResults = append(histogramResults, result)
+		}
+	}
+	jobResult := jobStatsResult{Total: 4, Failed: 1}
+	jobResult.Key.EventStatsKey.ProjectID = "blue-book"
+	jobResult.Key.JobName = "test"
+	flakyJobResult := jobStatsResult{Total: 2, Failed: 2}
+	flakyJobResult.Key.EventStatsKey.ProjectID = "blue-book"
+	flakyJobResult.Key.JobName = "flaky"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func([]api.EventStatsGroup, error)
+	}{
+		{
+			name: "error aggregating event statistics",
+			collection: &mongoTesting.MockCollection{
+				AggregateFn: func(
+					context.Context,
+					interface{},
+					...*options.AggregateOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ []api.EventStatsGroup, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error aggregating event statistics")
+			},
+		},
+		{
+			name: "error aggregating event durations",
+			collection: func() mongodb.Collection {
+				calls := 0
+				return &mongoTesting.MockCollection{
+					AggregateFn: func(
+						context.Context,
+						interface{},
+						...*options.AggregateOptions,
+					) (*mongo.Cursor, error) {
+						calls++
+						if calls == 1 {
+							return mongoTesting.MockCursor(eventResult)
+						}
+						return nil, errors.New("something went wrong")
+					},
+				}
+			}(),
+			assertions: func(_ []api.EventStatsGroup, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error aggregating event durations")
+			},
+		},
+		{
+			name: "error aggregating job statistics",
+			collection: func() mongodb.Collection {
+				calls := 0
+				return &mongoTesting.MockCollection{
+					AggregateFn: func(
+						context.Context,
+						interface{},
+						...*options.AggregateOptions,
+					) (*mongo.Cursor, error) {
+						calls++
+						switch calls {
+						case 1:
+							return mongoTesting.MockCursor(eventResult)
+						case 2:
+							return mongoTesting.MockCursor(histogramResults...)
+						}
+						return nil, errors.New("something went wrong")
+					},
+				}
+			}(),
+			assertions: func(_ []api.EventStatsGroup, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error aggregating job statistics")
+			},
+		},
+		{
+			name: "success",
+			collection: func() mongodb.Collection {
+				calls := 0
+				return &mongoTesting.MockCollection{
+					AggregateFn: func(
+						_ context.Context,
+						pipeline interface{},
+						_ ...*options.AggregateOptions,
+					) (*mongo.Cursor, error) {
+						stages, ok := pipeline.(bson.A)
+						require.True(t, ok)
+						match, ok := stages[0].(bson.M)["$match"].(bson.M)
+						require.True(t, ok)
+						require.Equal(t, "blue-book", match["projectID"])
+						calls++
+						switch calls {
+						case 1:
+							return mongoTesting.MockCursor(eventResult)
+						case 2:
+							return mongoTesting.MockCursor(histogramResults...)
+						}
+						return mongoTesting.MockCursor(jobResult, flakyJobResult)
+					},
+				}
+			}(),
+			assertions: func(groups []api.EventStatsGroup, err error) {
+				require.NoError(t, err)
+				require.Len(t, groups, 1)
+				// Percentiles are estimates, so they're checked separately
+				queueWait := groups[0].QueueWait
+				require.Equal(t, int64(5), queueWait.Count)
+				require.InEpsilon(t, 3, queueWait.P50Seconds, .05)
+				require.InEpsilon(t, 5, queueWait.P95Seconds, .05)
+				runDuration := groups[0].RunDuration
+				require.Equal(t, int64(1), runDuration.Count)
+				require.InEpsilon(t, 60, runDuration.P50Seconds, .05)
+				require.InEpsilon(t, 60, runDuration.P95Seconds, .05)
+				groups[0].QueueWait = api.DurationPercentiles{}
+				groups[0].RunDuration = api.DurationPercentiles{}
+				require.Equal(
+					t,
+					[]api.EventStatsGroup{
+						{
+							ProjectID: "blue-book",
+							Total:     5,
+							PhaseCounts: map[api.WorkerPhase]int64{
+								api.WorkerPhaseFailed:    1,
+								api.WorkerPhaseRunning:   1,
+								api.WorkerPhaseSucceeded: 3,
+							},
+							SuccessRate: .75,
+							JobFailureRates: []api.JobFailureRate{
+								{
+									JobName:     "flaky",
+									Total:       2,
+									Failed:      2,
+									FailureRate: 1,
+								},
+								{
+									JobName:     "test",
+									Total:       4,
+									Failed:      1,
+									FailureRate: .25,
+								},
+							},
+						},
+					},
+					groups,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &eventsStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(store.Stats(context.Background(), opts))
+		})
+	}
+}
+
+func TestEventStatsGroupKey(t *testing.T) {
+	since := time.Now().UTC()
+	require.Equal(
+		t,
+		bson.M{"projectID": "$projectID"},
+		eventStatsGroupKey(api.EventStatsOptions{}),
+	)
+	require.Equal(
+		t,
+		bson.M{"source": "$source", "type": "$type"},
+		eventStatsGroupKey(
+			api.EventStatsOptions{GroupBy: api.EventStatsGroupingSourceType},
+		),
+	)
+	require.Equal(
+		t,
+		bson.M{
+			"bucketStart": bson.M{
+				"$subtract": bson.A{
+					"$created",
+					bson.M{
+						"$mod": bson.A{
+							bson.M{"$subtract": bson.A{"$created", since}},
+							int64(3600000),
+						},
+					},
+				},
+			},
+		},
+		eventStatsGroupKey(
+			api.EventStatsOptions{
+				GroupBy:    api.EventStatsGroupingTime,
+				Since:      since,
+				BucketSize: time.Hour,
+			},
+		),
+	)
+}
+
+func TestDurationHistogramPercentiles(t *testing.T) {
+	require.Equal(
+		t,
+		api.DurationPercentiles{},
+		durationHistogram(nil).percentiles(),
+	)
+	require.Equal(
+		t,
+		api.DurationPercentiles{Count: 3},
+		durationHistogram{-1: 3}.percentiles(),
+	)
+	// 90 durations of about 1s and 10 of about 1m
+	percentiles := durationHistogram{79: 90, 126: 10}.percentiles()
+	require.Equal(t, int64(100), percentiles.Count)
+	require.InEpsilon(t, 1, percentiles.P50Seconds, .05)
+	require.InEpsilon(t, 60, percentiles.P95Seconds, .05)
+}
+
 func TestEventsStoreGet(t *testing.T) {
 	const testEventID = "123456789"
 	testCases := []struct {
@@ -394,9 +694,10 @@ func TestEventsStoreGetByHashedToken(t *testing.T) {
 func TestEventsStoreUpdateSourceState(t *testing.T) {
 	const testEvent = "123456789"
 	testCases := []struct {
-		name       string
-		collection mongodb.Collection
-		assertions func(err error)
+		name            string
+		resourceVersion int64
+		collection      mongodb.Collection
+		assertions      func(err error)
 	}{
 		{
 			name: "unanticipated error",
@@ -437,6 +738,66 @@ func TestEventsStoreUpdateSourceState(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "versioned event not found",
+			resourceVersion: 3,
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{
+						MatchedCount: 0,
+					}, nil
+				},
+				CountDocumentsFn: func(
+					context.Context,
+					interface{},
+					...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrNotFound{}, err)
+			},
+		},
+
+		{
+			name:            "resource version mismatch",
+			resourceVersion: 3,
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					_ context.Context,
+					filter interface{},
+					_ interface{},
+					_ ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(t, int64(3), criteria["resourceVersion"])
+					return &mongo.UpdateResult{
+						MatchedCount: 0,
+					}, nil
+				},
+				CountDocumentsFn: func(
+					context.Context,
+					interface{},
+					...*options.CountOptions,
+				) (int64, error) {
+					return 1, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrConflict{}, err)
+				require.Contains(t, err.Error(), "has been modified since version 3")
+			},
+		},
+
 		{
 			name: "success",
 			collection: &mongoTesting.MockCollection{
@@ -466,6 +827,7 @@ func TestEventsStoreUpdateSourceState(t *testing.T) {
 					context.Background(),
 					testEvent,
 					api.SourceState{},
+					testCase.resourceVersion,
 				)
 			testCase.assertions(err)
 		})
@@ -704,7 +1066,11 @@ func TestEventsStoreCancel(t *testing.T) {
 			store := &eventsStore{
 				collection: testCase.setup(),
 			}
-			err := store.Cancel(context.Background(), testEventID)
+			err := store.Cancel(
+				context.Background(),
+				testEventID,
+				api.PhaseTransition{},
+			)
 			testCase.assertions(err)
 		})
 	}
@@ -855,7 +1221,11 @@ func TestEventsStoreCancelMany(t *testing.T) {
 				collection: testCase.collection,
 			}
 			_, _, error :=
-				store.CancelMany(context.Background(), testCase.eventsSelector)
+				store.CancelMany(
+					context.Background(),
+					testCase.eventsSelector,
+					api.PhaseTransition{},
+				)
 			testCase.assertions(error)
 		})
 	}
@@ -939,6 +1309,7 @@ func TestEventsStoreDelete(t *testing.T) {
 }
 
 func TestEventsStoreDeleteMany(t *testing.T) {
+	testTime := time.Now().UTC()
 	testCases := []struct {
 		name           string
 		eventsSelector api.EventsSelector
@@ -970,6 +1341,35 @@ func TestEventsStoreDeleteMany(t *testing.T) {
 			},
 		},
 
+		{
+			name: "events selected by creation time",
+			eventsSelector: api.EventsSelector{
+				WorkerPhases: []api.WorkerPhase{
+					api.WorkerPhaseSucceeded,
+				},
+				CreatedBefore: &testTime,
+			},
+			collection: &mongoTesting.MockCollection{
+				UpdateManyFn: func(
+					_ context.Context,
+					filter interface{},
+					_ interface{},
+					_ ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					require.Equal(
+						t,
+						bson.M{"$lt": testTime},
+						filter.(bson.M)["created"],
+					)
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+			},
+		},
+
 		{
 			name: "error finding deleted events",
 			eventsSelector: api.EventsSelector{
@@ -1107,3 +1507,202 @@ func TestEventsStoreDeleteByProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsStoreListLogsMigrationCandidates(t *testing.T) {
+	testEvent := api.Event{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "foo",
+		},
+	}
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(events []api.Event, err error)
+	}{
+		{
+			name: "error finding events",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					context.Context,
+					interface{},
+					...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ []api.Event, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding events")
+			},
+		},
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					_ context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					require.Equal(
+						t,
+						bson.M{"$ne": true},
+						filter.(bson.M)["logsMigration.migrated"],
+					)
+					require.Equal(t, int64(100), *opts[0].Limit)
+					cursor, err := mongoTesting.MockCursor(testEvent)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+			},
+			assertions: func(events []api.Event, err error) {
+				require.NoError(t, err)
+				require.Len(t, events, 1)
+				require.Equal(t, testEvent.ID, events[0].ID)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &eventsStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(
+				store.ListLogsMigrationCandidates(
+					context.Background(),
+					time.Now().UTC(),
+					100,
+				),
+			)
+		})
+	}
+}
+
+func TestEventsStoreClaimLogsMigration(t *testing.T) {
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(claimed bool, err error)
+	}{
+		{
+			name: "error updating event",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ bool, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error claiming event")
+			},
+		},
+		{
+			name: "event already migrated or claimed",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{MatchedCount: 0}, nil
+				},
+			},
+			assertions: func(claimed bool, err error) {
+				require.NoError(t, err)
+				require.False(t, claimed)
+			},
+		},
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{MatchedCount: 1}, nil
+				},
+			},
+			assertions: func(claimed bool, err error) {
+				require.NoError(t, err)
+				require.True(t, claimed)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &eventsStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(
+				store.ClaimLogsMigration(
+					context.Background(),
+					"foo",
+					"bar",
+					time.Now().UTC().Add(time.Hour),
+				),
+			)
+		})
+	}
+}
+
+func TestEventsStoreMarkLogsMigrated(t *testing.T) {
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+		{
+			name: "error updating event",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error marking logs of event")
+			},
+		},
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{MatchedCount: 1}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &eventsStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(
+				store.MarkLogsMigrated(context.Background(), "foo"),
+			)
+		})
+	}
+}
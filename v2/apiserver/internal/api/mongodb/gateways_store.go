@@ -0,0 +1,260 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// gatewaysStore is a MongoDB-based implementation of the api.GatewaysStore
+// interface.
+type gatewaysStore struct {
+	collection mongodb.Collection
+}
+
+// NewGatewaysStore returns a MongoDB-based implementation of the
+// api.GatewaysStore interface.
+func NewGatewaysStore(database *mongo.Database) (api.GatewaysStore, error) {
+	ctx, cancel :=
+		context.WithTimeout(context.Background(), createIndexTimeout)
+	defer cancel()
+	unique := true
+	collection := database.Collection("gateways")
+	if _, err := collection.Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.M{
+					"id": 1,
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+			{
+				// This index is what guarantees that no two gateways can own the same
+				// source
+				Keys: bson.M{
+					"source": 1,
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+			{
+				Keys: bson.M{
+					"hashedToken": 1,
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "error adding indexes to gateways collection")
+	}
+	return &gatewaysStore{
+		collection: collection,
+	}, nil
+}
+
+func (g *gatewaysStore) Create(ctx context.Context, gateway api.Gateway) error {
+	if _, err := g.collection.InsertOne(ctx, gateway); err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			return &meta.ErrConflict{
+				Type: api.GatewayKind,
+				ID:   gateway.ID,
+				Reason: fmt.Sprintf(
+					"A gateway with the ID %q or owning the source %q already exists.",
+					gateway.ID,
+					gateway.Source,
+				),
+			}
+		}
+		return errors.Wrapf(err, "error inserting new gateway %q", gateway.ID)
+	}
+	return nil
+}
+
+func (g *gatewaysStore) List(
+	ctx context.Context,
+	opts meta.ListOptions,
+) (api.GatewayList, error) {
+	gateways := api.GatewayList{}
+
+	criteria := bson.M{}
+	if opts.Continue != "" {
+		criteria["id"] = bson.M{"$gt": opts.Continue}
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(
+		// bson.D preserves order so we use this wherever we sort so that if
+		// additional sort criteria are added in the future, they will be applied
+		// in the specified order.
+		bson.D{
+			{Key: "id", Value: 1},
+		},
+	)
+	findOptions.SetLimit(opts.Limit)
+	cur, err := g.collection.Find(ctx, criteria, findOptions)
+	if err != nil {
+		return gateways, errors.Wrap(err, "error finding gateways")
+	}
+	if err := cur.All(ctx, &gateways.Items); err != nil {
+		return gateways, errors.Wrap(err, "error decoding gateways")
+	}
+
+	if int64(len(gateways.Items)) == opts.Limit {
+		continueID := gateways.Items[opts.Limit-1].ID
+		criteria["id"] = bson.M{"$gt": continueID}
+		remaining, err := g.collection.CountDocuments(ctx, criteria)
+		if err != nil {
+			return gateways, errors.Wrap(err, "error counting remaining gateways")
+		}
+		if remaining > 0 {
+			gateways.Continue = continueID
+			gateways.RemainingItemCount = remaining
+		}
+	}
+
+	return gateways, nil
+}
+
+func (g *gatewaysStore) Get(ctx context.Context, id string) (api.Gateway, error) {
+	gateway := api.Gateway{}
+	res := g.collection.FindOne(ctx, bson.M{"id": id})
+	err := res.Decode(&gateway)
+	if err == mongo.ErrNoDocuments {
+		return gateway, &meta.ErrNotFound{
+			Type: api.GatewayKind,
+			ID:   id,
+		}
+	}
+	if err != nil {
+		return gateway, errors.Wrapf(err, "error finding/decoding gateway %q", id)
+	}
+	return gateway, nil
+}
+
+func (g *gatewaysStore) GetByHashedToken(
+	ctx context.Context,
+	hashedToken string,
+) (api.Gateway, error) {
+	gateway := api.Gateway{}
+	res := g.collection.FindOne(ctx, bson.M{"hashedToken": hashedToken})
+	err := res.Decode(&gateway)
+	if err == mongo.ErrNoDocuments {
+		return gateway, &meta.ErrNotFound{
+			Type: api.GatewayKind,
+		}
+	}
+	if err != nil {
+		return gateway,
+			errors.Wrap(err, "error finding/decoding gateway by hashed token")
+	}
+	return gateway, nil
+}
+
+func (g *gatewaysStore) GetBySource(
+	ctx context.Context,
+	source string,
+) (api.Gateway, error) {
+	gateway := api.Gateway{}
+	res := g.collection.FindOne(ctx, bson.M{"source": source})
+	err := res.Decode(&gateway)
+	if err == mongo.ErrNoDocuments {
+		return gateway, &meta.ErrNotFound{
+			Type: api.GatewayKind,
+		}
+	}
+	if err != nil {
+		return gateway, errors.Wrapf(
+			err,
+			"error finding/decoding gateway owning source %q",
+			source,
+		)
+	}
+	return gateway, nil
+}
+
+func (g *gatewaysStore) Update(ctx context.Context, gateway api.Gateway) error {
+	res, err := g.collection.UpdateOne(
+		ctx,
+		bson.M{"id": gateway.ID},
+		bson.M{
+			"$set": bson.M{
+				"description": gateway.Description,
+				"source":      gateway.Source,
+			},
+		},
+	)
+	if err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			return &meta.ErrConflict{
+				Type: api.GatewayKind,
+				ID:   gateway.ID,
+				Reason: fmt.Sprintf(
+					"A gateway owning the source %q already exists.",
+					gateway.Source,
+				),
+			}
+		}
+		return errors.Wrapf(err, "error updating gateway %q", gateway.ID)
+	}
+	if res.MatchedCount == 0 {
+		return &meta.ErrNotFound{
+			Type: api.GatewayKind,
+			ID:   gateway.ID,
+		}
+	}
+	return nil
+}
+
+func (g *gatewaysStore) UpdateLastSeen(
+	ctx context.Context,
+	id string,
+	lastSeen time.Time,
+) error {
+	res, err := g.collection.UpdateOne(
+		ctx,
+		bson.M{"id": id},
+		bson.M{
+			"$set": bson.M{
+				"lastSeen": lastSeen,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error updating gateway %q last seen time", id)
+	}
+	if res.MatchedCount == 0 {
+		return &meta.ErrNotFound{
+			Type: api.GatewayKind,
+			ID:   id,
+		}
+	}
+	return nil
+}
+
+func (g *gatewaysStore) Delete(ctx context.Context, id string) error {
+	res, err := g.collection.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return errors.Wrapf(err, "error deleting gateway %q", id)
+	}
+	if res.DeletedCount == 0 {
+		return &meta.ErrNotFound{
+			Type: api.GatewayKind,
+			ID:   id,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,739 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestGatewaysStoreCreate(t *testing.T) {
+	testGateway := api.Gateway{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "github",
+		},
+		Source: "brigade.sh/github",
+	}
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "id or source already exists",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, mongoTesting.MockWriteException
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				ec, ok := err.(*meta.ErrConflict)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, ec.Type)
+				require.Equal(t, testGateway.ID, ec.ID)
+				require.Contains(t, ec.Reason, "already exists")
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error inserting new gateway")
+			},
+		},
+
+		{
+			name: "successful creation",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			err := store.Create(context.Background(), testGateway)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysStoreList(t *testing.T) {
+	testGateway := api.Gateway{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "github",
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(gateways api.GatewayList, err error)
+	}{
+
+		{
+			name: "error finding gateways",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ api.GatewayList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding gateways")
+			},
+		},
+
+		{
+			name: "gateways found; no more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testGateway)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(gateways api.GatewayList, err error) {
+				require.NoError(t, err)
+				require.Empty(t, gateways.Continue)
+				require.Zero(t, gateways.RemainingItemCount)
+				require.Len(t, gateways.Items, 1)
+				require.Equal(t, testGateway.ID, gateways.Items[0].ID)
+			},
+		},
+
+		{
+			name: "gateways found; more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testGateway)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 5, nil
+				},
+			},
+			assertions: func(gateways api.GatewayList, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testGateway.ID, gateways.Continue)
+				require.Equal(t, int64(5), gateways.RemainingItemCount)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			gateways, err := store.List(
+				context.Background(),
+				meta.ListOptions{
+					Limit: 1,
+				},
+			)
+			testCase.assertions(gateways, err)
+		})
+	}
+}
+
+func TestGatewaysStoreGet(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(api.Gateway, error)
+	}{
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(mongo.ErrNoDocuments)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Equal(t, testGatewayID, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						errors.New("something went wrong"),
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding/decoding gateway")
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						api.Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: testGatewayID,
+							},
+						},
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(gateway api.Gateway, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testGatewayID, gateway.ID)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			gateway, err := store.Get(context.Background(), testGatewayID)
+			testCase.assertions(gateway, err)
+		})
+	}
+}
+
+func TestGatewaysStoreGetByHashedToken(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(api.Gateway, error)
+	}{
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(mongo.ErrNoDocuments)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Empty(t, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						errors.New("something went wrong"),
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding/decoding gateway by hashed token")
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						api.Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: testGatewayID,
+							},
+						},
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(gateway api.Gateway, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testGatewayID, gateway.ID)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			gateway, err := store.GetByHashedToken(context.Background(), "abcdefg")
+			testCase.assertions(gateway, err)
+		})
+	}
+}
+
+func TestGatewaysStoreGetBySource(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(api.Gateway, error)
+	}{
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(mongo.ErrNoDocuments)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Empty(t, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						errors.New("something went wrong"),
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Gateway, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding/decoding gateway owning source")
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						api.Gateway{
+							ObjectMeta: meta.ObjectMeta{
+								ID: testGatewayID,
+							},
+						},
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(gateway api.Gateway, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testGatewayID, gateway.ID)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			gateway, err := store.GetBySource(context.Background(), "brigade.sh/github")
+			testCase.assertions(gateway, err)
+		})
+	}
+}
+
+func TestGatewaysStoreUpdate(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(error)
+	}{
+
+		{
+			name: "source already owned by another gateway",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, mongoTesting.MockWriteException
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				ec, ok := err.(*meta.ErrConflict)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, ec.Type)
+				require.Equal(t, testGatewayID, ec.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error updating gateway")
+			},
+		},
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{
+						MatchedCount: 0,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Equal(t, testGatewayID, enf.ID)
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{
+						MatchedCount: 1,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			err := store.Update(
+				context.Background(),
+				api.Gateway{
+					ObjectMeta: meta.ObjectMeta{
+						ID: testGatewayID,
+					},
+				},
+			)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysStoreUpdateLastSeen(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(error)
+	}{
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "last seen time")
+			},
+		},
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{
+						MatchedCount: 0,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Equal(t, testGatewayID, enf.ID)
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return &mongo.UpdateResult{
+						MatchedCount: 1,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			err := store.UpdateLastSeen(
+				context.Background(),
+				testGatewayID,
+				time.Now().UTC(),
+			)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestGatewaysStoreDelete(t *testing.T) {
+	const testGatewayID = "github"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "gateway not found",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return &mongo.DeleteResult{
+						DeletedCount: 0,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.GatewayKind, enf.Type)
+				require.Equal(t, testGatewayID, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error deleting gateway")
+			},
+		},
+
+		{
+			name: "gateway found",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return &mongo.DeleteResult{
+						DeletedCount: 1,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &gatewaysStore{
+				collection: testCase.collection,
+			}
+			err := store.Delete(context.Background(), testGatewayID)
+			testCase.assertions(err)
+		})
+	}
+}
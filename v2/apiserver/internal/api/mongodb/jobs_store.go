@@ -61,18 +61,25 @@ func (j *jobsStore) UpdateStatus(
 	eventID string,
 	jobName string,
 	status api.JobStatus,
+	transition *api.PhaseTransition,
 ) error {
+	update := bson.M{
+		"$set": bson.M{
+			"worker.jobs.$.status": status,
+		},
+	}
+	if transition != nil {
+		update["$push"] = bson.M{
+			"worker.jobs.$.timeline": transition,
+		}
+	}
 	res, err := j.collection.UpdateOne(
 		ctx,
 		bson.M{
 			"id":               eventID,
 			"worker.jobs.name": jobName,
 		},
-		bson.M{
-			"$set": bson.M{
-				"worker.jobs.$.status": status,
-			},
-		},
+		update,
 	)
 	if err != nil {
 		return errors.Wrapf(
@@ -172,6 +172,7 @@ func TestJobsStoreUpdateStatus(t *testing.T) {
 				testEvent,
 				testJobName,
 				api.JobStatus{},
+				nil,
 			)
 			testCase.assertions(err)
 		})
@@ -0,0 +1,83 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// leasesStore is a MongoDB-based implementation of the api.LeasesStore
+// interface.
+type leasesStore struct {
+	collection mongodb.Collection
+}
+
+// NewLeasesStore returns a MongoDB-based implementation of the api.LeasesStore
+// interface.
+func NewLeasesStore(database *mongo.Database) (api.LeasesStore, error) {
+	ctx, cancel :=
+		context.WithTimeout(context.Background(), createIndexTimeout)
+	defer cancel()
+	unique := true
+	collection := database.Collection("leases")
+	if _, err := collection.Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.M{
+					"name": 1,
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "error adding indexes to leases collection")
+	}
+	return &leasesStore{
+		collection: collection,
+	}, nil
+}
+
+func (l *leasesStore) Acquire(
+	ctx context.Context,
+	name string,
+	holder string,
+	until time.Time,
+) (bool, error) {
+	upsert := true
+	if _, err := l.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"name": name,
+			"$or": []bson.M{
+				{"holder": holder},
+				{"until": bson.M{"$lt": time.Now().UTC()}},
+			},
+		},
+		bson.M{
+			"$set": bson.M{
+				"holder": holder,
+				"until":  until,
+			},
+		},
+		&options.UpdateOptions{
+			Upsert: &upsert,
+		},
+	); err != nil {
+		// If the lease exists but is held by another holder, the filter matches
+		// nothing and the attempted insert collides with the existing lease.
+		if mongodb.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error acquiring lease %q", name)
+	}
+	return true, nil
+}
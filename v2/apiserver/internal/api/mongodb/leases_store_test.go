@@ -0,0 +1,92 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestLeasesStoreAcquire(t *testing.T) {
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(held bool, err error)
+	}{
+		{
+			name: "error updating lease",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ bool, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error acquiring lease")
+			},
+		},
+		{
+			name: "lease held by another holder",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					context.Context,
+					interface{},
+					interface{},
+					...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					return nil, mongoTesting.MockWriteException
+				},
+			},
+			assertions: func(held bool, err error) {
+				require.NoError(t, err)
+				require.False(t, held)
+			},
+		},
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					_ context.Context,
+					_ interface{},
+					_ interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					require.Len(t, opts, 1)
+					require.True(t, *opts[0].Upsert)
+					return &mongo.UpdateResult{UpsertedCount: 1}, nil
+				},
+			},
+			assertions: func(held bool, err error) {
+				require.NoError(t, err)
+				require.True(t, held)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &leasesStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(
+				store.Acquire(
+					context.Background(),
+					"foo",
+					"bar",
+					time.Now().UTC().Add(time.Hour),
+				),
+			)
+		})
+	}
+}
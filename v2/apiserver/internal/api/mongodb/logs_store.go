@@ -2,12 +2,17 @@ package mongodb
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -21,13 +26,48 @@ type logsStore struct {
 // interface. This implementation relies on a log aggregator having forwarded
 // and stored log entries-- a process which necessarily introduces some latency.
 // Callers should favor another implementation of the api.LogsStore interface
-// and fall back on this implementation when the other fails.
-func NewLogsStore(database *mongo.Database) api.CoolLogsStore {
+// and fall back on this implementation when the other fails. The returned
+// store also implements the api.LogsExportStore interface so that logs can be
+// migrated out of the database.
+func NewLogsStore(database *mongo.Database) api.LogsExportStore {
 	return &logsStore{
 		collection: database.Collection("logs"),
 	}
 }
 
+// NewLogsSearchStore returns a MongoDB-based implementation of the
+// api.LogsSearchStore interface. It searches the same log entries that are
+// streamed by the implementation of the api.CoolLogsStore interface returned
+// by NewLogsStore.
+func NewLogsSearchStore(database *mongo.Database) (api.LogsSearchStore, error) {
+	ctx, cancel :=
+		context.WithTimeout(context.Background(), createIndexTimeout)
+	defer cancel()
+	collection := database.Collection("logs")
+	if _, err := collection.Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys: bson.D{
+					{Key: "log", Value: "text"},
+				},
+			},
+			{
+				Keys: bson.D{
+					{Key: "project", Value: 1},
+					{Key: "time", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "error adding indexes to logs collection")
+	}
+	return &logsStore{
+		collection: collection,
+	}, nil
+}
+
 func (l *logsStore) StreamLogs(
 	ctx context.Context,
 	_ api.Project,
@@ -36,38 +76,111 @@ func (l *logsStore) StreamLogs(
 	opts api.LogStreamOptions,
 ) (<-chan api.LogEntry, error) {
 	criteria := criteriaFromSelector(event.ID, selector)
+	if opts.Stream != "" {
+		criteria["stream"] = opts.Stream
+	}
+	if opts.Since != nil || opts.Until != nil {
+		timeCriteria := bson.M{}
+		if opts.Since != nil {
+			timeCriteria["$gte"] = *opts.Since
+		}
+		if opts.Until != nil {
+			timeCriteria["$lt"] = *opts.Until
+		}
+		criteria["time"] = timeCriteria
+	}
+
+	findOpts := &options.FindOptions{}
+	if opts.TailLines > 0 {
+		// Select the most recent lines by sorting newest first. They're put back
+		// in chronological order before they're sent.
+		findOpts.SetSort(bson.D{
+			{Key: "time", Value: -1},
+			{Key: "_id", Value: -1},
+		})
+		findOpts.SetLimit(opts.TailLines)
+	}
 
 	logEntryCh := make(chan api.LogEntry)
 	go func() {
 		defer close(logEntryCh)
 
-		cur, err := l.collection.Find(
-			ctx,
-			criteria,
-			&options.FindOptions{},
-		)
+		cur, err := l.collection.Find(ctx, criteria, findOpts)
+		if err != nil {
+			api.ReportLogStreamError(
+				ctx,
+				errors.Wrap(err, "error finding log entries"),
+			)
+			return
+		}
 
+		var logEntries []api.LogEntry
 		for cur.Next(ctx) {
 			logEntry := api.LogEntry{}
 			err = cur.Decode(&logEntry)
 			if err != nil {
-				log.Println(
+				api.ReportLogStreamError(
+					ctx,
 					errors.Wrapf(err, "error decoding log entry from collection"),
 				)
 				return
 			}
 
+			if opts.TailLines > 0 {
+				// Defer sending until all the most recent lines have been found
+				logEntries = append(logEntries, logEntry)
+				continue
+			}
+
 			select {
 			case logEntryCh <- logEntry:
 			case <-ctx.Done():
 				return
 			}
 		}
+		if err = cur.Err(); err != nil {
+			api.ReportLogStreamError(
+				ctx,
+				errors.Wrap(err, "error iterating over log entries"),
+			)
+			return
+		}
+
+		for i := len(logEntries) - 1; i >= 0; i-- {
+			select {
+			case logEntryCh <- logEntries[i]:
+			case <-ctx.Done():
+				return
+			}
+		}
 	}()
 
 	return logEntryCh, nil
 }
 
+func (l *logsStore) ExportLogs(
+	ctx context.Context,
+	event api.Event,
+	selector api.LogsSelector,
+	fn func(api.LogEntry) error,
+) error {
+	cur, err := l.collection.Find(ctx, criteriaFromSelector(event.ID, selector))
+	if err != nil {
+		return errors.Wrap(err, "error finding log entries")
+	}
+	defer cur.Close(ctx) // nolint: errcheck
+	for cur.Next(ctx) {
+		logEntry := api.LogEntry{}
+		if err = cur.Decode(&logEntry); err != nil {
+			return errors.Wrap(err, "error decoding log entry from collection")
+		}
+		if err = fn(logEntry); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(cur.Err(), "error iterating over log entries")
+}
+
 func criteriaFromSelector(
 	eventID string,
 	selector api.LogsSelector,
@@ -85,6 +198,129 @@ func criteriaFromSelector(
 	return criteria
 }
 
+// logSearchResult is an api.LogSearchResult that also carries the identifier
+// of the underlying log entry so that it can be used for pagination.
+type logSearchResult struct {
+	ID                  primitive.ObjectID `bson:"_id"`
+	api.LogSearchResult `bson:",inline"`
+}
+
+func (l *logsStore) SearchLogs(
+	ctx context.Context,
+	projectID string,
+	selector api.LogsSearchSelector,
+	opts meta.ListOptions,
+) (api.LogSearchResultList, error) {
+	results := api.LogSearchResultList{}
+
+	criteria := bson.M{
+		"project": projectID,
+	}
+	if selector.Text != "" {
+		criteria["$text"] = bson.M{"$search": selector.Text}
+	}
+	if selector.Regex != "" {
+		criteria["log"] = bson.M{"$regex": selector.Regex}
+	}
+	if selector.Job != "" {
+		criteria["job"] = selector.Job
+	}
+	if selector.Container != "" {
+		criteria["container"] = selector.Container
+	}
+	if selector.WrittenSince != nil || selector.WrittenUntil != nil {
+		timeCriteria := bson.M{}
+		if selector.WrittenSince != nil {
+			timeCriteria["$gte"] = *selector.WrittenSince
+		}
+		if selector.WrittenUntil != nil {
+			timeCriteria["$lt"] = *selector.WrittenUntil
+		}
+		criteria["time"] = timeCriteria
+	}
+	var continueCriteria bson.M
+	if opts.Continue != "" {
+		tokens := strings.Split(opts.Continue, ":")
+		if len(tokens) != 2 {
+			return results, errors.New("error parsing continue time")
+		}
+		continueTimeNano, err := strconv.ParseInt(tokens[0], 10, 64)
+		if err != nil {
+			return results, errors.Wrap(err, "error parsing continue time")
+		}
+		continueID, err := primitive.ObjectIDFromHex(tokens[1])
+		if err != nil {
+			return results, errors.Wrap(err, "error parsing continue ID")
+		}
+		continueCriteria = logEntriesAfter(
+			time.Unix(0, continueTimeNano).UTC(),
+			continueID,
+		)
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(
+		// bson.D preserves order, and we want to sort by time FIRST and id SECOND
+		bson.D{
+			{Key: "time", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	)
+	findOptions.SetLimit(opts.Limit)
+	cur, err := l.collection.Find(
+		ctx,
+		withContinueCriteria(criteria, continueCriteria),
+		findOptions,
+	)
+	if err != nil {
+		return results, errors.Wrap(err, "error finding log entries")
+	}
+	items := []logSearchResult{}
+	if err := cur.All(ctx, &items); err != nil {
+		return results, errors.Wrap(err, "error decoding log entries")
+	}
+	results.Items = make([]api.LogSearchResult, len(items))
+	for i, item := range items {
+		results.Items[i] = item.LogSearchResult
+	}
+
+	if int64(len(items)) == opts.Limit {
+		last := items[opts.Limit-1]
+		if last.Time == nil {
+			return results, nil
+		}
+		remaining, err := l.collection.CountDocuments(
+			ctx,
+			withContinueCriteria(criteria, logEntriesAfter(*last.Time, last.ID)),
+		)
+		if err != nil {
+			return results,
+				errors.Wrap(err, "error counting remaining log entries")
+		}
+		if remaining > 0 {
+			results.Continue =
+				fmt.Sprintf("%d:%s", last.Time.UnixNano(), last.ID.Hex())
+			results.RemainingItemCount = remaining
+		}
+	}
+
+	return results, nil
+}
+
+// logEntriesAfter returns criteria selecting log entries that sort after the
+// entry with the specified time and ID.
+func logEntriesAfter(
+	continueTime time.Time,
+	continueID primitive.ObjectID,
+) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"time": continueTime, "_id": bson.M{"$lt": continueID}},
+			{"time": bson.M{"$lt": continueTime}},
+		},
+	}
+}
+
 // DeleteEventLogs deletes all logs associated with the provided event from the
 // underlying mongo store.
 func (l *logsStore) DeleteEventLogs(
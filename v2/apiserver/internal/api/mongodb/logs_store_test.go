@@ -1,11 +1,22 @@
 package mongodb
 
+// nolint: lll
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // TODO: This is very difficult to test in isolation. The implementation of the
@@ -21,6 +32,159 @@ func TestLogStoreStreamLogs(t *testing.T) {
 	// require.Fail(t, "test me")
 }
 
+func TestLogStoreStreamLogsOptions(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	store := &logsStore{
+		collection: &mongoTesting.MockCollection{
+			FindFn: func(
+				_ context.Context,
+				filter interface{},
+				opts ...*options.FindOptions,
+			) (*mongo.Cursor, error) {
+				criteria, ok := filter.(bson.M)
+				require.True(t, ok)
+				require.Equal(
+					t,
+					bson.M{
+						"$gte": since,
+						"$lt":  until,
+					},
+					criteria["time"],
+				)
+				require.Equal(t, api.LogStreamStderr, criteria["stream"])
+				require.Len(t, opts, 1)
+				require.Equal(t, int64(2), *opts[0].Limit)
+				require.NotNil(t, opts[0].Sort)
+				// Most recent lines come back first
+				return mongoTesting.MockCursor(
+					api.LogEntry{Message: "third"},
+					api.LogEntry{Message: "second"},
+				)
+			},
+		},
+	}
+	logCh, err := store.StreamLogs(
+		context.Background(),
+		api.Project{},
+		api.Event{},
+		api.LogsSelector{},
+		api.LogStreamOptions{
+			TailLines: 2,
+			Stream:    api.LogStreamStderr,
+			Since:     &since,
+			Until:     &until,
+		},
+	)
+	require.NoError(t, err)
+	messages := []string{}
+	for logEntry := range logCh {
+		messages = append(messages, logEntry.Message)
+	}
+	// Lines should be sent in chronological order
+	require.Equal(t, []string{"second", "third"}, messages)
+}
+
+func TestLogStoreExportLogs(t *testing.T) {
+	testEvent := api.Event{
+		ObjectMeta: meta.ObjectMeta{ID: "123456789"},
+	}
+	testSelector := api.LogsSelector{Container: "worker"}
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		fn         func(api.LogEntry) error
+		assertions func(messages []string, err error)
+	}{
+		{
+			name: "error finding log entries",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					context.Context,
+					interface{},
+					...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ []string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding log entries")
+			},
+		},
+		{
+			name: "error from function",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					context.Context,
+					interface{},
+					...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return mongoTesting.MockCursor(
+						api.LogEntry{Message: "first"},
+						api.LogEntry{Message: "second"},
+					)
+				},
+			},
+			fn: func(api.LogEntry) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(messages []string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				// Exporting should have stopped at the first error
+				require.Equal(t, []string{"first"}, messages)
+			},
+		},
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					_ context.Context,
+					filter interface{},
+					_ ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					require.Equal(
+						t,
+						criteriaFromSelector(testEvent.ID, testSelector),
+						filter,
+					)
+					return mongoTesting.MockCursor(
+						api.LogEntry{Message: "first"},
+						api.LogEntry{Message: "second"},
+					)
+				},
+			},
+			fn: func(api.LogEntry) error {
+				return nil
+			},
+			assertions: func(messages []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"first", "second"}, messages)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &logsStore{
+				collection: testCase.collection,
+			}
+			messages := []string{}
+			err := store.ExportLogs(
+				context.Background(),
+				testEvent,
+				testSelector,
+				func(logEntry api.LogEntry) error {
+					messages = append(messages, logEntry.Message)
+					return testCase.fn(logEntry)
+				},
+			)
+			testCase.assertions(messages, err)
+		})
+	}
+}
+
 func TestCriteriaFromSelector(t *testing.T) {
 	const testEventID = "123456789"
 	const testJobName = "italian"
@@ -66,3 +230,227 @@ func TestCriteriaFromSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestLogStoreSearchLogs(t *testing.T) {
+	const testProjectID = "italian"
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	testID := primitive.NewObjectID()
+	testLogEntry := bson.M{
+		"_id":       testID,
+		"time":      now,
+		"project":   testProjectID,
+		"event":     "123456789",
+		"component": "job",
+		"job":       "foo",
+		"container": "bar",
+		"log":       "something went wrong",
+	}
+	testSince := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name       string
+		selector   api.LogsSearchSelector
+		opts       meta.ListOptions
+		collection mongodb.Collection
+		assertions func(results api.LogSearchResultList, err error)
+	}{
+
+		{
+			name: "invalid continue value",
+			opts: meta.ListOptions{
+				Continue: "foo",
+			},
+			assertions: func(_ api.LogSearchResultList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing continue time")
+			},
+		},
+
+		{
+			name: "invalid continue ID",
+			opts: meta.ListOptions{
+				Continue: "1:foo",
+			},
+			assertions: func(_ api.LogSearchResultList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing continue ID")
+			},
+		},
+
+		{
+			name: "error finding log entries",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ api.LogSearchResultList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding log entries")
+			},
+		},
+
+		{
+			name: "text search criteria are applied",
+			selector: api.LogsSearchSelector{
+				Text:         "wrong",
+				Job:          "foo",
+				Container:    "bar",
+				WrittenSince: &testSince,
+			},
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(t, testProjectID, criteria["project"])
+					require.Equal(t, bson.M{"$search": "wrong"}, criteria["$text"])
+					require.NotContains(t, criteria, "log")
+					require.Equal(t, "foo", criteria["job"])
+					require.Equal(t, "bar", criteria["container"])
+					require.Equal(t, bson.M{"$gte": testSince}, criteria["time"])
+					return mongoTesting.MockCursor(testLogEntry)
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(results api.LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Len(t, results.Items, 1)
+			},
+		},
+
+		{
+			name: "regex criteria are applied",
+			selector: api.LogsSearchSelector{
+				Regex: "went (wrong|right)",
+			},
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(
+						t,
+						bson.M{"$regex": "went (wrong|right)"},
+						criteria["log"],
+					)
+					require.NotContains(t, criteria, "$text")
+					return mongoTesting.MockCursor(testLogEntry)
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(results api.LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Len(t, results.Items, 1)
+			},
+		},
+
+		{
+			name: "log entries found; no more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return mongoTesting.MockCursor(testLogEntry)
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(results api.LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results.Continue)
+				require.Zero(t, results.RemainingItemCount)
+				require.Equal(
+					t,
+					[]api.LogSearchResult{
+						{
+							Time:      &now,
+							EventID:   "123456789",
+							Job:       "foo",
+							Container: "bar",
+							Message:   "something went wrong",
+						},
+					},
+					results.Items,
+				)
+			},
+		},
+
+		{
+			name: "log entries found; more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return mongoTesting.MockCursor(testLogEntry)
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 5, nil
+				},
+			},
+			assertions: func(results api.LogSearchResultList, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					fmt.Sprintf("%d:%s", now.UnixNano(), testID.Hex()),
+					results.Continue,
+				)
+				require.Equal(t, int64(5), results.RemainingItemCount)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &logsStore{
+				collection: testCase.collection,
+			}
+			if testCase.opts.Limit == 0 {
+				testCase.opts.Limit = 1
+			}
+			results, err := store.SearchLogs(
+				context.Background(),
+				testProjectID,
+				testCase.selector,
+				testCase.opts,
+			)
+			testCase.assertions(results, err)
+		})
+	}
+}
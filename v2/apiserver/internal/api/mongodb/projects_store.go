@@ -2,7 +2,6 @@ package mongodb
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
@@ -10,6 +9,7 @@ import (
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -39,6 +39,11 @@ func NewProjectsStore(database *mongo.Database) (api.ProjectsStore, error) {
 					Unique: &unique,
 				},
 			},
+			{
+				Keys: bson.M{
+					"spec.eventSubscriptions.source": 1,
+				},
+			},
 		},
 	); err != nil {
 		return nil, errors.Wrap(
@@ -55,6 +60,7 @@ func (p *projectsStore) Create(
 	ctx context.Context,
 	project api.Project,
 ) error {
+	project.ResourceVersion = 1
 	if _, err := p.collection.InsertOne(ctx, project); err != nil {
 		if mongodb.IsDuplicateKeyError(err) {
 			return &meta.ErrConflict{
@@ -121,81 +127,13 @@ func (p *projectsStore) ListSubscribers(
 	event api.Event,
 ) (api.ProjectList, error) {
 	projects := api.ProjectList{}
-	// Finding all Projects that are subscribed to a given Event is rather tricky.
-	// Matching on the basis of source, type, and qualifiers is easy enough, but
-	// LABELS account for the difficulty. If we were selecting Events, it's easy
-	// enough to say "select all events that have (at least) these labels," but
-	// when selecting PROJECTS, we're effectively working in reverse. We want to
-	// select all Projects having an EventSubscription that matches the Event's
-	// source, type, and qualifiers, AND doesn't have any labels that PRECLUDE a
-	// match. The only practical way of expressing those criteria in a MongoDB
-	// query is to provide a JavaScript function as a "where" clause. That
-	// function contains the logic for determining whether a SINGLE document
-	// (Project) is a match for the Event. For a large number of Projects, such a
-	// query is wildly inefficient, since the function needs to be applied to
-	// every single document (Project). To compensate, we build PRELIMINARY match
-	// criteria based on matching source, type, and qualifiers. MongoDB sensibly
-	// applies these criteria FIRST, then iterates over the preliminary results
-	// only, applying the provided "where" function to each in turn.
-	preliminaryMatchCriteria := bson.M{
-		"source": event.Source,
-		"types": bson.M{
-			"$in": []string{event.Type, "*"},
-		},
-	}
-	if len(event.Qualifiers) > 0 {
-		preliminaryMatchCriteria["qualifiers"] = event.Qualifiers
-	}
-	eventJSON, err := json.Marshal(
-		struct {
-			Source     string            `json:"source"`
-			Type       string            `json:"type"`
-			Qualifiers api.Qualifiers    `json:"qualifiers"`
-			Labels     map[string]string `json:"labels"`
-		}{
-			Source:     event.Source,
-			Type:       event.Type,
-			Qualifiers: event.Qualifiers,
-			Labels:     event.Labels,
-		},
-	)
-	// nolint: lll
-	where := fmt.Sprintf(`
-function() {
-	const project = this;
-	if (!project.spec.eventSubscriptions) {
-		return false;
-	}
-	const event = %s;
-	event.qualifiers = event.qualifiers || {};
-	event.labels = event.labels || {};
-	loop:
-	for (const subscription of project.spec.eventSubscriptions) {
-		subscription.qualifiers = subscription.qualifiers || {};
-		subscription.labels = subscription.labels || {};
-		if (subscription.source != event.source) continue;
-		if (!subscription.types.includes(event.type) && !subscription.types.includes("*")) continue; 
-		for (const key in event.qualifiers) {
-			if (subscription.qualifiers[key] != event.qualifiers[key]) continue loop;
-		}
-		for (const key in subscription.qualifiers) {
-			if (subscription.qualifiers[key] != event.qualifiers[key]) continue loop;
-		}
-		const eventLabelKeys = Object.keys(event.labels);
-		for (const labelKey in subscription.labels) {
-			if (!eventLabelKeys.includes(labelKey)) {
-				continue loop;
-			}
-			if (subscription.labels[labelKey] != event.labels[labelKey]) {
-				continue loop;
-			}
-		}
-		return true;
-	}
-	return false;
-}`,
-		eventJSON,
-	)
+	// EventSubscriptions may express types, qualifiers, and labels as glob
+	// patterns and may additionally specify CEL filters. None of these can be
+	// evaluated efficiently (or at all) by MongoDB, so we only select Projects
+	// having at least one EventSubscription for the Event's source that either
+	// lists the Event's type verbatim or lists at least one type pattern. This
+	// narrows the candidates considerably and leaves final matching to the
+	// caller.
 	findOptions := options.Find()
 	findOptions.SetSort(
 		// bson.D preserves order so we use this wherever we sort so that if
@@ -209,9 +147,43 @@ function() {
 		ctx,
 		bson.M{
 			"spec.eventSubscriptions": bson.M{
-				"$elemMatch": preliminaryMatchCriteria,
+				"$elemMatch": bson.M{
+					"source": event.Source,
+					"types": bson.M{
+						"$in": bson.A{
+							event.Type,
+							primitive.Regex{Pattern: `[*?]`},
+						},
+					},
+				},
 			},
-			"$where": where,
+		},
+		findOptions,
+	)
+	if err != nil {
+		return projects, errors.Wrap(err, "error finding projects")
+	}
+	if err := cur.All(ctx, &projects.Items); err != nil {
+		return projects, errors.Wrap(err, "error decoding projects")
+	}
+	return projects, nil
+}
+
+func (p *projectsStore) ListBySource(
+	ctx context.Context,
+	source string,
+) (api.ProjectList, error) {
+	projects := api.ProjectList{}
+	findOptions := options.Find()
+	findOptions.SetSort(
+		bson.D{
+			{Key: "id", Value: 1},
+		},
+	)
+	cur, err := p.collection.Find(
+		ctx,
+		bson.M{
+			"spec.eventSubscriptions.source": source,
 		},
 		findOptions,
 	)
@@ -247,22 +219,39 @@ func (p *projectsStore) Get(
 func (p *projectsStore) Update(
 	ctx context.Context, project api.Project,
 ) error {
+	criteria := bson.M{
+		"id": project.ID,
+	}
+	if project.ResourceVersion != 0 {
+		criteria["resourceVersion"] = project.ResourceVersion
+	}
 	res, err := p.collection.UpdateOne(
 		ctx,
-		bson.M{
-			"id": project.ID,
-		},
+		criteria,
 		bson.M{
 			"$set": bson.M{
 				"description": project.Description,
 				"spec":        project.Spec,
 			},
+			"$inc": bson.M{
+				"resourceVersion": 1,
+			},
 		},
 	)
 	if err != nil {
 		return errors.Wrapf(err, "error updating project %q", project.ID)
 	}
 	if res.MatchedCount == 0 {
+		if project.ResourceVersion != 0 {
+			return resourceVersionConflict(
+				ctx,
+				p.collection,
+				bson.M{"id": project.ID},
+				api.ProjectKind,
+				project.ID,
+				project.ResourceVersion,
+			)
+		}
 		return &meta.ErrNotFound{
 			Type: api.ProjectKind,
 			ID:   project.ID,
@@ -10,6 +10,7 @@ import (
 	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -265,6 +266,15 @@ func TestProjectsStoreListSubscribers(t *testing.T) {
 					filter interface{},
 					opts ...*options.FindOptions,
 				) (*mongo.Cursor, error) {
+					subscriptions :=
+						filter.(bson.M)["spec.eventSubscriptions"].(bson.M)
+					criteria := subscriptions["$elemMatch"].(bson.M)
+					require.Equal(t, testEvent.Source, criteria["source"])
+					require.Contains(
+						t,
+						criteria["types"].(bson.M)["$in"],
+						testEvent.Type,
+					)
 					cursor, err := mongoTesting.MockCursor(testProject1, testProject2)
 					require.NoError(t, err)
 					return cursor, nil
@@ -289,6 +299,72 @@ func TestProjectsStoreListSubscribers(t *testing.T) {
 	}
 }
 
+func TestProjectsStoreListBySource(t *testing.T) {
+	const testSource = "github.com/krancour/fake-gateway"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(projects api.ProjectList, err error)
+	}{
+		{
+			name: "error finding projects",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					context.Context,
+					interface{},
+					...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ api.ProjectList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding projects")
+			},
+		},
+		{
+			name: "found projects",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					_ context.Context,
+					filter interface{},
+					_ ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					require.Equal(
+						t,
+						bson.M{"spec.eventSubscriptions.source": testSource},
+						filter,
+					)
+					cursor, err := mongoTesting.MockCursor(
+						api.Project{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "project1",
+							},
+						},
+					)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+			},
+			assertions: func(projects api.ProjectList, err error) {
+				require.NoError(t, err)
+				require.Len(t, projects.Items, 1)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &projectsStore{
+				collection: testCase.collection,
+			}
+			testCase.assertions(
+				store.ListBySource(context.Background(), testSource),
+			)
+		})
+	}
+}
+
 func TestProjectsStoreGet(t *testing.T) {
 	const testProjectID = "blue-book"
 	testCases := []struct {
@@ -386,9 +462,10 @@ func TestProjectsStoreUpdate(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name       string
-		collection mongodb.Collection
-		assertions func(err error)
+		name            string
+		resourceVersion int64
+		collection      mongodb.Collection
+		assertions      func(err error)
 	}{
 
 		{
@@ -433,6 +510,40 @@ func TestProjectsStoreUpdate(t *testing.T) {
 			},
 		},
 
+		{
+			name:            "resource version mismatch",
+			resourceVersion: 3,
+			collection: &mongoTesting.MockCollection{
+				UpdateOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					update interface{},
+					opts ...*options.UpdateOptions,
+				) (*mongo.UpdateResult, error) {
+					criteria, ok := filter.(bson.M)
+					require.True(t, ok)
+					require.Equal(t, int64(3), criteria["resourceVersion"])
+					return &mongo.UpdateResult{
+						MatchedCount: 0,
+					}, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 1, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				ec, ok := err.(*meta.ErrConflict)
+				require.True(t, ok)
+				require.Equal(t, api.ProjectKind, ec.Type)
+				require.Equal(t, testProject.ID, ec.ID)
+			},
+		},
+
 		{
 			name: "project found",
 			collection: &mongoTesting.MockCollection{
@@ -458,7 +569,9 @@ func TestProjectsStoreUpdate(t *testing.T) {
 			store := &projectsStore{
 				collection: testCase.collection,
 			}
-			err := store.Update(context.Background(), testProject)
+			project := testProject
+			project.ResourceVersion = testCase.resourceVersion
+			err := store.Update(context.Background(), project)
 			testCase.assertions(err)
 		})
 	}
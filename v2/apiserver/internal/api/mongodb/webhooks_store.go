@@ -0,0 +1,208 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// webhooksStore is a MongoDB-based implementation of the api.WebhooksStore
+// interface.
+type webhooksStore struct {
+	collection mongodb.Collection
+}
+
+// NewWebhooksStore returns a MongoDB-based implementation of the
+// api.WebhooksStore interface.
+func NewWebhooksStore(database *mongo.Database) (api.WebhooksStore, error) {
+	ctx, cancel :=
+		context.WithTimeout(context.Background(), createIndexTimeout)
+	defer cancel()
+	unique := true
+	collection := database.Collection("webhooks")
+	if _, err := collection.Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				// Webhook IDs are only unique within the scope of a project
+				Keys: bson.D{
+					{Key: "projectID", Value: 1},
+					{Key: "id", Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Unique: &unique,
+				},
+			},
+		},
+	); err != nil {
+		return nil, errors.Wrap(err, "error adding indexes to webhooks collection")
+	}
+	return &webhooksStore{
+		collection: collection,
+	}, nil
+}
+
+func (w *webhooksStore) Create(ctx context.Context, webhook api.Webhook) error {
+	if _, err := w.collection.InsertOne(ctx, webhook); err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			return &meta.ErrConflict{
+				Type: api.WebhookKind,
+				ID:   webhook.ID,
+				Reason: fmt.Sprintf(
+					"A webhook with the ID %q already exists for project %q.",
+					webhook.ID,
+					webhook.ProjectID,
+				),
+			}
+		}
+		return errors.Wrapf(
+			err,
+			"error inserting new webhook %q for project %q",
+			webhook.ID,
+			webhook.ProjectID,
+		)
+	}
+	return nil
+}
+
+func (w *webhooksStore) List(
+	ctx context.Context,
+	projectID string,
+	opts meta.ListOptions,
+) (api.WebhookList, error) {
+	webhooks := api.WebhookList{}
+
+	criteria := bson.M{"projectID": projectID}
+	if opts.Continue != "" {
+		criteria["id"] = bson.M{"$gt": opts.Continue}
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(
+		// bson.D preserves order so we use this wherever we sort so that if
+		// additional sort criteria are added in the future, they will be applied
+		// in the specified order.
+		bson.D{
+			{Key: "id", Value: 1},
+		},
+	)
+	findOptions.SetLimit(opts.Limit)
+	cur, err := w.collection.Find(ctx, criteria, findOptions)
+	if err != nil {
+		return webhooks, errors.Wrapf(
+			err,
+			"error finding webhooks for project %q",
+			projectID,
+		)
+	}
+	if err := cur.All(ctx, &webhooks.Items); err != nil {
+		return webhooks, errors.Wrapf(
+			err,
+			"error decoding webhooks for project %q",
+			projectID,
+		)
+	}
+
+	if int64(len(webhooks.Items)) == opts.Limit {
+		continueID := webhooks.Items[opts.Limit-1].ID
+		criteria["id"] = bson.M{"$gt": continueID}
+		remaining, err := w.collection.CountDocuments(ctx, criteria)
+		if err != nil {
+			return webhooks, errors.Wrapf(
+				err,
+				"error counting remaining webhooks for project %q",
+				projectID,
+			)
+		}
+		if remaining > 0 {
+			webhooks.Continue = continueID
+			webhooks.RemainingItemCount = remaining
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (w *webhooksStore) Get(
+	ctx context.Context,
+	projectID string,
+	id string,
+) (api.Webhook, error) {
+	webhook := api.Webhook{}
+	res := w.collection.FindOne(
+		ctx,
+		bson.M{
+			"projectID": projectID,
+			"id":        id,
+		},
+	)
+	err := res.Decode(&webhook)
+	if err == mongo.ErrNoDocuments {
+		return webhook, &meta.ErrNotFound{
+			Type: api.WebhookKind,
+			ID:   id,
+		}
+	}
+	if err != nil {
+		return webhook, errors.Wrapf(
+			err,
+			"error finding/decoding webhook %q for project %q",
+			id,
+			projectID,
+		)
+	}
+	return webhook, nil
+}
+
+func (w *webhooksStore) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+) error {
+	res, err := w.collection.DeleteOne(
+		ctx,
+		bson.M{
+			"projectID": projectID,
+			"id":        id,
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error deleting webhook %q for project %q",
+			id,
+			projectID,
+		)
+	}
+	if res.DeletedCount == 0 {
+		return &meta.ErrNotFound{
+			Type: api.WebhookKind,
+			ID:   id,
+		}
+	}
+	return nil
+}
+
+func (w *webhooksStore) DeleteByProjectID(
+	ctx context.Context,
+	projectID string,
+) error {
+	if _, err := w.collection.DeleteMany(
+		ctx,
+		bson.M{"projectID": projectID},
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error deleting webhooks for project %q",
+			projectID,
+		)
+	}
+	return nil
+}
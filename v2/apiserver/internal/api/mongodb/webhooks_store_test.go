@@ -0,0 +1,430 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb"
+	mongoTesting "github.com/brigadecore/brigade/v2/apiserver/internal/lib/mongodb/testing" // nolint: lll
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestWebhooksStoreCreate(t *testing.T) {
+	testWebhook := api.Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "ci",
+		},
+		ProjectID: "italian",
+	}
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "id already exists",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, mongoTesting.MockWriteException
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				ec, ok := err.(*meta.ErrConflict)
+				require.True(t, ok)
+				require.Equal(t, api.WebhookKind, ec.Type)
+				require.Equal(t, testWebhook.ID, ec.ID)
+				require.Contains(t, ec.Reason, "already exists")
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error inserting new webhook")
+			},
+		},
+
+		{
+			name: "successful creation",
+			collection: &mongoTesting.MockCollection{
+				InsertOneFn: func(
+					ctx context.Context,
+					document interface{},
+					opts ...*options.InsertOneOptions,
+				) (*mongo.InsertOneResult, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhooksStore{
+				collection: testCase.collection,
+			}
+			err := store.Create(context.Background(), testWebhook)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestWebhooksStoreList(t *testing.T) {
+	testWebhook := api.Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "ci",
+		},
+		ProjectID: "italian",
+	}
+
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(webhooks api.WebhookList, err error)
+	}{
+
+		{
+			name: "error finding webhooks",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ api.WebhookList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding webhooks")
+			},
+		},
+
+		{
+			name: "webhooks found; no more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testWebhook)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 0, nil
+				},
+			},
+			assertions: func(webhooks api.WebhookList, err error) {
+				require.NoError(t, err)
+				require.Empty(t, webhooks.Continue)
+				require.Zero(t, webhooks.RemainingItemCount)
+				require.Len(t, webhooks.Items, 1)
+				require.Equal(t, testWebhook.ID, webhooks.Items[0].ID)
+			},
+		},
+
+		{
+			name: "webhooks found; more pages of results exist",
+			collection: &mongoTesting.MockCollection{
+				FindFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOptions,
+				) (*mongo.Cursor, error) {
+					cursor, err := mongoTesting.MockCursor(testWebhook)
+					require.NoError(t, err)
+					return cursor, nil
+				},
+				CountDocumentsFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.CountOptions,
+				) (int64, error) {
+					return 5, nil
+				},
+			},
+			assertions: func(webhooks api.WebhookList, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testWebhook.ID, webhooks.Continue)
+				require.Equal(t, int64(5), webhooks.RemainingItemCount)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhooksStore{
+				collection: testCase.collection,
+			}
+			webhooks, err := store.List(
+				context.Background(),
+				testWebhook.ProjectID,
+				meta.ListOptions{
+					Limit: 1,
+				},
+			)
+			testCase.assertions(webhooks, err)
+		})
+	}
+}
+
+func TestWebhooksStoreGet(t *testing.T) {
+	const testProjectID = "italian"
+	const testWebhookID = "ci"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(api.Webhook, error)
+	}{
+
+		{
+			name: "webhook not found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(mongo.ErrNoDocuments)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Webhook, err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.WebhookKind, enf.Type)
+				require.Equal(t, testWebhookID, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						errors.New("something went wrong"),
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(_ api.Webhook, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error finding/decoding webhook")
+			},
+		},
+
+		{
+			name: "webhook found",
+			collection: &mongoTesting.MockCollection{
+				FindOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.FindOneOptions,
+				) *mongo.SingleResult {
+					res, err := mongoTesting.MockSingleResult(
+						api.Webhook{
+							ObjectMeta: meta.ObjectMeta{
+								ID: testWebhookID,
+							},
+							ProjectID:   testProjectID,
+							HashedToken: "abcdefg",
+						},
+					)
+					require.NoError(t, err)
+					return res
+				},
+			},
+			assertions: func(webhook api.Webhook, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testWebhookID, webhook.ID)
+				require.Equal(t, testProjectID, webhook.ProjectID)
+				require.Equal(t, "abcdefg", webhook.HashedToken)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhooksStore{
+				collection: testCase.collection,
+			}
+			webhook, err :=
+				store.Get(context.Background(), testProjectID, testWebhookID)
+			testCase.assertions(webhook, err)
+		})
+	}
+}
+
+func TestWebhooksStoreDelete(t *testing.T) {
+	const testProjectID = "italian"
+	const testWebhookID = "ci"
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "webhook not found",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return &mongo.DeleteResult{
+						DeletedCount: 0,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				enf, ok := err.(*meta.ErrNotFound)
+				require.True(t, ok)
+				require.Equal(t, api.WebhookKind, enf.Type)
+				require.Equal(t, testWebhookID, enf.ID)
+			},
+		},
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error deleting webhook")
+			},
+		},
+
+		{
+			name: "webhook found",
+			collection: &mongoTesting.MockCollection{
+				DeleteOneFn: func(
+					ctx context.Context,
+					filter interface{},
+					opts ...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return &mongo.DeleteResult{
+						DeletedCount: 1,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhooksStore{
+				collection: testCase.collection,
+			}
+			err := store.Delete(context.Background(), testProjectID, testWebhookID)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestWebhooksStoreDeleteByProjectID(t *testing.T) {
+	testCases := []struct {
+		name       string
+		collection mongodb.Collection
+		assertions func(err error)
+	}{
+
+		{
+			name: "unanticipated error",
+			collection: &mongoTesting.MockCollection{
+				DeleteManyFn: func(
+					context.Context,
+					interface{},
+					...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error deleting webhooks")
+			},
+		},
+
+		{
+			name: "success",
+			collection: &mongoTesting.MockCollection{
+				DeleteManyFn: func(
+					context.Context,
+					interface{},
+					...*options.DeleteOptions,
+				) (*mongo.DeleteResult, error) {
+					return &mongo.DeleteResult{
+						DeletedCount: 2,
+					}, nil
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &webhooksStore{
+				collection: testCase.collection,
+			}
+			err := store.DeleteByProjectID(context.Background(), "italian")
+			testCase.assertions(err)
+		})
+	}
+}
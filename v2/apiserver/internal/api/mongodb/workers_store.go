@@ -32,16 +32,19 @@ func (w *workersStore) UpdateStatus(
 	ctx context.Context,
 	eventID string,
 	status api.WorkerStatus,
+	transition *api.PhaseTransition,
 ) error {
-	res, err := w.collection.UpdateOne(
-		ctx,
-		bson.M{"id": eventID},
-		bson.M{
-			"$set": bson.M{
-				"worker.status": status,
-			},
+	update := bson.M{
+		"$set": bson.M{
+			"worker.status": status,
 		},
-	)
+	}
+	if transition != nil {
+		update["$push"] = bson.M{
+			"worker.timeline": transition,
+		}
+	}
+	res, err := w.collection.UpdateOne(ctx, bson.M{"id": eventID}, update)
 	if err != nil {
 		return errors.Wrapf(
 			err,
@@ -91,6 +94,7 @@ func (w *workersStore) UpdateHashedToken(
 func (w *workersStore) Timeout(
 	ctx context.Context,
 	eventID string,
+	transition api.PhaseTransition,
 ) error {
 	timedOutTime := time.Now().UTC()
 
@@ -109,9 +113,25 @@ func (w *workersStore) Timeout(
 			"$set": bson.M{
 				"worker.status.ended":                           timedOutTime,
 				"worker.status.phase":                           api.WorkerPhaseTimedOut, // nolint: lll
+				"worker.status.reason":                          transition.Reason,
+				"worker.status.principal":                       transition.Principal,
 				"worker.jobs.$[pending].status.phase":           api.JobPhaseCanceled,
 				"worker.jobs.$[startingOrRunning].status.phase": api.JobPhaseAborted,
 			},
+			"$push": bson.M{
+				"worker.timeline": transitionTo(
+					transition,
+					string(api.WorkerPhaseTimedOut),
+				),
+				"worker.jobs.$[pending].timeline": transitionTo(
+					transition,
+					string(api.JobPhaseCanceled),
+				),
+				"worker.jobs.$[startingOrRunning].timeline": transitionTo(
+					transition,
+					string(api.JobPhaseAborted),
+				),
+			},
 		},
 		&options.UpdateOptions{
 			ArrayFilters: &options.ArrayFilters{
@@ -153,3 +173,13 @@ func (w *workersStore) Timeout(
 
 	return nil
 }
+
+// transitionTo returns a copy of the provided PhaseTransition with its Phase
+// replaced by the specified phase.
+func transitionTo(
+	transition api.PhaseTransition,
+	phase string,
+) api.PhaseTransition {
+	transition.Phase = phase
+	return transition
+}
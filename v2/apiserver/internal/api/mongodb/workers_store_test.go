@@ -10,6 +10,7 @@ import (
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -64,11 +65,14 @@ func TestWorkersStoreUpdateStatus(t *testing.T) {
 			name: "success",
 			collection: &mongoTesting.MockCollection{
 				UpdateOneFn: func(
-					context.Context,
-					interface{},
-					interface{},
-					...*options.UpdateOptions,
+					_ context.Context,
+					_ interface{},
+					update interface{},
+					_ ...*options.UpdateOptions,
 				) (*mongo.UpdateResult, error) {
+					pushes, ok := update.(bson.M)["$push"].(bson.M)
+					require.True(t, ok)
+					require.Contains(t, pushes, "worker.timeline")
 					return &mongo.UpdateResult{
 						MatchedCount: 1,
 					}, nil
@@ -84,8 +88,14 @@ func TestWorkersStoreUpdateStatus(t *testing.T) {
 			store := &workersStore{
 				collection: testCase.collection,
 			}
-			err :=
-				store.UpdateStatus(context.Background(), testEvent, api.WorkerStatus{})
+			err := store.UpdateStatus(
+				context.Background(),
+				testEvent,
+				api.WorkerStatus{},
+				&api.PhaseTransition{
+					Phase: string(api.WorkerPhaseRunning),
+				},
+			)
 			testCase.assertions(err)
 		})
 	}
@@ -244,7 +254,11 @@ func TestWorkersStoreTimeout(t *testing.T) {
 			store := &workersStore{
 				collection: testCase.collection,
 			}
-			err := store.Timeout(context.Background(), testEvent)
+			err := store.Timeout(
+				context.Background(),
+				testEvent,
+				api.PhaseTransition{},
+			)
 			testCase.assertions(err)
 		})
 	}
@@ -83,3 +83,34 @@ func GetWorkerPrincipal(eventID string) *WorkerPrincipal {
 		eventID: eventID,
 	}
 }
+
+// WebhookPrincipal is an implementation of the Principal interface that
+// represents a Project's Webhook, which is a special class of user because,
+// although it cannot do much, it has the ability to create Events for the
+// Project that owns it.
+type WebhookPrincipal struct {
+	projectID string
+	webhookID string
+}
+
+func (w *WebhookPrincipal) RoleAssignments() []RoleAssignment {
+	return nil
+}
+
+func (w *WebhookPrincipal) ProjectRoleAssignments() []ProjectRoleAssignment {
+	return []ProjectRoleAssignment{
+		{
+			ProjectID: w.projectID,
+			Role:      RoleProjectUser,
+		},
+	}
+}
+
+// GetWebhookPrincipal returns a Principal that represents the specified
+// Webhook belonging to the specified Project.
+func GetWebhookPrincipal(projectID, webhookID string) *WebhookPrincipal {
+	return &WebhookPrincipal{
+		projectID: projectID,
+		webhookID: webhookID,
+	}
+}
@@ -0,0 +1,458 @@
+package objectstorage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/pkg/errors"
+)
+
+// Objects are laid out within the bucket as follows:
+//
+//	events/<event>/imported                           (content: project ID)
+//	events/<event>/worker/<container>/<chunk>.ndjson.gz
+//	events/<event>/worker/<container>/complete
+//	events/<event>/jobs/<job>/<container>/<chunk>.ndjson.gz
+//	events/<event>/jobs/<job>/<container>/complete
+//	projects/<project>/<event>                        (empty)
+//
+// Each chunk is a gzipped file containing one JSON-encoded log entry per line.
+// Chunks are numbered so that listing them lexically also lists them in the
+// order they were written. A container's "complete" marker is written after
+// its last chunk and an Event's "imported" marker is written after all of its
+// containers' markers. Objects under projects/ only serve as an index of the
+// Events belonging to each Project.
+const (
+	chunkSuffix      = ".ndjson.gz"
+	completeMarker   = "complete"
+	importedMarker   = "imported"
+	eventsPrefix     = "events"
+	projectsPrefix   = "projects"
+	maxChunkLines    = 10000
+	followPollPeriod = 5 * time.Second
+)
+
+// chunkEntry is the representation of an api.LogEntry within a chunk. The Job
+// and Container fields are omitted since they're implied by the chunk's key.
+type chunkEntry struct {
+	Time    *time.Time    `json:"time,omitempty"`
+	Stream  api.LogStream `json:"stream,omitempty"`
+	Message string        `json:"log"`
+}
+
+// logsStore is an implementation of the api.LogsImportStore interface that
+// stores each container's logs as a series of compressed chunks in an
+// objectstorage.Bucket.
+type logsStore struct {
+	bucket           objectstorage.Bucket
+	fallback         api.CoolLogsStore
+	maxChunkLines    int
+	followPollPeriod time.Duration
+}
+
+// NewLogsStore returns an implementation of the api.LogsImportStore interface
+// that stores each container's logs as a series of compressed chunks in the
+// provided objectstorage.Bucket. Logs that have not been imported into the
+// bucket are streamed from the provided fallback store instead, and deleting
+// logs deletes them from both the bucket and the fallback store.
+func NewLogsStore(
+	bucket objectstorage.Bucket,
+	fallback api.CoolLogsStore,
+) api.LogsImportStore {
+	return &logsStore{
+		bucket:           bucket,
+		fallback:         fallback,
+		maxChunkLines:    maxChunkLines,
+		followPollPeriod: followPollPeriod,
+	}
+}
+
+func (l *logsStore) StreamLogs(
+	ctx context.Context,
+	project api.Project,
+	event api.Event,
+	selector api.LogsSelector,
+	opts api.LogStreamOptions,
+) (<-chan api.LogEntry, error) {
+	prefix := containerPrefix(event.ID, selector)
+	complete, err := l.bucket.Exists(ctx, prefix+completeMarker)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error checking whether logs for event %q are complete",
+			event.ID,
+		)
+	}
+	chunkKeys, err := l.chunkKeys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fallback store retains logs until their import is complete. So unless
+	// the import is underway AND the client wants to follow it, logs that
+	// haven't been completely imported are streamed from the fallback store.
+	if !complete && (len(chunkKeys) == 0 || !opts.Follow) {
+		return l.fallback.StreamLogs(ctx, project, event, selector, opts)
+	}
+
+	logEntryCh := make(chan api.LogEntry)
+	go l.streamChunks(
+		ctx,
+		selector,
+		opts,
+		prefix,
+		chunkKeys,
+		complete,
+		logEntryCh,
+	)
+	return logEntryCh, nil
+}
+
+// streamChunks sends log entries from the specified chunks, all stored under
+// the specified prefix, in order, over the provided channel and closes it when
+// done. If following logs that are not yet complete, it continues to poll for
+// and send entries from new chunks until the logs are complete or the context
+// is canceled.
+func (l *logsStore) streamChunks(
+	ctx context.Context,
+	selector api.LogsSelector,
+	opts api.LogStreamOptions,
+	prefix string,
+	chunkKeys []string,
+	complete bool,
+	logEntryCh chan<- api.LogEntry,
+) {
+	defer close(logEntryCh)
+
+	// Only the chunks available when the stream was opened are tailed. Entries
+	// from chunks written later are sent as they're found.
+	tailing := opts.TailLines > 0
+	var tailEntries []api.LogEntry
+	for next := 0; ; {
+		for ; next < len(chunkKeys); next++ {
+			logEntries, err := l.readChunk(ctx, chunkKeys[next], selector)
+			if err != nil {
+				api.ReportLogStreamError(ctx, err)
+				return
+			}
+			for _, logEntry := range logEntries {
+				if opts.Stream != "" && logEntry.Stream != opts.Stream {
+					continue
+				}
+				if logEntry.Time != nil {
+					if opts.Since != nil && logEntry.Time.Before(*opts.Since) {
+						continue
+					}
+					if opts.Until != nil && !logEntry.Time.Before(*opts.Until) {
+						sendLogEntries(ctx, logEntryCh, tailEntries)
+						return
+					}
+				}
+				if tailing {
+					tailEntries = append(tailEntries, logEntry)
+					if int64(len(tailEntries)) > opts.TailLines {
+						tailEntries = tailEntries[1:]
+					}
+					continue
+				}
+				if !sendLogEntries(ctx, logEntryCh, []api.LogEntry{logEntry}) {
+					return
+				}
+			}
+		}
+		if tailing {
+			if !sendLogEntries(ctx, logEntryCh, tailEntries) {
+				return
+			}
+			tailing = false
+			tailEntries = nil
+		}
+
+		if complete || !opts.Follow {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.followPollPeriod):
+		}
+		// Completeness is checked BEFORE listing chunks so that if the logs are
+		// found to be complete, the listing is certain to include the last chunk.
+		var err error
+		if complete, err =
+			l.bucket.Exists(ctx, prefix+completeMarker); err != nil {
+			api.ReportLogStreamError(
+				ctx,
+				errors.Wrap(err, "error checking whether logs are complete"),
+			)
+			return
+		}
+		if chunkKeys, err = l.chunkKeys(ctx, prefix); err != nil {
+			api.ReportLogStreamError(ctx, err)
+			return
+		}
+	}
+}
+
+// sendLogEntries sends the provided log entries over the provided channel. It
+// returns false if the context was canceled before all were sent.
+func sendLogEntries(
+	ctx context.Context,
+	logEntryCh chan<- api.LogEntry,
+	logEntries []api.LogEntry,
+) bool {
+	for _, logEntry := range logEntries {
+		select {
+		case logEntryCh <- logEntry:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// chunkKeys returns the keys of all chunks stored under the specified prefix,
+// in the order they were written.
+func (l *logsStore) chunkKeys(
+	ctx context.Context,
+	prefix string,
+) ([]string, error) {
+	keys, err := l.bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing log chunks")
+	}
+	chunkKeys := []string{}
+	for _, key := range keys {
+		if strings.HasSuffix(key, chunkSuffix) {
+			chunkKeys = append(chunkKeys, key)
+		}
+	}
+	return chunkKeys, nil
+}
+
+// readChunk retrieves and decodes all log entries from the chunk having the
+// specified key.
+func (l *logsStore) readChunk(
+	ctx context.Context,
+	key string,
+	selector api.LogsSelector,
+) ([]api.LogEntry, error) {
+	content, err := l.bucket.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving log chunk %q", key)
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decompressing log chunk %q", key)
+	}
+	decoder := json.NewDecoder(gzipReader)
+	logEntries := []api.LogEntry{}
+	for {
+		entry := chunkEntry{}
+		if err = decoder.Decode(&entry); err == io.EOF {
+			return logEntries, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "error decoding log chunk %q", key)
+		}
+		logEntries = append(
+			logEntries,
+			api.LogEntry{
+				Time:      entry.Time,
+				Message:   entry.Message,
+				Stream:    entry.Stream,
+				Job:       selector.Job,
+				Container: selector.Container,
+			},
+		)
+	}
+}
+
+func (l *logsStore) HasEventLogs(
+	ctx context.Context,
+	eventID string,
+) (bool, error) {
+	return l.bucket.Exists(ctx, eventKey(eventID, importedMarker))
+}
+
+func (l *logsStore) ImportLogs(
+	ctx context.Context,
+	event api.Event,
+	selector api.LogsSelector,
+) (api.LogsWriter, error) {
+	prefix := containerPrefix(event.ID, selector)
+	// Discard anything left behind by a previous attempt
+	keys, err := l.bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing existing log chunks")
+	}
+	for _, key := range keys {
+		if err = l.bucket.Delete(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	return &logsWriter{
+		ctx:           ctx,
+		bucket:        l.bucket,
+		prefix:        prefix,
+		maxChunkLines: l.maxChunkLines,
+	}, nil
+}
+
+func (l *logsStore) CompleteEventLogs(
+	ctx context.Context,
+	event api.Event,
+) error {
+	// The index entry is written first so that the Event's logs are always
+	// found when its Project's logs are deleted.
+	if err := l.bucket.Put(
+		ctx,
+		path.Join(projectsPrefix, event.ProjectID, event.ID),
+		[]byte{},
+	); err != nil {
+		return err
+	}
+	return l.bucket.Put(
+		ctx,
+		eventKey(event.ID, importedMarker),
+		[]byte(event.ProjectID),
+	)
+}
+
+func (l *logsStore) DeleteEventLogs(ctx context.Context, id string) error {
+	projectID, err := l.bucket.Get(ctx, eventKey(id, importedMarker))
+	if err != nil && err != objectstorage.ErrObjectNotFound {
+		return errors.Wrapf(err, "error retrieving project for event %q", id)
+	}
+	if err = l.deleteEventObjects(ctx, id); err != nil {
+		return err
+	}
+	if len(projectID) > 0 {
+		if err = l.bucket.Delete(
+			ctx,
+			path.Join(projectsPrefix, string(projectID), id),
+		); err != nil {
+			return errors.Wrapf(err, "error deleting logs for event %q", id)
+		}
+	}
+	return l.fallback.DeleteEventLogs(ctx, id)
+}
+
+func (l *logsStore) DeleteProjectLogs(ctx context.Context, id string) error {
+	indexKeys, err := l.bucket.List(ctx, path.Join(projectsPrefix, id)+"/")
+	if err != nil {
+		return errors.Wrapf(err, "error listing events for project %q", id)
+	}
+	for _, indexKey := range indexKeys {
+		if err = l.deleteEventObjects(ctx, path.Base(indexKey)); err != nil {
+			return err
+		}
+		if err = l.bucket.Delete(ctx, indexKey); err != nil {
+			return errors.Wrapf(err, "error deleting logs for project %q", id)
+		}
+	}
+	return l.fallback.DeleteProjectLogs(ctx, id)
+}
+
+// deleteEventObjects deletes all objects belonging to the specified Event.
+func (l *logsStore) deleteEventObjects(ctx context.Context, id string) error {
+	keys, err := l.bucket.List(ctx, eventKey(id, ""))
+	if err != nil {
+		return errors.Wrapf(err, "error listing logs for event %q", id)
+	}
+	for _, key := range keys {
+		if err = l.bucket.Delete(ctx, key); err != nil {
+			return errors.Wrapf(err, "error deleting logs for event %q", id)
+		}
+	}
+	return nil
+}
+
+// logsWriter is an implementation of the api.LogsWriter interface that
+// buffers log entries and writes them to an objectstorage.Bucket in
+// compressed chunks.
+type logsWriter struct {
+	ctx           context.Context
+	bucket        objectstorage.Bucket
+	prefix        string
+	maxChunkLines int
+	chunk         int
+	lines         int
+	buffer        bytes.Buffer
+	gzipWriter    *gzip.Writer
+	encoder       *json.Encoder
+}
+
+func (l *logsWriter) Write(logEntry api.LogEntry) error {
+	if l.gzipWriter == nil {
+		l.buffer.Reset()
+		l.gzipWriter = gzip.NewWriter(&l.buffer)
+		l.encoder = json.NewEncoder(l.gzipWriter)
+	}
+	if err := l.encoder.Encode(
+		chunkEntry{
+			Time:    logEntry.Time,
+			Stream:  logEntry.Stream,
+			Message: logEntry.Message,
+		},
+	); err != nil {
+		return errors.Wrap(err, "error encoding log entry")
+	}
+	if l.lines++; l.lines >= l.maxChunkLines {
+		return l.flush()
+	}
+	return nil
+}
+
+func (l *logsWriter) Close() error {
+	if l.gzipWriter != nil {
+		if err := l.flush(); err != nil {
+			return err
+		}
+	}
+	return l.bucket.Put(l.ctx, l.prefix+completeMarker, []byte{})
+}
+
+// flush writes all buffered log entries as a new chunk.
+func (l *logsWriter) flush() error {
+	if err := l.gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "error compressing log chunk")
+	}
+	if err := l.bucket.Put(
+		l.ctx,
+		fmt.Sprintf("%s%08d%s", l.prefix, l.chunk, chunkSuffix),
+		l.buffer.Bytes(),
+	); err != nil {
+		return err
+	}
+	l.chunk++
+	l.lines = 0
+	l.gzipWriter = nil
+	return nil
+}
+
+// containerPrefix returns the prefix of the keys of all objects belonging to
+// the container specified by the provided Event ID and LogsSelector.
+func containerPrefix(eventID string, selector api.LogsSelector) string {
+	if selector.Job == "" {
+		return eventKey(eventID, path.Join("worker", selector.Container)) + "/"
+	}
+	return eventKey(
+		eventID,
+		path.Join("jobs", selector.Job, selector.Container),
+	) + "/"
+}
+
+// eventKey returns the key of the object having the specified name and
+// belonging to the Event with the specified ID.
+func eventKey(eventID string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", eventsPrefix, eventID, name)
+}
@@ -0,0 +1,408 @@
+package objectstorage
+
+// nolint: lll
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage/filesystem"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEvent = api.Event{
+		ObjectMeta: meta.ObjectMeta{ID: "tony"},
+		ProjectID:  "italian",
+	}
+	testSelector = api.LogsSelector{
+		Job:       "foo",
+		Container: "bar",
+	}
+	testStart = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestNewLogsStore(t *testing.T) {
+	bucket := newTestBucket(t)
+	fallback := &mockLogsStore{}
+	store, ok := NewLogsStore(bucket, fallback).(*logsStore)
+	require.True(t, ok)
+	require.Same(t, bucket, store.bucket)
+	require.Same(t, fallback, store.fallback)
+	require.Equal(t, maxChunkLines, store.maxChunkLines)
+	require.Equal(t, followPollPeriod, store.followPollPeriod)
+}
+
+func TestLogsStoreImportLogs(t *testing.T) {
+	ctx := context.Background()
+	bucket := newTestBucket(t)
+	store := &logsStore{
+		bucket:        bucket,
+		maxChunkLines: 2,
+	}
+
+	// Something left behind by a previous attempt
+	require.NoError(
+		t,
+		bucket.Put(ctx, "events/tony/jobs/foo/bar/00000009.ndjson.gz", []byte{}),
+	)
+
+	importTestLogs(t, store, 5)
+
+	keys, err := bucket.List(ctx, "events/tony/")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{
+			"events/tony/jobs/foo/bar/00000000.ndjson.gz",
+			"events/tony/jobs/foo/bar/00000001.ndjson.gz",
+			"events/tony/jobs/foo/bar/00000002.ndjson.gz",
+			"events/tony/jobs/foo/bar/complete",
+		},
+		keys,
+	)
+	imported, err := store.HasEventLogs(ctx, testEvent.ID)
+	require.NoError(t, err)
+	require.False(t, imported)
+
+	require.NoError(t, store.CompleteEventLogs(ctx, testEvent))
+	imported, err = store.HasEventLogs(ctx, testEvent.ID)
+	require.NoError(t, err)
+	require.True(t, imported)
+	exists, err := bucket.Exists(ctx, "projects/italian/tony")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestLogsStoreStreamLogs(t *testing.T) {
+	since := testStart.Add(time.Minute)
+	until := testStart.Add(4 * time.Minute)
+	testCases := []struct {
+		name       string
+		opts       api.LogStreamOptions
+		assertions func(logEntries []api.LogEntry)
+	}{
+		{
+			name: "all lines",
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, testMessages(0, 5), messages(logEntries))
+				for _, logEntry := range logEntries {
+					require.Equal(t, testSelector.Job, logEntry.Job)
+					require.Equal(t, testSelector.Container, logEntry.Container)
+				}
+			},
+		},
+		{
+			name: "tail lines",
+			opts: api.LogStreamOptions{TailLines: 3},
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, testMessages(2, 5), messages(logEntries))
+			},
+		},
+		{
+			name: "stream",
+			opts: api.LogStreamOptions{Stream: api.LogStreamStderr},
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, []string{"line 1", "line 3"}, messages(logEntries))
+				for _, logEntry := range logEntries {
+					require.Equal(t, api.LogStreamStderr, logEntry.Stream)
+				}
+			},
+		},
+		{
+			name: "since and until",
+			opts: api.LogStreamOptions{
+				Since: &since,
+				Until: &until,
+			},
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, testMessages(1, 4), messages(logEntries))
+			},
+		},
+		{
+			name: "tail lines until",
+			opts: api.LogStreamOptions{
+				TailLines: 2,
+				Until:     &until,
+			},
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, testMessages(2, 4), messages(logEntries))
+			},
+		},
+		{
+			name: "follow complete logs",
+			opts: api.LogStreamOptions{Follow: true},
+			assertions: func(logEntries []api.LogEntry) {
+				require.Equal(t, testMessages(0, 5), messages(logEntries))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			store := &logsStore{
+				bucket:        newTestBucket(t),
+				maxChunkLines: 2,
+			}
+			importTestLogs(t, store, 5)
+			logCh, err := store.StreamLogs(
+				context.Background(),
+				api.Project{},
+				testEvent,
+				testSelector,
+				testCase.opts,
+			)
+			require.NoError(t, err)
+			testCase.assertions(drain(logCh))
+		})
+	}
+}
+
+func TestLogsStoreStreamLogsFallback(t *testing.T) {
+	ctx := context.Background()
+	fallbackCh := make(chan api.LogEntry)
+	close(fallbackCh)
+	testCases := []struct {
+		name     string
+		setup    func(*logsStore)
+		opts     api.LogStreamOptions
+		fallback bool
+	}{
+		{
+			name:     "logs not imported",
+			fallback: true,
+		},
+		{
+			name:     "logs not imported; following",
+			opts:     api.LogStreamOptions{Follow: true},
+			fallback: true,
+		},
+		{
+			name: "import in progress",
+			setup: func(store *logsStore) {
+				writer, err := store.ImportLogs(ctx, testEvent, testSelector)
+				require.NoError(t, err)
+				for i := 0; i < 2; i++ {
+					require.NoError(t, writer.Write(testLogEntry(i)))
+				}
+			},
+			fallback: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var fellBack bool
+			store := &logsStore{
+				bucket: newTestBucket(t),
+				fallback: &mockLogsStore{
+					StreamLogsFn: func(
+						context.Context,
+						api.Project,
+						api.Event,
+						api.LogsSelector,
+						api.LogStreamOptions,
+					) (<-chan api.LogEntry, error) {
+						fellBack = true
+						return fallbackCh, nil
+					},
+				},
+				maxChunkLines: 2,
+			}
+			if testCase.setup != nil {
+				testCase.setup(store)
+			}
+			logCh, err := store.StreamLogs(
+				ctx,
+				api.Project{},
+				testEvent,
+				testSelector,
+				testCase.opts,
+			)
+			require.NoError(t, err)
+			drain(logCh)
+			require.Equal(t, testCase.fallback, fellBack)
+		})
+	}
+}
+
+func TestLogsStoreStreamLogsFollow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	store := &logsStore{
+		bucket:           newTestBucket(t),
+		maxChunkLines:    2,
+		followPollPeriod: 10 * time.Millisecond,
+	}
+	writer, err := store.ImportLogs(ctx, testEvent, testSelector)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, writer.Write(testLogEntry(i)))
+	}
+
+	logCh, err := store.StreamLogs(
+		ctx,
+		api.Project{},
+		testEvent,
+		testSelector,
+		api.LogStreamOptions{Follow: true},
+	)
+	require.NoError(t, err)
+
+	// Entries from the chunk that was already written arrive first
+	for i := 0; i < 2; i++ {
+		select {
+		case logEntry := <-logCh:
+			require.Equal(t, testLogEntry(i).Message, logEntry.Message)
+		case <-ctx.Done():
+			require.Fail(t, "timed out waiting for log entry")
+		}
+	}
+
+	// The rest arrive as they're written and the stream concludes once the
+	// import is complete
+	for i := 2; i < 5; i++ {
+		require.NoError(t, writer.Write(testLogEntry(i)))
+	}
+	require.NoError(t, writer.Close())
+	require.Equal(t, testMessages(2, 5), messages(drain(logCh)))
+}
+
+func TestLogsStoreDeleteEventLogs(t *testing.T) {
+	ctx := context.Background()
+	var deletedID string
+	store := &logsStore{
+		bucket: newTestBucket(t),
+		fallback: &mockLogsStore{
+			DeleteEventLogsFn: func(_ context.Context, id string) error {
+				deletedID = id
+				return nil
+			},
+		},
+		maxChunkLines: 2,
+	}
+	importTestLogs(t, store, 5)
+	require.NoError(t, store.CompleteEventLogs(ctx, testEvent))
+
+	require.NoError(t, store.DeleteEventLogs(ctx, testEvent.ID))
+	keys, err := store.bucket.List(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	require.Equal(t, testEvent.ID, deletedID)
+}
+
+func TestLogsStoreDeleteProjectLogs(t *testing.T) {
+	ctx := context.Background()
+	var deletedID string
+	store := &logsStore{
+		bucket: newTestBucket(t),
+		fallback: &mockLogsStore{
+			DeleteProjectLogsFn: func(_ context.Context, id string) error {
+				deletedID = id
+				return nil
+			},
+		},
+		maxChunkLines: 2,
+	}
+	importTestLogs(t, store, 5)
+	require.NoError(t, store.CompleteEventLogs(ctx, testEvent))
+
+	require.NoError(t, store.DeleteProjectLogs(ctx, testEvent.ProjectID))
+	keys, err := store.bucket.List(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	require.Equal(t, testEvent.ProjectID, deletedID)
+}
+
+func newTestBucket(t *testing.T) objectstorage.Bucket {
+	bucket, err := filesystem.NewBucket(t.TempDir())
+	require.NoError(t, err)
+	return bucket
+}
+
+// importTestLogs imports the specified number of test log entries, one minute
+// apart, as the logs of the test container.
+func importTestLogs(t *testing.T, store *logsStore, count int) {
+	writer, err := store.ImportLogs(context.Background(), testEvent, testSelector)
+	require.NoError(t, err)
+	for i := 0; i < count; i++ {
+		require.NoError(t, writer.Write(testLogEntry(i)))
+	}
+	require.NoError(t, writer.Close())
+}
+
+// testLogEntry returns a test log entry. Odd-numbered entries are written to
+// stderr and even-numbered ones to stdout.
+func testLogEntry(i int) api.LogEntry {
+	logTime := testStart.Add(time.Duration(i) * time.Minute)
+	stream := api.LogStreamStdout
+	if i%2 == 1 {
+		stream = api.LogStreamStderr
+	}
+	return api.LogEntry{
+		Time:    &logTime,
+		Stream:  stream,
+		Message: fmt.Sprintf("line %d", i),
+	}
+}
+
+// testMessages returns the messages of test log entries from start
+// (inclusive) to end (exclusive).
+func testMessages(start, end int) []string {
+	msgs := []string{}
+	for i := start; i < end; i++ {
+		msgs = append(msgs, testLogEntry(i).Message)
+	}
+	return msgs
+}
+
+func messages(logEntries []api.LogEntry) []string {
+	msgs := []string{}
+	for _, logEntry := range logEntries {
+		msgs = append(msgs, logEntry.Message)
+	}
+	return msgs
+}
+
+func drain(logCh <-chan api.LogEntry) []api.LogEntry {
+	logEntries := []api.LogEntry{}
+	for logEntry := range logCh {
+		logEntries = append(logEntries, logEntry)
+	}
+	return logEntries
+}
+
+type mockLogsStore struct {
+	StreamLogsFn func(
+		context.Context,
+		api.Project,
+		api.Event,
+		api.LogsSelector,
+		api.LogStreamOptions,
+	) (<-chan api.LogEntry, error)
+	DeleteEventLogsFn   func(context.Context, string) error
+	DeleteProjectLogsFn func(context.Context, string) error
+}
+
+func (m *mockLogsStore) StreamLogs(
+	ctx context.Context,
+	project api.Project,
+	event api.Event,
+	selector api.LogsSelector,
+	opts api.LogStreamOptions,
+) (<-chan api.LogEntry, error) {
+	return m.StreamLogsFn(ctx, project, event, selector, opts)
+}
+
+func (m *mockLogsStore) DeleteEventLogs(ctx context.Context, id string) error {
+	return m.DeleteEventLogsFn(ctx, id)
+}
+
+func (m *mockLogsStore) DeleteProjectLogs(
+	ctx context.Context,
+	id string,
+) error {
+	return m.DeleteProjectLogsFn(ctx, id)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// PhaseTransition records a single change in the phase of a Worker or Job.
+type PhaseTransition struct {
+	// Phase is the phase that was transitioned to. This will be either a
+	// WorkerPhase or a JobPhase, depending on what transitioned.
+	Phase string `json:"phase" bson:"phase"`
+	// Time indicates when the transition occurred.
+	Time time.Time `json:"time" bson:"time"`
+	// Principal references the principal that effected the transition. It will
+	// be nil for transitions effected by Brigade's own internal components, such
+	// as the scheduler or observer.
+	Principal *PrincipalReference `json:"principal,omitempty" bson:"principal,omitempty"` // nolint: lll
+	// Reason is an optional, human-readable explanation of the transition.
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// newPhaseTransition returns a PhaseTransition to the specified phase, for the
+// specified reason, attributed to the principal found in the provided
+// context.Context, if any.
+func newPhaseTransition(
+	ctx context.Context,
+	phase string,
+	reason string,
+) PhaseTransition {
+	transition := PhaseTransition{
+		Phase:  phase,
+		Time:   time.Now().UTC(),
+		Reason: reason,
+	}
+	if ref, ok := principalReferenceFromContext(ctx); ok {
+		transition.Principal = &ref
+	}
+	return transition
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhaseTransition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ctx        context.Context
+		assertions func(PhaseTransition)
+	}{
+		{
+			name: "principal is a system component",
+			ctx: ContextWithPrincipal(
+				context.Background(),
+				&SchedulerPrincipal{},
+			),
+			assertions: func(transition PhaseTransition) {
+				require.Nil(t, transition.Principal)
+			},
+		},
+		{
+			name: "principal is a user",
+			ctx: ContextWithPrincipal(
+				context.Background(),
+				&User{
+					ObjectMeta: meta.ObjectMeta{
+						ID: "tony@starkindustries.com",
+					},
+				},
+			),
+			assertions: func(transition PhaseTransition) {
+				require.Equal(
+					t,
+					&PrincipalReference{
+						Type: PrincipalTypeUser,
+						ID:   "tony@starkindustries.com",
+					},
+					transition.Principal,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transition := newPhaseTransition(
+				testCase.ctx,
+				string(WorkerPhaseCanceled),
+				"Event was canceled",
+			)
+			require.Equal(t, string(WorkerPhaseCanceled), transition.Phase)
+			require.Equal(t, "Event was canceled", transition.Reason)
+			require.False(t, transition.Time.IsZero())
+			testCase.assertions(transition)
+		})
+	}
+}
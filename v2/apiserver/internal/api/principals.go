@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"path"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
 )
@@ -18,6 +19,8 @@ const PrincipalReferenceKind = "PrincipalReference"
 type PrincipalType string
 
 const (
+	// PrincipalTypeGateway represents a principal that is a Gateway.
+	PrincipalTypeGateway PrincipalType = "GATEWAY"
 	// PrincipalTypeServiceAccount represents a principal that is authenticated as
 	// the root user.
 	PrincipalTypeRoot PrincipalType = "ROOT"
@@ -26,6 +29,10 @@ const (
 	PrincipalTypeServiceAccount PrincipalType = "SERVICE_ACCOUNT"
 	// PrincipalTypeUser represents a principal that is a User.
 	PrincipalTypeUser PrincipalType = "USER"
+	// PrincipalTypeWebhook represents a principal that is a Project's Webhook.
+	// References to such principals identify the Webhook as
+	// <project ID>/<webhook ID>.
+	PrincipalTypeWebhook PrincipalType = "WEBHOOK"
 )
 
 // PrincipalReference is a reference to any sort of security principal (human
@@ -103,19 +110,40 @@ func NewPrincipalsService(authorizeFn AuthorizeFn) PrincipalsService {
 func (p *principalsService) WhoAmI(
 	ctx context.Context,
 ) (PrincipalReference, error) {
+	ref, ok := principalReferenceFromContext(ctx)
+	if !ok { // What kind of principal is this??? This shouldn't happen.
+		return ref, &meta.ErrAuthorization{}
+	}
+	return ref, nil
+}
+
+// principalReferenceFromContext returns a reference to the principal found in
+// the provided context.Context. The boolean return value indicates whether that
+// principal is one that can be referenced (true) or not (false). Notably,
+// internal system components like the scheduler and observer cannot be
+// referenced.
+func principalReferenceFromContext(
+	ctx context.Context,
+) (PrincipalReference, bool) {
 	ref := PrincipalReference{}
 	switch principal := PrincipalFromContext(ctx).(type) {
 	case *RootPrincipal:
 		ref.Type = PrincipalTypeRoot
 		ref.ID = "root"
+	case *Gateway:
+		ref.Type = PrincipalTypeGateway
+		ref.ID = principal.ID
 	case *ServiceAccount:
 		ref.Type = PrincipalTypeServiceAccount
 		ref.ID = principal.ID
 	case *User:
 		ref.Type = PrincipalTypeUser
 		ref.ID = principal.ID
-	default: // What kind of principal is this??? This shouldn't happen.
-		return ref, &meta.ErrAuthorization{}
+	case *WebhookPrincipal:
+		ref.Type = PrincipalTypeWebhook
+		ref.ID = path.Join(principal.projectID, principal.webhookID)
+	default:
+		return ref, false
 	}
-	return ref, nil
+	return ref, true
 }
@@ -71,6 +71,25 @@ func TestPrincipalsServiceWhoAmI(t *testing.T) {
 				require.Equal(t, "root", ref.ID)
 			},
 		},
+		{
+			name: "principal is a gateway",
+			ctx: ContextWithPrincipal(
+				context.Background(),
+				&Gateway{
+					ObjectMeta: meta.ObjectMeta{
+						ID: testID,
+					},
+				},
+			),
+			service: &principalsService{
+				authorize: alwaysAuthorize,
+			},
+			assertions: func(ref PrincipalReference, err error) {
+				require.NoError(t, err)
+				require.Equal(t, PrincipalTypeGateway, ref.Type)
+				require.Equal(t, testID, ref.ID)
+			},
+		},
 		{
 			name: "principal is a service account",
 			ctx: ContextWithPrincipal(
@@ -102,6 +102,10 @@ type ProjectUpdateOptions struct {
 	// CreateIfNotFound when set to true will cause a non-existing Project to be
 	// created instead of updated.
 	CreateIfNotFound bool
+	// ResourceVersion, when non-zero, specifies that the update should only be
+	// applied if the Project's current ResourceVersion matches. This permits
+	// clients to avoid overwriting changes made by others in the interim.
+	ResourceVersion int64
 }
 
 // ProjectSpec is the technical component of a Project. It pairs
@@ -111,8 +115,19 @@ type ProjectSpec struct {
 	// EventSubscription defines a set of trigger conditions under which a new
 	// Worker should be created.
 	EventSubscriptions []EventSubscription `json:"eventSubscriptions,omitempty" bson:"eventSubscriptions,omitempty"` // nolint: lll
+	// EventInputs declares typed inputs accepted by Events of specific sources
+	// and types. Inputs supplied with such Events are validated against these
+	// declarations when the Events are created.
+	EventInputs []EventInputs `json:"eventInputs,omitempty" bson:"eventInputs,omitempty"` // nolint: lll
 	// WorkerTemplate is a prototypical WorkerSpec.
 	WorkerTemplate WorkerSpec `json:"workerTemplate" bson:"workerTemplate"`
+	// RetentionPolicy optionally specifies rules for automatically pruning the
+	// Project's Events once their Workers have reached a terminal phase. Rules
+	// specified here override the corresponding system-wide rules.
+	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty" bson:"retentionPolicy,omitempty"` // nolint: lll
+	// SecretPolicies optionally specifies policies governing how the Project's
+	// secrets are made available to its Workers and Jobs.
+	SecretPolicies *SecretPolicies `json:"secretPolicies,omitempty" bson:"secretPolicies,omitempty"` // nolint: lll
 }
 
 // EventSubscription defines a set of Events of interest. ProjectSpecs utilize
@@ -125,9 +140,12 @@ type EventSubscription struct {
 	Source string `json:"source,omitempty" bson:"source,omitempty"`
 	// Types enumerates specific Events of interest from the specified Source.
 	// This is useful in narrowing a subscription when a Source also emits many
-	// Event types that are NOT of interest. This is a required field. The value
-	// "*" may be utilized to denote that ALL events originating from the
-	// specified Source are of interest.
+	// Event types that are NOT of interest. This is a required field. Values
+	// may be glob patterns, wherein "*" matches any sequence of characters and
+	// "?" matches any single character. e.g. The value "*" may be utilized to
+	// denote that ALL events originating from the specified Source are of
+	// interest, while "push:*" would match Event types "push:branch" and
+	// "push:tag".
 	Types []string `json:"types,omitempty" bson:"types,omitempty"`
 	// Qualifiers specifies an EXACT set of key/value pairs with which an Event
 	// MUST also be qualified for a Project to be considered subscribed. To
@@ -155,7 +173,20 @@ type EventSubscription struct {
 	// that Label in their EventSubscription. Note that the Labels field's "MAY
 	// match" subscription semantics differ from the Qualifiers field's "MUST
 	// match" subscription semantics.
+	//
+	// Values of both Qualifiers and Labels may be glob patterns, following the
+	// same rules as the Types field. e.g. A label value of "refs/heads/release-*"
+	// would match all Events labeled with a release branch.
 	Labels map[string]string `json:"labels,omitempty" bson:"labels,omitempty"`
+	// Filter is an optional CEL (Common Expression Language) expression that
+	// further narrows the subscription. It is evaluated only after all other
+	// criteria have matched and it MUST evaluate to a boolean. The expression may
+	// reference a single variable, "event", with the keys "source", "type",
+	// "qualifiers", "labels", "git" (containing "cloneURL", "commit", and
+	// "ref"), and "payload". If the Event's payload is valid JSON, it is
+	// available in parsed form. e.g.
+	// event.payload.pull_request.base.ref == "main"
+	Filter string `json:"filter,omitempty" bson:"filter,omitempty"`
 }
 
 // KubernetesDetails represents Kubernetes-specific configuration.
@@ -185,9 +216,11 @@ type ProjectsService interface {
 	// *meta.ErrNotFound error.
 	Get(context.Context, string) (Project, error)
 	// Update updates an existing Project. If the specified Project does not
-	// exist, implementations MUST return a *meta.ErrNotFound error.
-	// Implementations may assume the Project passed to this function has been
-	// pre-validated.
+	// exist, implementations MUST return a *meta.ErrNotFound error. If a
+	// ResourceVersion is specified via the ProjectUpdateOptions parameter and it
+	// does not match the Project's current ResourceVersion, implementations MUST
+	// return a *meta.ErrConflict error. Implementations may assume the Project
+	// passed to this function has been pre-validated.
 	Update(context.Context, Project, ProjectUpdateOptions) error
 	// Delete deletes a single Project specified by its identifier. If the
 	// specified Project does not exist, implementations MUST return a
@@ -202,6 +235,7 @@ type projectsService struct {
 	eventsStore                 EventsStore
 	logsStore                   CoolLogsStore
 	projectRoleAssignmentsStore ProjectRoleAssignmentsStore
+	webhooksStore               WebhooksStore
 	substrate                   Substrate
 }
 
@@ -213,6 +247,7 @@ func NewProjectsService(
 	eventsStore EventsStore,
 	logsStore CoolLogsStore,
 	projectRoleAssignmentsStore ProjectRoleAssignmentsStore,
+	webhooksStore WebhooksStore,
 	substrate Substrate,
 ) ProjectsService {
 	return &projectsService{
@@ -222,6 +257,7 @@ func NewProjectsService(
 		eventsStore:                 eventsStore,
 		logsStore:                   logsStore,
 		projectRoleAssignmentsStore: projectRoleAssignmentsStore,
+		webhooksStore:               webhooksStore,
 		substrate:                   substrate,
 	}
 }
@@ -234,6 +270,20 @@ func (p *projectsService) Create(
 		return project, err
 	}
 
+	if err := validateEventSubscriptions(
+		project.Spec.EventSubscriptions,
+	); err != nil {
+		return project, err
+	}
+
+	if err := validateEventInputs(project.Spec.EventInputs); err != nil {
+		return project, err
+	}
+
+	if err := validateRetentionPolicy(project.Spec.RetentionPolicy); err != nil {
+		return project, err
+	}
+
 	now := time.Now().UTC()
 	project.Created = &now
 
@@ -390,6 +440,21 @@ func (p *projectsService) Update(
 		return err
 	}
 
+	if err := validateEventSubscriptions(
+		project.Spec.EventSubscriptions,
+	); err != nil {
+		return err
+	}
+
+	if err := validateEventInputs(project.Spec.EventInputs); err != nil {
+		return err
+	}
+
+	if err := validateRetentionPolicy(project.Spec.RetentionPolicy); err != nil {
+		return err
+	}
+
+	project.ResourceVersion = opts.ResourceVersion
 	err := p.projectsStore.Update(ctx, project)
 	if err == nil {
 		return nil
@@ -449,6 +514,16 @@ func (p *projectsService) Delete(ctx context.Context, id string) error {
 		)
 	}
 
+	// Delete all webhooks associated with this project. For the same reason as
+	// above, a new project with the same name should not inherit them.
+	if err := p.webhooksStore.DeleteByProjectID(ctx, id); err != nil {
+		return errors.Wrapf(
+			err,
+			"error deleting all webhooks associated with project %q",
+			id,
+		)
+	}
+
 	// Delete the project itself
 	if err := p.projectsStore.Delete(ctx, id); err != nil {
 		return errors.Wrapf(err, "error removing project %q from store", id)
@@ -476,10 +551,21 @@ type ProjectsStore interface {
 		context.Context,
 		meta.ListOptions,
 	) (ProjectList, error)
+	// ListSubscribers returns a ProjectList containing every Project having at
+	// least one EventSubscription for the provided Event's source whose types
+	// either include the Event's type or include a glob pattern. Because
+	// EventSubscriptions may express their remaining criteria as glob patterns
+	// or CEL filters, implementations are NOT required to evaluate those
+	// criteria. Callers are responsible for doing so using
+	// Project.IsSubscribedTo().
 	ListSubscribers(
 		ctx context.Context,
 		event Event,
 	) (ProjectList, error)
+	// ListBySource returns a ProjectList containing every Project having at
+	// least one EventSubscription for the specified source, regardless of any of
+	// that EventSubscription's other criteria.
+	ListBySource(ctx context.Context, source string) (ProjectList, error)
 	// Get returns a Project having the indicated ID. If no such Project exists,
 	// implementations MUST return a *meta.ErrNotFound error.
 	Get(context.Context, string) (Project, error)
@@ -488,7 +574,10 @@ type ProjectsStore interface {
 	// exists, implementations MUST return a *meta.ErrNotFound error.
 	// Implementations MUST apply updates ONLY to the Description and Spec fields,
 	// as only these fields are intended to be mutable. Implementations MUST
-	// ignore changes to all other fields when updating.
+	// ignore changes to all other fields when updating, but MUST increment the
+	// Project's ResourceVersion. If the provided Project's ResourceVersion is
+	// non-zero and does not match the stored Project's ResourceVersion,
+	// implementations MUST return a *meta.ErrConflict error.
 	Update(context.Context, Project) error
 	// Delete deletes the specified Project. If no Project having the given
 	// identifier is found, implementations MUST return a *meta.ErrNotFound error.
@@ -81,6 +81,7 @@ func TestNewProjectsService(t *testing.T) {
 	eventsStore := &mockEventsStore{}
 	logsStore := &mockLogsStore{}
 	projectRoleAssignmentsStore := &mockProjectRoleAssignmentsStore{}
+	webhooksStore := &mockWebhooksStore{}
 	substrate := &mockSubstrate{}
 	svc, ok := NewProjectsService(
 		alwaysAuthorize,
@@ -89,6 +90,7 @@ func TestNewProjectsService(t *testing.T) {
 		eventsStore,
 		logsStore,
 		projectRoleAssignmentsStore,
+		webhooksStore,
 		substrate,
 	).(*projectsService)
 	require.True(t, ok)
@@ -97,6 +99,7 @@ func TestNewProjectsService(t *testing.T) {
 	require.Same(t, projectsStore, svc.projectsStore)
 	require.Same(t, eventsStore, svc.eventsStore)
 	require.Same(t, projectRoleAssignmentsStore, svc.projectRoleAssignmentsStore)
+	require.Same(t, webhooksStore, svc.webhooksStore)
 	require.Same(t, substrate, svc.substrate)
 }
 
@@ -440,6 +443,26 @@ func TestProjectServiceUpdate(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "resource version conflict",
+			opts: ProjectUpdateOptions{
+				CreateIfNotFound: true,
+				ResourceVersion:  3,
+			},
+			service: &projectsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					UpdateFn: func(_ context.Context, project Project) error {
+						require.Equal(t, int64(3), project.ResourceVersion)
+						return &meta.ErrConflict{}
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrConflict{}, errors.Cause(err))
+			},
+		},
 		{
 			name: "success",
 			service: &projectsService{
@@ -556,6 +579,11 @@ func TestProjectServiceDelete(t *testing.T) {
 						return nil
 					},
 				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
 				substrate: &mockSubstrate{
 					DeleteProjectFn: func(context.Context, Project) error {
 						return nil
@@ -608,6 +636,49 @@ func TestProjectServiceDelete(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "error deleting webhooks associated with project",
+			service: &projectsService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				eventsStore: &mockEventsStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
+				logsStore: &mockLogsStore{
+					DeleteProjectLogsFn: func(
+						context.Context,
+						string,
+					) error {
+						return nil
+					},
+				},
+				projectRoleAssignmentsStore: &mockProjectRoleAssignmentsStore{
+					RevokeByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(
+					t,
+					err.Error(),
+					"error deleting all webhooks associated with project",
+				)
+			},
+		},
 		{
 			name: "error deleting project from store",
 			service: &projectsService{
@@ -638,6 +709,11 @@ func TestProjectServiceDelete(t *testing.T) {
 						return nil
 					},
 				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
 			},
 			assertions: func(err error) {
 				require.Error(t, err)
@@ -675,6 +751,11 @@ func TestProjectServiceDelete(t *testing.T) {
 						return nil
 					},
 				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
 				substrate: &mockSubstrate{
 					DeleteProjectFn: func(context.Context, Project) error {
 						return errors.New("substrate error")
@@ -717,6 +798,11 @@ func TestProjectServiceDelete(t *testing.T) {
 						return nil
 					},
 				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteByProjectIDFn: func(context.Context, string) error {
+						return nil
+					},
+				},
 				substrate: &mockSubstrate{
 					DeleteProjectFn: func(context.Context, Project) error {
 						return nil
@@ -740,6 +826,7 @@ type mockProjectsStore struct {
 	CreateFn          func(context.Context, Project) error
 	ListFn            func(context.Context, meta.ListOptions) (ProjectList, error)
 	ListSubscribersFn func(context.Context, Event) (ProjectList, error)
+	ListBySourceFn    func(context.Context, string) (ProjectList, error)
 	GetFn             func(context.Context, string) (Project, error)
 	UpdateFn          func(context.Context, Project) error
 	DeleteFn          func(context.Context, string) error
@@ -763,6 +850,13 @@ func (m *mockProjectsStore) ListSubscribers(
 	return m.ListSubscribersFn(ctx, event)
 }
 
+func (m *mockProjectsStore) ListBySource(
+	ctx context.Context,
+	source string,
+) (ProjectList, error) {
+	return m.ListBySourceFn(ctx, source)
+}
+
 func (m *mockProjectsStore) Get(
 	ctx context.Context,
 	id string,
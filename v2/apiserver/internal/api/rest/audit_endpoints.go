@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/gorilla/mux"
+)
+
+type AuditEndpoints struct {
+	AuthFilter restmachinery.Filter
+	Service    api.AuditService
+}
+
+func (a *AuditEndpoints) Register(router *mux.Router) {
+	// List audit records
+	router.HandleFunc(
+		"/v2/audit-records",
+		a.AuthFilter.Decorate(a.list),
+	).Methods(http.MethodGet)
+}
+
+func (a *AuditEndpoints) list(w http.ResponseWriter, r *http.Request) {
+	selector, err := auditRecordsSelectorFromURLQuery(r.URL.Query())
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusBadRequest,
+			err,
+		)
+		return
+	}
+	opts := meta.ListOptions{
+		Continue: r.URL.Query().Get("continue"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if opts.Limit, err = strconv.ParseInt(limitStr, 10, 64); err != nil ||
+			opts.Limit < 1 || opts.Limit > 100 {
+			restmachinery.WriteAPIResponse(
+				w,
+				http.StatusBadRequest,
+				&meta.ErrBadRequest{
+					Reason: fmt.Sprintf(
+						`Invalid value %q for "limit" query parameter`,
+						limitStr,
+					),
+				},
+			)
+			return
+		}
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return a.Service.List(r.Context(), selector, opts)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func auditRecordsSelectorFromURLQuery(
+	queryParams url.Values,
+) (api.AuditRecordsSelector, *meta.ErrBadRequest) {
+	selector := api.AuditRecordsSelector{}
+	if queryParams == nil {
+		return selector, nil
+	}
+	selector.Action = queryParams.Get("action")
+	selector.TargetType = queryParams.Get("targetType")
+	selector.TargetID = queryParams.Get("targetID")
+	selector.ProjectID = queryParams.Get("projectID")
+	selector.PrincipalType = api.PrincipalType(queryParams.Get("principalType"))
+	selector.PrincipalID = queryParams.Get("principalID")
+	if resultStr := queryParams.Get("result"); resultStr != "" {
+		selector.Result = api.AuditResult(resultStr)
+		if selector.Result != api.AuditResultFailed &&
+			selector.Result != api.AuditResultSucceeded {
+			return selector, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					`Invalid value %q for "result" query parameter`,
+					resultStr,
+				),
+			}
+		}
+	}
+	var err *meta.ErrBadRequest
+	if selector.Since, err = timeFromURLQuery(queryParams, "since"); err != nil {
+		return selector, err
+	}
+	if selector.Until, err = timeFromURLQuery(queryParams, "until"); err != nil {
+		return selector, err
+	}
+	return selector, nil
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRecordsSelectorFromURLQuery(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		queryParams url.Values
+		assertions  func(api.AuditRecordsSelector, *meta.ErrBadRequest)
+	}{
+		{
+			name: "nil query params",
+			assertions: func(
+				selector api.AuditRecordsSelector,
+				err *meta.ErrBadRequest,
+			) {
+				require.Nil(t, err)
+				require.Equal(t, api.AuditRecordsSelector{}, selector)
+			},
+		},
+		{
+			name: "invalid result",
+			queryParams: url.Values{
+				"result": []string{"MAYBE"},
+			},
+			assertions: func(_ api.AuditRecordsSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "MAYBE"`)
+			},
+		},
+		{
+			name: "invalid since",
+			queryParams: url.Values{
+				"since": []string{"yesterday"},
+			},
+			assertions: func(_ api.AuditRecordsSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "yesterday"`)
+			},
+		},
+		{
+			name: "invalid until",
+			queryParams: url.Values{
+				"until": []string{"tomorrow"},
+			},
+			assertions: func(_ api.AuditRecordsSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "tomorrow"`)
+			},
+		},
+		{
+			name: "success",
+			queryParams: url.Values{
+				"action":        []string{"events.cancel"},
+				"targetType":    []string{api.EventKind},
+				"targetID":      []string{"123456789"},
+				"projectID":     []string{"italian"},
+				"principalType": []string{string(api.PrincipalTypeUser)},
+				"principalID":   []string{"tony@starkindustries.com"},
+				"result":        []string{string(api.AuditResultSucceeded)},
+				"since":         []string{since.Format(time.RFC3339)},
+				"until":         []string{until.Format(time.RFC3339)},
+			},
+			assertions: func(
+				selector api.AuditRecordsSelector,
+				err *meta.ErrBadRequest,
+			) {
+				require.Nil(t, err)
+				require.Equal(
+					t,
+					api.AuditRecordsSelector{
+						Action:        "events.cancel",
+						TargetType:    api.EventKind,
+						TargetID:      "123456789",
+						ProjectID:     "italian",
+						PrincipalType: api.PrincipalTypeUser,
+						PrincipalID:   "tony@starkindustries.com",
+						Result:        api.AuditResultSucceeded,
+						Since:         &since,
+						Until:         &until,
+					},
+					selector,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			selector, err := auditRecordsSelectorFromURLQuery(testCase.queryParams)
+			testCase.assertions(selector, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
@@ -35,6 +36,12 @@ func (e *EventsEndpoints) Register(router *mux.Router) {
 		e.AuthFilter.Decorate(e.list),
 	).Methods(http.MethodGet)
 
+	// Get event statistics
+	router.HandleFunc(
+		"/v2/event-stats",
+		e.AuthFilter.Decorate(e.stats),
+	).Methods(http.MethodGet)
+
 	// Get event
 	router.HandleFunc(
 		"/v2/events/{id}",
@@ -88,6 +95,18 @@ func (e *EventsEndpoints) Register(router *mux.Router) {
 		"/v2/events/{id}/retries",
 		e.AuthFilter.Decorate(e.retry),
 	).Methods(http.MethodPost)
+
+	// Retry a collection of events
+	router.HandleFunc(
+		"/v2/events/retries",
+		e.AuthFilter.Decorate(e.retryMany),
+	).Methods(http.MethodPost)
+
+	// Clone a collection of events
+	router.HandleFunc(
+		"/v2/events/clones",
+		e.AuthFilter.Decorate(e.cloneMany),
+	).Methods(http.MethodPost)
 }
 
 func (e *EventsEndpoints) clone(
@@ -110,7 +129,23 @@ func (e *EventsEndpoints) clone(
 }
 
 func (e *EventsEndpoints) create(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")) // nolint: errcheck
 	event := api.Event{}
+	if dryRun {
+		restmachinery.ServeRequest(
+			restmachinery.InboundRequest{
+				W:                   w,
+				R:                   r,
+				ReqBodySchemaLoader: e.EventSchemaLoader,
+				ReqBodyObj:          &event,
+				EndpointLogic: func() (interface{}, error) {
+					return e.Service.CreateDryRun(r.Context(), event)
+				},
+				SuccessCode: http.StatusOK,
+			},
+		)
+		return
+	}
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
 			W:                   w,
@@ -166,6 +201,28 @@ func (e *EventsEndpoints) list(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (e *EventsEndpoints) stats(w http.ResponseWriter, r *http.Request) {
+	opts, err := eventStatsOptionsFromURLQuery(r.URL.Query())
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusBadRequest,
+			err,
+		)
+		return
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return e.Service.Stats(r.Context(), opts)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
 func (e *EventsEndpoints) get(w http.ResponseWriter, r *http.Request) {
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
@@ -183,6 +240,11 @@ func (e *EventsEndpoints) updateSourceState(
 	w http.ResponseWriter,
 	r *http.Request,
 ) {
+	resourceVersion, err := resourceVersionFromIfMatch(r)
+	if err != nil {
+		restmachinery.WriteAPIResponse(w, http.StatusBadRequest, err)
+		return
+	}
 	sourceState := api.SourceState{}
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
@@ -196,6 +258,9 @@ func (e *EventsEndpoints) updateSourceState(
 						r.Context(),
 						mux.Vars(r)["id"],
 						sourceState,
+						api.EventSourceStateUpdateOptions{
+							ResourceVersion: resourceVersion,
+						},
 					)
 			},
 			SuccessCode: http.StatusOK,
@@ -233,7 +298,13 @@ func (e *EventsEndpoints) cancel(w http.ResponseWriter, r *http.Request) {
 			W: w,
 			R: r,
 			EndpointLogic: func() (interface{}, error) {
-				return nil, e.Service.Cancel(r.Context(), mux.Vars(r)["id"])
+				return nil, e.Service.Cancel(
+					r.Context(),
+					mux.Vars(r)["id"],
+					api.EventCancelOptions{
+						Reason: r.URL.Query().Get("reason"),
+					},
+				)
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -252,12 +323,20 @@ func (e *EventsEndpoints) cancelMany(
 			err,
 		)
 	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")) // nolint: errcheck
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
 			W: w,
 			R: r,
 			EndpointLogic: func() (interface{}, error) {
-				return e.Service.CancelMany(r.Context(), selector)
+				return e.Service.CancelMany(
+					r.Context(),
+					selector,
+					api.EventCancelManyOptions{
+						Reason: r.URL.Query().Get("reason"),
+						DryRun: dryRun,
+					},
+				)
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -270,7 +349,13 @@ func (e *EventsEndpoints) delete(w http.ResponseWriter, r *http.Request) {
 			W: w,
 			R: r,
 			EndpointLogic: func() (interface{}, error) {
-				return nil, e.Service.Delete(r.Context(), mux.Vars(r)["id"])
+				return nil, e.Service.Delete(
+					r.Context(),
+					mux.Vars(r)["id"],
+					api.EventDeleteOptions{
+						Reason: r.URL.Query().Get("reason"),
+					},
+				)
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -286,12 +371,20 @@ func (e *EventsEndpoints) deleteMany(w http.ResponseWriter, r *http.Request) {
 			err,
 		)
 	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")) // nolint: errcheck
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
 			W: w,
 			R: r,
 			EndpointLogic: func() (interface{}, error) {
-				return e.Service.DeleteMany(r.Context(), selector)
+				return e.Service.DeleteMany(
+					r.Context(),
+					selector,
+					api.EventDeleteManyOptions{
+						Reason: r.URL.Query().Get("reason"),
+						DryRun: dryRun,
+					},
+				)
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -310,6 +403,9 @@ func (e *EventsEndpoints) retry(
 				return e.Service.Retry(
 					r.Context(),
 					mux.Vars(r)["id"],
+					api.EventRetryOptions{
+						FromJob: r.URL.Query().Get("fromJob"),
+					},
 				)
 			},
 			SuccessCode: http.StatusCreated,
@@ -317,6 +413,56 @@ func (e *EventsEndpoints) retry(
 	)
 }
 
+func (e *EventsEndpoints) retryMany(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	selector, err := eventsSelectorFromURLQuery(r.URL.Query())
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusBadRequest,
+			err,
+		)
+		return
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return e.Service.RetryMany(r.Context(), selector)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (e *EventsEndpoints) cloneMany(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	selector, err := eventsSelectorFromURLQuery(r.URL.Query())
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusBadRequest,
+			err,
+		)
+		return
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return e.Service.CloneMany(r.Context(), selector)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
 func eventsSelectorFromURLQuery(
 	queryParams url.Values,
 ) (api.EventsSelector, *meta.ErrBadRequest) {
@@ -386,5 +532,76 @@ func eventsSelectorFromURLQuery(
 			selector.WorkerPhases[i] = api.WorkerPhase(workerPhaseStr)
 		}
 	}
+	var err *meta.ErrBadRequest
+	if selector.CreatedAfter, err =
+		timeFromURLQuery(queryParams, "createdAfter"); err != nil {
+		return selector, err
+	}
+	if selector.CreatedBefore, err =
+		timeFromURLQuery(queryParams, "createdBefore"); err != nil {
+		return selector, err
+	}
+	selector.Commit = queryParams.Get("commit")
+	selector.Ref = queryParams.Get("ref")
+	selector.Text = queryParams.Get("text")
 	return selector, nil
 }
+
+// timeFromURLQuery parses the value of the specified query parameter as an
+// RFC 3339 formatted time. If the parameter is not set, nil is returned.
+func eventStatsOptionsFromURLQuery(
+	queryParams url.Values,
+) (api.EventStatsOptions, *meta.ErrBadRequest) {
+	opts := api.EventStatsOptions{
+		ProjectID: queryParams.Get("projectID"),
+		GroupBy:   api.EventStatsGrouping(queryParams.Get("groupBy")),
+	}
+	since, err := timeFromURLQuery(queryParams, "since")
+	if err != nil {
+		return opts, err
+	}
+	if since != nil {
+		opts.Since = *since
+	}
+	until, err := timeFromURLQuery(queryParams, "until")
+	if err != nil {
+		return opts, err
+	}
+	if until != nil {
+		opts.Until = *until
+	}
+	if bucketSizeStr := queryParams.Get("bucketSize"); bucketSizeStr != "" {
+		var perr error
+		if opts.BucketSize, perr = time.ParseDuration(bucketSizeStr); perr != nil {
+			return opts, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					`Invalid value %q for "bucketSize" query parameter`,
+					bucketSizeStr,
+				),
+			}
+		}
+	}
+	return opts, nil
+}
+
+func timeFromURLQuery(
+	queryParams url.Values,
+	param string,
+) (*time.Time, *meta.ErrBadRequest) {
+	timeStr := queryParams.Get(param)
+	if timeStr == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, timeStr)
+	if err != nil {
+		return nil, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				`Invalid value %q for %q query parameter`,
+				timeStr,
+				param,
+			),
+		}
+	}
+	t = t.UTC()
+	return &t, nil
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
@@ -10,6 +11,8 @@ import (
 )
 
 func TestEventsSelectorFromURLQuery(t *testing.T) {
+	createdAfter := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name        string
 		queryParams url.Values
@@ -49,16 +52,41 @@ func TestEventsSelectorFromURLQuery(t *testing.T) {
 				require.Contains(t, err.Error(), `Invalid value "key-value"`)
 			},
 		},
+		{
+			name: "invalid created after",
+			queryParams: url.Values{
+				"createdAfter": []string{"yesterday"},
+			},
+			assertions: func(selector api.EventsSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "yesterday"`)
+				require.Contains(t, err.Error(), `"createdAfter"`)
+			},
+		},
+		{
+			name: "invalid created before",
+			queryParams: url.Values{
+				"createdBefore": []string{"tomorrow"},
+			},
+			assertions: func(selector api.EventsSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "tomorrow"`)
+				require.Contains(t, err.Error(), `"createdBefore"`)
+			},
+		},
 		{
 			name: "success",
 			queryParams: url.Values{
-				"projectID":    []string{"blue-book"},
-				"source":       []string{"brigade.sh/cli"},
-				"qualifiers":   []string{"foo=bar,bat=baz"},
-				"labels":       []string{"abc=easy-as,123=do-rei-mei"},
-				"sourceState":  []string{"baby=you,and=me-girl"},
-				"type":         []string{"exec"},
-				"workerPhases": []string{"PENDING,STARTING"},
+				"projectID":     []string{"blue-book"},
+				"source":        []string{"brigade.sh/cli"},
+				"qualifiers":    []string{"foo=bar,bat=baz"},
+				"labels":        []string{"abc=easy-as,123=do-rei-mei"},
+				"sourceState":   []string{"baby=you,and=me-girl"},
+				"type":          []string{"exec"},
+				"workerPhases":  []string{"PENDING,STARTING"},
+				"createdAfter":  []string{"2021-01-01T00:00:00Z"},
+				"createdBefore": []string{"2021-01-02T02:00:00+02:00"},
+				"commit":        []string{"1234567"},
+				"ref":           []string{"refs/heads/main"},
+				"text":          []string{"deploy"},
 			},
 			assertions: func(selector api.EventsSelector, err *meta.ErrBadRequest) {
 				require.Nil(t, err)
@@ -84,6 +112,11 @@ func TestEventsSelectorFromURLQuery(t *testing.T) {
 							api.WorkerPhasePending,
 							api.WorkerPhaseStarting,
 						},
+						CreatedAfter:  &createdAfter,
+						CreatedBefore: &createdBefore,
+						Commit:        "1234567",
+						Ref:           "refs/heads/main",
+						Text:          "deploy",
 					},
 					selector,
 				)
@@ -98,3 +131,80 @@ func TestEventsSelectorFromURLQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestEventStatsOptionsFromURLQuery(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		queryParams url.Values
+		assertions  func(api.EventStatsOptions, *meta.ErrBadRequest)
+	}{
+		{
+			name: "nil query params",
+			assertions: func(opts api.EventStatsOptions, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(t, api.EventStatsOptions{}, opts)
+			},
+		},
+		{
+			name: "invalid since",
+			queryParams: url.Values{
+				"since": []string{"last week"},
+			},
+			assertions: func(_ api.EventStatsOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "last week"`)
+				require.Contains(t, err.Error(), `"since"`)
+			},
+		},
+		{
+			name: "invalid until",
+			queryParams: url.Values{
+				"until": []string{"now"},
+			},
+			assertions: func(_ api.EventStatsOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "now"`)
+				require.Contains(t, err.Error(), `"until"`)
+			},
+		},
+		{
+			name: "invalid bucket size",
+			queryParams: url.Values{
+				"bucketSize": []string{"daily"},
+			},
+			assertions: func(_ api.EventStatsOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "daily"`)
+				require.Contains(t, err.Error(), `"bucketSize"`)
+			},
+		},
+		{
+			name: "success",
+			queryParams: url.Values{
+				"projectID":  []string{"blue-book"},
+				"groupBy":    []string{"TIME"},
+				"since":      []string{since.Format(time.RFC3339)},
+				"until":      []string{until.Format(time.RFC3339)},
+				"bucketSize": []string{"6h"},
+			},
+			assertions: func(opts api.EventStatsOptions, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(
+					t,
+					api.EventStatsOptions{
+						ProjectID:  "blue-book",
+						GroupBy:    api.EventStatsGroupingTime,
+						Since:      since,
+						Until:      until,
+						BucketSize: 6 * time.Hour,
+					},
+					opts,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(eventStatsOptionsFromURLQuery(testCase.queryParams))
+		})
+	}
+}
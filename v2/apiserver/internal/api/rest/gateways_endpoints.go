@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/gorilla/mux"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type GatewaysEndpoints struct {
+	AuthFilter          restmachinery.Filter
+	GatewaySchemaLoader gojsonschema.JSONLoader
+	Service             api.GatewaysService
+}
+
+func (g *GatewaysEndpoints) Register(router *mux.Router) {
+	// Create gateway
+	router.HandleFunc(
+		"/v2/gateways",
+		g.AuthFilter.Decorate(g.create),
+	).Methods(http.MethodPost)
+
+	// List gateways
+	router.HandleFunc(
+		"/v2/gateways",
+		g.AuthFilter.Decorate(g.list),
+	).Methods(http.MethodGet)
+
+	// Get gateway
+	router.HandleFunc(
+		"/v2/gateways/{id}",
+		g.AuthFilter.Decorate(g.get),
+	).Methods(http.MethodGet)
+
+	// Update gateway
+	router.HandleFunc(
+		"/v2/gateways/{id}",
+		g.AuthFilter.Decorate(g.update),
+	).Methods(http.MethodPut)
+
+	// Delete gateway
+	router.HandleFunc(
+		"/v2/gateways/{id}",
+		g.AuthFilter.Decorate(g.delete),
+	).Methods(http.MethodDelete)
+}
+
+func (g *GatewaysEndpoints) create(w http.ResponseWriter, r *http.Request) {
+	gateway := api.Gateway{}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W:                   w,
+			R:                   r,
+			ReqBodySchemaLoader: g.GatewaySchemaLoader,
+			ReqBodyObj:          &gateway,
+			EndpointLogic: func() (interface{}, error) {
+				return g.Service.Create(r.Context(), gateway)
+			},
+			SuccessCode: http.StatusCreated,
+		},
+	)
+}
+
+func (g *GatewaysEndpoints) list(w http.ResponseWriter, r *http.Request) {
+	opts := meta.ListOptions{
+		Continue: r.URL.Query().Get("continue"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if opts.Limit, err = strconv.ParseInt(limitStr, 10, 64); err != nil ||
+			opts.Limit < 1 || opts.Limit > 100 {
+			restmachinery.WriteAPIResponse(
+				w,
+				http.StatusBadRequest,
+				&meta.ErrBadRequest{
+					Reason: fmt.Sprintf(
+						`Invalid value %q for "limit" query parameter`,
+						limitStr,
+					),
+				},
+			)
+			return
+		}
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return g.Service.List(r.Context(), opts)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (g *GatewaysEndpoints) get(w http.ResponseWriter, r *http.Request) {
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return g.Service.Get(r.Context(), mux.Vars(r)["id"])
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (g *GatewaysEndpoints) update(w http.ResponseWriter, r *http.Request) {
+	gateway := api.Gateway{}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W:                   w,
+			R:                   r,
+			ReqBodySchemaLoader: g.GatewaySchemaLoader,
+			ReqBodyObj:          &gateway,
+			EndpointLogic: func() (interface{}, error) {
+				if mux.Vars(r)["id"] != gateway.ID {
+					return nil, &meta.ErrBadRequest{
+						Reason: "The gateway IDs in the URL path and request body do " +
+							"not match.",
+					}
+				}
+				return nil, g.Service.Update(r.Context(), gateway)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (g *GatewaysEndpoints) delete(w http.ResponseWriter, r *http.Request) {
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return nil, g.Service.Delete(r.Context(), mux.Vars(r)["id"])
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
@@ -232,6 +232,9 @@ func (j *JobsEndpoints) timeout(
 					r.Context(),
 					mux.Vars(r)["eventID"],
 					mux.Vars(r)["jobName"],
+					api.JobTimeoutOptions{
+						Reason: r.URL.Query().Get("reason"),
+					},
 				)
 			},
 			SuccessCode: http.StatusOK,
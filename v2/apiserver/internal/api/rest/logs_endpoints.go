@@ -1,11 +1,23 @@
 package rest
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"path"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
@@ -29,6 +41,18 @@ func (l *LogsEndpoints) Register(router *mux.Router) {
 		"/v2/events/{id}/logs",
 		l.AuthFilter.Decorate(l.stream),
 	).Methods(http.MethodGet)
+
+	// Download an archive of an event's logs
+	router.HandleFunc(
+		"/v2/events/{id}/logs/archive",
+		l.AuthFilter.Decorate(l.archive),
+	).Methods(http.MethodGet)
+
+	// Search logs
+	router.HandleFunc(
+		"/v2/projects/{id}/logs/search",
+		l.AuthFilter.Decorate(l.search),
+	).Methods(http.MethodGet)
 }
 
 func (l *LogsEndpoints) stream(
@@ -36,20 +60,22 @@ func (l *LogsEndpoints) stream(
 	r *http.Request,
 ) {
 	id := mux.Vars(r)["id"]
-	// nolint: errcheck
-	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
 
 	// Clients can request use of the SSE protocol instead of HTTP/2 streaming.
 	// Not every potential client language has equally good support for both of
 	// those, so allowing clients to pick is useful.
 	sse, _ := strconv.ParseBool(r.URL.Query().Get("sse")) // nolint: errcheck
 
+	all, _ := strconv.ParseBool(r.URL.Query().Get("all")) // nolint: errcheck
 	selector := api.LogsSelector{
 		Job:       r.URL.Query().Get("job"),
 		Container: r.URL.Query().Get("container"),
+		All:       all,
 	}
-	opts := api.LogStreamOptions{
-		Follow: follow,
+	opts, badReqErr := logStreamOptionsFromURLQuery(r.URL.Query())
+	if badReqErr != nil {
+		restmachinery.WriteAPIResponse(w, http.StatusBadRequest, badReqErr)
+		return
 	}
 
 	var lastEventID int64
@@ -85,6 +111,18 @@ func (l *LogsEndpoints) stream(
 			restmachinery.WriteAPIResponse(w, http.StatusNotFound, errors.Cause(err))
 			return
 		}
+		if _, ok := errors.Cause(err).(*meta.ErrBadRequest); ok {
+			restmachinery.WriteAPIResponse(
+				w,
+				http.StatusBadRequest,
+				errors.Cause(err),
+			)
+			return
+		}
+		if _, ok := errors.Cause(err).(*meta.ErrAuthorization); ok {
+			restmachinery.WriteAPIResponse(w, http.StatusForbidden, errors.Cause(err))
+			return
+		}
 		log.Println(
 			errors.Wrapf(err, "error retrieving log stream for event %q", id),
 		)
@@ -134,3 +172,321 @@ func (l *LogsEndpoints) stream(
 		flusher.Flush()
 	}
 }
+
+func (l *LogsEndpoints) archive(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	id := mux.Vars(r)["id"]
+
+	files, err := l.Service.Archive(r.Context(), id)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+			restmachinery.WriteAPIResponse(w, http.StatusNotFound, errors.Cause(err))
+			return
+		}
+		if _, ok := errors.Cause(err).(*meta.ErrAuthorization); ok {
+			restmachinery.WriteAPIResponse(w, http.StatusForbidden, errors.Cause(err))
+			return
+		}
+		log.Println(
+			errors.Wrapf(err, "error retrieving logs archive for event %q", id),
+		)
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusInternalServerError,
+			&meta.ErrInternalServer{},
+		)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s-logs.tar.gz"`, id),
+	)
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	// Logs that could not be retrieved in full are listed in an extra file so
+	// that the client can tell an incomplete archive from a complete one.
+	var incompleteNames []string
+	for _, file := range files {
+		name, complete, err :=
+			writeLogsArchiveFile(r.Context(), tarWriter, id, file)
+		if err != nil {
+			// The response is already underway, so all that can be done is to stop
+			// writing it. The client will find the archive is truncated.
+			log.Println(
+				errors.Wrapf(err, "error writing logs archive for event %q", id),
+			)
+			return
+		}
+		if !complete {
+			incompleteNames = append(incompleteNames, name)
+		}
+	}
+	if len(incompleteNames) > 0 {
+		if err = writeLogsArchiveErrorsFile(
+			tarWriter,
+			id,
+			incompleteNames,
+		); err != nil {
+			log.Println(
+				errors.Wrapf(err, "error writing logs archive for event %q", id),
+			)
+			return
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		log.Println(
+			errors.Wrapf(err, "error writing logs archive for event %q", id),
+		)
+		return
+	}
+	if err = gzipWriter.Close(); err != nil {
+		log.Println(
+			errors.Wrapf(err, "error writing logs archive for event %q", id),
+		)
+	}
+}
+
+// writeLogsArchiveFile writes the logs of a single container to the provided
+// tar.Writer as a file named for the Event and the Worker or Job and container
+// that wrote them. Each line is prefixed with the time it was written, if
+// known. It returns the name of the file, which is empty if the container has
+// no logs, and a bool indicating whether the container's logs were retrieved in
+// full. If they weren't, the file holds as much of them as was retrieved. An
+// error is returned only if the file could not be written.
+func writeLogsArchiveFile(
+	ctx context.Context,
+	tarWriter *tar.Writer,
+	eventID string,
+	file api.LogsArchiveFile,
+) (string, bool, error) {
+	name := path.Join(eventID, "worker", file.Container+".log")
+	if file.Job != "" {
+		name = path.Join(eventID, "jobs", file.Job, file.Container+".log")
+	}
+
+	streamErrs := 0
+	mu := sync.Mutex{}
+	ctx = api.ContextWithLogStreamErrorHandler(ctx, func(error) {
+		mu.Lock()
+		defer mu.Unlock()
+		streamErrs++
+	})
+	logCh, err := file.Open(ctx)
+	if err != nil {
+		// A container that has no logs is simply left out
+		if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+			return "", true, nil
+		}
+		log.Println(errors.Wrapf(err, "error opening logs for %q", name))
+		return name, false, nil
+	}
+
+	// The size of each file must be known before it's written, so the
+	// container's logs are spooled to a temporary file rather than held in
+	// memory.
+	spool, err := ioutil.TempFile("", "brigade-logs-")
+	if err != nil {
+		// Drain the stream so the goroutines sending over it can finish
+		for range logCh {
+		}
+		return name, false, errors.Wrap(err, "error creating temporary file")
+	}
+	defer os.Remove(spool.Name()) // nolint: errcheck
+	defer spool.Close()
+	spoolWriter := bufio.NewWriter(spool)
+	modTime := time.Now().UTC()
+	for logEntry := range logCh {
+		if err != nil {
+			continue // Drain the stream
+		}
+		if logEntry.Time != nil {
+			modTime = *logEntry.Time
+			_, err = fmt.Fprintf(
+				spoolWriter,
+				"%s %s\n",
+				logEntry.Time.Format(time.RFC3339Nano),
+				logEntry.Message,
+			)
+		} else {
+			_, err = fmt.Fprintln(spoolWriter, logEntry.Message)
+		}
+	}
+	if err == nil {
+		err = spoolWriter.Flush()
+	}
+	if err != nil {
+		return name, false, errors.Wrapf(err, "error spooling %q", name)
+	}
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return name, false, errors.Wrapf(err, "error spooling %q", name)
+	}
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return name, false, errors.Wrapf(err, "error spooling %q", name)
+	}
+
+	if err = tarWriter.WriteHeader(
+		&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    size,
+			ModTime: modTime,
+		},
+	); err != nil {
+		return name, false, errors.Wrapf(err, "error writing header for %q", name)
+	}
+	if _, err = io.Copy(tarWriter, spool); err != nil {
+		return name, false, errors.Wrapf(err, "error writing %q", name)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return name, streamErrs == 0, nil
+}
+
+// writeLogsArchiveErrorsFile writes a file named ERRORS.txt to the provided
+// tar.Writer that lists each of the provided files of the archive whose logs
+// could not be retrieved in full. The errors themselves are logged by the API
+// server rather than exposed to the client.
+func writeLogsArchiveErrorsFile(
+	tarWriter *tar.Writer,
+	eventID string,
+	incompleteNames []string,
+) error {
+	content := &bytes.Buffer{}
+	fmt.Fprintln(
+		content,
+		"An error occurred while retrieving the logs of the following files, "+
+			"so they may be incomplete or missing:",
+	)
+	for _, name := range incompleteNames {
+		fmt.Fprintln(content, name)
+	}
+	name := path.Join(eventID, "ERRORS.txt")
+	if err := tarWriter.WriteHeader(
+		&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(content.Len()),
+			ModTime: time.Now().UTC(),
+		},
+	); err != nil {
+		return errors.Wrapf(err, "error writing header for %q", name)
+	}
+	_, err := tarWriter.Write(content.Bytes())
+	return errors.Wrapf(err, "error writing %q", name)
+}
+
+func (l *LogsEndpoints) search(w http.ResponseWriter, r *http.Request) {
+	selector, err := logsSearchSelectorFromURLQuery(r.URL.Query())
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			w,
+			http.StatusBadRequest,
+			err,
+		)
+		return
+	}
+	opts := meta.ListOptions{
+		Continue: r.URL.Query().Get("continue"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if opts.Limit, err = strconv.ParseInt(limitStr, 10, 64); err != nil ||
+			opts.Limit < 1 || opts.Limit > 100 {
+			restmachinery.WriteAPIResponse(
+				w,
+				http.StatusBadRequest,
+				&meta.ErrBadRequest{
+					Reason: fmt.Sprintf(
+						`Invalid value %q for "limit" query parameter`,
+						limitStr,
+					),
+				},
+			)
+			return
+		}
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: w,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return l.Service.Search(
+					r.Context(),
+					mux.Vars(r)["id"],
+					selector,
+					opts,
+				)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func logsSearchSelectorFromURLQuery(
+	queryParams url.Values,
+) (api.LogsSearchSelector, *meta.ErrBadRequest) {
+	selector := api.LogsSearchSelector{}
+	if queryParams == nil {
+		return selector, nil
+	}
+	selector.Text = queryParams.Get("text")
+	selector.Regex = queryParams.Get("regex")
+	selector.Job = queryParams.Get("job")
+	selector.Container = queryParams.Get("container")
+	var err *meta.ErrBadRequest
+	if selector.WrittenSince, err =
+		timeFromURLQuery(queryParams, "writtenSince"); err != nil {
+		return selector, err
+	}
+	if selector.WrittenUntil, err =
+		timeFromURLQuery(queryParams, "writtenUntil"); err != nil {
+		return selector, err
+	}
+	return selector, nil
+}
+
+func logStreamOptionsFromURLQuery(
+	queryParams url.Values,
+) (api.LogStreamOptions, *meta.ErrBadRequest) {
+	opts := api.LogStreamOptions{}
+	// nolint: errcheck
+	opts.Follow, _ = strconv.ParseBool(queryParams.Get("follow"))
+	// nolint: errcheck
+	opts.Unredacted, _ = strconv.ParseBool(queryParams.Get("unredacted"))
+	if tailLinesStr := queryParams.Get("tailLines"); tailLinesStr != "" {
+		var err error
+		if opts.TailLines, err = strconv.ParseInt(tailLinesStr, 10, 64); err != nil ||
+			opts.TailLines < 1 {
+			return opts, &meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					`Invalid value %q for "tailLines" query parameter`,
+					tailLinesStr,
+				),
+			}
+		}
+	}
+	opts.Stream = api.LogStream(queryParams.Get("stream"))
+	if opts.Stream != "" &&
+		opts.Stream != api.LogStreamStdout &&
+		opts.Stream != api.LogStreamStderr {
+		return opts, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(
+				`Invalid value %q for "stream" query parameter`,
+				opts.Stream,
+			),
+		}
+	}
+	var err *meta.ErrBadRequest
+	if opts.Since, err = timeFromURLQuery(queryParams, "since"); err != nil {
+		return opts, err
+	}
+	if opts.Until, err = timeFromURLQuery(queryParams, "until"); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
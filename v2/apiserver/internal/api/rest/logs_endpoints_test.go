@@ -0,0 +1,291 @@
+package rest
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogStreamOptionsFromURLQuery(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2021, time.January, 2, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		queryParams url.Values
+		assertions  func(api.LogStreamOptions, *meta.ErrBadRequest)
+	}{
+		{
+			name: "no query params",
+			assertions: func(opts api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(t, api.LogStreamOptions{}, opts)
+			},
+		},
+		{
+			name: "invalid tailLines",
+			queryParams: url.Values{
+				"tailLines": []string{"0"},
+			},
+			assertions: func(_ api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "0"`)
+			},
+		},
+		{
+			name: "invalid stream",
+			queryParams: url.Values{
+				"stream": []string{"stdin"},
+			},
+			assertions: func(_ api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "stdin"`)
+			},
+		},
+		{
+			name: "invalid since",
+			queryParams: url.Values{
+				"since": []string{"yesterday"},
+			},
+			assertions: func(_ api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "yesterday"`)
+			},
+		},
+		{
+			name: "invalid until",
+			queryParams: url.Values{
+				"until": []string{"tomorrow"},
+			},
+			assertions: func(_ api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "tomorrow"`)
+			},
+		},
+		{
+			name: "success",
+			queryParams: url.Values{
+				"follow":     []string{"true"},
+				"tailLines":  []string{"100"},
+				"stream":     []string{"stderr"},
+				"since":      []string{since.Format(time.RFC3339)},
+				"until":      []string{until.Format(time.RFC3339)},
+				"unredacted": []string{"true"},
+			},
+			assertions: func(opts api.LogStreamOptions, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(
+					t,
+					api.LogStreamOptions{
+						Follow:     true,
+						TailLines:  100,
+						Stream:     api.LogStreamStderr,
+						Since:      &since,
+						Until:      &until,
+						Unredacted: true,
+					},
+					opts,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts, err := logStreamOptionsFromURLQuery(testCase.queryParams)
+			testCase.assertions(opts, err)
+		})
+	}
+}
+
+func TestLogsSearchSelectorFromURLQuery(t *testing.T) {
+	since := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		queryParams url.Values
+		assertions  func(api.LogsSearchSelector, *meta.ErrBadRequest)
+	}{
+		{
+			name: "no query params",
+			assertions: func(selector api.LogsSearchSelector, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(t, api.LogsSearchSelector{}, selector)
+			},
+		},
+		{
+			name: "invalid writtenSince",
+			queryParams: url.Values{
+				"writtenSince": []string{"yesterday"},
+			},
+			assertions: func(_ api.LogsSearchSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "yesterday"`)
+			},
+		},
+		{
+			name: "invalid writtenUntil",
+			queryParams: url.Values{
+				"writtenUntil": []string{"tomorrow"},
+			},
+			assertions: func(_ api.LogsSearchSelector, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "tomorrow"`)
+			},
+		},
+		{
+			name: "success",
+			queryParams: url.Values{
+				"regex":        []string{"went (wrong|right)"},
+				"job":          []string{"foo"},
+				"container":    []string{"bar"},
+				"writtenSince": []string{since.Format(time.RFC3339)},
+			},
+			assertions: func(selector api.LogsSearchSelector, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(
+					t,
+					api.LogsSearchSelector{
+						Regex:        "went (wrong|right)",
+						Job:          "foo",
+						Container:    "bar",
+						WrittenSince: &since,
+					},
+					selector,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			selector, err := logsSearchSelectorFromURLQuery(testCase.queryParams)
+			testCase.assertions(selector, err)
+		})
+	}
+}
+
+func TestWriteLogsArchiveFile(t *testing.T) {
+	logTime := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	openFn := func(streamErr error) func(
+		context.Context,
+	) (<-chan api.LogEntry, error) {
+		return func(ctx context.Context) (<-chan api.LogEntry, error) {
+			logCh := make(chan api.LogEntry, 2)
+			logCh <- api.LogEntry{Time: &logTime, Message: "hello"}
+			logCh <- api.LogEntry{Message: "world"}
+			if streamErr != nil {
+				api.ReportLogStreamError(ctx, streamErr)
+			}
+			close(logCh)
+			return logCh, nil
+		}
+	}
+	testCases := []struct {
+		name             string
+		file             api.LogsArchiveFile
+		expectedName     string
+		expectedComplete bool
+	}{
+		{
+			name: "container has no logs",
+			file: api.LogsArchiveFile{
+				Container: "worker",
+				Open: func(context.Context) (<-chan api.LogEntry, error) {
+					return nil, &meta.ErrNotFound{}
+				},
+			},
+			expectedComplete: true,
+		},
+		{
+			name: "error opening logs",
+			file: api.LogsArchiveFile{
+				Container: "worker",
+				Open: func(context.Context) (<-chan api.LogEntry, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			expectedName:     "tony/worker/worker.log",
+			expectedComplete: false,
+		},
+		{
+			name: "worker container",
+			file: api.LogsArchiveFile{
+				Container: "worker",
+				Open:      openFn(nil),
+			},
+			expectedName:     "tony/worker/worker.log",
+			expectedComplete: true,
+		},
+		{
+			name: "job container",
+			file: api.LogsArchiveFile{
+				Job:       "foo",
+				Container: "bar",
+				Open:      openFn(nil),
+			},
+			expectedName:     "tony/jobs/foo/bar.log",
+			expectedComplete: true,
+		},
+		{
+			name: "error while streaming logs",
+			file: api.LogsArchiveFile{
+				Container: "worker",
+				Open:      openFn(errors.New("something went wrong")),
+			},
+			expectedName:     "tony/worker/worker.log",
+			expectedComplete: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tarWriter := tar.NewWriter(buf)
+			name, complete, err := writeLogsArchiveFile(
+				context.Background(),
+				tarWriter,
+				"tony",
+				testCase.file,
+			)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedName, name)
+			require.Equal(t, testCase.expectedComplete, complete)
+			require.NoError(t, tarWriter.Flush())
+			if buf.Len() == 0 {
+				return
+			}
+			require.NoError(t, tarWriter.Close())
+			tarReader := tar.NewReader(buf)
+			header, err := tarReader.Next()
+			require.NoError(t, err)
+			require.Equal(t, name, header.Name)
+			require.Equal(t, logTime, header.ModTime.UTC())
+			content, err := io.ReadAll(tarReader)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				"2021-01-01T00:00:00Z hello\nworld\n",
+				string(content),
+			)
+		})
+	}
+}
+
+func TestWriteLogsArchiveErrorsFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(buf)
+	err := writeLogsArchiveErrorsFile(
+		tarWriter,
+		"tony",
+		[]string{"tony/worker/worker.log", "tony/jobs/foo/bar.log"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	tarReader := tar.NewReader(buf)
+	header, err := tarReader.Next()
+	require.NoError(t, err)
+	require.Equal(t, "tony/ERRORS.txt", header.Name)
+	content, err := io.ReadAll(tarReader)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "tony/worker/worker.log\n")
+	require.Contains(t, string(content), "tony/jobs/foo/bar.log\n")
+}
@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+)
+
+// resourceVersionFromIfMatch returns the resource version specified by the
+// provided request's If-Match header, which may optionally be quoted in the
+// manner of an ETag. Zero is returned if the header is absent.
+func resourceVersionFromIfMatch(r *http.Request) (int64, *meta.ErrBadRequest) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		return 0, nil
+	}
+	resourceVersion, err := strconv.ParseInt(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil || resourceVersion < 1 {
+		return 0, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf(`Invalid value %q for "If-Match" header`, ifMatch),
+		}
+	}
+	return resourceVersion, nil
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceVersionFromIfMatch(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ifMatch    string
+		assertions func(int64, *meta.ErrBadRequest)
+	}{
+		{
+			name: "header absent",
+			assertions: func(resourceVersion int64, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Zero(t, resourceVersion)
+			},
+		},
+		{
+			name:    "header not parsable",
+			ifMatch: "*",
+			assertions: func(_ int64, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "*"`)
+			},
+		},
+		{
+			name:    "header not positive",
+			ifMatch: "0",
+			assertions: func(_ int64, err *meta.ErrBadRequest) {
+				require.Contains(t, err.Error(), `Invalid value "0"`)
+			},
+		},
+		{
+			name:    "unquoted",
+			ifMatch: "42",
+			assertions: func(resourceVersion int64, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(t, int64(42), resourceVersion)
+			},
+		},
+		{
+			name:    "quoted",
+			ifMatch: `"42"`,
+			assertions: func(resourceVersion int64, err *meta.ErrBadRequest) {
+				require.Nil(t, err)
+				require.Equal(t, int64(42), resourceVersion)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodPut, "/", nil)
+			require.NoError(t, err)
+			if testCase.ifMatch != "" {
+				r.Header.Set("If-Match", testCase.ifMatch)
+			}
+			testCase.assertions(resourceVersionFromIfMatch(r))
+		})
+	}
+}
@@ -119,6 +119,11 @@ func (p *ProjectsEndpoints) get(w http.ResponseWriter, r *http.Request) {
 func (p *ProjectsEndpoints) update(w http.ResponseWriter, r *http.Request) {
 	// nolint: errcheck
 	createIfNotFound, _ := strconv.ParseBool(r.URL.Query().Get("create"))
+	resourceVersion, err := resourceVersionFromIfMatch(r)
+	if err != nil {
+		restmachinery.WriteAPIResponse(w, http.StatusBadRequest, err)
+		return
+	}
 	project := api.Project{}
 	restmachinery.ServeRequest(
 		restmachinery.InboundRequest{
@@ -133,11 +138,17 @@ func (p *ProjectsEndpoints) update(w http.ResponseWriter, r *http.Request) {
 							"not match.",
 					}
 				}
+				// A version specified via the If-Match header takes precedence over
+				// one specified in the Project's own metadata.
+				if resourceVersion == 0 {
+					resourceVersion = project.ResourceVersion
+				}
 				return project, p.Service.Update(
 					r.Context(),
 					project,
 					api.ProjectUpdateOptions{
 						CreateIfNotFound: createIfNotFound,
+						ResourceVersion:  resourceVersion,
 					},
 				)
 			},
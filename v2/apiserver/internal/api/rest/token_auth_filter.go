@@ -53,6 +53,10 @@ type tokenAuthFilter struct {
 		ctx context.Context,
 		token string,
 	) (api.ServiceAccount, error)
+	findGatewayByTokenFn func(
+		ctx context.Context,
+		token string,
+	) (api.Gateway, error)
 	findSessionByTokenFn func(
 		ctx context.Context,
 		token string,
@@ -73,6 +77,10 @@ func NewTokenAuthFilter(
 		ctx context.Context,
 		token string,
 	) (api.ServiceAccount, error),
+	findGatewayByTokenFn func(
+		ctx context.Context,
+		token string,
+	) (api.Gateway, error),
 	findSessionFn func(ctx context.Context, token string) (api.Session, error),
 	findEventByTokenFn func(
 		ctx context.Context,
@@ -85,6 +93,7 @@ func NewTokenAuthFilter(
 	}
 	return &tokenAuthFilter{
 		findServiceAccountByTokenFn: findServiceAccountByTokenFn,
+		findGatewayByTokenFn:        findGatewayByTokenFn,
 		findSessionByTokenFn:        findSessionFn,
 		findEventByTokenFn:          findEventByTokenFn,
 		config:                      *config,
@@ -177,6 +186,23 @@ func (t *tokenAuthFilter) Decorate(handle http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Is it a Gateway's token?
+		if gateway, err := t.findGatewayByTokenFn(r.Context(), token); err != nil {
+			if _, ok := errors.Cause(err).(*meta.ErrNotFound); !ok {
+				log.Println(err)
+				t.writeResponse(
+					w,
+					http.StatusInternalServerError,
+					&meta.ErrInternalServer{},
+				)
+				return
+			}
+		} else {
+			ctx := api.ContextWithPrincipal(r.Context(), &gateway)
+			handle(w, r.WithContext(ctx))
+			return
+		}
+
 		session, err := t.findSessionByTokenFn(r.Context(), token)
 		if err != nil {
 			if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
@@ -229,6 +229,73 @@ func TestFilter(t *testing.T) {
 			},
 		},
 
+		{
+			name: "error finding gateway",
+			filter: &tokenAuthFilter{
+				findEventByTokenFn: func(context.Context, string) (api.Event, error) {
+					return api.Event{}, &meta.ErrNotFound{}
+				},
+				findServiceAccountByTokenFn: func(
+					context.Context,
+					string,
+				) (api.ServiceAccount, error) {
+					return api.ServiceAccount{}, &meta.ErrNotFound{}
+				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, errors.New("something went wrong")
+				},
+			},
+			setup: func() *http.Request {
+				req, err := http.NewRequest(http.MethodGet, "/", nil)
+				require.NoError(t, err)
+				req.Header.Add("Authorization", "Bearer foo")
+				return req
+			},
+			assertions: func(handlerCalled bool, r *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, r.StatusCode)
+				require.False(t, handlerCalled)
+			},
+		},
+
+		{
+			name: "token belongs to a gateway",
+			filter: &tokenAuthFilter{
+				findEventByTokenFn: func(context.Context, string) (api.Event, error) {
+					return api.Event{}, &meta.ErrNotFound{}
+				},
+				findServiceAccountByTokenFn: func(
+					context.Context,
+					string,
+				) (api.ServiceAccount, error) {
+					return api.ServiceAccount{}, &meta.ErrNotFound{}
+				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, nil
+				},
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				principal := api.PrincipalFromContext(r.Context())
+				require.NotNil(t, principal)
+				require.IsType(t, &api.Gateway{}, principal)
+			},
+			setup: func() *http.Request {
+				req, err := http.NewRequest(http.MethodGet, "/", nil)
+				require.NoError(t, err)
+				req.Header.Add("Authorization", "Bearer foo")
+				return req
+			},
+			assertions: func(handlerCalled bool, r *http.Response) {
+				require.Equal(t, http.StatusOK, r.StatusCode)
+				assert.True(t, handlerCalled)
+			},
+		},
+
 		{
 			name: "error finding session",
 			filter: &tokenAuthFilter{
@@ -241,6 +308,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -272,6 +345,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -303,6 +382,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -339,6 +424,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -381,6 +472,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -415,6 +512,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -449,6 +552,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -491,6 +600,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -536,6 +651,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
@@ -578,6 +699,12 @@ func TestFilter(t *testing.T) {
 				) (api.ServiceAccount, error) {
 					return api.ServiceAccount{}, &meta.ErrNotFound{}
 				},
+				findGatewayByTokenFn: func(
+					context.Context,
+					string,
+				) (api.Gateway, error) {
+					return api.Gateway{}, &meta.ErrNotFound{}
+				},
 				findSessionByTokenFn: func(
 					context.Context,
 					string,
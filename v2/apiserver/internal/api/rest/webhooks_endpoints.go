@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/restmachinery"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/gorilla/mux"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// maxWebhookRequestBodyBytes is the maximum size of a request body that will
+// be accepted by a Webhook.
+const maxWebhookRequestBodyBytes = 1024 * 1024
+
+type WebhooksEndpoints struct {
+	AuthFilter          restmachinery.Filter
+	WebhookSchemaLoader gojsonschema.JSONLoader
+	Service             api.WebhooksService
+}
+
+func (w *WebhooksEndpoints) Register(router *mux.Router) {
+	// Create webhook
+	router.HandleFunc(
+		"/v2/projects/{projectID}/webhooks",
+		w.AuthFilter.Decorate(w.create),
+	).Methods(http.MethodPost)
+
+	// List webhooks
+	router.HandleFunc(
+		"/v2/projects/{projectID}/webhooks",
+		w.AuthFilter.Decorate(w.list),
+	).Methods(http.MethodGet)
+
+	// Get webhook
+	router.HandleFunc(
+		"/v2/projects/{projectID}/webhooks/{id}",
+		w.AuthFilter.Decorate(w.get),
+	).Methods(http.MethodGet)
+
+	// Delete webhook
+	router.HandleFunc(
+		"/v2/projects/{projectID}/webhooks/{id}",
+		w.AuthFilter.Decorate(w.delete),
+	).Methods(http.MethodDelete)
+
+	// Receive webhook request. Note this is NOT decorated by the AuthFilter
+	// because callers authenticate using the webhook's own token or HMAC secret.
+	router.HandleFunc(
+		"/v2/projects/{projectID}/webhooks/{id}/events",
+		w.receive,
+	).Methods(http.MethodPost)
+}
+
+func (w *WebhooksEndpoints) create(rw http.ResponseWriter, r *http.Request) {
+	webhook := api.Webhook{}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W:                   rw,
+			R:                   r,
+			ReqBodySchemaLoader: w.WebhookSchemaLoader,
+			ReqBodyObj:          &webhook,
+			EndpointLogic: func() (interface{}, error) {
+				webhook.ProjectID = mux.Vars(r)["projectID"]
+				return w.Service.Create(r.Context(), webhook)
+			},
+			SuccessCode: http.StatusCreated,
+		},
+	)
+}
+
+func (w *WebhooksEndpoints) list(rw http.ResponseWriter, r *http.Request) {
+	opts := meta.ListOptions{
+		Continue: r.URL.Query().Get("continue"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		if opts.Limit, err = strconv.ParseInt(limitStr, 10, 64); err != nil ||
+			opts.Limit < 1 || opts.Limit > 100 {
+			restmachinery.WriteAPIResponse(
+				rw,
+				http.StatusBadRequest,
+				&meta.ErrBadRequest{
+					Reason: fmt.Sprintf(
+						`Invalid value %q for "limit" query parameter`,
+						limitStr,
+					),
+				},
+			)
+			return
+		}
+	}
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: rw,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return w.Service.List(r.Context(), mux.Vars(r)["projectID"], opts)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (w *WebhooksEndpoints) get(rw http.ResponseWriter, r *http.Request) {
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: rw,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return w.Service.Get(
+					r.Context(),
+					mux.Vars(r)["projectID"],
+					mux.Vars(r)["id"],
+				)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (w *WebhooksEndpoints) delete(rw http.ResponseWriter, r *http.Request) {
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: rw,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return nil, w.Service.Delete(
+					r.Context(),
+					mux.Vars(r)["projectID"],
+					mux.Vars(r)["id"],
+				)
+			},
+			SuccessCode: http.StatusOK,
+		},
+	)
+}
+
+func (w *WebhooksEndpoints) receive(rw http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(
+		http.MaxBytesReader(rw, r.Body, maxWebhookRequestBodyBytes),
+	)
+	if err != nil {
+		restmachinery.WriteAPIResponse(
+			rw,
+			http.StatusBadRequest,
+			&meta.ErrBadRequest{
+				Reason: fmt.Sprintf(
+					"Could not read request body. Webhook request bodies may not "+
+						"exceed %d bytes.",
+					maxWebhookRequestBodyBytes,
+				),
+			},
+		)
+		return
+	}
+	// Tokens are deliberately NOT accepted via a query parameter because URLs
+	// are routinely written to access logs, browser history, and proxies.
+	token := r.Header.Get(api.WebhookTokenHeader)
+	restmachinery.ServeRequest(
+		restmachinery.InboundRequest{
+			W: rw,
+			R: r,
+			EndpointLogic: func() (interface{}, error) {
+				return w.Service.Receive(
+					r.Context(),
+					mux.Vars(r)["projectID"],
+					mux.Vars(r)["id"],
+					api.WebhookRequest{
+						Token:  token,
+						Header: r.Header,
+						Body:   body,
+					},
+				)
+			},
+			SuccessCode: http.StatusCreated,
+		},
+	)
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksEndpointsReceive(t *testing.T) {
+	const testPath = "/v2/projects/italian/webhooks/ci/events"
+	testBody := []byte(`{"foo":"bar"}`)
+	testCases := []struct {
+		name       string
+		request    func() *http.Request
+		receiveFn  func(api.WebhookRequest) (api.EventList, error)
+		assertions func(*httptest.ResponseRecorder)
+	}{
+		{
+			name: "request body too large",
+			request: func() *http.Request {
+				return httptest.NewRequest(
+					http.MethodPost,
+					testPath,
+					bytes.NewReader(make([]byte, maxWebhookRequestBodyBytes+1)),
+				)
+			},
+			receiveFn: func(api.WebhookRequest) (api.EventList, error) {
+				require.Fail(t, "receive should not have been called")
+				return api.EventList{}, nil
+			},
+			assertions: func(rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.Contains(t, rr.Body.String(), "may not exceed")
+			},
+		},
+		{
+			name: "token in query parameter is ignored",
+			request: func() *http.Request {
+				return httptest.NewRequest(
+					http.MethodPost,
+					testPath+"?token=abcdefg",
+					bytes.NewReader(testBody),
+				)
+			},
+			receiveFn: func(req api.WebhookRequest) (api.EventList, error) {
+				require.Empty(t, req.Token)
+				return api.EventList{}, &meta.ErrAuthentication{
+					Reason: "Could not authenticate the request.",
+				}
+			},
+			assertions: func(rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name: "success",
+			request: func() *http.Request {
+				req := httptest.NewRequest(
+					http.MethodPost,
+					testPath,
+					bytes.NewReader(testBody),
+				)
+				req.Header.Set(api.WebhookTokenHeader, "abcdefg")
+				req.Header.Set("X-Delivery", "42")
+				return req
+			},
+			receiveFn: func(req api.WebhookRequest) (api.EventList, error) {
+				require.Equal(t, "abcdefg", req.Token)
+				require.Equal(t, "42", req.Header.Get("X-Delivery"))
+				require.Equal(t, testBody, req.Body)
+				return api.EventList{
+					Items: []api.Event{
+						{
+							ObjectMeta: meta.ObjectMeta{
+								ID: "123456789",
+							},
+						},
+					},
+				}, nil
+			},
+			assertions: func(rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rr.Code)
+				require.Contains(t, rr.Body.String(), "123456789")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			endpoints := &WebhooksEndpoints{
+				Service: &mockWebhooksService{
+					ReceiveFn: func(
+						_ context.Context,
+						projectID string,
+						id string,
+						req api.WebhookRequest,
+					) (api.EventList, error) {
+						require.Equal(t, "italian", projectID)
+						require.Equal(t, "ci", id)
+						return testCase.receiveFn(req)
+					},
+				},
+			}
+			// The receive endpoint is registered directly because, unlike the
+			// others, it isn't decorated by the AuthFilter.
+			router := mux.NewRouter()
+			router.HandleFunc(
+				"/v2/projects/{projectID}/webhooks/{id}/events",
+				endpoints.receive,
+			)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, testCase.request())
+			testCase.assertions(rr)
+		})
+	}
+}
+
+type mockWebhooksService struct {
+	api.WebhooksService
+	ReceiveFn func(
+		context.Context,
+		string,
+		string,
+		api.WebhookRequest,
+	) (api.EventList, error)
+}
+
+func (m *mockWebhooksService) Receive(
+	ctx context.Context,
+	projectID string,
+	id string,
+	req api.WebhookRequest,
+) (api.EventList, error) {
+	return m.ReceiveFn(ctx, projectID, id, req)
+}
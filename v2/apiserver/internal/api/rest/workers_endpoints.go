@@ -190,8 +190,13 @@ func (w *WorkersEndpoints) timeout(
 			W: wr,
 			R: r,
 			EndpointLogic: func() (interface{}, error) {
-				return nil,
-					w.Service.Timeout(r.Context(), mux.Vars(r)["eventID"])
+				return nil, w.Service.Timeout(
+					r.Context(),
+					mux.Vars(r)["eventID"],
+					api.WorkerTimeoutOptions{
+						Reason: r.URL.Query().Get("reason"),
+					},
+				)
 			},
 			SuccessCode: http.StatusOK,
 		},
@@ -52,6 +52,18 @@ func (s SecretList) MarshalJSON() ([]byte, error) {
 	)
 }
 
+// SecretPolicies represents policies governing how a Project's secrets are
+// made available to its Workers and Jobs.
+type SecretPolicies struct {
+	// HideFromWorker specifies whether the Project's secrets should be withheld
+	// from its Workers entirely. When true, a Worker's view of the Project
+	// includes no secrets and Jobs can only access secrets by referencing them
+	// (see SecretRef) or by mounting them as a volume (see
+	// ProjectSecretVolume). In either case, secret values never pass through the
+	// Worker.
+	HideFromWorker bool `json:"hideFromWorker" bson:"hideFromWorker"`
+}
+
 // Secret represents Project-level sensitive information.
 type Secret struct {
 	// Key is a key by which the secret can referred.
@@ -224,6 +236,10 @@ type SecretsStore interface {
 		project Project,
 		opts meta.ListOptions,
 	) (SecretList, error)
+	// Values returns the values of all Secrets associated with the specified
+	// Project. This exists only so that secret values can be redacted from
+	// output such as logs. Values MUST NEVER be returned to clients.
+	Values(ctx context.Context, project Project) ([]string, error)
 	// Set adds or updates the provided Secret associated with the specified
 	// Project.
 	Set(ctx context.Context, project Project, secret Secret) error
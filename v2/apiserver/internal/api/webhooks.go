@@ -0,0 +1,649 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade-foundations/crypto"
+	libCrypto "github.com/brigadecore/brigade/v2/apiserver/internal/lib/crypto"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	"github.com/pkg/errors"
+)
+
+const (
+	// WebhookKind represents the canonical Webhook kind string
+	WebhookKind = "Webhook"
+
+	// WebhookTokenHeader is the HTTP header in which callers of a Webhook that
+	// uses WebhookAuthTypeToken may present the Webhook's token.
+	WebhookTokenHeader = "X-Brigade-Webhook-Token"
+	// WebhookSignatureHeader is the HTTP header in which callers of a Webhook
+	// that uses WebhookAuthTypeHMAC are, by default, expected to present the
+	// request body's signature.
+	WebhookSignatureHeader = "X-Brigade-Signature-256"
+
+	defaultWebhookSource = "brigade.sh/webhook"
+	defaultWebhookType   = "webhook"
+)
+
+// WebhookAuthType represents a means by which callers of a Webhook are
+// authenticated.
+type WebhookAuthType string
+
+const (
+	// WebhookAuthTypeToken represents authentication by means of a random token
+	// that callers must present in the X-Brigade-Webhook-Token header.
+	WebhookAuthTypeToken WebhookAuthType = "TOKEN"
+	// WebhookAuthTypeHMAC represents authentication by means of a shared secret
+	// that callers must use to sign the request body using HMAC-SHA256.
+	WebhookAuthTypeHMAC WebhookAuthType = "HMAC"
+)
+
+// Webhook represents an inbound HTTP endpoint, owned by a single Project, that
+// converts arbitrary POST requests into Events for that Project. Webhooks are
+// suitable for simple integrations where deploying a dedicated gateway would
+// be overkill.
+type Webhook struct {
+	// ObjectMeta encapsulates Webhook metadata. The ID is unique only within the
+	// scope of the Project that owns the Webhook.
+	meta.ObjectMeta `json:"metadata" bson:",inline"`
+	// ProjectID specifies the Project that owns the Webhook. Events created by
+	// the Webhook are always for this Project.
+	ProjectID string `json:"projectID" bson:"projectID"`
+	// Description is a natural language description of the Webhook's purpose.
+	Description string `json:"description,omitempty" bson:"description,omitempty"` // nolint: lll
+	// AuthType specifies how callers of the Webhook are authenticated. If not
+	// specified, WebhookAuthTypeToken is assumed.
+	AuthType WebhookAuthType `json:"authType,omitempty" bson:"authType,omitempty"` // nolint: lll
+	// SignatureHeader optionally overrides the name of the HTTP header in which
+	// callers present the request body's signature. This is applicable only
+	// when AuthType is WebhookAuthTypeHMAC and is useful for accepting requests
+	// from upstream systems that sign requests in their own header, e.g.
+	// X-Hub-Signature-256. Signatures may optionally be prefixed with "sha256=".
+	SignatureHeader string `json:"signatureHeader,omitempty" bson:"signatureHeader,omitempty"` // nolint: lll
+	// Source specifies the Source of all Events created by the Webhook. If not
+	// specified, "brigade.sh/webhook" is assumed.
+	Source string `json:"source,omitempty" bson:"source,omitempty"`
+	// Type specifies how the Type of each Event created by the Webhook should
+	// be determined. If not specified, or if no value can be extracted from the
+	// request, "webhook" is assumed.
+	Type *WebhookValueSource `json:"type,omitempty" bson:"type,omitempty"`
+	// Labels specifies how the Labels of each Event created by the Webhook
+	// should be determined. Keys are label keys. Labels whose values cannot be
+	// extracted from the request are omitted.
+	Labels map[string]WebhookValueSource `json:"labels,omitempty" bson:"labels,omitempty"` // nolint: lll
+	// HashedToken is a secure, one-way hash of the Webhook's token. This is
+	// applicable only when AuthType is WebhookAuthTypeToken.
+	HashedToken string `json:"-" bson:"hashedToken,omitempty"`
+}
+
+// MarshalJSON amends Webhook instances with type metadata.
+func (w Webhook) MarshalJSON() ([]byte, error) {
+	type Alias Webhook
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       WebhookKind,
+			},
+			Alias: (Alias)(w),
+		},
+	)
+}
+
+// WebhookValueSource describes how a single value should be determined from
+// an inbound Webhook request. Exactly one field should be specified.
+type WebhookValueSource struct {
+	// Value specifies a static value.
+	Value string `json:"value,omitempty" bson:"value,omitempty"`
+	// Header specifies the name of an HTTP header whose value should be used.
+	Header string `json:"header,omitempty" bson:"header,omitempty"`
+	// JSONPath specifies a dot-delimited path to a field within a JSON request
+	// body whose value should be used, e.g. "pull_request.base.ref". Numeric
+	// path elements index into arrays. An optional "$." prefix is permitted.
+	JSONPath string `json:"jsonPath,omitempty" bson:"jsonPath,omitempty"`
+}
+
+// extract returns the value described by the WebhookValueSource from the
+// provided request headers and (optionally parsed) body.
+func (w WebhookValueSource) extract(
+	header http.Header,
+	parsedBody interface{},
+) string {
+	switch {
+	case w.Value != "":
+		return w.Value
+	case w.Header != "":
+		return header.Get(w.Header)
+	case w.JSONPath != "":
+		return jsonPathValue(parsedBody, w.JSONPath)
+	}
+	return ""
+}
+
+// WebhookList is an ordered and pageable list of Webhooks.
+type WebhookList struct {
+	// ListMeta contains list metadata.
+	meta.ListMeta `json:"metadata"`
+	// Items is a slice of Webhooks.
+	Items []Webhook `json:"items,omitempty"`
+}
+
+// MarshalJSON amends WebhookList instances with type metadata.
+func (w WebhookList) MarshalJSON() ([]byte, error) {
+	type Alias WebhookList
+	return json.Marshal(
+		struct {
+			meta.TypeMeta `json:",inline"`
+			Alias         `json:",inline"`
+		}{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: meta.APIVersion,
+				Kind:       "WebhookList",
+			},
+			Alias: (Alias)(w),
+		},
+	)
+}
+
+// WebhookRequest represents an inbound request to a Webhook in a
+// transport-agnostic fashion.
+type WebhookRequest struct {
+	// Token is the token presented by the caller, if any.
+	Token string
+	// Header contains the request's headers.
+	Header http.Header
+	// Body is the raw request body.
+	Body []byte
+}
+
+// WebhooksService is the specialized interface for managing Webhooks. It's
+// decoupled from underlying technology choices (e.g. data store) to keep
+// business logic reusable and consistent while the underlying tech stack
+// remains free to change.
+type WebhooksService interface {
+	// Create creates a new Webhook for the specified Project and returns a Token
+	// whose value is either the Webhook's token or its HMAC secret, depending on
+	// the Webhook's AuthType. This value cannot be retrieved again later. If a
+	// Webhook having the same ID already exists for the Project,
+	// implementations MUST return a *meta.ErrConflict error.
+	Create(context.Context, Webhook) (Token, error)
+	// List retrieves a WebhookList for the specified Project.
+	List(context.Context, string, meta.ListOptions) (WebhookList, error)
+	// Get retrieves a single Webhook specified by Project and Webhook
+	// identifiers. If the specified Webhook does not exist, implementations MUST
+	// return a *meta.ErrNotFound error.
+	Get(context.Context, string, string) (Webhook, error)
+	// Delete removes a single Webhook specified by Project and Webhook
+	// identifiers. If the specified Webhook does not exist, implementations MUST
+	// return a *meta.ErrNotFound error.
+	Delete(context.Context, string, string) error
+	// Receive authenticates an inbound request to the Webhook specified by
+	// Project and Webhook identifiers and, if successful, converts it into an
+	// Event for the Webhook's Project. If the request cannot be authenticated,
+	// or if the Webhook does not exist, implementations MUST return a
+	// *meta.ErrAuthentication error.
+	Receive(
+		ctx context.Context,
+		projectID string,
+		id string,
+		req WebhookRequest,
+	) (EventList, error)
+}
+
+type webhooksService struct {
+	authorize           AuthorizeFn
+	projectAuthorize    ProjectAuthorizeFn
+	projectsStore       ProjectsStore
+	webhooksStore       WebhooksStore
+	webhookSecretsStore WebhookSecretsStore
+	createEventFn       func(context.Context, Event) (EventList, error)
+}
+
+// NewWebhooksService returns a specialized interface for managing Webhooks.
+// Events are created on behalf of Webhooks using the provided createEventFn,
+// which is expected to apply the same authorization checks and subscription
+// matching logic as any other means of creating an Event.
+func NewWebhooksService(
+	authorizeFn AuthorizeFn,
+	projectAuthorize ProjectAuthorizeFn,
+	projectsStore ProjectsStore,
+	webhooksStore WebhooksStore,
+	webhookSecretsStore WebhookSecretsStore,
+	createEventFn func(context.Context, Event) (EventList, error),
+) WebhooksService {
+	return &webhooksService{
+		authorize:           authorizeFn,
+		projectAuthorize:    projectAuthorize,
+		projectsStore:       projectsStore,
+		webhooksStore:       webhooksStore,
+		webhookSecretsStore: webhookSecretsStore,
+		createEventFn:       createEventFn,
+	}
+}
+
+func (w *webhooksService) Create(
+	ctx context.Context,
+	webhook Webhook,
+) (Token, error) {
+	token := Token{}
+
+	if err := w.projectAuthorize(
+		ctx,
+		webhook.ProjectID,
+		RoleProjectAdmin,
+	); err != nil {
+		return token, err
+	}
+
+	// Make sure the project exists
+	project, err := w.projectsStore.Get(ctx, webhook.ProjectID)
+	if err != nil {
+		return token, errors.Wrapf(
+			err,
+			"error retrieving project %q from store",
+			webhook.ProjectID,
+		)
+	}
+
+	token.Value = libCrypto.NewToken(256)
+	switch webhook.AuthType {
+	case "", WebhookAuthTypeToken:
+		webhook.AuthType = WebhookAuthTypeToken
+		webhook.HashedToken = crypto.Hash("", token.Value)
+	case WebhookAuthTypeHMAC:
+		// The secret is stored separately, below, once we know the Webhook's ID
+		// isn't already taken.
+	default:
+		return Token{}, &meta.ErrBadRequest{
+			Reason: fmt.Sprintf("Unrecognized auth type %q", webhook.AuthType),
+		}
+	}
+	if webhook.Source == "" {
+		webhook.Source = defaultWebhookSource
+	}
+	now := time.Now().UTC()
+	webhook.Created = &now
+
+	if err := w.webhooksStore.Create(ctx, webhook); err != nil {
+		return Token{}, errors.Wrapf(
+			err,
+			"error storing new webhook %q for project %q",
+			webhook.ID,
+			webhook.ProjectID,
+		)
+	}
+
+	if webhook.AuthType == WebhookAuthTypeHMAC {
+		if err := w.webhookSecretsStore.Set(
+			ctx,
+			project,
+			webhook.ID,
+			token.Value,
+		); err != nil {
+			// Don't leave behind a Webhook that nobody can authenticate to
+			if delErr := w.webhooksStore.Delete(
+				ctx,
+				webhook.ProjectID,
+				webhook.ID,
+			); delErr != nil {
+				log.Println(
+					errors.Wrapf(
+						delErr,
+						"error deleting webhook %q for project %q from store",
+						webhook.ID,
+						webhook.ProjectID,
+					),
+				)
+			}
+			return Token{}, errors.Wrapf(
+				err,
+				"error storing secret for new webhook %q for project %q",
+				webhook.ID,
+				webhook.ProjectID,
+			)
+		}
+	}
+	return token, nil
+}
+
+func (w *webhooksService) List(
+	ctx context.Context,
+	projectID string,
+	opts meta.ListOptions,
+) (WebhookList, error) {
+	if err := w.authorize(ctx, RoleReader, ""); err != nil {
+		return WebhookList{}, err
+	}
+
+	// Make sure the project exists
+	if _, err := w.projectsStore.Get(ctx, projectID); err != nil {
+		return WebhookList{}, errors.Wrapf(
+			err,
+			"error retrieving project %q from store",
+			projectID,
+		)
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+	webhooks, err := w.webhooksStore.List(ctx, projectID, opts)
+	if err != nil {
+		return webhooks, errors.Wrapf(
+			err,
+			"error retrieving webhooks for project %q from store",
+			projectID,
+		)
+	}
+	return webhooks, nil
+}
+
+func (w *webhooksService) Get(
+	ctx context.Context,
+	projectID string,
+	id string,
+) (Webhook, error) {
+	if err := w.authorize(ctx, RoleReader, ""); err != nil {
+		return Webhook{}, err
+	}
+
+	webhook, err := w.webhooksStore.Get(ctx, projectID, id)
+	if err != nil {
+		return webhook, errors.Wrapf(
+			err,
+			"error retrieving webhook %q for project %q from store",
+			id,
+			projectID,
+		)
+	}
+	return webhook, nil
+}
+
+func (w *webhooksService) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+) error {
+	if err := w.projectAuthorize(ctx, projectID, RoleProjectAdmin); err != nil {
+		return err
+	}
+
+	project, err := w.projectsStore.Get(ctx, projectID)
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"error retrieving project %q from store",
+			projectID,
+		)
+	}
+
+	if err := w.webhooksStore.Delete(ctx, projectID, id); err != nil {
+		return errors.Wrapf(
+			err,
+			"error deleting webhook %q for project %q from store",
+			id,
+			projectID,
+		)
+	}
+
+	// This is a no-op for Webhooks that don't have a secret
+	if err := w.webhookSecretsStore.Unset(ctx, project, id); err != nil {
+		return errors.Wrapf(
+			err,
+			"error deleting secret for webhook %q for project %q",
+			id,
+			projectID,
+		)
+	}
+	return nil
+}
+
+func (w *webhooksService) Receive(
+	ctx context.Context,
+	projectID string,
+	id string,
+	req WebhookRequest,
+) (EventList, error) {
+	// No authz requirements here because the caller is authenticated by the
+	// webhook's own token or HMAC secret instead of by the usual means.
+
+	webhook, err := w.webhooksStore.Get(ctx, projectID, id)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+			// Don't reveal whether the webhook exists to unauthenticated callers.
+			return EventList{}, &meta.ErrAuthentication{
+				Reason: "Could not authenticate the request.",
+			}
+		}
+		return EventList{}, errors.Wrapf(
+			err,
+			"error retrieving webhook %q for project %q from store",
+			id,
+			projectID,
+		)
+	}
+
+	var hmacSecret string
+	if webhook.AuthType == WebhookAuthTypeHMAC {
+		if hmacSecret, err = w.getHMACSecret(ctx, webhook); err != nil {
+			return EventList{}, err
+		}
+	}
+
+	if !webhook.authenticate(req, hmacSecret) {
+		return EventList{}, &meta.ErrAuthentication{
+			Reason: "Could not authenticate the request.",
+		}
+	}
+
+	events, err := w.createEventFn(
+		// Events are created on behalf of the webhook, which is only permitted to
+		// create events for its own project.
+		ContextWithPrincipal(
+			ctx,
+			GetWebhookPrincipal(webhook.ProjectID, webhook.ID),
+		),
+		webhook.event(req),
+	)
+	if err != nil {
+		return events, errors.Wrapf(
+			err,
+			"error creating event for webhook %q of project %q",
+			id,
+			projectID,
+		)
+	}
+	return events, nil
+}
+
+// getHMACSecret retrieves the provided Webhook's HMAC secret. If the secret
+// cannot be found, a *meta.ErrAuthentication error is returned.
+func (w *webhooksService) getHMACSecret(
+	ctx context.Context,
+	webhook Webhook,
+) (string, error) {
+	project, err := w.projectsStore.Get(ctx, webhook.ProjectID)
+	if err != nil {
+		return "", errors.Wrapf(
+			err,
+			"error retrieving project %q from store",
+			webhook.ProjectID,
+		)
+	}
+	secret, err := w.webhookSecretsStore.Get(ctx, project, webhook.ID)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*meta.ErrNotFound); ok {
+			return "", &meta.ErrAuthentication{
+				Reason: "Could not authenticate the request.",
+			}
+		}
+		return "", errors.Wrapf(
+			err,
+			"error retrieving secret for webhook %q for project %q",
+			webhook.ID,
+			webhook.ProjectID,
+		)
+	}
+	return secret, nil
+}
+
+// authenticate returns a boolean indicating whether the provided request
+// bears valid credentials for the Webhook. The provided HMAC secret is used
+// only if the Webhook's AuthType is WebhookAuthTypeHMAC.
+func (w Webhook) authenticate(req WebhookRequest, hmacSecret string) bool {
+	switch w.AuthType {
+	case WebhookAuthTypeHMAC:
+		signatureHeader := w.SignatureHeader
+		if signatureHeader == "" {
+			signatureHeader = WebhookSignatureHeader
+		}
+		signature, err := hex.DecodeString(
+			strings.TrimPrefix(req.Header.Get(signatureHeader), "sha256="),
+		)
+		if err != nil || len(signature) == 0 || hmacSecret == "" {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(hmacSecret))
+		mac.Write(req.Body) // nolint: errcheck
+		return hmac.Equal(signature, mac.Sum(nil))
+	default:
+		if req.Token == "" || w.HashedToken == "" {
+			return false
+		}
+		return subtle.ConstantTimeCompare(
+			[]byte(crypto.Hash("", req.Token)),
+			[]byte(w.HashedToken),
+		) == 1
+	}
+}
+
+// event returns an Event for the Webhook's Project derived from the provided
+// request.
+func (w Webhook) event(req WebhookRequest) Event {
+	var parsedBody interface{}
+	if err := json.Unmarshal(req.Body, &parsedBody); err != nil {
+		parsedBody = nil
+	}
+	event := Event{
+		ProjectID: w.ProjectID,
+		Source:    w.Source,
+		Payload:   string(req.Body),
+	}
+	if event.Source == "" {
+		event.Source = defaultWebhookSource
+	}
+	if w.Type != nil {
+		event.Type = w.Type.extract(req.Header, parsedBody)
+	}
+	if event.Type == "" {
+		event.Type = defaultWebhookType
+	}
+	for key, valueSource := range w.Labels {
+		if value := valueSource.extract(req.Header, parsedBody); value != "" {
+			if event.Labels == nil {
+				event.Labels = map[string]string{}
+			}
+			event.Labels[key] = value
+		}
+	}
+	return event
+}
+
+// jsonPathValue returns a string representation of the value found at the
+// provided dot-delimited path within the provided, parsed JSON document. If no
+// value is found, an empty string is returned.
+func jsonPathValue(doc interface{}, path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	current := doc
+	if path != "" {
+		for _, element := range strings.Split(path, ".") {
+			switch c := current.(type) {
+			case map[string]interface{}:
+				var ok bool
+				if current, ok = c[element]; !ok {
+					return ""
+				}
+			case []interface{}:
+				index, err := strconv.Atoi(element)
+				if err != nil || index < 0 || index >= len(c) {
+					return ""
+				}
+				current = c[index]
+			default:
+				return ""
+			}
+		}
+	}
+	switch c := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return c
+	default:
+		valueBytes, err := json.Marshal(c)
+		if err != nil {
+			return ""
+		}
+		return string(valueBytes)
+	}
+}
+
+// WebhooksStore is an interface for components that implement Webhook
+// persistence concerns.
+type WebhooksStore interface {
+	// Create persists a new Webhook in the underlying data store. If a Webhook
+	// having the same ID already exists for the same Project, implementations
+	// MUST return a *meta.ErrConflict error.
+	Create(context.Context, Webhook) error
+	// List retrieves a WebhookList for the specified Project from the
+	// underlying data store, with its Items (Webhooks) ordered by ID.
+	List(context.Context, string, meta.ListOptions) (WebhookList, error)
+	// Get retrieves a single Webhook, specified by Project and Webhook
+	// identifiers, from the underlying data store. If the specified Webhook does
+	// not exist, implementations MUST return a *meta.ErrNotFound error.
+	Get(context.Context, string, string) (Webhook, error)
+	// Delete deletes a single Webhook, specified by Project and Webhook
+	// identifiers, from the underlying data store. If the specified Webhook does
+	// not exist, implementations MUST return a *meta.ErrNotFound error.
+	Delete(context.Context, string, string) error
+	// DeleteByProjectID deletes all Webhooks belonging to the specified Project
+	// from the underlying data store.
+	DeleteByProjectID(context.Context, string) error
+}
+
+// WebhookSecretsStore is an interface for components that implement
+// persistence concerns for the shared secrets of Webhooks that use
+// WebhookAuthTypeHMAC. Unlike tokens, these cannot be stored as one-way hashes
+// because they are needed to compute the expected signature of each request,
+// so they are kept on the substrate, alongside the Project's other secrets,
+// instead of in Brigade's database.
+type WebhookSecretsStore interface {
+	// Get retrieves the secret of the specified Webhook of the provided Project.
+	// If no secret is found, implementations MUST return a *meta.ErrNotFound
+	// error.
+	Get(ctx context.Context, project Project, webhookID string) (string, error)
+	// Set sets the secret of the specified Webhook of the provided Project.
+	Set(
+		ctx context.Context,
+		project Project,
+		webhookID string,
+		secret string,
+	) error
+	// Unset removes the secret of the specified Webhook of the provided Project.
+	// Implementations MUST NOT return an error if no such secret exists.
+	Unset(ctx context.Context, project Project, webhookID string) error
+}
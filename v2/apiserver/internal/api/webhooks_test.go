@@ -0,0 +1,889 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/brigadecore/brigade-foundations/crypto"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	metaTesting "github.com/brigadecore/brigade/v2/apiserver/internal/meta/testing" // nolint: lll
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, Webhook{}, WebhookKind)
+}
+
+func TestWebhookListMarshalJSON(t *testing.T) {
+	metaTesting.RequireAPIVersionAndType(t, WebhookList{}, "WebhookList")
+}
+
+func TestNewWebhooksService(t *testing.T) {
+	projectsStore := &mockProjectsStore{}
+	webhooksStore := &mockWebhooksStore{}
+	webhookSecretsStore := &mockWebhookSecretsStore{}
+	svc, ok := NewWebhooksService(
+		alwaysAuthorize,
+		alwaysProjectAuthorize,
+		projectsStore,
+		webhooksStore,
+		webhookSecretsStore,
+		func(context.Context, Event) (EventList, error) {
+			return EventList{}, nil
+		},
+	).(*webhooksService)
+	require.True(t, ok)
+	require.NotNil(t, svc.authorize)
+	require.NotNil(t, svc.projectAuthorize)
+	require.Same(t, projectsStore, svc.projectsStore)
+	require.Same(t, webhooksStore, svc.webhooksStore)
+	require.Same(t, webhookSecretsStore, svc.webhookSecretsStore)
+	require.NotNil(t, svc.createEventFn)
+}
+
+func TestWebhooksServiceCreate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		webhook    Webhook
+		service    WebhooksService
+		assertions func(Token, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &webhooksService{
+				projectAuthorize: neverProjectAuthorize,
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error retrieving project from store",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving project")
+			},
+		},
+		{
+			name: "unrecognized auth type",
+			webhook: Webhook{
+				AuthType: "BOGUS",
+			},
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrBadRequest{}, err)
+			},
+		},
+		{
+			name: "error creating webhook in store",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					CreateFn: func(context.Context, Webhook) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ Token, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error storing new webhook")
+			},
+		},
+		{
+			name: "success with token auth",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					CreateFn: func(_ context.Context, webhook Webhook) error {
+						require.Equal(t, WebhookAuthTypeToken, webhook.AuthType)
+						require.NotEmpty(t, webhook.HashedToken)
+						require.Equal(t, defaultWebhookSource, webhook.Source)
+						require.NotNil(t, webhook.Created)
+						return nil
+					},
+				},
+			},
+			assertions: func(token Token, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, token.Value)
+			},
+		},
+		{
+			name: "error storing HMAC secret",
+			webhook: Webhook{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "bar",
+				},
+				AuthType: WebhookAuthTypeHMAC,
+			},
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					CreateFn: func(context.Context, Webhook) error {
+						return nil
+					},
+					DeleteFn: func(_ context.Context, _ string, id string) error {
+						require.Equal(t, "bar", id)
+						return nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					SetFn: func(context.Context, Project, string, string) error {
+						return errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(token Token, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error storing secret")
+				require.Empty(t, token.Value)
+			},
+		},
+		{
+			name: "success with HMAC auth",
+			webhook: Webhook{
+				ObjectMeta: meta.ObjectMeta{
+					ID: "bar",
+				},
+				AuthType: WebhookAuthTypeHMAC,
+				Source:   "example.com/ci",
+			},
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					CreateFn: func(_ context.Context, webhook Webhook) error {
+						require.Empty(t, webhook.HashedToken)
+						require.Equal(t, "example.com/ci", webhook.Source)
+						return nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					SetFn: func(
+						_ context.Context,
+						_ Project,
+						webhookID string,
+						secret string,
+					) error {
+						require.Equal(t, "bar", webhookID)
+						require.NotEmpty(t, secret)
+						return nil
+					},
+				},
+			},
+			assertions: func(token Token, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, token.Value)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.Create(context.Background(), testCase.webhook),
+			)
+		})
+	}
+}
+
+func TestWebhooksServiceList(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    WebhooksService
+		assertions func(WebhookList, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &webhooksService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(_ WebhookList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error retrieving project from store",
+			service: &webhooksService{
+				authorize: alwaysAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ WebhookList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving project")
+			},
+		},
+		{
+			name: "error listing webhooks",
+			service: &webhooksService{
+				authorize: alwaysAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					ListFn: func(
+						context.Context,
+						string,
+						meta.ListOptions,
+					) (WebhookList, error) {
+						return WebhookList{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ WebhookList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving webhooks")
+			},
+		},
+		{
+			name: "success",
+			service: &webhooksService{
+				authorize: alwaysAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					ListFn: func(
+						_ context.Context,
+						_ string,
+						opts meta.ListOptions,
+					) (WebhookList, error) {
+						require.Equal(t, int64(20), opts.Limit)
+						return WebhookList{
+							Items: []Webhook{{}, {}},
+						}, nil
+					},
+				},
+			},
+			assertions: func(webhooks WebhookList, err error) {
+				require.NoError(t, err)
+				require.Len(t, webhooks.Items, 2)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.List(
+					context.Background(),
+					"foo",
+					meta.ListOptions{},
+				),
+			)
+		})
+	}
+}
+
+func TestWebhooksServiceGet(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    WebhooksService
+		assertions func(Webhook, error)
+	}{
+		{
+			name: "unauthorized",
+			service: &webhooksService{
+				authorize: neverAuthorize,
+			},
+			assertions: func(_ Webhook, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting webhook from store",
+			service: &webhooksService{
+				authorize: alwaysAuthorize,
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return Webhook{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ Webhook, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving webhook")
+			},
+		},
+		{
+			name: "success",
+			service: &webhooksService{
+				authorize: alwaysAuthorize,
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(
+						_ context.Context,
+						projectID string,
+						id string,
+					) (Webhook, error) {
+						return Webhook{
+							ObjectMeta: meta.ObjectMeta{
+								ID: id,
+							},
+							ProjectID: projectID,
+						}, nil
+					},
+				},
+			},
+			assertions: func(webhook Webhook, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "foo", webhook.ProjectID)
+				require.Equal(t, "bar", webhook.ID)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.Get(context.Background(), "foo", "bar"),
+			)
+		})
+	}
+}
+
+func TestWebhooksServiceDelete(t *testing.T) {
+	testCases := []struct {
+		name       string
+		service    WebhooksService
+		assertions func(error)
+	}{
+		{
+			name: "unauthorized",
+			service: &webhooksService{
+				projectAuthorize: neverProjectAuthorize,
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthorization{}, err)
+			},
+		},
+		{
+			name: "error getting project from store",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving project")
+			},
+		},
+		{
+			name: "error deleting webhook from store",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteFn: func(context.Context, string, string) error {
+						return errors.New("store error")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error deleting webhook")
+			},
+		},
+		{
+			name: "error deleting webhook secret",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteFn: func(context.Context, string, string) error {
+						return nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					UnsetFn: func(context.Context, Project, string) error {
+						return errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error deleting secret")
+			},
+		},
+		{
+			name: "success",
+			service: &webhooksService{
+				projectAuthorize: alwaysProjectAuthorize,
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhooksStore: &mockWebhooksStore{
+					DeleteFn: func(context.Context, string, string) error {
+						return nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					UnsetFn: func(_ context.Context, _ Project, id string) error {
+						require.Equal(t, "bar", id)
+						return nil
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.Delete(context.Background(), "foo", "bar"),
+			)
+		})
+	}
+}
+
+func TestWebhooksServiceReceive(t *testing.T) {
+	const testToken = "abcdefghijklmnopqrstuvwxyz"
+	testBody := []byte(`{"action":"deploy","target":{"env":"staging"}}`)
+	mac := hmac.New(sha256.New, []byte(testToken))
+	mac.Write(testBody) // nolint: errcheck
+	testSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	tokenWebhook := Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "bar",
+		},
+		ProjectID:   "foo",
+		AuthType:    WebhookAuthTypeToken,
+		HashedToken: crypto.Hash("", testToken),
+		Type: &WebhookValueSource{
+			JSONPath: "action",
+		},
+		Labels: map[string]WebhookValueSource{
+			"env": {
+				JSONPath: "$.target.env",
+			},
+			"delivery": {
+				Header: "X-Delivery",
+			},
+			"missing": {
+				JSONPath: "nope",
+			},
+		},
+	}
+	hmacWebhook := Webhook{
+		ObjectMeta: meta.ObjectMeta{
+			ID: "bar",
+		},
+		ProjectID:       "foo",
+		AuthType:        WebhookAuthTypeHMAC,
+		SignatureHeader: "X-Hub-Signature-256",
+		Source:          "example.com/ci",
+	}
+	testCases := []struct {
+		name       string
+		request    WebhookRequest
+		service    WebhooksService
+		assertions func(EventList, error)
+	}{
+		{
+			name: "webhook not found",
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return Webhook{}, &meta.ErrNotFound{}
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthentication{}, err)
+			},
+		},
+		{
+			name: "error getting webhook from store",
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return Webhook{}, errors.New("store error")
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "store error")
+				require.Contains(t, err.Error(), "error retrieving webhook")
+			},
+		},
+		{
+			name: "invalid token",
+			request: WebhookRequest{
+				Token: "bogus",
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return tokenWebhook, nil
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthentication{}, err)
+			},
+		},
+		{
+			name: "HMAC secret not found",
+			request: WebhookRequest{
+				Header: http.Header{
+					"X-Hub-Signature-256": []string{testSignature},
+				},
+				Body: testBody,
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return hmacWebhook, nil
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					GetFn: func(context.Context, Project, string) (string, error) {
+						return "", &meta.ErrNotFound{}
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthentication{}, err)
+			},
+		},
+		{
+			name: "invalid signature",
+			request: WebhookRequest{
+				Header: http.Header{
+					"X-Hub-Signature-256": []string{"sha256=abcdef"},
+				},
+				Body: testBody,
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return hmacWebhook, nil
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					GetFn: func(context.Context, Project, string) (string, error) {
+						return testToken, nil
+					},
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.IsType(t, &meta.ErrAuthentication{}, err)
+			},
+		},
+		{
+			name: "error creating event",
+			request: WebhookRequest{
+				Token: testToken,
+				Body:  testBody,
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return tokenWebhook, nil
+					},
+				},
+				createEventFn: func(context.Context, Event) (EventList, error) {
+					return EventList{}, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ EventList, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error creating event for webhook")
+			},
+		},
+		{
+			name: "success with token auth",
+			request: WebhookRequest{
+				Token: testToken,
+				Header: http.Header{
+					"X-Delivery": []string{"42"},
+				},
+				Body: testBody,
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return tokenWebhook, nil
+					},
+				},
+				createEventFn: func(
+					ctx context.Context,
+					event Event,
+				) (EventList, error) {
+					require.IsType(t, &WebhookPrincipal{}, PrincipalFromContext(ctx))
+					require.Equal(
+						t,
+						Event{
+							ProjectID: "foo",
+							Source:    defaultWebhookSource,
+							Type:      "deploy",
+							Labels: map[string]string{
+								"env":      "staging",
+								"delivery": "42",
+							},
+							Payload: string(testBody),
+						},
+						event,
+					)
+					return EventList{Items: []Event{event}}, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
+		{
+			name: "success with HMAC auth",
+			request: WebhookRequest{
+				Header: http.Header{
+					"X-Hub-Signature-256": []string{testSignature},
+				},
+				Body: testBody,
+			},
+			service: &webhooksService{
+				webhooksStore: &mockWebhooksStore{
+					GetFn: func(context.Context, string, string) (Webhook, error) {
+						return hmacWebhook, nil
+					},
+				},
+				projectsStore: &mockProjectsStore{
+					GetFn: func(context.Context, string) (Project, error) {
+						return Project{}, nil
+					},
+				},
+				webhookSecretsStore: &mockWebhookSecretsStore{
+					GetFn: func(context.Context, Project, string) (string, error) {
+						return testToken, nil
+					},
+				},
+				createEventFn: func(
+					_ context.Context,
+					event Event,
+				) (EventList, error) {
+					require.Equal(t, "example.com/ci", event.Source)
+					require.Equal(t, defaultWebhookType, event.Type)
+					require.Nil(t, event.Labels)
+					return EventList{Items: []Event{event}}, nil
+				},
+			},
+			assertions: func(events EventList, err error) {
+				require.NoError(t, err)
+				require.Len(t, events.Items, 1)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				testCase.service.Receive(
+					context.Background(),
+					"foo",
+					"bar",
+					testCase.request,
+				),
+			)
+		})
+	}
+}
+
+func TestJSONPathValue(t *testing.T) {
+	doc := map[string]interface{}{
+		"ref": "refs/heads/main",
+		"pull_request": map[string]interface{}{
+			"number": float64(42),
+			"merged": true,
+		},
+		"commits": []interface{}{
+			map[string]interface{}{
+				"id": "1abc9c",
+			},
+		},
+	}
+	testCases := []struct {
+		path  string
+		value string
+	}{
+		{"ref", "refs/heads/main"},
+		{"$.ref", "refs/heads/main"},
+		{"pull_request.number", "42"},
+		{"pull_request.merged", "true"},
+		{"commits.0.id", "1abc9c"},
+		{"commits.1.id", ""},
+		{"commits.foo", ""},
+		{"ref.foo", ""},
+		{"nope", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			require.Equal(t, testCase.value, jsonPathValue(doc, testCase.path))
+		})
+	}
+}
+
+type mockWebhooksStore struct {
+	CreateFn func(context.Context, Webhook) error
+	ListFn   func(
+		context.Context,
+		string,
+		meta.ListOptions,
+	) (WebhookList, error)
+	GetFn               func(context.Context, string, string) (Webhook, error)
+	DeleteFn            func(context.Context, string, string) error
+	DeleteByProjectIDFn func(context.Context, string) error
+}
+
+func (m *mockWebhooksStore) Create(ctx context.Context, webhook Webhook) error {
+	return m.CreateFn(ctx, webhook)
+}
+
+func (m *mockWebhooksStore) List(
+	ctx context.Context,
+	projectID string,
+	opts meta.ListOptions,
+) (WebhookList, error) {
+	return m.ListFn(ctx, projectID, opts)
+}
+
+func (m *mockWebhooksStore) Get(
+	ctx context.Context,
+	projectID string,
+	id string,
+) (Webhook, error) {
+	return m.GetFn(ctx, projectID, id)
+}
+
+func (m *mockWebhooksStore) Delete(
+	ctx context.Context,
+	projectID string,
+	id string,
+) error {
+	return m.DeleteFn(ctx, projectID, id)
+}
+
+func (m *mockWebhooksStore) DeleteByProjectID(
+	ctx context.Context,
+	projectID string,
+) error {
+	return m.DeleteByProjectIDFn(ctx, projectID)
+}
+
+type mockWebhookSecretsStore struct {
+	GetFn   func(context.Context, Project, string) (string, error)
+	SetFn   func(context.Context, Project, string, string) error
+	UnsetFn func(context.Context, Project, string) error
+}
+
+func (m *mockWebhookSecretsStore) Get(
+	ctx context.Context,
+	project Project,
+	webhookID string,
+) (string, error) {
+	return m.GetFn(ctx, project, webhookID)
+}
+
+func (m *mockWebhookSecretsStore) Set(
+	ctx context.Context,
+	project Project,
+	webhookID string,
+	secret string,
+) error {
+	return m.SetFn(ctx, project, webhookID, secret)
+}
+
+func (m *mockWebhookSecretsStore) Unset(
+	ctx context.Context,
+	project Project,
+	webhookID string,
+) error {
+	return m.UnsetFn(ctx, project, webhookID)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/brigadecore/brigade-foundations/crypto"
 	libCrypto "github.com/brigadecore/brigade/v2/apiserver/internal/lib/crypto"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/meta"
+	myk8s "github.com/brigadecore/brigade/v2/internal/kubernetes"
 	"github.com/pkg/errors"
 )
 
@@ -104,6 +105,13 @@ type Worker struct {
 	// Jobs contains details of all Jobs spawned by the Worker during handling of
 	// the Event.
 	Jobs []Job `json:"jobs,omitempty" bson:"jobs"`
+	// RetryJobs is set only on the Worker of an Event that retries another. It
+	// names, in their original order, the Jobs of the original Event that are
+	// to be executed again rather than inherited.
+	RetryJobs []string `json:"retryJobs,omitempty" bson:"retryJobs,omitempty"`
+	// Timeline is a chronological record of every phase the Worker has
+	// transitioned through.
+	Timeline []PhaseTransition `json:"timeline,omitempty" bson:"timeline,omitempty"` // nolint: lll
 }
 
 // Job retrieves a Job by name. It returns a boolean indicating whether the
@@ -118,6 +126,15 @@ func (w *Worker) Job(jobName string) (Job, bool) {
 	return Job{}, false
 }
 
+// containerNames returns the names of all the Worker's containers from which
+// logs may be streamed. The "vcs" container, if present, is listed first.
+func (w *Worker) containerNames() []string {
+	if w.Spec.Git != nil {
+		return []string{"vcs", myk8s.LabelKeyWorker}
+	}
+	return []string{myk8s.LabelKeyWorker}
+}
+
 // WorkerSpec is the technical blueprint for a Worker.
 type WorkerSpec struct {
 	// Container specifies the details of an OCI container that forms the
@@ -220,6 +237,20 @@ type WorkerStatus struct {
 	Ended *time.Time `json:"ended,omitempty" bson:"ended,omitempty"`
 	// Phase indicates where the Worker is in its lifecycle.
 	Phase WorkerPhase `json:"phase,omitempty" bson:"phase,omitempty"`
+	// Reason is an optional, human-readable explanation of why the Worker was
+	// canceled, aborted, or timed out.
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
+	// Principal references the principal that canceled or aborted the Worker,
+	// if applicable.
+	Principal *PrincipalReference `json:"principal,omitempty" bson:"principal,omitempty"` // nolint: lll
+}
+
+// WorkerTimeoutOptions represents useful, optional settings for timing out a
+// Worker.
+type WorkerTimeoutOptions struct {
+	// Reason is an optional, human-readable explanation of why the Worker is
+	// being timed out.
+	Reason string
 }
 
 // WorkersService is the specialized interface for managing Workers. It's
@@ -257,7 +288,11 @@ type WorkersService interface {
 	Cleanup(ctx context.Context, eventID string) error
 	// Timeout updates the status of an Event's Worker that has timed out and
 	// then proceeds to remove Worker-related resources from the substrate.
-	Timeout(ctx context.Context, eventID string) error
+	Timeout(
+		ctx context.Context,
+		eventID string,
+		opts WorkerTimeoutOptions,
+	) error
 }
 
 type workersService struct {
@@ -336,6 +371,10 @@ func (w *workersService) Start(ctx context.Context, eventID string) error {
 		WorkerStatus{
 			Phase: WorkerPhaseStarting,
 		},
+		&PhaseTransition{
+			Phase: string(WorkerPhaseStarting),
+			Time:  time.Now().UTC(),
+		},
 	); err != nil {
 		return errors.Wrapf(
 			err,
@@ -442,6 +481,7 @@ func (w *workersService) Cleanup(
 func (w *workersService) Timeout(
 	ctx context.Context,
 	eventID string,
+	opts WorkerTimeoutOptions,
 ) error {
 	if err := w.authorize(ctx, RoleObserver, ""); err != nil {
 		return err
@@ -452,7 +492,11 @@ func (w *workersService) Timeout(
 		return errors.Wrapf(err, "error retrieving event %q from store", eventID)
 	}
 
-	if err := w.workersStore.Timeout(ctx, eventID); err != nil {
+	if err := w.workersStore.Timeout(
+		ctx,
+		eventID,
+		newPhaseTransition(ctx, "", opts.Reason),
+	); err != nil {
 		return errors.Wrapf(err, "error timing out worker for event %q", eventID)
 	}
 
@@ -478,11 +522,19 @@ func (w *workersService) updateStatus(
 		}
 	}
 
+	// Only record a transition if the phase has actually changed
+	var transition *PhaseTransition
+	if status.Phase != event.Worker.Status.Phase {
+		t := newPhaseTransition(ctx, string(status.Phase), "")
+		transition = &t
+	}
+
 	return errors.Wrapf(
 		w.workersStore.UpdateStatus(
 			ctx,
 			event.ID,
 			status,
+			transition,
 		),
 		"error updating status of event %q worker in store",
 		event.ID,
@@ -511,10 +563,14 @@ func (w *workersService) cleanup(ctx context.Context, event Event) error {
 // WorkersStore is an interface for components that implement Worker persistence
 // concerns.
 type WorkersStore interface {
+	// UpdateStatus updates the status of the specified Event's Worker. If the
+	// provided PhaseTransition is non-nil, implementations MUST also append it to
+	// the Worker's timeline.
 	UpdateStatus(
 		ctx context.Context,
 		eventID string,
 		status WorkerStatus,
+		transition *PhaseTransition,
 	) error
 
 	UpdateHashedToken(
@@ -523,5 +579,15 @@ type WorkersStore interface {
 		hashedToken string,
 	) error
 
-	Timeout(ctx context.Context, eventID string) error
+	// Timeout updates the status of the specified Event's Worker and any of its
+	// Jobs that have not reached a terminal phase to reflect that the Worker has
+	// timed out. Implementations MUST append the provided PhaseTransition to the
+	// timelines of the Worker and any affected Jobs, replacing its Phase with
+	// the phase each actually transitioned to, and MUST record its Reason and
+	// Principal in the Worker's status.
+	Timeout(
+		ctx context.Context,
+		eventID string,
+		transition PhaseTransition,
+	) error
 }
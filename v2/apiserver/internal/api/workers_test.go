@@ -167,7 +167,12 @@ func TestWorkersServiceStart(t *testing.T) {
 					UpdateHashedTokenFn: func(context.Context, string, string) error {
 						return nil
 					},
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						return errors.New("something went wrong")
 					},
 				},
@@ -202,7 +207,12 @@ func TestWorkersServiceStart(t *testing.T) {
 					UpdateHashedTokenFn: func(context.Context, string, string) error {
 						return nil
 					},
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						return nil
 					},
 				},
@@ -242,7 +252,12 @@ func TestWorkersServiceStart(t *testing.T) {
 					UpdateHashedTokenFn: func(context.Context, string, string) error {
 						return nil
 					},
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						return nil
 					},
 				},
@@ -448,7 +463,12 @@ func TestWorkersServiceUpdateStatus(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						return errors.New("something went wrong")
 					},
 				},
@@ -475,7 +495,12 @@ func TestWorkersServiceUpdateStatus(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						require.Fail(
 							t,
 							"UpdateStatusFn should not have been called, but was",
@@ -503,7 +528,12 @@ func TestWorkersServiceUpdateStatus(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					UpdateStatusFn: func(context.Context, string, WorkerStatus) error {
+					UpdateStatusFn: func(
+						context.Context,
+						string,
+						WorkerStatus,
+						*PhaseTransition,
+					) error {
 						return nil
 					},
 				},
@@ -697,7 +727,7 @@ func TestWorkersServiceTimeout(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					TimeoutFn: func(context.Context, string) error {
+					TimeoutFn: func(context.Context, string, PhaseTransition) error {
 						return errors.New("something went wrong")
 					},
 				},
@@ -718,7 +748,7 @@ func TestWorkersServiceTimeout(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					TimeoutFn: func(context.Context, string) error {
+					TimeoutFn: func(context.Context, string, PhaseTransition) error {
 						return nil
 					},
 				},
@@ -749,7 +779,7 @@ func TestWorkersServiceTimeout(t *testing.T) {
 					},
 				},
 				workersStore: &mockWorkersStore{
-					TimeoutFn: func(context.Context, string) error {
+					TimeoutFn: func(context.Context, string, PhaseTransition) error {
 						return nil
 					},
 				},
@@ -771,7 +801,11 @@ func TestWorkersServiceTimeout(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			err := testCase.service.Timeout(context.Background(), testEventID)
+			err := testCase.service.Timeout(
+				context.Background(),
+				testEventID,
+				WorkerTimeoutOptions{},
+			)
 			testCase.assertions(err)
 		})
 	}
@@ -782,6 +816,7 @@ type mockWorkersStore struct {
 		ctx context.Context,
 		eventID string,
 		status WorkerStatus,
+		transition *PhaseTransition,
 	) error
 
 	UpdateHashedTokenFn func(
@@ -790,15 +825,20 @@ type mockWorkersStore struct {
 		hashedToken string,
 	) error
 
-	TimeoutFn func(ctx context.Context, eventID string) error
+	TimeoutFn func(
+		ctx context.Context,
+		eventID string,
+		transition PhaseTransition,
+	) error
 }
 
 func (m *mockWorkersStore) UpdateStatus(
 	ctx context.Context,
 	eventID string,
 	status WorkerStatus,
+	transition *PhaseTransition,
 ) error {
-	return m.UpdateStatusFn(ctx, eventID, status)
+	return m.UpdateStatusFn(ctx, eventID, status, transition)
 }
 
 func (m *mockWorkersStore) UpdateHashedToken(
@@ -809,6 +849,10 @@ func (m *mockWorkersStore) UpdateHashedToken(
 	return m.UpdateHashedTokenFn(ctx, eventID, hashedToken)
 }
 
-func (m *mockWorkersStore) Timeout(ctx context.Context, eventID string) error {
-	return m.TimeoutFn(ctx, eventID)
+func (m *mockWorkersStore) Timeout(
+	ctx context.Context,
+	eventID string,
+	transition PhaseTransition,
+) error {
+	return m.TimeoutFn(ctx, eventID, transition)
 }
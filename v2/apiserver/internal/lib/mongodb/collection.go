@@ -13,6 +13,13 @@ import (
 // mock implementation for testing purposes. Adding only the subset of functions
 // that we actually use limits the effort involved in creating such mocks.
 type Collection interface {
+	// Aggregate executes an aggregate command against the collection and returns
+	// a Cursor over the resulting documents.
+	Aggregate(
+		ctx context.Context,
+		pipeline interface{},
+		opts ...*options.AggregateOptions,
+	) (*mongo.Cursor, error)
 	// CountDocuments returns the number of documents in the collection.
 	CountDocuments(
 		ctx context.Context,
@@ -14,6 +14,12 @@ import (
 )
 
 type MockCollection struct {
+	AggregateFn func(
+		ctx context.Context,
+		pipeline interface{},
+		opts ...*options.AggregateOptions,
+	) (*mongo.Cursor, error)
+
 	CountDocumentsFn func(
 		ctx context.Context,
 		filter interface{},
@@ -72,6 +78,14 @@ type MockCollection struct {
 	) (*mongo.UpdateResult, error)
 }
 
+func (m *MockCollection) Aggregate(
+	ctx context.Context,
+	pipeline interface{},
+	opts ...*options.AggregateOptions,
+) (*mongo.Cursor, error) {
+	return m.AggregateFn(ctx, pipeline, opts...)
+}
+
 func (m *MockCollection) CountDocuments(
 	ctx context.Context,
 	filter interface{},
@@ -0,0 +1,33 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrObjectNotFound is returned by implementations of the Bucket interface
+// when a requested object does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// Bucket is an interface used to abstract client code wishing to store and
+// retrieve opaque objects away from the underlying object storage system
+// (e.g. a filesystem or an S3-compatible service) in use. Objects are
+// addressed by slash-delimited keys.
+type Bucket interface {
+	// Put stores the provided content under the specified key, replacing any
+	// object already stored under that key. Readers MUST never observe a
+	// partially written object.
+	Put(ctx context.Context, key string, content []byte) error
+	// Get retrieves the content stored under the specified key. If no such
+	// object exists, implementations MUST return ErrObjectNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Exists returns a bool indicating whether an object is stored under the
+	// specified key.
+	Exists(ctx context.Context, key string) (bool, error)
+	// List returns the keys of all objects whose keys begin with the specified
+	// prefix, in lexical order.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete deletes the object stored under the specified key. Deleting an
+	// object that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
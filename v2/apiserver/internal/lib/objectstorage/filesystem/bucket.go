@@ -0,0 +1,165 @@
+package filesystem
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/pkg/errors"
+)
+
+// tempFilePrefix is the prefix of the names of temporary files that objects
+// are written to before being moved into place. Such files are never listed.
+const tempFilePrefix = ".tmp-"
+
+// bucket is a filesystem-based implementation of the objectstorage.Bucket
+// interface.
+type bucket struct {
+	root string
+}
+
+// NewBucket returns a filesystem-based implementation of the
+// objectstorage.Bucket interface that stores each object as a file beneath
+// the specified root directory. The root directory is created if it does not
+// already exist. When multiple API server replicas share a bucket of this
+// type, the root directory should be a shared volume.
+func NewBucket(root string) (objectstorage.Bucket, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.Wrapf(err, "error creating directory %q", root)
+	}
+	return &bucket{
+		root: filepath.Clean(root),
+	}, nil
+}
+
+func (b *bucket) Put(_ context.Context, key string, content []byte) error {
+	filename, err := b.filename(key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(filename)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating directory %q", dir)
+	}
+	// Write to a temporary file first and then move it into place so that
+	// readers never observe a partially written object.
+	tempFile, err := ioutil.TempFile(dir, tempFilePrefix)
+	if err != nil {
+		return errors.Wrapf(err, "error creating temporary file for %q", key)
+	}
+	defer os.Remove(tempFile.Name()) // nolint: errcheck
+	if _, err = tempFile.Write(content); err != nil {
+		tempFile.Close() // nolint: errcheck
+		return errors.Wrapf(err, "error writing object %q", key)
+	}
+	if err = tempFile.Close(); err != nil {
+		return errors.Wrapf(err, "error writing object %q", key)
+	}
+	return errors.Wrapf(
+		os.Rename(tempFile.Name(), filename),
+		"error writing object %q",
+		key,
+	)
+}
+
+func (b *bucket) Get(_ context.Context, key string) ([]byte, error) {
+	filename, err := b.filename(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, objectstorage.ErrObjectNotFound
+	}
+	return content, errors.Wrapf(err, "error reading object %q", key)
+}
+
+func (b *bucket) Exists(_ context.Context, key string) (bool, error) {
+	filename, err := b.filename(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err = os.Stat(filename); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error checking for object %q", key)
+	}
+	return true, nil
+}
+
+func (b *bucket) List(_ context.Context, prefix string) ([]string, error) {
+	// Only the directory that the prefix falls within needs to be walked
+	dir := prefix
+	if !strings.HasSuffix(prefix, "/") {
+		dir = path.Dir(prefix)
+	}
+	walkRoot, err := b.filename(dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	if err = filepath.Walk(
+		walkRoot,
+		func(filename string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if info.IsDir() || strings.HasPrefix(info.Name(), tempFilePrefix) {
+				return nil
+			}
+			relPath, err := filepath.Rel(b.root, filename)
+			if err != nil {
+				return err
+			}
+			if key := filepath.ToSlash(relPath); strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+			return nil
+		},
+	); err != nil {
+		return nil,
+			errors.Wrapf(err, "error listing objects with prefix %q", prefix)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *bucket) Delete(_ context.Context, key string) error {
+	filename, err := b.filename(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error deleting object %q", key)
+	}
+	// Clean up any directories left empty, stopping at the root
+	for dir := filepath.Dir(filename); dir != b.root; dir = filepath.Dir(dir) {
+		if err = os.Remove(dir); err != nil {
+			// The directory isn't empty or is already gone
+			break
+		}
+	}
+	return nil
+}
+
+// filename returns the path to the file in which the object having the
+// specified key is stored. An error is returned if the key contains any ".."
+// elements, as these could address a file outside the bucket's root
+// directory.
+func (b *bucket) filename(key string) (string, error) {
+	for _, element := range strings.Split(key, "/") {
+		if element == ".." {
+			return "", errors.Errorf("invalid object key %q", key)
+		}
+	}
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,82 @@
+package filesystem
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBucket(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "logs")
+	b, err := NewBucket(root)
+	require.NoError(t, err)
+	require.IsType(t, &bucket{}, b)
+	require.Equal(t, root, b.(*bucket).root)
+	info, err := os.Stat(root)
+	require.NoError(t, err)
+	require.True(t, info.IsDir())
+}
+
+func TestBucket(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	b, err := NewBucket(root)
+	require.NoError(t, err)
+
+	// Objects that don't exist
+	_, err = b.Get(ctx, "foo/bar")
+	require.Equal(t, objectstorage.ErrObjectNotFound, err)
+	exists, err := b.Exists(ctx, "foo/bar")
+	require.NoError(t, err)
+	require.False(t, exists)
+	keys, err := b.List(ctx, "foo/")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	// Put and get
+	require.NoError(t, b.Put(ctx, "foo/bar", []byte("bar")))
+	require.NoError(t, b.Put(ctx, "foo/bat/baz", []byte("baz")))
+	require.NoError(t, b.Put(ctx, "foobar", []byte("foobar")))
+	require.NoError(t, b.Put(ctx, "foo/bar", []byte("bar again")))
+	content, err := b.Get(ctx, "foo/bar")
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar again"), content)
+	exists, err = b.Exists(ctx, "foo/bat/baz")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// No temporary files are left behind
+	files, err := ioutil.ReadDir(filepath.Join(root, "foo"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// List
+	keys, err = b.List(ctx, "foo/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/bar", "foo/bat/baz"}, keys)
+	keys, err = b.List(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/bar", "foo/bat/baz", "foobar"}, keys)
+	keys, err = b.List(ctx, "foo/ba")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/bar", "foo/bat/baz"}, keys)
+
+	// Delete
+	require.NoError(t, b.Delete(ctx, "foo/bat/baz"))
+	require.NoError(t, b.Delete(ctx, "foo/bat/baz"))
+	_, err = os.Stat(filepath.Join(root, "foo", "bat"))
+	require.True(t, os.IsNotExist(err))
+	keys, err = b.List(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/bar", "foobar"}, keys)
+
+	// Keys may not escape the root directory
+	err = b.Put(ctx, "../escaped", []byte("nope"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid object key")
+}
@@ -0,0 +1,169 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/pkg/errors"
+)
+
+// BucketConfig encapsulates configuration for an S3-based implementation of
+// the objectstorage.Bucket interface.
+type BucketConfig struct {
+	// Bucket is the name of the S3 bucket in which objects are stored.
+	Bucket string
+	// Endpoint optionally specifies the address of an S3-compatible service
+	// (e.g. MinIO) to use in place of Amazon S3.
+	Endpoint string
+	// Region is the region in which the bucket exists.
+	Region string
+	// AccessKeyID, together with SecretAccessKey, optionally specifies static
+	// credentials. If not specified, credentials are discovered from the
+	// environment in the usual manner.
+	AccessKeyID string
+	// SecretAccessKey, together with AccessKeyID, optionally specifies static
+	// credentials.
+	SecretAccessKey string
+	// PathStyle specifies whether the bucket name should be specified in the
+	// path of requests instead of the hostname. Most S3-compatible services
+	// other than Amazon S3 itself require this.
+	PathStyle bool
+}
+
+// bucket is an S3-based implementation of the objectstorage.Bucket interface.
+type bucket struct {
+	name   string
+	client s3iface.S3API
+}
+
+// NewBucket returns an implementation of the objectstorage.Bucket interface
+// that stores objects in Amazon S3 or any S3-compatible service.
+func NewBucket(config BucketConfig) (objectstorage.Bucket, error) {
+	awsConfig := aws.NewConfig().
+		WithRegion(config.Region).
+		WithS3ForcePathStyle(config.PathStyle)
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint)
+	}
+	if config.AccessKeyID != "" {
+		awsConfig = awsConfig.WithCredentials(
+			credentials.NewStaticCredentials(
+				config.AccessKeyID,
+				config.SecretAccessKey,
+				"",
+			),
+		)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating S3 session")
+	}
+	return &bucket{
+		name:   config.Bucket,
+		client: s3.New(sess),
+	}, nil
+}
+
+func (b *bucket) Put(ctx context.Context, key string, content []byte) error {
+	_, err := b.client.PutObjectWithContext(
+		ctx,
+		&s3.PutObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(content),
+		},
+	)
+	return errors.Wrapf(err, "error writing object %q", key)
+}
+
+func (b *bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	output, err := b.client.GetObjectWithContext(
+		ctx,
+		&s3.GetObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+		},
+	)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, objectstorage.ErrObjectNotFound
+		}
+		return nil, errors.Wrapf(err, "error reading object %q", key)
+	}
+	defer output.Body.Close()
+	content, err := ioutil.ReadAll(output.Body)
+	return content, errors.Wrapf(err, "error reading object %q", key)
+}
+
+func (b *bucket) Exists(ctx context.Context, key string) (bool, error) {
+	if _, err := b.client.HeadObjectWithContext(
+		ctx,
+		&s3.HeadObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+		},
+	); err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error checking for object %q", key)
+	}
+	return true, nil
+}
+
+func (b *bucket) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	if err := b.client.ListObjectsV2PagesWithContext(
+		ctx,
+		&s3.ListObjectsV2Input{
+			Bucket: aws.String(b.name),
+			Prefix: aws.String(prefix),
+		},
+		func(page *s3.ListObjectsV2Output, _ bool) bool {
+			for _, object := range page.Contents {
+				keys = append(keys, aws.StringValue(object.Key))
+			}
+			return true
+		},
+	); err != nil {
+		return nil,
+			errors.Wrapf(err, "error listing objects with prefix %q", prefix)
+	}
+	// S3 already lists keys in lexical order, but not every S3-compatible
+	// service is guaranteed to.
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObjectWithContext(
+		ctx,
+		&s3.DeleteObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+		},
+	)
+	return errors.Wrapf(err, "error deleting object %q", key)
+}
+
+// isNotFound returns a bool indicating whether the provided error indicates
+// that a requested object does not exist.
+func isNotFound(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == http.StatusNotFound
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey
+	}
+	return false
+}
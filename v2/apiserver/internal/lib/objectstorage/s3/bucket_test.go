@@ -0,0 +1,231 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/objectstorage"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBucket(t *testing.T) {
+	b, err := NewBucket(
+		BucketConfig{
+			Bucket:          "logs",
+			Endpoint:        "http://minio:9000",
+			Region:          "us-east-1",
+			AccessKeyID:     "foo",
+			SecretAccessKey: "bar",
+			PathStyle:       true,
+		},
+	)
+	require.NoError(t, err)
+	require.IsType(t, &bucket{}, b)
+	require.Equal(t, "logs", b.(*bucket).name)
+	require.NotNil(t, b.(*bucket).client)
+}
+
+func TestBucketGet(t *testing.T) {
+	testCases := []struct {
+		name       string
+		client     s3iface.S3API
+		assertions func([]byte, error)
+	}{
+		{
+			name: "object not found",
+			client: &mockS3Client{
+				GetObjectFn: func(*s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+					return nil, awserr.NewRequestFailure(
+						awserr.New(s3.ErrCodeNoSuchKey, "not found", nil),
+						http.StatusNotFound,
+						"",
+					)
+				},
+			},
+			assertions: func(_ []byte, err error) {
+				require.Equal(t, objectstorage.ErrObjectNotFound, err)
+			},
+		},
+		{
+			name: "unanticipated error",
+			client: &mockS3Client{
+				GetObjectFn: func(*s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ []byte, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error reading object")
+			},
+		},
+		{
+			name: "success",
+			client: &mockS3Client{
+				GetObjectFn: func(
+					input *s3.GetObjectInput,
+				) (*s3.GetObjectOutput, error) {
+					require.Equal(t, "logs", aws.StringValue(input.Bucket))
+					require.Equal(t, "foo/bar", aws.StringValue(input.Key))
+					return &s3.GetObjectOutput{
+						Body: ioutil.NopCloser(bytes.NewBufferString("bar")),
+					}, nil
+				},
+			},
+			assertions: func(content []byte, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []byte("bar"), content)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := &bucket{
+				name:   "logs",
+				client: testCase.client,
+			}
+			content, err := b.Get(context.Background(), "foo/bar")
+			testCase.assertions(content, err)
+		})
+	}
+}
+
+func TestBucketExists(t *testing.T) {
+	testCases := []struct {
+		name       string
+		client     s3iface.S3API
+		assertions func(bool, error)
+	}{
+		{
+			name: "object not found",
+			client: &mockS3Client{
+				HeadObjectFn: func(
+					*s3.HeadObjectInput,
+				) (*s3.HeadObjectOutput, error) {
+					return nil, awserr.NewRequestFailure(
+						awserr.New("NotFound", "not found", nil),
+						http.StatusNotFound,
+						"",
+					)
+				},
+			},
+			assertions: func(exists bool, err error) {
+				require.NoError(t, err)
+				require.False(t, exists)
+			},
+		},
+		{
+			name: "unanticipated error",
+			client: &mockS3Client{
+				HeadObjectFn: func(
+					*s3.HeadObjectInput,
+				) (*s3.HeadObjectOutput, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ bool, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Contains(t, err.Error(), "error checking for object")
+			},
+		},
+		{
+			name: "object found",
+			client: &mockS3Client{
+				HeadObjectFn: func(
+					*s3.HeadObjectInput,
+				) (*s3.HeadObjectOutput, error) {
+					return &s3.HeadObjectOutput{}, nil
+				},
+			},
+			assertions: func(exists bool, err error) {
+				require.NoError(t, err)
+				require.True(t, exists)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b := &bucket{
+				name:   "logs",
+				client: testCase.client,
+			}
+			exists, err := b.Exists(context.Background(), "foo/bar")
+			testCase.assertions(exists, err)
+		})
+	}
+}
+
+func TestBucketList(t *testing.T) {
+	b := &bucket{
+		name: "logs",
+		client: &mockS3Client{
+			ListObjectsV2PagesFn: func(
+				input *s3.ListObjectsV2Input,
+				fn func(*s3.ListObjectsV2Output, bool) bool,
+			) error {
+				require.Equal(t, "foo/", aws.StringValue(input.Prefix))
+				fn(
+					&s3.ListObjectsV2Output{
+						Contents: []*s3.Object{{Key: aws.String("foo/baz")}},
+					},
+					false,
+				)
+				fn(
+					&s3.ListObjectsV2Output{
+						Contents: []*s3.Object{{Key: aws.String("foo/bar")}},
+					},
+					true,
+				)
+				return nil
+			},
+		},
+	}
+	keys, err := b.List(context.Background(), "foo/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/bar", "foo/baz"}, keys)
+}
+
+type mockS3Client struct {
+	s3iface.S3API
+	GetObjectFn          func(*s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	HeadObjectFn         func(*s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
+	ListObjectsV2PagesFn func(
+		*s3.ListObjectsV2Input,
+		func(*s3.ListObjectsV2Output, bool) bool,
+	) error
+}
+
+func (m *mockS3Client) GetObjectWithContext(
+	_ context.Context,
+	input *s3.GetObjectInput,
+	_ ...request.Option,
+) (*s3.GetObjectOutput, error) {
+	return m.GetObjectFn(input)
+}
+
+func (m *mockS3Client) HeadObjectWithContext(
+	_ context.Context,
+	input *s3.HeadObjectInput,
+	_ ...request.Option,
+) (*s3.HeadObjectOutput, error) {
+	return m.HeadObjectFn(input)
+}
+
+func (m *mockS3Client) ListObjectsV2PagesWithContext(
+	_ context.Context,
+	input *s3.ListObjectsV2Input,
+	fn func(*s3.ListObjectsV2Output, bool) bool,
+	_ ...request.Option,
+) error {
+	return m.ListObjectsV2PagesFn(input, fn)
+}
@@ -24,6 +24,11 @@ type ObjectMeta struct {
 	ID string `json:"id,omitempty" bson:"id,omitempty"`
 	// Created indicates the time at which a resource was created.
 	Created *time.Time `json:"created,omitempty" bson:"created,omitempty"`
+	// ResourceVersion is maintained by the underlying data store for resource
+	// types that support optimistic concurrency. It changes each time the
+	// portions of a resource that clients may update are changed. A zero value
+	// indicates the version is unknown.
+	ResourceVersion int64 `json:"resourceVersion,omitempty" bson:"resourceVersion,omitempty"` // nolint: lll
 }
 
 // ListMeta is metadata for ordered collections of resources.
@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/brigadecore/brigade-foundations/retries"
@@ -12,6 +13,7 @@ import (
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api"
 	apiKubernetes "github.com/brigadecore/brigade/v2/apiserver/internal/api/kubernetes"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/mongodb"
+	"github.com/brigadecore/brigade/v2/apiserver/internal/api/objectstorage"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/api/rest"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/assets"
 	"github.com/brigadecore/brigade/v2/apiserver/internal/lib/queue"
@@ -54,9 +56,14 @@ func main() {
 		}
 	}
 
+	var auditStore api.AuditStore
 	var coolLogsStore api.CoolLogsStore
 	var eventsStore api.EventsStore
+	var gatewaysStore api.GatewaysStore
 	var jobsStore api.JobsStore
+	var leasesStore api.LeasesStore
+	var logsSearchStore api.LogsSearchStore
+	var mongoLogsStore api.LogsExportStore
 	var projectsStore api.ProjectsStore
 	var projectRoleAssignmentsStore api.ProjectRoleAssignmentsStore
 	var roleAssignmentsStore api.RoleAssignmentsStore
@@ -65,17 +72,36 @@ func main() {
 	var sessionsStore api.SessionsStore
 	var usersStore api.UsersStore
 	var warmLogsStore api.LogsStore
+	var webhooksStore api.WebhooksStore
+	var webhookSecretsStore api.WebhookSecretsStore
 	var workersStore api.WorkersStore
 	{
-		coolLogsStore = mongodb.NewLogsStore(database)
+		auditStore, err = mongodb.NewAuditStore(database)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mongoLogsStore = mongodb.NewLogsStore(database)
+		coolLogsStore = mongoLogsStore
 		eventsStore, err = mongodb.NewEventsStore(database)
 		if err != nil {
 			log.Fatal(err)
 		}
+		gatewaysStore, err = mongodb.NewGatewaysStore(database)
+		if err != nil {
+			log.Fatal(err)
+		}
 		jobsStore, err = mongodb.NewJobsStore(database)
 		if err != nil {
 			log.Fatal(err)
 		}
+		leasesStore, err = mongodb.NewLeasesStore(database)
+		if err != nil {
+			log.Fatal(err)
+		}
+		logsSearchStore, err = mongodb.NewLogsSearchStore(database)
+		if err != nil {
+			log.Fatal(err)
+		}
 		projectsStore, err = mongodb.NewProjectsStore(database)
 		if err != nil {
 			log.Fatal(err)
@@ -96,13 +122,61 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
-		warmLogsStore = apiKubernetes.NewLogsStore(kubeClient)
+		warmLogsStoreConfig, err := warmLogsStoreConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		warmLogsStore =
+			apiKubernetes.NewLogsStore(kubeClient, warmLogsStoreConfig)
+		webhooksStore, err = mongodb.NewWebhooksStore(database)
+		if err != nil {
+			log.Fatal(err)
+		}
+		webhookSecretsStore = apiKubernetes.NewWebhookSecretsStore(kubeClient)
 		workersStore, err = mongodb.NewWorkersStore(database)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 
+	// Cool logs are kept in the database unless object storage is configured,
+	// in which case they're periodically migrated there from the database.
+	var logsMigrator api.LogsMigrator
+	{
+		bucket, err := coolLogsBucket()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if bucket != nil {
+			logsImportStore := objectstorage.NewLogsStore(bucket, mongoLogsStore)
+			coolLogsStore = logsImportStore
+			config, err := logsMigratorConfig()
+			if err != nil {
+				log.Fatal(err)
+			}
+			logsMigrator = api.NewLogsMigrator(
+				eventsStore,
+				mongoLogsStore,
+				logsImportStore,
+				config,
+			)
+		}
+	}
+
+	// When invoked as "apiserver migrate-logs", migrate any eligible logs once
+	// and exit instead of serving the API.
+	if len(os.Args) > 1 && os.Args[1] == "migrate-logs" {
+		if logsMigrator == nil {
+			log.Fatal("no object storage is configured for cool logs")
+		}
+		count, err := logsMigrator.Migrate(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("migrated logs for %d events", count)
+		return
+	}
+
 	// Message sending abstraction
 	var queueWriterFactory queue.WriterFactory
 	{
@@ -141,14 +215,38 @@ func main() {
 	authorizer := api.NewAuthorizer(roleAssignmentsStore)
 	projectAuthorizer := api.NewProjectAuthorizer(projectRoleAssignmentsStore)
 
+	// Audit recorder
+	var auditRecorder api.AuditRecorder
+	{
+		config, err := auditRecorderConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		auditRecorder = api.NewAuditRecorder(auditStore, config)
+	}
+
+	// Audit service
+	auditService := api.NewAuditService(authorizer.Authorize, auditStore)
+
 	// Events service
-	eventsService := api.NewEventsService(
-		authorizer.Authorize,
-		projectAuthorizer.Authorize,
-		projectsStore,
-		eventsStore,
-		coolLogsStore,
-		substrate,
+	eventsService := api.NewAuditedEventsService(
+		api.NewEventsService(
+			authorizer.Authorize,
+			projectAuthorizer.Authorize,
+			projectsStore,
+			eventsStore,
+			gatewaysStore,
+			coolLogsStore,
+			substrate,
+			auditRecorder,
+		),
+		auditRecorder,
+	)
+
+	// Gateways service
+	gatewaysService := api.NewAuditedGatewaysService(
+		api.NewGatewaysService(authorizer.Authorize, gatewaysStore),
+		auditRecorder,
 	)
 
 	// Jobs service
@@ -166,56 +264,74 @@ func main() {
 		projectAuthorizer.Authorize,
 		projectsStore,
 		eventsStore,
+		secretsStore,
 		warmLogsStore,
 		coolLogsStore,
+		logsSearchStore,
 	)
 
 	// Principals service
 	principalsService := api.NewPrincipalsService(authorizer.Authorize)
 
 	// Projects service
-	projectsService := api.NewProjectsService(
-		authorizer.Authorize,
-		projectAuthorizer.Authorize,
-		projectsStore,
-		eventsStore,
-		coolLogsStore,
-		projectRoleAssignmentsStore,
-		substrate,
+	projectsService := api.NewAuditedProjectsService(
+		api.NewProjectsService(
+			authorizer.Authorize,
+			projectAuthorizer.Authorize,
+			projectsStore,
+			eventsStore,
+			coolLogsStore,
+			projectRoleAssignmentsStore,
+			webhooksStore,
+			substrate,
+		),
+		auditRecorder,
 	)
 
 	// ProjectRoleAssignments service
-	projectRoleAssignmentsService := api.NewProjectRoleAssignmentsService(
-		authorizer.Authorize,
-		projectAuthorizer.Authorize,
-		projectsStore,
-		usersStore,
-		serviceAccountsStore,
-		projectRoleAssignmentsStore,
+	projectRoleAssignmentsService := api.NewAuditedProjectRoleAssignmentsService(
+		api.NewProjectRoleAssignmentsService(
+			authorizer.Authorize,
+			projectAuthorizer.Authorize,
+			projectsStore,
+			usersStore,
+			serviceAccountsStore,
+			projectRoleAssignmentsStore,
+		),
+		auditRecorder,
 	)
 
 	// Roles service
-	roleAssignmentsService := api.NewRoleAssignmentsService(
-		authorizer.Authorize,
-		usersStore,
-		serviceAccountsStore,
-		roleAssignmentsStore,
+	roleAssignmentsService := api.NewAuditedRoleAssignmentsService(
+		api.NewRoleAssignmentsService(
+			authorizer.Authorize,
+			usersStore,
+			serviceAccountsStore,
+			roleAssignmentsStore,
+		),
+		auditRecorder,
 	)
 
 	// ServiceAccounts service
-	serviceAccountsService := api.NewServiceAccountsService(
-		authorizer.Authorize,
-		serviceAccountsStore,
-		roleAssignmentsStore,
-		projectRoleAssignmentsStore,
+	serviceAccountsService := api.NewAuditedServiceAccountsService(
+		api.NewServiceAccountsService(
+			authorizer.Authorize,
+			serviceAccountsStore,
+			roleAssignmentsStore,
+			projectRoleAssignmentsStore,
+		),
+		auditRecorder,
 	)
 
 	// Secrets service
-	secretsService := api.NewSecretsService(
-		authorizer.Authorize,
-		projectAuthorizer.Authorize,
-		projectsStore,
-		secretsStore,
+	secretsService := api.NewAuditedSecretsService(
+		api.NewSecretsService(
+			authorizer.Authorize,
+			projectAuthorizer.Authorize,
+			projectsStore,
+			secretsStore,
+		),
+		auditRecorder,
 	)
 
 	// Session service
@@ -242,13 +358,29 @@ func main() {
 	substrateService := api.NewSubstrateService(authorizer.Authorize, substrate)
 
 	// Users service
-	usersService := api.NewUsersService(
-		authorizer.Authorize,
-		usersStore,
-		sessionsStore,
-		roleAssignmentsStore,
-		projectRoleAssignmentsStore,
-		usersServiceConfig(),
+	usersService := api.NewAuditedUsersService(
+		api.NewUsersService(
+			authorizer.Authorize,
+			usersStore,
+			sessionsStore,
+			roleAssignmentsStore,
+			projectRoleAssignmentsStore,
+			usersServiceConfig(),
+		),
+		auditRecorder,
+	)
+
+	// Webhooks service
+	webhooksService := api.NewAuditedWebhooksService(
+		api.NewWebhooksService(
+			authorizer.Authorize,
+			projectAuthorizer.Authorize,
+			projectsStore,
+			webhooksStore,
+			webhookSecretsStore,
+			eventsService.Create,
+		),
+		auditRecorder,
 	)
 
 	// Workers service
@@ -269,6 +401,7 @@ func main() {
 		}
 		authFilter := rest.NewTokenAuthFilter(
 			serviceAccountsService.GetByToken,
+			gatewaysService.GetByToken,
 			sessionsService.GetByToken,
 			eventsService.GetByWorkerToken,
 			&authFilterConfig,
@@ -279,6 +412,10 @@ func main() {
 		}
 		apiServer = restmachinery.NewServer(
 			[]restmachinery.Endpoints{
+				&rest.AuditEndpoints{
+					AuthFilter: authFilter,
+					Service:    auditService,
+				},
 				&rest.AuthnEndpoints{
 					AuthFilter: authFilter,
 					Service:    principalsService,
@@ -296,6 +433,13 @@ func main() {
 					),
 					Service: eventsService,
 				},
+				&rest.GatewaysEndpoints{
+					AuthFilter: authFilter,
+					GatewaySchemaLoader: gojsonschema.NewReferenceLoader(
+						"file:///brigade/schemas/gateway.json",
+					),
+					Service: gatewaysService,
+				},
 				&rest.JobsEndpoints{
 					AuthFilter: authFilter,
 					JobSchemaLoader: gojsonschema.NewReferenceLoader(
@@ -357,6 +501,13 @@ func main() {
 					AuthFilter: authFilter,
 					Service:    usersService,
 				},
+				&rest.WebhooksEndpoints{
+					AuthFilter: authFilter,
+					WebhookSchemaLoader: gojsonschema.NewReferenceLoader(
+						"file:///brigade/schemas/webhook.json",
+					),
+					Service: webhooksService,
+				},
 				&rest.WorkersEndpoints{
 					AuthFilter: authFilter,
 					WorkerStatusSchemaLoader: gojsonschema.NewReferenceLoader(
@@ -374,7 +525,28 @@ func main() {
 		)
 	}
 
+	// Events pruner
+	var eventsPruner api.EventsPruner
+	{
+		config, err := eventsPrunerConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+		eventsPruner = api.NewEventsPruner(
+			projectsStore,
+			eventsStore,
+			coolLogsStore,
+			leasesStore,
+			substrate,
+			config,
+		)
+	}
+
 	// Run it!
+	go eventsPruner.Run(ctx)
+	if logsMigrator != nil {
+		go logsMigrator.Run(ctx)
+	}
 	log.Println(apiServer.ListenAndServe(ctx))
 }
 
@@ -27,10 +27,12 @@ export class Job extends BrigadierJob {
         name: this.name,
         spec: {
           primaryContainer: this.primaryContainer,
+          initContainers: this.initContainers,
           sidecarContainers: this.sidecarContainers,
           timeoutDuration: this.timeoutSeconds + "s",
-          host: this.host
-        }
+          host: this.host,
+          volumes: this.volumes
+        } as core.JobSpec
       }
       await jobsClient.create(this.event.id, sdkJob)
     }
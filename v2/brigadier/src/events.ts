@@ -23,6 +23,8 @@ export interface Event {
   longTitle?: string
   /** The content of the event. This is source- and type-specific. */
   payload?: string
+  /** Values for inputs declared by the project for events of this source and type. */
+  inputs?: { [key: string]: string }
   /** The Brigade worker assigned to handle the event. */
   worker: Worker
 }
@@ -1,6 +1,19 @@
 export { Event, EventHandler, EventRegistry, events } from "./events"
 export { ConcurrentGroup, SerialGroup } from "./groups"
-export { Container, ImagePullPolicy, Job, JobHost } from "./jobs"
+export {
+  ConfigFilesVolume,
+  Container,
+  EmptyDirVolume,
+  ImagePullPolicy,
+  InitContainer,
+  Job,
+  JobHost,
+  ProjectSecretVolume,
+  ReadinessProbe,
+  SecretRef,
+  Volume,
+  VolumeMount
+} from "./jobs"
 export { Logger, logger } from "./logger"
 export { Project } from "./projects"
 export { Runnable } from "./runnables"
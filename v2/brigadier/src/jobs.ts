@@ -15,7 +15,11 @@ const defaultTimeoutSeconds: number = 60 * 15
  * executed (via its default entry point). Set the Job#primaryContainer#command
  * property to run a specific command in the container instead.
  * Other containers, specified via Job#sidecarContainers, are automatically
- * terminated a short time after the primary container completes.
+ * terminated a short time after the primary container completes. Sidecars are
+ * started before the primary container and, if a sidecar specifies a
+ * Container#readinessProbe, the primary container is not started until that
+ * sidecar is ready. Containers specified via Job#initContainers run to
+ * completion, one at a time, before any other container is started.
  * 
  * Job also provides static methods for building up runnable
  * elements out of more basic ones. For example, to compose
@@ -29,6 +33,13 @@ export class Job implements Runnable {
   /** Provides configuration options for the job's primary container. */
   public primaryContainer: Container
 
+  /**
+   * Specifies init containers to run to completion, in order, before the
+   * sidecar and primary containers are started. If any of them fails, the job
+   * fails.
+   */
+  public initContainers: InitContainer[] = []
+
   /** Specifies sidecar containers to run alongside the primary container. */
   public sidecarContainers: { [key: string]: Container } = {}
 
@@ -42,6 +53,18 @@ export class Job implements Runnable {
   /** Specifies requirements for the job execution environment. */
   public host: JobHost = new JobHost()
 
+  /**
+   * Specifies, by name, volumes that may be mounted into any of the job's
+   * containers using Container#volumeMounts. This is useful, for instance, for
+   * sharing a scratch directory between the primary container and its
+   * sidecars.
+   *
+   * @example
+   * job.volumes.scratch = { emptyDir: {} }
+   * job.primaryContainer.volumeMounts = [{ name: "scratch", mountPath: "/var/scratch" }]
+   */
+  public volumes: { [key: string]: Volume } = {}
+
   /** Specifies whether the job is permitted to fail WITHOUT causing the worker
    * process to fail.
    */
@@ -252,6 +275,32 @@ export class Container {
    * If so, the container will run unprivileged.
    */
   public privileged = false
+  /**
+   * Specifies which of the volumes declared in Job#volumes should be mounted
+   * into the container and where.
+   */
+  public volumeMounts: VolumeMount[] = []
+  /**
+   * Specifies which of the project's secrets should be made available to the
+   * container, each either as an environment variable or as a file. Unlike
+   * secrets passed via Container#environment, referenced secrets are resolved
+   * by Brigade when the container is created, so their values never pass
+   * through the worker.
+   *
+   * @example
+   * job.primaryContainer.secretRefs = [{ key: "apiToken", envVar: "API_TOKEN" }]
+   */
+  public secretRefs: SecretRef[] = []
+  /**
+   * Specifies how to determine whether the container is ready. This is
+   * applicable only to sidecar containers. The job's primary container is not
+   * started until all of its sidecars that specify a readiness probe are ready.
+   * If a sidecar does not become ready, the job fails.
+   *
+   * @example
+   * job.sidecarContainers.db.readinessProbe = { tcpSocket: { port: 5432 } }
+   */
+  public readinessProbe?: ReadinessProbe
   /**
    * Whether the container should mount the host's Docker socket into its own
    * file system. This is typically required only for "Docker-out-of-Docker" ("DooD")
@@ -279,6 +328,28 @@ export class Container {
   }
 }
 
+/**
+ * A container that runs to completion before a Job's sidecar and primary
+ * containers are started.
+ */
+export class InitContainer extends Container {
+  /**
+   * The name of the init container. It must be unique among all of the Job's
+   * containers.
+   */
+  public name: string
+
+  /**
+   * Constructs a new InitContainer.
+   * @param name The name of the init container
+   * @param image The OCI reference to the container image
+   */
+  constructor(name: string, image: string) {
+    super(image)
+    this.name = name
+  }
+}
+
 /**
  * The execution environment required by a Job.
  */
@@ -295,3 +366,132 @@ export class JobHost {
    */
   public nodeSelector: { [key: string]: string } = {}
 }
+
+/**
+ * A volume that may be mounted into any of a Job's containers. Exactly one of
+ * its fields must be specified.
+ */
+export interface Volume {
+  /**
+   * An initially empty scratch directory that exists for the lifetime of the
+   * Job.
+   */
+  emptyDir?: EmptyDirVolume
+  /** Some or all of the project's secrets, made available as files. */
+  projectSecret?: ProjectSecretVolume
+  /**
+   * Files whose contents are provided inline. These should not contain
+   * sensitive information. Use projectSecret for that instead.
+   */
+  configFiles?: ConfigFilesVolume
+}
+
+/**
+ * An initially empty scratch directory that exists for the lifetime of a Job.
+ */
+export interface EmptyDirVolume {
+  /**
+   * The type of storage backing the directory. Specify "Memory" for a
+   * RAM-backed file system. When empty, the host's default medium is used.
+   */
+  medium?: string
+  /**
+   * The maximum amount of storage the directory may use, expressed as a
+   * quantity such as "500Mi" or "1Gi".
+   */
+  sizeLimit?: string
+}
+
+/**
+ * A volume containing a project's secrets as files.
+ */
+export interface ProjectSecretVolume {
+  /**
+   * Which of the project's secrets should be included and the relative paths
+   * at which they should appear. When empty, every one of the project's secrets
+   * is included, each in a file named after the secret's key.
+   */
+  items?: { key: string, path: string }[]
+}
+
+/**
+ * A volume containing files whose contents are provided inline.
+ */
+export interface ConfigFilesVolume {
+  /** The files, each with a path relative to the volume's mount path. */
+  files: { path: string, content: string }[]
+}
+
+/**
+ * The mounting of one of a Job's volumes into one of its containers.
+ */
+export interface VolumeMount {
+  /** The name of a volume declared in Job#volumes. */
+  name: string
+  /** The path in the container's file system where the volume is mounted. */
+  mountPath: string
+  /** Whether the volume should be mounted read-only. */
+  readOnly?: boolean
+}
+
+/**
+ * A reference to one of a project's secrets. Exactly one of envVar or path must
+ * be specified.
+ */
+export interface SecretRef {
+  /** The key of the project secret. */
+  key: string
+  /**
+   * The name of the environment variable that should contain the secret's
+   * value.
+   */
+  envVar?: string
+  /**
+   * The absolute path of a read-only file that should contain the secret's
+   * value.
+   */
+  path?: string
+}
+
+/**
+ * Describes how to determine whether a sidecar container is ready. Exactly one
+ * of exec, tcpSocket, or httpGet must be specified.
+ */
+export interface ReadinessProbe {
+  /**
+   * A command to execute within the container. The container is ready once the
+   * command exits with a zero exit code.
+   */
+  exec?: { command: string[] }
+  /**
+   * A port on which the container accepts TCP connections. The container is
+   * ready once a connection can be opened.
+   */
+  tcpSocket?: { port: number }
+  /**
+   * An HTTP endpoint exposed by the container. The container is ready once a
+   * GET request to that endpoint returns a status code greater than or equal
+   * to 200 and less than 400. When path is not specified, "/" is assumed.
+   */
+  httpGet?: { path?: string, port: number }
+  /**
+   * The number of seconds to wait after the container has started before
+   * probing it for the first time.
+   */
+  initialDelaySeconds?: number
+  /**
+   * The number of seconds to wait between attempts to probe the container.
+   * The default is 5.
+   */
+  periodSeconds?: number
+  /**
+   * The number of seconds after which a single attempt to probe the container
+   * times out. The default is 1.
+   */
+  timeoutSeconds?: number
+  /**
+   * The number of consecutive failed attempts after which the container is
+   * considered to have failed to become ready. The default is 12.
+   */
+  failureThreshold?: number
+}
@@ -4,6 +4,9 @@
 export interface Project {
   /** The unique identifier of the project. */
   id: string
-  /** A map of secrets defined in the Brigade project. */
+  /**
+   * A map of secrets defined in the Brigade project. This is empty if the
+   * project hides its secrets from the worker.
+   */
   secrets: { [key: string]: string }
 }
@@ -2,7 +2,7 @@ import "mocha"
 import { assert } from "chai"
 
 import { Event } from "../src/events"
-import { Job, Container, JobHost, ImagePullPolicy } from "../src/jobs"
+import { Job, Container, InitContainer, JobHost, ImagePullPolicy } from "../src/jobs"
 
 describe("jobs", () => {
 
@@ -28,9 +28,11 @@ describe("jobs", () => {
         assert.equal(job.name, "my-name")
         assert.deepEqual(job.primaryContainer, new Container("debian:latest"))
         assert.deepEqual(job.primaryContainer.imagePullPolicy, ImagePullPolicy.IfNotPresent)
+        assert.deepEqual(job.initContainers, [])
         assert.deepEqual(job.sidecarContainers, {})
         assert.equal(job.timeoutSeconds, 60 * 15)
         assert.deepEqual(job.host, new JobHost())
+        assert.deepEqual(job.volumes, {})
       })
     })
   })
@@ -47,11 +49,25 @@ describe("jobs", () => {
         assert.isEmpty(container.workspaceMountPath)
         assert.isEmpty(container.sourceMountPath)
         assert.isFalse(container.privileged)
+        assert.deepEqual(container.volumeMounts, [])
+        assert.deepEqual(container.secretRefs, [])
+        assert.isUndefined(container.readinessProbe)
         // assert.isFalse(container.useHostDockerSocket)
       })
     })
   })
 
+  describe("InitContainer", () => {
+    describe("#constructor", () => {
+      const initContainer = new InitContainer("setup", "debian:latest")
+      it("initializes fields properly", () => {
+        assert.equal(initContainer.name, "setup")
+        assert.equal(initContainer.image, "debian:latest")
+        assert.deepEqual(initContainer.command, [])
+      })
+    })
+  })
+
   describe("JobHost", () => {
     describe("#constructor", () => {
       const jobHost = new JobHost()
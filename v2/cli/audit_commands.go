@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var auditCommand = &cli.Command{
+	Name:  "audit",
+	Usage: "Review the record of changes made via the Brigade API",
+	Subcommands: []*cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"ls"},
+			Usage:   "List audit records; requires ADMIN permissions",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.StringFlag{
+					Name: flagAction,
+					Usage: "If set, will retrieve only records of the specified " +
+						"action (e.g. projects.delete)",
+				},
+				&cli.StringFlag{
+					Name: flagContinue,
+					Usage: "Advanced-- passes an opaque value obtained from a " +
+						"previous command back to the server to access the next page " +
+						"of results",
+				},
+				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name: flagPrincipalID,
+					Usage: "If set, will retrieve only records of operations " +
+						"attempted by the principal with the specified ID",
+				},
+				&cli.StringFlag{
+					Name: flagPrincipalType,
+					Usage: "If set, will retrieve only records of operations " +
+						"attempted by principals of the specified type (e.g. USER)",
+				},
+				&cli.StringFlag{
+					Name:    flagProject,
+					Aliases: []string{"p"},
+					Usage: "If set, will retrieve only records of operations that " +
+						"targeted the specified project or its resources",
+				},
+				&cli.StringFlag{
+					Name: flagResult,
+					Usage: "If set, will retrieve only records of operations with " +
+						"the specified result; supported values: FAILED, SUCCEEDED",
+				},
+				&cli.StringFlag{
+					Name: flagSince,
+					Usage: "If set, will retrieve only records of operations " +
+						"attempted at or after the specified time; accepts an RFC3339 " +
+						"timestamp or a duration (e.g. 24h) interpreted as that long ago",
+				},
+				&cli.StringFlag{
+					Name: flagTargetID,
+					Usage: "If set, will retrieve only records of operations that " +
+						"targeted the resource with the specified ID",
+				},
+				&cli.StringFlag{
+					Name: flagTargetType,
+					Usage: "If set, will retrieve only records of operations that " +
+						"targeted resources of the specified kind (e.g. Project)",
+				},
+				&cli.StringFlag{
+					Name: flagUntil,
+					Usage: "If set, will retrieve only records of operations " +
+						"attempted before the specified time; accepts an RFC3339 " +
+						"timestamp or a duration (e.g. 24h) interpreted as that long ago",
+				},
+			},
+			Action: auditList,
+		},
+	},
+}
+
+func auditList(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	selector := sdk.AuditRecordsSelector{
+		Action:        c.String(flagAction),
+		TargetType:    c.String(flagTargetType),
+		TargetID:      c.String(flagTargetID),
+		ProjectID:     c.String(flagProject),
+		PrincipalType: sdk.PrincipalType(c.String(flagPrincipalType)),
+		PrincipalID:   c.String(flagPrincipalID),
+		Result:        sdk.AuditResult(c.String(flagResult)),
+	}
+	var err error
+	if selector.Since, err = timeFromFlag(c, flagSince); err != nil {
+		return err
+	}
+	if selector.Until, err = timeFromFlag(c, flagUntil); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	opts := meta.ListOptions{
+		Continue: c.String(flagContinue),
+	}
+
+	for {
+		records, err := client.Audit().List(c.Context, &selector, &opts)
+		if err != nil {
+			return err
+		}
+
+		if len(records.Items) == 0 {
+			fmt.Println("No audit records found.")
+			return nil
+		}
+
+		switch strings.ToLower(output) {
+		case flagOutputTable:
+			table := uitable.New()
+			table.AddRow(
+				"AGE",
+				"PRINCIPAL",
+				"ACTION",
+				"TARGET",
+				"PROJECT",
+				"RESULT",
+			)
+			for _, record := range records.Items {
+				target := record.TargetType
+				if record.TargetID != "" {
+					target = fmt.Sprintf("%s/%s", record.TargetType, record.TargetID)
+				}
+				table.AddRow(
+					duration.ShortHumanDuration(time.Since(record.Time)),
+					formatPrincipalReference(record.Principal),
+					record.Action,
+					target,
+					record.ProjectID,
+					record.Result,
+				)
+			}
+			fmt.Println(table)
+
+		case flagOutputYAML:
+			yamlBytes, err := yaml.Marshal(records)
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from list audit records operation",
+				)
+			}
+			fmt.Println(string(yamlBytes))
+
+		case flagOutputJSON:
+			prettyJSON, err := json.MarshalIndent(records, "", "  ")
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from list audit records operation",
+				)
+			}
+			fmt.Println(string(prettyJSON))
+		}
+
+		if shouldContinue, err :=
+			shouldContinue(
+				c,
+				records.RemainingItemCount,
+				records.Continue,
+			); err != nil {
+			return err
+		} else if !shouldContinue {
+			break
+		}
+
+		opts.Continue = records.Continue
+	}
+
+	return nil
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/brigadecore/brigade-foundations/file"
 	"github.com/brigadecore/brigade/sdk/v3"
 	"github.com/brigadecore/brigade/sdk/v3/meta"
@@ -14,6 +18,7 @@ import (
 	"github.com/gosuri/uitable"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh/terminal"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
 
@@ -36,6 +41,10 @@ var eventCommand = &cli.Command{
 					Required: true,
 				},
 				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name:  flagReason,
+					Usage: "Optionally, a reason for canceling the event",
+				},
 				&cli.BoolFlag{
 					Name:    flagYes,
 					Aliases: []string{"y"},
@@ -51,6 +60,11 @@ var eventCommand = &cli.Command{
 			Description: "By default, only cancels events for the specified " +
 				"project with their worker in a PENDING phase",
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name: flagDryRun,
+					Usage: "List the events that would be canceled without canceling " +
+						"them",
+				},
 				&cli.StringFlag{
 					Name:     flagProject,
 					Aliases:  []string{"p"},
@@ -70,6 +84,10 @@ var eventCommand = &cli.Command{
 						"their worker in a STARTING phase",
 				},
 				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name:  flagReason,
+					Usage: "Optionally, a reason for canceling the events",
+				},
 				&cli.BoolFlag{
 					Name:    flagYes,
 					Aliases: []string{"y"},
@@ -101,17 +119,113 @@ var eventCommand = &cli.Command{
 			},
 			Action: eventClone,
 		},
+		{
+			Name:    "clone-many",
+			Aliases: []string{"clm"},
+			Usage:   "Clone multiple events",
+			Description: "Clones events for the specified project with their " +
+				"workers in the specified phase(s), exactly as clone would clone " +
+				"each of them individually. Failures to clone individual events " +
+				"are reported without affecting the others.",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.BoolFlag{
+					Name: flagAborted,
+					Usage: "If set, will clone events with their worker in an ABORTED " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagAnyPhase,
+					Usage: "If set, will clone events with their worker in any phase; " +
+						"mutually exclusive with all other phase flags",
+				},
+				&cli.BoolFlag{
+					Name: flagCanceled,
+					Usage: "If set, will clone events with their worker in a CANCELED " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagFailed,
+					Usage: "If set, will clone events with their worker in a FAILED " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagPending,
+					Usage: "If set, will clone events with their worker in a PENDING " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Clone events for the specified project only (required)",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name: flagRunning,
+					Usage: "If set, will clone events with their worker in a RUNNING " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagStarting,
+					Usage: "If set, will clone events with their worker in a " +
+						"STARTING phase; mutually exclusive with --any-phase and " +
+						"--terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagSucceeded,
+					Usage: "If set, will clone events with their worker in a " +
+						"SUCCEEDED phase; mutually exclusive with --any-phase and " +
+						"--terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagTerminal,
+					Usage: "If set, will clone events with their worker in any " +
+						"terminal phase; mutually exclusive with all other phase flags",
+				},
+				&cli.BoolFlag{
+					Name: flagTimedOut,
+					Usage: "If set, will clone events with their worker in a " +
+						"TIMED_OUT phase; mutually exclusive with --any-phase and " +
+						"--terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagUnknown,
+					Usage: "If set, will clone events with their worker in an UNKNOWN " +
+						"phase; mutually exclusive with --any-phase and --terminal",
+				},
+				nonInteractiveFlag,
+				&cli.BoolFlag{
+					Name:    flagYes,
+					Aliases: []string{"y"},
+					Usage:   "Non-interactively confirm clones",
+				},
+			},
+			Action: eventCloneMany,
+		},
 		{
 			Name:        "create",
 			Usage:       "Create a new event",
 			Description: "Creates a new event for the specified project",
 			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name: flagDryRun,
+					Usage: "Report whether the specified project is subscribed to the " +
+						"event, and if not, why not, without creating anything",
+				},
 				&cli.BoolFlag{
 					Name:    flagFollow,
 					Aliases: []string{"f"},
 					Usage: "Synchronously wait for the event to be processed and " +
 						"stream logs from its worker",
 				},
+				&cli.StringSliceFlag{
+					Name: flagInput,
+					Usage: "Supply a value for an input declared by the project for " +
+						"events of this source and type; specify as key=value (may " +
+						"be specified multiple times). Any declared inputs not " +
+						"specified are prompted for unless --non-interactive is set.",
+				},
+				nonInteractiveFlag,
 				&cli.StringFlag{
 					Name:  flagPayload,
 					Usage: "The event payload",
@@ -155,6 +269,10 @@ var eventCommand = &cli.Command{
 					Required: true,
 				},
 				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name:  flagReason,
+					Usage: "Optionally, a reason for deleting the event",
+				},
 				&cli.BoolFlag{
 					Name:    flagYes,
 					Aliases: []string{"y"},
@@ -185,6 +303,11 @@ var eventCommand = &cli.Command{
 					Usage: "If set, will delete events with their worker in a CANCELED " +
 						"phase; mutually exclusive with --any-phase and --terminal",
 				},
+				&cli.BoolFlag{
+					Name: flagDryRun,
+					Usage: "List the events that would be deleted without deleting " +
+						"them",
+				},
 				&cli.BoolFlag{
 					Name: flagFailed,
 					Usage: "If set, will delete events with their worker in a FAILED " +
@@ -236,6 +359,10 @@ var eventCommand = &cli.Command{
 						"phase; mutually exclusive with --any-phase and --terminal",
 				},
 				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name:  flagReason,
+					Usage: "Optionally, a reason for deleting the events",
+				},
 				&cli.BoolFlag{
 					Name:    flagYes,
 					Aliases: []string{"y"},
@@ -255,6 +382,11 @@ var eventCommand = &cli.Command{
 					Required: true,
 				},
 				cliFlagOutput,
+				&cli.BoolFlag{
+					Name: flagTimeline,
+					Usage: "If set, also displays the timeline of phase transitions " +
+						"for the event's worker and jobs; only applies to table output",
+				},
 			},
 			Action: eventGet,
 		},
@@ -277,12 +409,29 @@ var eventCommand = &cli.Command{
 						"CANCELED phase; mutually exclusive with --terminal and " +
 						"--non-terminal",
 				},
+				&cli.StringFlag{
+					Name: flagCommit,
+					Usage: "If set, will retrieve only events referencing the " +
+						"specified git commit (by SHA)",
+				},
 				&cli.StringFlag{
 					Name: flagContinue,
 					Usage: "Advanced-- passes an opaque value obtained from a " +
 						"previous command back to the server to access the next page " +
 						"of results",
 				},
+				&cli.StringFlag{
+					Name: flagCreatedAfter,
+					Usage: "If set, will retrieve only events created after the " +
+						"specified time; accepts an RFC3339 timestamp or a duration " +
+						"(e.g. 24h) interpreted as that long ago",
+				},
+				&cli.StringFlag{
+					Name: flagCreatedBefore,
+					Usage: "If set, will retrieve only events created before the " +
+						"specified time; accepts an RFC3339 timestamp or a duration " +
+						"(e.g. 24h) interpreted as that long ago",
+				},
 				&cli.BoolFlag{
 					Name: flagFailed,
 					Usage: "If set, will retrieve events with their worker in a FAILED " +
@@ -306,11 +455,21 @@ var eventCommand = &cli.Command{
 					Usage: "If set, will retrieve events only for the specified " +
 						"project",
 				},
+				&cli.StringFlag{
+					Name: flagRef,
+					Usage: "If set, will retrieve only events referencing the " +
+						"specified git ref (e.g. refs/heads/main)",
+				},
 				&cli.BoolFlag{
 					Name: flagRunning,
 					Usage: "If set, will retrieve events with their worker in RUNNING " +
 						"phase; mutually exclusive with --terminal and --non-terminal",
 				},
+				&cli.StringFlag{
+					Name: flagSearch,
+					Usage: "If set, will retrieve only events whose titles or summary " +
+						"match the specified words; quote phrases to match them exactly",
+				},
 				&cli.BoolFlag{
 					Name: flagStarting,
 					Usage: "If set, will retrieve events with their worker in a " +
@@ -359,6 +518,12 @@ var eventCommand = &cli.Command{
 					Usage: "Synchronously wait for the event to be processed and " +
 						"stream logs from its worker",
 				},
+				&cli.StringFlag{
+					Name: flagFromJob,
+					Usage: "If set, will re-execute the specified job and all jobs " +
+						"following it, inheriting only eligible jobs that preceded it; " +
+						"defaults to the first job that did not succeed",
+				},
 				&cli.StringFlag{
 					Name:     flagID,
 					Aliases:  []string{"i", flagEvent, "e"},
@@ -368,11 +533,142 @@ var eventCommand = &cli.Command{
 			},
 			Action: eventRetry,
 		},
+		{
+			Name:    "retry-many",
+			Aliases: []string{"rtm"},
+			Usage:   "Retry multiple events",
+			Description: "Retries events for the specified project with their " +
+				"workers in the specified terminal phase(s), exactly as retry " +
+				"would retry each of them individually. Failures to retry " +
+				"individual events are reported without affecting the others.",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.BoolFlag{
+					Name: flagAborted,
+					Usage: "If set, will retry events with their worker in an ABORTED " +
+						"phase; mutually exclusive with --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagCanceled,
+					Usage: "If set, will retry events with their worker in a CANCELED " +
+						"phase; mutually exclusive with --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagFailed,
+					Usage: "If set, will retry events with their worker in a FAILED " +
+						"phase; mutually exclusive with --terminal",
+				},
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Retry events for the specified project only (required)",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name: flagSucceeded,
+					Usage: "If set, will retry events with their worker in a " +
+						"SUCCEEDED phase; mutually exclusive with --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagTerminal,
+					Usage: "If set, will retry events with their worker in any " +
+						"terminal phase; mutually exclusive with all other phase flags",
+				},
+				&cli.BoolFlag{
+					Name: flagTimedOut,
+					Usage: "If set, will retry events with their worker in a " +
+						"TIMED_OUT phase; mutually exclusive with --terminal",
+				},
+				&cli.BoolFlag{
+					Name: flagUnknown,
+					Usage: "If set, will retry events with their worker in an UNKNOWN " +
+						"phase; mutually exclusive with --terminal",
+				},
+				nonInteractiveFlag,
+				&cli.BoolFlag{
+					Name:    flagYes,
+					Aliases: []string{"y"},
+					Usage:   "Non-interactively confirm retries",
+				},
+			},
+			Action: eventRetryMany,
+		},
 		logsCommand,
 	},
 }
 
+func eventCloneMany(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	workerPhases, err := workerPhasesFromFlags(c)
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Core().Events().CloneMany(
+		c.Context,
+		sdk.EventsSelector{
+			ProjectID:    c.String(flagProject),
+			WorkerPhases: workerPhases,
+		},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputTable:
+		printEventCopyResults(result.Items)
+		fmt.Printf(
+			"Cloned %d events; failed to clone %d events.\n",
+			result.Count,
+			result.FailedCount,
+		)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(result)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from clone many events operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from clone many events operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
 func eventCreate(c *cli.Context) error {
+	dryRun := c.Bool(flagDryRun)
 	follow := c.Bool(flagFollow)
 	payload := c.String(flagPayload)
 	payloadFile := c.String(flagPayloadFile)
@@ -380,6 +676,9 @@ func eventCreate(c *cli.Context) error {
 	source := c.String(flagSource)
 	eventType := c.String(flagType)
 
+	if dryRun && follow {
+		return errors.New("--dry-run and --follow may not be used together")
+	}
 	if payload != "" && payloadFile != "" {
 		return errors.New(
 			"only one of --payload or --payload-file may be specified",
@@ -407,16 +706,45 @@ func eventCreate(c *cli.Context) error {
 		payload = string(payloadBytes)
 	}
 
+	inputs := map[string]string{}
+	for _, inputStr := range c.StringSlice(flagInput) {
+		kvTokens := strings.SplitN(inputStr, "=", 2)
+		if len(kvTokens) != 2 {
+			return errors.Errorf("invalid value %q for --input flag", inputStr)
+		}
+		inputs[kvTokens[0]] = kvTokens[1]
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	if !c.Bool(flagNonInteractive) && terminal.IsTerminal(int(os.Stdout.Fd())) {
+		project, err := client.Core().Projects().Get(c.Context, projectID, nil)
+		if err != nil {
+			return err
+		}
+		if err = promptForEventInputs(project, source, eventType, inputs); err != nil {
+			return err
+		}
+	}
+
 	event := sdk.Event{
 		ProjectID: projectID,
 		Source:    source,
 		Type:      eventType,
 		Payload:   payload,
+		Inputs:    inputs,
 	}
 
-	client, err := getClient(false)
-	if err != nil {
-		return err
+	if dryRun {
+		result, err := client.Core().Events().CreateDryRun(c.Context, event, nil)
+		if err != nil {
+			return err
+		}
+		printEventCreateDryRunResult(result)
+		return nil
 	}
 
 	events, err := client.Core().Events().Create(c.Context, event, nil)
@@ -451,6 +779,76 @@ func eventCreate(c *cli.Context) error {
 	)
 }
 
+// promptForEventInputs interactively prompts the user for values of any
+// inputs the provided Project declares for Events of the specified source and
+// type that are not already present in the provided inputs map. Values
+// obtained are added to the map.
+func promptForEventInputs(
+	project sdk.Project,
+	source string,
+	eventType string,
+	inputs map[string]string,
+) error {
+	for _, decl := range project.Spec.EventInputs {
+		if decl.Source != source || decl.Type != eventType {
+			continue
+		}
+		for _, input := range decl.Inputs {
+			if _, ok := inputs[input.Name]; ok {
+				continue
+			}
+			var prompt survey.Prompt
+			switch input.Type {
+			case sdk.EventInputTypeBoolean:
+				defaultVal, _ := strconv.ParseBool(input.Default)
+				prompt = &survey.Confirm{
+					Message: input.Name,
+					Help:    input.Description,
+					Default: defaultVal,
+				}
+			case sdk.EventInputTypeEnum:
+				selectPrompt := &survey.Select{
+					Message: input.Name,
+					Help:    input.Description,
+					Options: input.Options,
+				}
+				if input.Default != "" {
+					selectPrompt.Default = input.Default
+				}
+				prompt = selectPrompt
+			default:
+				prompt = &survey.Input{
+					Message: input.Name,
+					Help:    input.Description,
+					Default: input.Default,
+				}
+			}
+			var opts []survey.AskOpt
+			if input.Required && input.Default == "" {
+				opts = append(opts, survey.WithValidator(survey.Required))
+			}
+			if input.Type == sdk.EventInputTypeBoolean {
+				var value bool
+				if err := survey.AskOne(prompt, &value, opts...); err != nil {
+					return errors.Wrapf(err, "error prompting for input %q", input.Name)
+				}
+				inputs[input.Name] = strconv.FormatBool(value)
+				continue
+			}
+			var value string
+			if err := survey.AskOne(prompt, &value, opts...); err != nil {
+				return errors.Wrapf(err, "error prompting for input %q", input.Name)
+			}
+			if value != "" {
+				inputs[input.Name] = value
+			}
+		}
+		fmt.Println()
+		return nil
+	}
+	return nil
+}
+
 // nolint: gocyclo
 func eventList(c *cli.Context) error {
 	output := c.String(flagOutput)
@@ -529,7 +927,16 @@ func eventList(c *cli.Context) error {
 		workerPhases = sdk.WorkerPhasesNonTerminal()
 	}
 
-	if err := validateOutputFormat(output); err != nil {
+	createdAfter, err := timeFromFlag(c, flagCreatedAfter)
+	if err != nil {
+		return err
+	}
+	createdBefore, err := timeFromFlag(c, flagCreatedBefore)
+	if err != nil {
+		return err
+	}
+
+	if err = validateOutputFormat(output); err != nil {
 		return err
 	}
 
@@ -539,12 +946,17 @@ func eventList(c *cli.Context) error {
 	}
 
 	selector := sdk.EventsSelector{
-		ProjectID:    c.String(flagProject),
-		Source:       c.String(flagSource),
-		Type:         c.String(flagType),
-		Qualifiers:   qualifiers,
-		Labels:       labels,
-		WorkerPhases: workerPhases,
+		ProjectID:     c.String(flagProject),
+		Source:        c.String(flagSource),
+		Type:          c.String(flagType),
+		Qualifiers:    qualifiers,
+		Labels:        labels,
+		WorkerPhases:  workerPhases,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		Commit:        c.String(flagCommit),
+		Ref:           c.String(flagRef),
+		Text:          c.String(flagSearch),
 	}
 	opts := meta.ListOptions{
 		Continue: c.String(flagContinue),
@@ -615,6 +1027,61 @@ func eventList(c *cli.Context) error {
 	return nil
 }
 
+// timeFromFlag parses the value of the specified flag as either an RFC3339
+// timestamp or a duration that is interpreted as that long ago. It returns nil
+// if the flag was not set.
+func timeFromFlag(c *cli.Context, flag string) (*time.Time, error) {
+	str := c.String(flag)
+	if str == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return &t, nil
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return nil, errors.Errorf(
+			"invalid value %q for --%s flag; expected an RFC3339 timestamp or a "+
+				"duration",
+			str,
+			flag,
+		)
+	}
+	t := time.Now().Add(-d)
+	return &t, nil
+}
+
+// printEventCreateDryRunResult prints a table indicating which projects would
+// have been sent an event and, for those that would not, which criteria of each
+// of their event subscriptions the event failed to meet.
+func printEventCreateDryRunResult(result sdk.EventCreateDryRunResult) {
+	table := uitable.New()
+	table.AddRow("PROJECT", "SUBSCRIBED", "REASONS")
+	for _, projectID := range result.Subscribers {
+		table.AddRow(projectID, "yes", "")
+	}
+	for _, nonSubscriber := range result.NonSubscribers {
+		if len(nonSubscriber.Subscriptions) == 0 {
+			table.AddRow(nonSubscriber.ProjectID, "no", "no event subscriptions")
+			continue
+		}
+		projectID, subscribed := nonSubscriber.ProjectID, "no"
+		for _, subscription := range nonSubscriber.Subscriptions {
+			for _, reason := range subscription.Reasons {
+				table.AddRow(
+					projectID,
+					subscribed,
+					fmt.Sprintf("subscription %d: %s", subscription.Index, reason),
+				)
+				// Only label the first row for each project
+				projectID, subscribed = "", ""
+			}
+		}
+	}
+	fmt.Println(table)
+	fmt.Println("\nDry run only. No event created.")
+}
+
 func eventGet(c *cli.Context) error {
 	id := c.String(flagID)
 	output := c.String(flagOutput)
@@ -651,6 +1118,19 @@ func eventGet(c *cli.Context) error {
 		)
 		fmt.Println(table)
 
+		if workerStatus := event.Worker.Status; workerStatus.Reason != "" ||
+			workerStatus.Principal != nil {
+			fmt.Printf("\nEvent %q worker status details:\n\n", event.ID)
+			table = uitable.New()
+			table.AddRow("PHASE", "PRINCIPAL", "REASON")
+			table.AddRow(
+				workerStatus.Phase,
+				formatPrincipalReference(workerStatus.Principal),
+				workerStatus.Reason,
+			)
+			fmt.Println(table)
+		}
+
 		if len(event.Worker.Jobs) > 0 {
 			fmt.Printf("\nEvent %q jobs:\n\n", event.ID)
 			table = uitable.New()
@@ -676,6 +1156,11 @@ func eventGet(c *cli.Context) error {
 			fmt.Println(table)
 		}
 
+		if c.Bool(flagTimeline) {
+			fmt.Printf("\nEvent %q timeline:\n\n", event.ID)
+			fmt.Println(timelineTable(event.Worker))
+		}
+
 	case flagOutputYAML:
 		yamlBytes, err := yaml.Marshal(event)
 		if err != nil {
@@ -700,6 +1185,62 @@ func eventGet(c *cli.Context) error {
 	return nil
 }
 
+// timelineTable returns a table of all phase transitions of the provided
+// Worker and its Jobs, in chronological order.
+func timelineTable(worker *sdk.Worker) *uitable.Table {
+	type timelineEntry struct {
+		component  string
+		transition sdk.PhaseTransition
+	}
+	entries := []timelineEntry{}
+	for _, transition := range worker.Timeline {
+		entries = append(
+			entries,
+			timelineEntry{
+				component:  "worker",
+				transition: transition,
+			},
+		)
+	}
+	for _, job := range worker.Jobs {
+		for _, transition := range job.Timeline {
+			entries = append(
+				entries,
+				timelineEntry{
+					component:  fmt.Sprintf("job %s", job.Name),
+					transition: transition,
+				},
+			)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].transition.Time.Before(entries[j].transition.Time)
+	})
+
+	table := uitable.New()
+	table.AddRow("TIME", "COMPONENT", "PHASE", "PRINCIPAL", "REASON")
+	for _, entry := range entries {
+		table.AddRow(
+			entry.transition.Time.UTC().Format(time.RFC3339),
+			entry.component,
+			entry.transition.Phase,
+			formatPrincipalReference(entry.transition.Principal),
+			entry.transition.Reason,
+		)
+	}
+	return table
+}
+
+// formatPrincipalReference returns a short, human-readable representation of
+// the provided PrincipalReference. It returns an empty string if the reference
+// is nil.
+func formatPrincipalReference(ref *sdk.PrincipalReference) string {
+	if ref == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", ref.Type, ref.ID)
+}
+
 func eventCancel(c *cli.Context) error {
 	id := c.String(flagID)
 
@@ -716,7 +1257,13 @@ func eventCancel(c *cli.Context) error {
 		return err
 	}
 
-	if err = client.Core().Events().Cancel(c.Context, id, nil); err != nil {
+	if err = client.Core().Events().Cancel(
+		c.Context,
+		id,
+		&sdk.EventCancelOptions{
+			Reason: c.String(flagReason),
+		},
+	); err != nil {
 		return err
 	}
 	fmt.Printf("Event %q canceled.\n", id)
@@ -738,14 +1285,6 @@ func eventCancelMany(c *cli.Context) error {
 		workerPhases = append(workerPhases, sdk.WorkerPhaseStarting)
 	}
 
-	confirmed, err := confirmed(c)
-	if err != nil {
-		return err
-	}
-	if !confirmed {
-		return nil
-	}
-
 	client, err := getClient(false)
 	if err != nil {
 		return err
@@ -756,7 +1295,38 @@ func eventCancelMany(c *cli.Context) error {
 		WorkerPhases: workerPhases,
 	}
 
-	events, err := client.Core().Events().CancelMany(c.Context, selector, nil)
+	if c.Bool(flagDryRun) || !c.Bool(flagYes) {
+		preview, err := client.Core().Events().CancelMany(
+			c.Context,
+			selector,
+			&sdk.EventCancelManyOptions{
+				DryRun: true,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		printEventsPreview(preview.Count, preview.SampleIDs, "canceled")
+		if c.Bool(flagDryRun) || preview.Count == 0 {
+			return nil
+		}
+	}
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	events, err := client.Core().Events().CancelMany(
+		c.Context,
+		selector,
+		&sdk.EventCancelManyOptions{
+			Reason: c.String(flagReason),
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -781,7 +1351,13 @@ func eventDelete(c *cli.Context) error {
 		return err
 	}
 
-	if err = client.Core().Events().Delete(c.Context, id, nil); err != nil {
+	if err = client.Core().Events().Delete(
+		c.Context,
+		id,
+		&sdk.EventDeleteOptions{
+			Reason: c.String(flagReason),
+		},
+	); err != nil {
 		return err
 	}
 	fmt.Printf("Event %q deleted.\n", id)
@@ -839,14 +1415,6 @@ func eventDeleteMany(c *cli.Context) error {
 		workerPhases = sdk.WorkerPhasesTerminal()
 	}
 
-	confirmed, err := confirmed(c)
-	if err != nil {
-		return err
-	}
-	if !confirmed {
-		return nil
-	}
-
 	client, err := getClient(false)
 	if err != nil {
 		return err
@@ -857,7 +1425,38 @@ func eventDeleteMany(c *cli.Context) error {
 		WorkerPhases: workerPhases,
 	}
 
-	events, err := client.Core().Events().DeleteMany(c.Context, selector, nil)
+	if c.Bool(flagDryRun) || !c.Bool(flagYes) {
+		preview, err := client.Core().Events().DeleteMany(
+			c.Context,
+			selector,
+			&sdk.EventDeleteManyOptions{
+				DryRun: true,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		printEventsPreview(preview.Count, preview.SampleIDs, "deleted")
+		if c.Bool(flagDryRun) || preview.Count == 0 {
+			return nil
+		}
+	}
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	events, err := client.Core().Events().DeleteMany(
+		c.Context,
+		selector,
+		&sdk.EventDeleteManyOptions{
+			Reason: c.String(flagReason),
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -911,7 +1510,13 @@ func eventRetry(c *cli.Context) error {
 		return err
 	}
 
-	event, err := client.Core().Events().Retry(c.Context, id, nil)
+	event, err := client.Core().Events().Retry(
+		c.Context,
+		id,
+		&sdk.EventRetryOptions{
+			FromJob: c.String(flagFromJob),
+		},
+	)
 	if err != nil {
 		return err
 	}
@@ -938,3 +1543,161 @@ func eventRetry(c *cli.Context) error {
 		},
 	)
 }
+
+func eventRetryMany(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	workerPhases, err := workerPhasesFromFlags(c)
+	if err != nil {
+		return err
+	}
+	if len(workerPhases) == 0 {
+		return errors.New("at least one terminal phase flag must be set")
+	}
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.Core().Events().RetryMany(
+		c.Context,
+		sdk.EventsSelector{
+			ProjectID:    c.String(flagProject),
+			WorkerPhases: workerPhases,
+		},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputTable:
+		printEventCopyResults(result.Items)
+		fmt.Printf(
+			"Retried %d events; failed to retry %d events.\n",
+			result.Count,
+			result.FailedCount,
+		)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(result)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from retry many events operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from retry many events operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+// printEventCopyResults prints a table summarizing the outcome of retrying or
+// cloning each of many events. Nothing is printed if there are no results.
+func printEventCopyResults(results []sdk.EventCopyResult) {
+	if len(results) == 0 {
+		return
+	}
+	table := uitable.New()
+	table.AddRow("ORIGINAL EVENT", "NEW EVENT", "ERROR")
+	for _, result := range results {
+		table.AddRow(result.SourceEventID, result.EventID, result.Error)
+	}
+	fmt.Println(table)
+	fmt.Println()
+}
+
+// workerPhasesFromFlags returns the worker phases selected by whichever worker
+// phase flags are defined and set for the current command. It returns an error
+// if --any-phase or --terminal is combined with any other phase flag.
+func workerPhasesFromFlags(c *cli.Context) ([]sdk.WorkerPhase, error) {
+	phaseFlags := []struct {
+		flag  string
+		phase sdk.WorkerPhase
+	}{
+		{flagAborted, sdk.WorkerPhaseAborted},
+		{flagCanceled, sdk.WorkerPhaseCanceled},
+		{flagFailed, sdk.WorkerPhaseFailed},
+		{flagPending, sdk.WorkerPhasePending},
+		{flagRunning, sdk.WorkerPhaseRunning},
+		{flagStarting, sdk.WorkerPhaseStarting},
+		{flagSucceeded, sdk.WorkerPhaseSucceeded},
+		{flagTimedOut, sdk.WorkerPhaseTimedOut},
+		{flagUnknown, sdk.WorkerPhaseUnknown},
+	}
+	workerPhases := []sdk.WorkerPhase{}
+	for _, phaseFlag := range phaseFlags {
+		if c.Bool(phaseFlag.flag) {
+			workerPhases = append(workerPhases, phaseFlag.phase)
+		}
+	}
+
+	if c.Bool(flagAnyPhase) {
+		if len(workerPhases) > 0 {
+			return nil, errors.New(
+				"--any-phase is mutually exclusive with all other phase flags",
+			)
+		}
+		workerPhases = sdk.WorkerPhasesAll()
+	}
+
+	if c.Bool(flagTerminal) {
+		if len(workerPhases) > 0 {
+			return nil, errors.New(
+				"--terminal is mutually exclusive with all other phase flags",
+			)
+		}
+		workerPhases = sdk.WorkerPhasesTerminal()
+	}
+
+	return workerPhases, nil
+}
+
+// maxPreviewedEvents is the maximum number of event IDs listed when previewing
+// the events a mass operation would affect.
+const maxPreviewedEvents = 10
+
+// printEventsPreview summarizes the events that a mass operation would affect,
+// listing a sample of their IDs.
+func printEventsPreview(count int64, sampleIDs []string, verb string) {
+	if count == 0 {
+		fmt.Printf("No events would be %s.\n", verb)
+		return
+	}
+	fmt.Printf("%d events would be %s:\n", count, verb)
+	if len(sampleIDs) > maxPreviewedEvents {
+		sampleIDs = sampleIDs[:maxPreviewedEvents]
+	}
+	for _, id := range sampleIDs {
+		fmt.Printf("  %s\n", id)
+	}
+	if remaining := count - int64(len(sampleIDs)); remaining > 0 {
+		fmt.Printf("  ...and %d more\n", remaining)
+	}
+	fmt.Println()
+}
@@ -3,50 +3,87 @@ package main
 import "github.com/urfave/cli/v2"
 
 const (
-	flagAborted        = "aborted"
-	flagAnyPhase       = "any-phase"
-	flagBrowse         = "browse"
-	flagCanceled       = "canceled"
-	flagClient         = "client"
-	flagContainer      = "container"
-	flagContinue       = "continue"
-	flagCreate         = "create"
-	flagDescription    = "description"
-	flagEvent          = "event"
-	flagFailed         = "failed"
-	flagFile           = "file"
-	flagFollow         = "follow"
-	flagGit            = "git"
-	flagID             = "id"
-	flagInsecure       = "insecure"
-	flagJob            = "job"
-	flagLabel          = "label"
-	flagLanguage       = "language"
-	flagNonInteractive = "non-interactive"
-	flagNonTerminal    = "non-terminal"
-	flagOutput         = "output"
-	flagPassword       = "password"
-	flagPayload        = "payload"
-	flagPayloadFile    = "payload-file"
-	flagPending        = "pending"
-	flagProject        = "project"
-	flagQualifier      = "qualifier"
-	flagRole           = "role"
-	flagRoot           = "root"
-	flagRunning        = "running"
-	flagServer         = "server"
-	flagServiceAccount = "service-account"
-	flagSet            = "set"
-	flagSource         = "source"
-	flagStarting       = "starting"
-	flagSucceeded      = "succeeded"
-	flagTerminal       = "terminal"
-	flagTimedOut       = "timedout"
-	flagType           = "type"
-	flagUnknown        = "unknown"
-	flagUnset          = "unset"
-	flagUser           = "user"
-	flagYes            = "yes"
+	flagAborted         = "aborted"
+	flagAction          = "action"
+	flagAll             = "all"
+	flagAnyPhase        = "any-phase"
+	flagBrowse          = "browse"
+	flagBucketSize      = "bucket-size"
+	flagCanceled        = "canceled"
+	flagClient          = "client"
+	flagCommit          = "commit"
+	flagContainer       = "container"
+	flagContinue        = "continue"
+	flagCreate          = "create"
+	flagCreatedAfter    = "created-after"
+	flagCreatedBefore   = "created-before"
+	flagDescription     = "description"
+	flagDryRun          = "dry-run"
+	flagEvent           = "event"
+	flagFailed          = "failed"
+	flagFile            = "file"
+	flagFollow          = "follow"
+	flagFromJob         = "from-job"
+	flagGit             = "git"
+	flagGroupBy         = "group-by"
+	flagID              = "id"
+	flagInput           = "input"
+	flagInsecure        = "insecure"
+	flagJob             = "job"
+	flagLabel           = "label"
+	flagLanguage        = "language"
+	flagNonInteractive  = "non-interactive"
+	flagNonTerminal     = "non-terminal"
+	flagOutput          = "output"
+	flagPassword        = "password"
+	flagPayload         = "payload"
+	flagPayloadFile     = "payload-file"
+	flagPending         = "pending"
+	flagPrincipalID     = "principal-id"
+	flagPrincipalType   = "principal-type"
+	flagProject         = "project"
+	flagQualifier       = "qualifier"
+	flagReason          = "reason"
+	flagRegex           = "regex"
+	flagRef             = "ref"
+	flagResourceVersion = "resource-version"
+	flagResult          = "result"
+	flagRole            = "role"
+	flagRoot            = "root"
+	flagRunning         = "running"
+	flagSearch          = "search"
+	flagServer          = "server"
+	flagServiceAccount  = "service-account"
+	flagSet             = "set"
+	flagSince           = "since"
+	flagSource          = "source"
+	flagStarting        = "starting"
+	flagStream          = "stream"
+	flagSucceeded       = "succeeded"
+	flagTail            = "tail"
+	flagTargetID        = "target-id"
+	flagTargetType      = "target-type"
+	flagTerminal        = "terminal"
+	flagText            = "text"
+	flagTimedOut        = "timedout"
+	flagTimeline        = "timeline"
+	flagType            = "type"
+	flagUnknown         = "unknown"
+	flagUnredacted      = "unredacted"
+	flagUnset           = "unset"
+	flagUntil           = "until"
+	flagUser            = "user"
+	flagYes             = "yes"
+)
+
+// Flags used only when creating Webhooks
+const (
+	flagAuthType        = "auth-type"
+	flagLabelHeader     = "label-header"
+	flagLabelJSONPath   = "label-json-path"
+	flagSignatureHeader = "signature-header"
+	flagTypeHeader      = "type-header"
+	flagTypeJSONPath    = "type-json-path"
 )
 
 const (
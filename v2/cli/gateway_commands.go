@@ -0,0 +1,402 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+var gatewayCommand = &cli.Command{
+	Name:    "gateway",
+	Aliases: []string{"gateways", "gw"},
+	Usage:   "Manage gateways",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "Create a new gateway",
+			Flags: []cli.Flag{
+				// Using custom flagOutput here, to support plaintext output
+				// as opposed to table.
+				&cli.StringFlag{
+					Name:    flagOutput,
+					Aliases: []string{"o"},
+					Usage: "Return output in the specified format; supported formats: " +
+						"plaintext, yaml, json",
+					Value: flagOutputPlaintext,
+				},
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Create a gateway with the specified ID (required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    flagDescription,
+					Aliases: []string{"d"},
+					Usage: "Create a gateway with the specified description " +
+						"(required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    flagSource,
+					Aliases: []string{"s"},
+					Usage: "Create a gateway that owns the specified event source " +
+						"(required)",
+					Required: true,
+				},
+			},
+			Action: gatewayCreate,
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a single gateway",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Delete the specified gateway (required)",
+					Required: true,
+				},
+				nonInteractiveFlag,
+				&cli.BoolFlag{
+					Name:    flagYes,
+					Aliases: []string{"y"},
+					Usage:   "Non-interactively confirm deletion",
+				},
+			},
+			Action: gatewayDelete,
+		},
+		{
+			Name:  "get",
+			Usage: "Retrieve a gateway",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Retrieve the specified gateway (required)",
+					Required: true,
+				},
+				cliFlagOutput,
+			},
+			Action: gatewayGet,
+		},
+		{
+			Name:    "list",
+			Aliases: []string{"ls"},
+			Usage:   "List gateways",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.StringFlag{
+					Name: flagContinue,
+					Usage: "Advanced-- passes an opaque value obtained from a " +
+						"previous command back to the server to access the next page " +
+						"of results",
+				},
+				nonInteractiveFlag,
+			},
+			Action: gatewayList,
+		},
+		{
+			Name:  "update",
+			Usage: "Update a gateway's description or owned event source",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Update the specified gateway (required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    flagDescription,
+					Aliases: []string{"d"},
+					Usage:   "Change the gateway's description",
+				},
+				&cli.StringFlag{
+					Name:    flagSource,
+					Aliases: []string{"s"},
+					Usage:   "Change the event source owned by the gateway",
+				},
+			},
+			Action: gatewayUpdate,
+		},
+	},
+}
+
+func gatewayCreate(c *cli.Context) error {
+	output := c.String(flagOutput)
+	id := c.String(flagID)
+
+	// Validate output format
+	// Note: currently not using validateOutputFormat as this command supports
+	// plaintext as opposed to table output.
+	switch strings.ToLower(output) {
+	case flagOutputPlaintext:
+	case flagOutputYAML:
+	case flagOutputJSON:
+	default:
+		return errors.Errorf("unknown output format %q", output)
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Authn().Gateways().Create(
+		c.Context,
+		sdk.Gateway{
+			ObjectMeta: meta.ObjectMeta{
+				ID: id,
+			},
+			Description: c.String(flagDescription),
+			Source:      c.String(flagSource),
+		},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputPlaintext:
+		fmt.Printf("\nGateway %q created with token:\n", id)
+		fmt.Printf("\n\t%s\n", token.Value)
+		fmt.Println(
+			"\nStore this token someplace secure NOW. It cannot be retrieved " +
+				"later through any other means.",
+		)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(token)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from create gateway operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(token, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from create gateway operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+func gatewayList(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	opts := meta.ListOptions{
+		Continue: c.String(flagContinue),
+	}
+
+	for {
+		gateways, err := client.Authn().Gateways().List(c.Context, &opts)
+		if err != nil {
+			return err
+		}
+
+		if len(gateways.Items) == 0 {
+			fmt.Println("No gateways found.")
+			return nil
+		}
+
+		switch strings.ToLower(output) {
+		case flagOutputTable:
+			table := uitable.New()
+			table.AddRow("ID", "SOURCE", "DESCRIPTION", "AGE", "LAST SEEN")
+			for _, gateway := range gateways.Items {
+				table.AddRow(gatewayTableRow(gateway)...)
+			}
+			fmt.Println(table)
+
+		case flagOutputYAML:
+			yamlBytes, err := yaml.Marshal(gateways)
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from get gateways operation",
+				)
+			}
+			fmt.Println(string(yamlBytes))
+
+		case flagOutputJSON:
+			prettyJSON, err := json.MarshalIndent(gateways, "", "  ")
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from get gateways operation",
+				)
+			}
+			fmt.Println(string(prettyJSON))
+		}
+
+		if shouldContinue, err :=
+			shouldContinue(
+				c,
+				gateways.RemainingItemCount,
+				gateways.Continue,
+			); err != nil {
+			return err
+		} else if !shouldContinue {
+			break
+		}
+
+		opts.Continue = gateways.Continue
+	}
+
+	return nil
+}
+
+func gatewayGet(c *cli.Context) error {
+	id := c.String(flagID)
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	gateway, err := client.Authn().Gateways().Get(c.Context, id, nil)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputTable:
+		table := uitable.New()
+		table.AddRow("ID", "SOURCE", "DESCRIPTION", "AGE", "LAST SEEN")
+		table.AddRow(gatewayTableRow(gateway)...)
+		fmt.Println(table)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(gateway)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get gateway operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(gateway, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get gateway operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+func gatewayUpdate(c *cli.Context) error {
+	id := c.String(flagID)
+
+	if !c.IsSet(flagDescription) && !c.IsSet(flagSource) {
+		return errors.New(
+			"at least one of the --description or --source flags must be specified",
+		)
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	gateway, err := client.Authn().Gateways().Get(c.Context, id, nil)
+	if err != nil {
+		return err
+	}
+	if c.IsSet(flagDescription) {
+		gateway.Description = c.String(flagDescription)
+	}
+	if c.IsSet(flagSource) {
+		gateway.Source = c.String(flagSource)
+	}
+
+	if err = client.Authn().Gateways().Update(c.Context, gateway, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Gateway %q updated.\n", id)
+
+	return nil
+}
+
+func gatewayDelete(c *cli.Context) error {
+	id := c.String(flagID)
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Authn().Gateways().Delete(c.Context, id, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Gateway %q deleted.\n", id)
+
+	return nil
+}
+
+func gatewayTableRow(gateway sdk.Gateway) []interface{} {
+	var age string
+	if gateway.Created != nil {
+		age = duration.ShortHumanDuration(time.Since(*gateway.Created))
+	}
+	lastSeen := "never"
+	if gateway.LastSeen != nil {
+		lastSeen =
+			duration.ShortHumanDuration(time.Since(*gateway.LastSeen)) + " ago"
+	}
+	return []interface{}{
+		gateway.ID,
+		gateway.Source,
+		gateway.Description,
+		age,
+		lastSeen,
+	}
+}
@@ -2,17 +2,53 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ssh/terminal"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
+// logPrefixColors are the ANSI color codes used, in rotation, to distinguish
+// lines written by different containers when logs from all containers are
+// streamed.
+var logPrefixColors = []string{
+	"\x1b[36m", // Cyan
+	"\x1b[33m", // Yellow
+	"\x1b[32m", // Green
+	"\x1b[35m", // Magenta
+	"\x1b[34m", // Blue
+	"\x1b[31m", // Red
+}
+
+// logStderrColor is the ANSI color code used to distinguish lines written to
+// stderr.
+const logStderrColor = "\x1b[31m" // Red
+
+const ansiReset = "\x1b[0m"
+
 var logsCommand = &cli.Command{
 	Name:    "log",
 	Aliases: []string{"logs"},
 	Usage:   "View worker or job logs",
 	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    flagAll,
+			Aliases: []string{"a"},
+			Usage: "View interleaved logs from all of the worker's and jobs' " +
+				"containers, each line prefixed by its source; mutually exclusive " +
+				"with --job and --container",
+		},
 		&cli.StringFlag{
 			Name:    flagContainer,
 			Aliases: []string{"c"},
@@ -20,10 +56,11 @@ var logsCommand = &cli.Command{
 				"logs from the worker or job's \"primary\" container",
 		},
 		&cli.StringFlag{
-			Name:     flagID,
-			Aliases:  []string{"i", flagEvent, "e"},
-			Usage:    "View logs from the specified event",
-			Required: true,
+			Name:    flagID,
+			Aliases: []string{"i", flagEvent, "e"},
+			// This isn't marked as required because it would then also be required
+			// by the search subcommand. It's validated by the logs action instead.
+			Usage: "View logs from the specified event (required)",
 		},
 		&cli.BoolFlag{
 			Name:    flagFollow,
@@ -36,20 +73,167 @@ var logsCommand = &cli.Command{
 			Usage: "View logs from the specified job; if not set, displays " +
 				"worker logs",
 		},
+		&cli.StringFlag{
+			Name: flagSince,
+			Usage: "If set, will display only lines written at or after the " +
+				"specified time; accepts an RFC3339 timestamp or a duration (e.g. " +
+				"10m) interpreted as that long ago",
+		},
+		&cli.StringFlag{
+			Name: flagStream,
+			Usage: "If set, will display only lines written to the specified " +
+				"stream; valid values are \"stdout\" and \"stderr\"; unless " +
+				"Brigade is configured to split log streams, logs of running " +
+				"workers and jobs have no stream and cannot be filtered this way",
+		},
+		&cli.Int64Flag{
+			Name:    flagTail,
+			Aliases: []string{"t"},
+			Usage: "If set, will display only the specified number of most " +
+				"recent lines before any new lines are streamed",
+		},
+		&cli.BoolFlag{
+			Name: flagUnredacted,
+			Usage: "If set, will display the values of project secrets instead of " +
+				"redacting them; requires ADMIN permissions",
+		},
+		&cli.StringFlag{
+			Name: flagUntil,
+			Usage: "If set, will display only lines written before the " +
+				"specified time; accepts an RFC3339 timestamp or a duration (e.g. " +
+				"10m) interpreted as that long ago",
+		},
 	},
 	Action: logs,
+	Subcommands: []*cli.Command{
+		{
+			Name:  "download",
+			Usage: "Download all of an event's logs as an archive",
+			Description: "Downloads a gzipped tarball containing one file for " +
+				"each container of the event's worker and of each of its jobs " +
+				"that has started, including jobs inherited from another event.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    flagFile,
+					Aliases: []string{"f"},
+					Usage: "Write the archive to the specified file; if not set, " +
+						"writes to <event ID>-logs.tar.gz in the current directory",
+				},
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i", flagEvent, "e"},
+					Usage:    "Download logs of the specified event (required)",
+					Required: true,
+				},
+			},
+			Action: logsDownload,
+		},
+		{
+			Name:  "search",
+			Usage: "Search a project's logs",
+			Description: "Searches logs written by the workers and jobs of all " +
+				"of a project's events, newest first. Exactly one of --text or " +
+				"--regex must be specified. Logs that have been moved to object " +
+				"storage are not searched.",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.StringFlag{
+					Name:    flagContainer,
+					Aliases: []string{"c"},
+					Usage: "If set, will retrieve only lines written by containers " +
+						"with the specified name",
+				},
+				&cli.StringFlag{
+					Name: flagContinue,
+					Usage: "Advanced-- passes an opaque value obtained from a " +
+						"previous command back to the server to access the next page " +
+						"of results",
+				},
+				&cli.StringFlag{
+					Name:    flagJob,
+					Aliases: []string{"j"},
+					Usage: "If set, will retrieve only lines written by containers " +
+						"of jobs with the specified name",
+				},
+				nonInteractiveFlag,
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Search logs of the specified project",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    flagRegex,
+					Aliases: []string{"r"},
+					Usage: "Retrieve lines matching the specified regular " +
+						"expression",
+				},
+				&cli.StringFlag{
+					Name: flagSince,
+					Usage: "If set, will retrieve only lines written at or after the " +
+						"specified time; accepts an RFC3339 timestamp or a duration " +
+						"(e.g. 24h) interpreted as that long ago",
+				},
+				&cli.StringFlag{
+					Name:    flagText,
+					Aliases: []string{"t"},
+					Usage: "Retrieve lines containing the specified words, " +
+						"regardless of case",
+				},
+				&cli.StringFlag{
+					Name: flagUntil,
+					Usage: "If set, will retrieve only lines written before the " +
+						"specified time; accepts an RFC3339 timestamp or a duration " +
+						"(e.g. 24h) interpreted as that long ago",
+				},
+			},
+			Action: logsSearch,
+		},
+	},
 }
 
 func logs(c *cli.Context) error {
 	eventID := c.String(flagID)
+	if eventID == "" {
+		return fmt.Errorf("required flag %q not set", flagID)
+	}
 	follow := c.Bool(flagFollow)
 
 	selector := &sdk.LogsSelector{
 		Job:       c.String(flagJob),
 		Container: c.String(flagContainer),
+		All:       c.Bool(flagAll),
+	}
+	if selector.All && (selector.Job != "" || selector.Container != "") {
+		return fmt.Errorf(
+			"--%s is mutually exclusive with --%s and --%s",
+			flagAll,
+			flagJob,
+			flagContainer,
+		)
 	}
 	opts := &sdk.LogStreamOptions{
-		Follow: follow,
+		Follow:     follow,
+		TailLines:  c.Int64(flagTail),
+		Stream:     sdk.LogStream(c.String(flagStream)),
+		Unredacted: c.Bool(flagUnredacted),
+	}
+	if opts.Stream != "" &&
+		opts.Stream != sdk.LogStreamStdout &&
+		opts.Stream != sdk.LogStreamStderr {
+		return fmt.Errorf(
+			"--%s must be %q or %q",
+			flagStream,
+			sdk.LogStreamStdout,
+			sdk.LogStreamStderr,
+		)
+	}
+	var err error
+	if opts.Since, err = timeFromFlag(c, flagSince); err != nil {
+		return err
+	}
+	if opts.Until, err = timeFromFlag(c, flagUntil); err != nil {
+		return err
 	}
 
 	client, err := getClient(false)
@@ -66,6 +250,152 @@ func logs(c *cli.Context) error {
 	)
 }
 
+func logsDownload(c *cli.Context) error {
+	eventID := c.String(flagID)
+	filename := c.String(flagFile)
+	if filename == "" {
+		filename = fmt.Sprintf("%s-logs.tar.gz", eventID)
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	archive, err := client.Core().Events().Logs().Archive(c.Context, eventID)
+	if err != nil {
+		return err
+	}
+	defer archive.Close() // nolint: errcheck
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", filename)
+	}
+	if _, err = io.Copy(file, archive); err != nil {
+		file.Close()        // nolint: errcheck
+		os.Remove(filename) // nolint: errcheck
+		return errors.Wrapf(err, "error downloading logs of event %q", eventID)
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrapf(err, "error writing file %q", filename)
+	}
+
+	fmt.Printf("Logs of event %q downloaded to %s.\n", eventID, filename)
+	return nil
+}
+
+func logsSearch(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	projectID := c.String(flagProject)
+	selector := sdk.LogsSearchSelector{
+		Text:      c.String(flagText),
+		Regex:     c.String(flagRegex),
+		Job:       c.String(flagJob),
+		Container: c.String(flagContainer),
+	}
+	if (selector.Text == "") == (selector.Regex == "") {
+		return fmt.Errorf(
+			"exactly one of --%s or --%s must be specified",
+			flagText,
+			flagRegex,
+		)
+	}
+	var err error
+	if selector.WrittenSince, err = timeFromFlag(c, flagSince); err != nil {
+		return err
+	}
+	if selector.WrittenUntil, err = timeFromFlag(c, flagUntil); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	opts := meta.ListOptions{
+		Continue: c.String(flagContinue),
+	}
+
+	for {
+		results, err := client.Core().Events().Logs().Search(
+			c.Context,
+			projectID,
+			&selector,
+			&opts,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(results.Items) == 0 {
+			fmt.Println("No matching log lines found.")
+			return nil
+		}
+
+		switch strings.ToLower(output) {
+		case flagOutputTable:
+			table := uitable.New()
+			table.AddRow("AGE", "EVENT", "JOB", "CONTAINER", "MESSAGE")
+			for _, result := range results.Items {
+				var age string
+				if result.Time != nil {
+					age = duration.ShortHumanDuration(time.Since(*result.Time))
+				}
+				table.AddRow(
+					age,
+					result.EventID,
+					result.Job,
+					result.Container,
+					result.Message,
+				)
+			}
+			fmt.Println(table)
+
+		case flagOutputYAML:
+			yamlBytes, err := yaml.Marshal(results)
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from search logs operation",
+				)
+			}
+			fmt.Println(string(yamlBytes))
+
+		case flagOutputJSON:
+			prettyJSON, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from search logs operation",
+				)
+			}
+			fmt.Println(string(prettyJSON))
+		}
+
+		if shouldContinue, err :=
+			shouldContinue(
+				c,
+				results.RemainingItemCount,
+				results.Continue,
+			); err != nil {
+			return err
+		} else if !shouldContinue {
+			break
+		}
+
+		opts.Continue = results.Continue
+	}
+
+	return nil
+}
+
 func streamLogs(
 	ctx context.Context,
 	logsClient sdk.LogsClient,
@@ -77,11 +407,16 @@ func streamLogs(
 	if err != nil {
 		return err
 	}
+	printer := &logEntryPrinter{
+		prefixed: selector != nil && selector.All,
+		colorize: terminal.IsTerminal(int(os.Stdout.Fd())),
+		colors:   map[string]string{},
+	}
 	for {
 		select {
 		case logEntry, ok := <-logEntryCh:
 			if ok {
-				fmt.Println(logEntry.Message)
+				printer.print(logEntry)
 			} else {
 				// logEntryCh was closed, but want to keep looping through this select
 				// in case there are pending errors on the errCh still. nil channels are
@@ -105,3 +440,37 @@ func streamLogs(
 		}
 	}
 }
+
+// logEntryPrinter prints log entries. If prefixed is true, each is prefixed
+// with the job and container that wrote it. If colorize is true, lines written
+// to stderr are colored and each distinct prefix is assigned its own color.
+type logEntryPrinter struct {
+	prefixed bool
+	colorize bool
+	colors   map[string]string
+}
+
+func (l *logEntryPrinter) print(logEntry sdk.LogEntry) {
+	message := logEntry.Message
+	if l.colorize && logEntry.Stream == sdk.LogStreamStderr {
+		message = fmt.Sprintf("%s%s%s", logStderrColor, message, ansiReset)
+	}
+	if !l.prefixed {
+		fmt.Println(message)
+		return
+	}
+	prefix := logEntry.Container
+	if logEntry.Job != "" {
+		prefix = fmt.Sprintf("%s/%s", logEntry.Job, logEntry.Container)
+	}
+	if !l.colorize {
+		fmt.Printf("[%s] %s\n", prefix, message)
+		return
+	}
+	color, ok := l.colors[prefix]
+	if !ok {
+		color = logPrefixColors[len(l.colors)%len(logPrefixColors)]
+		l.colors[prefix] = color
+	}
+	fmt.Printf("%s[%s]%s %s\n", color, prefix, ansiReset, message)
+}
@@ -14,7 +14,9 @@ func main() {
 	app.Usage = "Event Driven Scripting for Kubernetes"
 	app.HideVersion = true
 	app.Commands = []*cli.Command{
+		auditCommand,
 		eventCommand,
+		gatewayCommand,
 		initCommand,
 		loginCommand,
 		logoutCommand,
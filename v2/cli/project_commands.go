@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 	"time"
 
@@ -87,6 +88,46 @@ var projectCommand = &cli.Command{
 		},
 		projectRolesCommands,
 		secretsCommand,
+		{
+			Name:  "stats",
+			Usage: "Summarize event statistics",
+			Description: "Aggregates counts by worker phase, success rate, queue " +
+				"wait and run duration percentiles, and per-job failure rates for " +
+				"events created within a window of time",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.StringFlag{
+					Name: flagBucketSize,
+					Usage: "The size of each time bucket when grouping by time " +
+						"(e.g. 6h); defaults to 1h",
+				},
+				&cli.StringFlag{
+					Name: flagGroupBy,
+					Usage: "How to group statistics; supported groupings: project, " +
+						"source-type, time",
+					Value: "project",
+				},
+				&cli.StringFlag{
+					Name:    flagID,
+					Aliases: []string{"i", flagProject, "p"},
+					Usage: "If set, will summarize events only for the specified " +
+						"project",
+				},
+				&cli.StringFlag{
+					Name: flagSince,
+					Usage: "Summarize events created after the specified time; accepts " +
+						"an RFC3339 timestamp or a duration (e.g. 24h) interpreted as " +
+						"that long ago; defaults to 7 days before --until",
+				},
+				&cli.StringFlag{
+					Name: flagUntil,
+					Usage: "Summarize events created before the specified time; " +
+						"accepts an RFC3339 timestamp or a duration (e.g. 24h) " +
+						"interpreted as that long ago; defaults to now",
+				},
+			},
+			Action: projectStats,
+		},
 		{
 			Name:  "update",
 			Usage: "Update a project",
@@ -105,9 +146,17 @@ var projectCommand = &cli.Command{
 					Usage: "If set and project does not exist, will create the " +
 						"project",
 				},
+				&cli.Int64Flag{
+					Name: flagResourceVersion,
+					Usage: "If set, the project will only be updated if its current " +
+						"version matches; overrides any metadata.resourceVersion " +
+						"specified by the file. If neither is specified, the " +
+						"update is applied unconditionally",
+				},
 			},
 			Action: projectUpdate,
 		},
+		webhooksCommand,
 	},
 }
 
@@ -291,6 +340,186 @@ func projectGet(c *cli.Context) error {
 	return nil
 }
 
+func projectStats(c *cli.Context) error {
+	output := c.String(flagOutput)
+
+	groupBy := sdk.EventStatsGrouping(
+		strings.ReplaceAll(strings.ToUpper(c.String(flagGroupBy)), "-", "_"),
+	)
+	switch groupBy {
+	case sdk.EventStatsGroupingProject,
+		sdk.EventStatsGroupingSourceType,
+		sdk.EventStatsGroupingTime:
+	default:
+		return errors.Errorf(
+			"invalid value %q for --%s flag; supported groupings: project, "+
+				"source-type, time",
+			c.String(flagGroupBy),
+			flagGroupBy,
+		)
+	}
+
+	opts := sdk.EventStatsOptions{
+		ProjectID: c.String(flagID),
+		GroupBy:   groupBy,
+	}
+	var err error
+	if opts.Since, err = timeFromFlag(c, flagSince); err != nil {
+		return err
+	}
+	if opts.Until, err = timeFromFlag(c, flagUntil); err != nil {
+		return err
+	}
+	if bucketSizeStr := c.String(flagBucketSize); bucketSizeStr != "" {
+		if opts.BucketSize, err = time.ParseDuration(bucketSizeStr); err != nil {
+			return errors.Errorf(
+				"invalid value %q for --%s flag",
+				bucketSizeStr,
+				flagBucketSize,
+			)
+		}
+	}
+
+	if err = validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	stats, err := client.Core().Events().Stats(c.Context, &opts)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputTable:
+		if len(stats.Groups) == 0 {
+			fmt.Println("No events found.")
+			return nil
+		}
+		var keyHeaders []interface{}
+		switch stats.GroupBy {
+		case sdk.EventStatsGroupingSourceType:
+			keyHeaders = []interface{}{"SOURCE", "TYPE"}
+		case sdk.EventStatsGroupingTime:
+			keyHeaders = []interface{}{"BUCKET"}
+		default:
+			keyHeaders = []interface{}{"PROJECT"}
+		}
+		groupKey := func(group sdk.EventStatsGroup) []interface{} {
+			switch stats.GroupBy {
+			case sdk.EventStatsGroupingSourceType:
+				return []interface{}{group.Source, group.Type}
+			case sdk.EventStatsGroupingTime:
+				var bucketStart string
+				if group.BucketStart != nil {
+					bucketStart = group.BucketStart.Format(time.RFC3339)
+				}
+				return []interface{}{bucketStart}
+			default:
+				return []interface{}{group.ProjectID}
+			}
+		}
+
+		table := uitable.New()
+		table.AddRow(
+			append(
+				keyHeaders,
+				"TOTAL",
+				"SUCCEEDED",
+				"FAILED",
+				"SUCCESS RATE",
+				"QUEUE P50",
+				"QUEUE P95",
+				"RUN P50",
+				"RUN P95",
+			)...,
+		)
+		var hasJobFailureRates bool
+		for _, group := range stats.Groups {
+			var failed int64
+			for phase, count := range group.PhaseCounts {
+				if phase.IsTerminal() && phase != sdk.WorkerPhaseSucceeded {
+					failed += count
+				}
+			}
+			table.AddRow(
+				append(
+					groupKey(group),
+					group.Total,
+					group.PhaseCounts[sdk.WorkerPhaseSucceeded],
+					failed,
+					fmt.Sprintf("%.1f%%", group.SuccessRate*100),
+					formatSeconds(group.QueueWait.P50Seconds),
+					formatSeconds(group.QueueWait.P95Seconds),
+					formatSeconds(group.RunDuration.P50Seconds),
+					formatSeconds(group.RunDuration.P95Seconds),
+				)...,
+			)
+			if len(group.JobFailureRates) > 0 {
+				hasJobFailureRates = true
+			}
+		}
+		fmt.Println(table)
+
+		if hasJobFailureRates {
+			table = uitable.New()
+			table.AddRow(
+				append(keyHeaders, "JOB", "FAILED", "TOTAL", "FAILURE RATE")...,
+			)
+			for _, group := range stats.Groups {
+				for _, rate := range group.JobFailureRates {
+					table.AddRow(
+						append(
+							groupKey(group),
+							rate.JobName,
+							rate.Failed,
+							rate.Total,
+							fmt.Sprintf("%.1f%%", rate.FailureRate*100),
+						)...,
+					)
+				}
+			}
+			fmt.Printf("\n%s\n", table)
+		}
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(stats)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get project stats operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get project stats operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+// formatSeconds formats the provided number of seconds as a human-readable
+// duration.
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
 func projectUpdate(c *cli.Context) error {
 	filename := c.String(flagFile)
 	create := c.Bool(flagCreate)
@@ -330,8 +559,26 @@ func projectUpdate(c *cli.Context) error {
 		return err
 	}
 
+	// The project is only updated if it hasn't changed since the version
+	// specified by the flag or the file. If neither specifies one, no version is
+	// sent and the update is applied unconditionally.
+	resourceVersion := c.Int64(flagResourceVersion)
+	if resourceVersion == 0 {
+		resourceVersion = project.ResourceVersion
+	}
+	if resourceVersion == 0 {
+		fmt.Fprintf(
+			os.Stderr,
+			"Warning: no resource version was specified for project %q. Any "+
+				"changes made to it by someone else since you last retrieved it "+
+				"may be overwritten.\n",
+			project.ID,
+		)
+	}
+
 	opts := &sdk.ProjectUpdateOptions{
 		CreateIfNotFound: create,
+		ResourceVersion:  resourceVersion,
 	}
 
 	if _, err = client.Core().Projects().UpdateFromBytes(
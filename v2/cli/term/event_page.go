@@ -217,6 +217,21 @@ func (e *eventPage) fillWorkerInfo(event sdk.Event) {
 		getTextColorFromWorkerPhase(event.Worker.Status.Phase),
 		event.Worker.Status.Phase,
 	)
+	if principal := event.Worker.Status.Principal; principal != nil {
+		infoText = fmt.Sprintf(
+			"%s\n[grey]By: [white]%s/%s",
+			infoText,
+			principal.Type,
+			tview.Escape(principal.ID),
+		)
+	}
+	if event.Worker.Status.Reason != "" {
+		infoText = fmt.Sprintf(
+			"%s\n[grey]Reason: [white]%s",
+			infoText,
+			tview.Escape(event.Worker.Status.Reason),
+		)
+	}
 	e.workerInfo.SetText(infoText)
 }
 
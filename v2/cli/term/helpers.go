@@ -123,3 +123,25 @@ func formatDateTimeToString(time *time.Time) string {
 	}
 	return time.UTC().Format("2006-01-02 15:04:05")
 }
+
+// formatSeconds formats the provided number of seconds as a human-readable
+// duration.
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// countNonTerminal returns the sum of the provided counts for all non-terminal
+// WorkerPhases.
+func countNonTerminal(phaseCounts map[sdk.WorkerPhase]int64) int64 {
+	var count int64
+	for phase, phaseCount := range phaseCounts {
+		if !phase.IsTerminal() {
+			count += phaseCount
+		}
+	}
+	return count
+}
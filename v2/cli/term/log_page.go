@@ -14,11 +14,20 @@ import (
 
 const logPageName = "log"
 
+// logStreams are the log streams, in order, that can be cycled through to
+// filter the logs displayed. An empty stream displays lines from all streams.
+var logStreams = []sdk.LogStream{"", sdk.LogStreamStderr, sdk.LogStreamStdout}
+
 type logPage struct {
 	*page
 	logText  *tview.TextView
 	logBuf   *circbuf.Buffer
 	maxBytes int64
+	// streamIndex is the index of the stream within logStreams whose lines are
+	// displayed.
+	streamIndex int
+	// cancelStreamFn stops streaming logs to the buffer.
+	cancelStreamFn context.CancelFunc
 }
 
 func newLogPage(
@@ -32,7 +41,7 @@ func newLogPage(
 	}
 
 	l.maxBytes = 65535
-	l.logText.SetBorder(true).SetTitle("Logs (<-/Del) Quit")
+	l.logText.SetBorder(true)
 
 	// Returns a new primitive which puts the provided primitive in the center and
 	// sets its size to the given width and height.
@@ -95,6 +104,12 @@ func (l *logPage) load(ctx context.Context, eventID string, jobID string) {
 			} else {
 				l.router.loadJobPage(eventID, jobID)
 			}
+		case tcell.KeyRune: // Regular key handling
+			switch evt.Rune() {
+			case 's', 'S': // Cycle through streams
+				l.streamIndex = (l.streamIndex + 1) % len(logStreams)
+				l.startStreaming(ctx, eventID, jobID)
+			}
 		}
 		return evt
 	})
@@ -106,16 +121,40 @@ func (l *logPage) load(ctx context.Context, eventID string, jobID string) {
 		return
 	}
 
-	go l.streamLogsToBuffer(ctx, eventID, jobID)
+	l.streamIndex = 0
+	l.startStreaming(ctx, eventID, jobID)
 	go l.writeLogs(ctx)
 }
 
+// startStreaming stops streaming any previously selected stream's logs to the
+// buffer and starts streaming the currently selected stream's logs to it.
+func (l *logPage) startStreaming(
+	ctx context.Context,
+	eventID string,
+	jobID string,
+) {
+	if l.cancelStreamFn != nil {
+		l.cancelStreamFn()
+	}
+	stream := logStreams[l.streamIndex]
+	streamName := string(stream)
+	if streamName == "" {
+		streamName = "all"
+	}
+	l.logText.SetTitle(
+		fmt.Sprintf("Logs: %s (<-/Del) Quit (S) Stream", streamName),
+	)
+	var streamCtx context.Context
+	streamCtx, l.cancelStreamFn = context.WithCancel(ctx)
+	go l.streamLogsToBuffer(streamCtx, eventID, jobID, stream)
+}
+
 // refresh refreshes Event info and associated Jobs and repaints the page.
 func (l *logPage) refresh(ctx context.Context, eventID string, jobID string) {
 }
 
 // nolint: lll
-func (l *logPage) streamLogsToBuffer(ctx context.Context, eventID string, jobID string) {
+func (l *logPage) streamLogsToBuffer(ctx context.Context, eventID string, jobID string, stream sdk.LogStream) {
 	l.logBuf.Reset()
 	var logsSelector sdk.LogsSelector
 	if jobID == "" {
@@ -127,7 +166,10 @@ func (l *logPage) streamLogsToBuffer(ctx context.Context, eventID string, jobID
 		ctx,
 		eventID,
 		&logsSelector,
-		&sdk.LogStreamOptions{Follow: true},
+		&sdk.LogStreamOptions{
+			Follow: true,
+			Stream: stream,
+		},
 	)
 	if err != nil {
 		l.logText.SetText(err.Error())
@@ -137,7 +179,12 @@ func (l *logPage) streamLogsToBuffer(ctx context.Context, eventID string, jobID
 		select {
 		case logEntry, ok := <-logEntryCh:
 			if ok {
-				if _, err = l.logBuf.Write([]byte(logEntry.Message)); err != nil {
+				// Text is escaped so that it can't be mistaken for color tags
+				message := tview.Escape(logEntry.Message)
+				if logEntry.Stream == sdk.LogStreamStderr {
+					message = fmt.Sprintf("[red]%s[-]", message)
+				}
+				if _, err = l.logBuf.Write([]byte(message)); err != nil {
 					l.logText.SetText(err.Error())
 					break
 				}
@@ -14,19 +14,20 @@ import (
 
 const projectPageName = "project"
 
-// projectPage is a custom UI component that displays Project info and a list of
-// associated Events.
+// projectPage is a custom UI component that displays Project info, statistics
+// for recent Events, and a list of associated Events.
 type projectPage struct {
 	*page
 	currentProjectID     string
 	projectInfo          *tview.TextView
+	projectStats         *tview.TextView
 	eventsContinueValues []string // Stack of "continue" values to aid paging
 	eventsTable          *tview.Table
 	usage                *tview.TextView
 }
 
-// newProjectPage returns a custom UI component that displays Project info and a
-// list of associated Events.
+// newProjectPage returns a custom UI component that displays Project info,
+// statistics for recent Events, and a list of associated Events.
 func newProjectPage(
 	apiClient sdk.APIClient,
 	app *tview.Application,
@@ -35,17 +36,33 @@ func newProjectPage(
 	p := &projectPage{
 		page:                 newPage(apiClient, app, router),
 		projectInfo:          tview.NewTextView().SetDynamicColors(true),
+		projectStats:         tview.NewTextView().SetDynamicColors(true),
 		eventsContinueValues: []string{""}, // "" == continue value for first page
 		eventsTable:          tview.NewTable().SetSelectable(true, false),
 		usage:                tview.NewTextView().SetDynamicColors(true),
 	}
 	p.projectInfo.SetBorder(true).SetBorderColor(tcell.ColorWhite)
+	p.projectStats.SetBorder(true).
+		SetBorderColor(tcell.ColorWhite).
+		SetTitle(" Last 7 Days ")
 	p.eventsTable.SetBorder(true).SetTitle(" Events ")
 	// Create the layout
 	p.page.Flex = tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(
-			p.projectInfo, // Project details
+			tview.NewFlex().
+				AddItem(
+					p.projectInfo, // Project details
+					0,
+					1,     // Relative width-- 1 unit
+					false, // Don't bring into focus
+				).
+				AddItem(
+					p.projectStats, // Project statistics
+					0,
+					1,     // Relative width-- 1 unit
+					false, // Don't bring into focus
+				),
 			0,
 			2,     // Relative height-- 2 units
 			false, // Don't bring into focus
@@ -94,7 +111,14 @@ func (p *projectPage) refresh(ctx context.Context, projectID string) {
 	if err != nil {
 		// TODO: Handle this
 	}
+	stats, err := p.apiClient.Core().Events().Stats(
+		ctx,
+		&sdk.EventStatsOptions{
+			ProjectID: projectID,
+		},
+	)
 	p.fillProjectInfo(project)
+	p.fillProjectStats(stats, err)
 	p.fillEventsTable(events)
 	p.fillUsage(events)
 	// Set key handlers
@@ -179,6 +203,59 @@ func (p *projectPage) fillProjectInfo(project sdk.Project) {
 	p.projectInfo.SetText(infoText)
 }
 
+func (p *projectPage) fillProjectStats(stats sdk.EventStats, err error) {
+	p.projectStats.Clear()
+	if err != nil {
+		p.projectStats.SetText("[grey]Statistics are unavailable")
+		return
+	}
+	if len(stats.Groups) == 0 {
+		p.projectStats.SetText("[grey]No events")
+		return
+	}
+	group := stats.Groups[0]
+	statsText := fmt.Sprintf(
+		"[grey]Events: [white]%d    [grey]Succeeded: %s%d    [grey]Failed: %s%d",
+		group.Total,
+		textGreen,
+		group.PhaseCounts[sdk.WorkerPhaseSucceeded],
+		textRed,
+		group.Total-group.PhaseCounts[sdk.WorkerPhaseSucceeded]-
+			countNonTerminal(group.PhaseCounts),
+	)
+	statsText = fmt.Sprintf(
+		"%s\n[grey]Success Rate: [white]%.1f%%",
+		statsText,
+		group.SuccessRate*100,
+	)
+	statsText = fmt.Sprintf(
+		"%s\n[grey]Queue Wait: [white]p50 %s  p95 %s",
+		statsText,
+		formatSeconds(group.QueueWait.P50Seconds),
+		formatSeconds(group.QueueWait.P95Seconds),
+	)
+	statsText = fmt.Sprintf(
+		"%s\n[grey]Run Duration: [white]p50 %s  p95 %s",
+		statsText,
+		formatSeconds(group.RunDuration.P50Seconds),
+		formatSeconds(group.RunDuration.P95Seconds),
+	)
+	if len(group.JobFailureRates) > 0 {
+		statsText = fmt.Sprintf("%s\n[grey]Job Failure Rates:", statsText)
+		for _, rate := range group.JobFailureRates {
+			statsText = fmt.Sprintf(
+				"%s\n  [grey]%s: [white]%.1f%% (%d/%d)",
+				statsText,
+				rate.JobName,
+				rate.FailureRate*100,
+				rate.Failed,
+				rate.Total,
+			)
+		}
+	}
+	p.projectStats.SetText(statsText)
+}
+
 func (p *projectPage) fillUsage(events sdk.EventList) {
 	usageText := "[yellow](F5 R) [white]Reload    [yellow](<-/Del) [white]Back    [yellow](ESC) [white]Home" // nolint: lll
 	if len(p.eventsContinueValues) > 1 {
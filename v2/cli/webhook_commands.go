@@ -0,0 +1,522 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/brigadecore/brigade/sdk/v3/meta"
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var webhooksCommand = &cli.Command{
+	Name:    "webhook",
+	Aliases: []string{"webhooks"},
+	Usage:   "Manage project webhooks",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "Create a new webhook for a project",
+			Flags: []cli.Flag{
+				// Using custom flagOutput here, to support plaintext output
+				// as opposed to table.
+				&cli.StringFlag{
+					Name:    flagOutput,
+					Aliases: []string{"o"},
+					Usage: "Return output in the specified format; supported formats: " +
+						"plaintext, yaml, json",
+					Value: flagOutputPlaintext,
+				},
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Create a webhook for the specified project (required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    flagID,
+					Aliases: []string{"i"},
+					Usage: "Create a webhook with the specified ID; required unless " +
+						"specified in the file",
+				},
+				&cli.StringFlag{
+					Name:    flagFile,
+					Aliases: []string{"f"},
+					Usage: "A YAML or JSON file that describes the webhook. Values " +
+						"specified using other flags take precedence over any specified " +
+						"in the file",
+					TakesFile: true,
+				},
+				&cli.StringFlag{
+					Name:    flagDescription,
+					Aliases: []string{"d"},
+					Usage:   "Create a webhook with the specified description",
+				},
+				&cli.StringFlag{
+					Name: flagAuthType,
+					Usage: "Specify how callers of the webhook are authenticated; " +
+						"supported types: token, hmac (default: token)",
+				},
+				&cli.StringFlag{
+					Name: flagSignatureHeader,
+					Usage: "Specify the header in which callers present an " +
+						"HMAC-SHA256 signature of the request body; applicable only " +
+						"when --auth-type is hmac",
+				},
+				&cli.StringFlag{
+					Name:    flagSource,
+					Aliases: []string{"s"},
+					Usage: "Specify the source of all events created by the webhook " +
+						"(default: brigade.sh/webhook)",
+				},
+				&cli.StringFlag{
+					Name:    flagType,
+					Aliases: []string{"t"},
+					Usage:   "Specify a static type for events created by the webhook",
+				},
+				&cli.StringFlag{
+					Name: flagTypeHeader,
+					Usage: "Specify a request header from which the type of each " +
+						"event should be extracted",
+				},
+				&cli.StringFlag{
+					Name: flagTypeJSONPath,
+					Usage: "Specify a dot-delimited path to a field in the JSON " +
+						"request body from which the type of each event should be " +
+						"extracted",
+				},
+				&cli.StringSliceFlag{
+					Name:    flagLabel,
+					Aliases: []string{"l"},
+					Usage: "Apply a static label to events created by the webhook " +
+						"using the specified key=value pair",
+				},
+				&cli.StringSliceFlag{
+					Name: flagLabelHeader,
+					Usage: "Extract a label from a request header using the specified " +
+						"key=header pair",
+				},
+				&cli.StringSliceFlag{
+					Name: flagLabelJSONPath,
+					Usage: "Extract a label from the JSON request body using the " +
+						"specified key=path pair",
+				},
+			},
+			Action: webhookCreate,
+		},
+		{
+			Name:  "delete",
+			Usage: "Delete a single webhook",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Delete a webhook of the specified project (required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Delete the specified webhook (required)",
+					Required: true,
+				},
+				nonInteractiveFlag,
+				&cli.BoolFlag{
+					Name:    flagYes,
+					Aliases: []string{"y"},
+					Usage:   "Non-interactively confirm deletion",
+				},
+			},
+			Action: webhookDelete,
+		},
+		{
+			Name:  "get",
+			Usage: "Retrieve a webhook",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Retrieve a webhook of the specified project (required)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     flagID,
+					Aliases:  []string{"i"},
+					Usage:    "Retrieve the specified webhook (required)",
+					Required: true,
+				},
+				cliFlagOutput,
+			},
+			Action: webhookGet,
+		},
+		{
+			Name:    "list",
+			Aliases: []string{"ls"},
+			Usage:   "List webhooks for a project",
+			Flags: []cli.Flag{
+				cliFlagOutput,
+				&cli.StringFlag{
+					Name: flagContinue,
+					Usage: "Advanced-- passes an opaque value obtained from a " +
+						"previous command back to the server to access the next page " +
+						"of results",
+				},
+				&cli.StringFlag{
+					Name:     flagProject,
+					Aliases:  []string{"p"},
+					Usage:    "Retrieve webhooks for the specified project (required)",
+					Required: true,
+				},
+				nonInteractiveFlag,
+			},
+			Action: webhookList,
+		},
+	},
+}
+
+func webhookCreate(c *cli.Context) error {
+	output := c.String(flagOutput)
+	projectID := c.String(flagProject)
+	filename := c.String(flagFile)
+
+	// Validate output format
+	// Note: currently not using validateOutputFormat as this command supports
+	// plaintext as opposed to table output.
+	switch strings.ToLower(output) {
+	case flagOutputPlaintext:
+	case flagOutputYAML:
+	case flagOutputJSON:
+	default:
+		return errors.Errorf("unknown output format %q", output)
+	}
+
+	webhook := sdk.Webhook{}
+	if filename != "" {
+		webhookBytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return errors.Wrapf(err, "error reading webhook file %s", filename)
+		}
+		if strings.HasSuffix(filename, ".yaml") ||
+			strings.HasSuffix(filename, ".yml") {
+			if webhookBytes, err = yaml.YAMLToJSON(webhookBytes); err != nil {
+				return errors.Wrapf(err, "error converting file %s to JSON", filename)
+			}
+		}
+		if err = json.Unmarshal(webhookBytes, &webhook); err != nil {
+			return errors.Wrapf(err, "error unmarshaling webhook file %s", filename)
+		}
+	}
+
+	if id := c.String(flagID); id != "" {
+		webhook.ID = id
+	}
+	if webhook.ID == "" {
+		return errors.New(
+			"a webhook ID must be specified using the --id flag or in the file " +
+				"specified by the --file flag",
+		)
+	}
+	if description := c.String(flagDescription); description != "" {
+		webhook.Description = description
+	}
+	if authType := c.String(flagAuthType); authType != "" {
+		webhook.AuthType = sdk.WebhookAuthType(strings.ToUpper(authType))
+	}
+	if signatureHeader := c.String(flagSignatureHeader); signatureHeader != "" {
+		webhook.SignatureHeader = signatureHeader
+	}
+	if source := c.String(flagSource); source != "" {
+		webhook.Source = source
+	}
+
+	typeSources := 0
+	for _, flag := range []string{flagType, flagTypeHeader, flagTypeJSONPath} {
+		if c.String(flag) != "" {
+			typeSources++
+		}
+	}
+	if typeSources > 1 {
+		return errors.Errorf(
+			"at most one of --%s, --%s, or --%s may be specified",
+			flagType,
+			flagTypeHeader,
+			flagTypeJSONPath,
+		)
+	}
+	if typeSources == 1 {
+		webhook.Type = &sdk.WebhookValueSource{
+			Value:    c.String(flagType),
+			Header:   c.String(flagTypeHeader),
+			JSONPath: c.String(flagTypeJSONPath),
+		}
+	}
+
+	for flag, valueSourceFn := range map[string]func(string) sdk.WebhookValueSource{
+		flagLabel: func(val string) sdk.WebhookValueSource {
+			return sdk.WebhookValueSource{Value: val}
+		},
+		flagLabelHeader: func(val string) sdk.WebhookValueSource {
+			return sdk.WebhookValueSource{Header: val}
+		},
+		flagLabelJSONPath: func(val string) sdk.WebhookValueSource {
+			return sdk.WebhookValueSource{JSONPath: val}
+		},
+	} {
+		for _, kvPairStr := range c.StringSlice(flag) {
+			kvTokens := strings.SplitN(kvPairStr, "=", 2)
+			if len(kvTokens) != 2 || kvTokens[0] == "" || kvTokens[1] == "" {
+				return errors.Errorf(
+					"--%s argument %q is formatted incorrectly",
+					flag,
+					kvPairStr,
+				)
+			}
+			if webhook.Labels == nil {
+				webhook.Labels = map[string]sdk.WebhookValueSource{}
+			}
+			webhook.Labels[kvTokens[0]] = valueSourceFn(kvTokens[1])
+		}
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Core().Projects().Webhooks().Create(
+		c.Context,
+		projectID,
+		webhook,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputPlaintext:
+		if webhook.AuthType == sdk.WebhookAuthTypeHMAC {
+			fmt.Printf(
+				"\nWebhook %q created for project %q with HMAC secret:\n",
+				webhook.ID,
+				projectID,
+			)
+		} else {
+			fmt.Printf(
+				"\nWebhook %q created for project %q with token:\n",
+				webhook.ID,
+				projectID,
+			)
+		}
+		fmt.Printf("\n\t%s\n", token.Value)
+		fmt.Println(
+			"\nStore this value someplace secure NOW. It cannot be retrieved " +
+				"later through any other means.",
+		)
+		fmt.Printf(
+			"\nSend requests to: POST /v2/projects/%s/webhooks/%s/events\n",
+			projectID,
+			webhook.ID,
+		)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(token)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from create webhook operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(token, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from create webhook operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+func webhookList(c *cli.Context) error {
+	output := c.String(flagOutput)
+	projectID := c.String(flagProject)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	opts := meta.ListOptions{
+		Continue: c.String(flagContinue),
+	}
+
+	for {
+		webhooks, err :=
+			client.Core().Projects().Webhooks().List(c.Context, projectID, &opts)
+		if err != nil {
+			return err
+		}
+
+		if len(webhooks.Items) == 0 {
+			fmt.Println("No webhooks found.")
+			return nil
+		}
+
+		switch strings.ToLower(output) {
+		case flagOutputTable:
+			table := uitable.New()
+			table.AddRow("ID", "AUTH TYPE", "SOURCE", "AGE")
+			for _, webhook := range webhooks.Items {
+				table.AddRow(webhookTableRow(webhook)...)
+			}
+			fmt.Println(table)
+
+		case flagOutputYAML:
+			yamlBytes, err := yaml.Marshal(webhooks)
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from get webhooks operation",
+				)
+			}
+			fmt.Println(string(yamlBytes))
+
+		case flagOutputJSON:
+			prettyJSON, err := json.MarshalIndent(webhooks, "", "  ")
+			if err != nil {
+				return errors.Wrap(
+					err,
+					"error formatting output from get webhooks operation",
+				)
+			}
+			fmt.Println(string(prettyJSON))
+		}
+
+		if shouldContinue, err :=
+			shouldContinue(
+				c,
+				webhooks.RemainingItemCount,
+				webhooks.Continue,
+			); err != nil {
+			return err
+		} else if !shouldContinue {
+			break
+		}
+
+		opts.Continue = webhooks.Continue
+	}
+
+	return nil
+}
+
+func webhookGet(c *cli.Context) error {
+	projectID := c.String(flagProject)
+	id := c.String(flagID)
+	output := c.String(flagOutput)
+
+	if err := validateOutputFormat(output); err != nil {
+		return err
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	webhook, err :=
+		client.Core().Projects().Webhooks().Get(c.Context, projectID, id, nil)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(output) {
+	case flagOutputTable:
+		table := uitable.New()
+		table.AddRow("ID", "AUTH TYPE", "SOURCE", "AGE")
+		table.AddRow(webhookTableRow(webhook)...)
+		fmt.Println(table)
+
+	case flagOutputYAML:
+		yamlBytes, err := yaml.Marshal(webhook)
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get webhook operation",
+			)
+		}
+		fmt.Println(string(yamlBytes))
+
+	case flagOutputJSON:
+		prettyJSON, err := json.MarshalIndent(webhook, "", "  ")
+		if err != nil {
+			return errors.Wrap(
+				err,
+				"error formatting output from get webhook operation",
+			)
+		}
+		fmt.Println(string(prettyJSON))
+	}
+
+	return nil
+}
+
+func webhookDelete(c *cli.Context) error {
+	projectID := c.String(flagProject)
+	id := c.String(flagID)
+
+	confirmed, err := confirmed(c)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+
+	client, err := getClient(false)
+	if err != nil {
+		return err
+	}
+
+	if err := client.Core().Projects().Webhooks().Delete(
+		c.Context,
+		projectID,
+		id,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	fmt.Printf("Webhook %q deleted from project %q.\n", id, projectID)
+
+	return nil
+}
+
+func webhookTableRow(webhook sdk.Webhook) []interface{} {
+	var age string
+	if webhook.Created != nil {
+		age = duration.ShortHumanDuration(time.Since(*webhook.Created))
+	}
+	return []interface{}{
+		webhook.ID,
+		webhook.AuthType,
+		webhook.Source,
+		age,
+	}
+}
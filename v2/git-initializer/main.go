@@ -82,12 +82,20 @@ func gitCheckout() error {
 
 	// Check for SSH Key
 	privateKey, ok := event.Project.Secrets["gitSSHKey"]
+	privateKeyPassword := event.Project.Secrets["gitSSHKeyPassword"]
+	if !ok {
+		// If the Project's secrets are hidden from its Workers, they won't be in
+		// the event, but any SSH key will have been provided via the environment
+		// instead.
+		privateKey, ok = os.LookupEnv("GIT_SSH_KEY")
+		privateKeyPassword = os.Getenv("GIT_SSH_KEY_PASSWORD")
+	}
 	if ok {
 		var publicKeys *gitssh.PublicKeys
 		publicKeys, err = gitssh.NewPublicKeys(
 			"git",
 			[]byte(privateKey),
-			event.Project.Secrets["gitSSHKeyPassword"],
+			privateKeyPassword,
 		)
 		if err != nil {
 			return errors.Wrapf(
@@ -20,12 +20,19 @@ const (
 	LabelKeyEvent          = "event"
 	LabelKeyWorkspace      = "workspace"
 	LabelKeyProjectSecrets = "project-secrets"
+	LabelKeyWebhookSecrets = "webhook-secrets"
 
 	SecretTypeProjectSecrets = "brigade.sh/project-secrets" // nolint: gosec
+	SecretTypeWebhookSecrets = "brigade.sh/webhook-secrets" // nolint: gosec
 	SecretTypeEvent          = "brigade.sh/event"           // nolint: gosec
 	SecretTypeJobSecrets     = "brigade.sh/job"             // nolint: gosec
 )
 
+// ReadinessFailurePrefix prefixes the termination message of any Job sidecar
+// container that was terminated because it never became ready. This permits
+// such sidecars to be distinguished from those that simply exited.
+const ReadinessFailurePrefix = "brigade.sh/readiness-failure: "
+
 func EventSecretName(eventID string) string {
 	return eventID
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/brigadecore/brigade/sdk/v3"
@@ -113,13 +114,17 @@ func (o *observer) getJobStatusFromPod(pod *corev1.Pod) sdk.JobStatus {
 			// For Brigade's purposes, this counts as running
 			status.Phase = sdk.JobPhaseRunning
 			// Unless... when an image pull backoff occurs, the pod still shows as
-			// pending. We account for that here and treat it as a failure.
+			// pending. The same is true when a container references a project
+			// secret that doesn't exist. We account for that here and treat it as a
+			// failure.
 			//
 			// TODO: Are there other conditions we need to watch out for?
 			for _, containerStatus := range pod.Status.ContainerStatuses {
 				if containerStatus.State.Waiting != nil &&
 					(containerStatus.State.Waiting.Reason == "ImagePullBackOff" ||
-						containerStatus.State.Waiting.Reason == "ErrImagePull") {
+						containerStatus.State.Waiting.Reason == "ErrImagePull" ||
+						containerStatus.State.Waiting.Reason ==
+							"CreateContainerConfigError") {
 					status.Phase = sdk.JobPhaseFailed
 					break
 				}
@@ -134,11 +139,13 @@ func (o *observer) getJobStatusFromPod(pod *corev1.Pod) sdk.JobStatus {
 			status.Phase = sdk.JobPhaseUnknown
 		}
 	}
+	// The job's primary container is named after the job.
+	primaryContainerName := pod.Labels[myk8s.LabelJob]
 	// Due to our sidecar support, even if the phase is running, we're not REALLY
-	// running if container[0] has exited. Adjust accordingly.
+	// running if the primary container has exited. Adjust accordingly.
 	if status.Phase == sdk.JobPhaseRunning {
 		for _, containerStatus := range pod.Status.ContainerStatuses {
-			if containerStatus.Name == pod.Spec.Containers[0].Name {
+			if containerStatus.Name == primaryContainerName {
 				if containerStatus.State.Terminated != nil {
 					if containerStatus.State.Terminated.ExitCode == 0 {
 						status.Phase = sdk.JobPhaseSucceeded
@@ -154,9 +161,19 @@ func (o *observer) getJobStatusFromPod(pod *corev1.Pod) sdk.JobStatus {
 	if pod.Status.StartTime != nil {
 		status.Started = &pod.Status.StartTime.Time
 	}
-	// Determine the job's end time based on container[0] end time
+	// If an init container failed or a sidecar never became ready, the job has
+	// failed, even if the primary container is still running or was started
+	// anyway. Record why.
+	if status.Phase != sdk.JobPhaseAborted &&
+		status.Phase != sdk.JobPhaseSucceeded {
+		if reason := getJobFailureReason(pod); reason != "" {
+			status.Phase = sdk.JobPhaseFailed
+			status.Reason = reason
+		}
+	}
+	// Determine the job's end time based on the primary container's end time
 	for _, containerStatus := range pod.Status.ContainerStatuses {
-		if containerStatus.Name == pod.Spec.Containers[0].Name {
+		if containerStatus.Name == primaryContainerName {
 			if containerStatus.State.Terminated != nil {
 				status.Ended = &containerStatus.State.Terminated.FinishedAt.Time
 			}
@@ -166,6 +183,39 @@ func (o *observer) getJobStatusFromPod(pod *corev1.Pod) sdk.JobStatus {
 	return status
 }
 
+// getJobFailureReason returns a description of why the provided job pod failed
+// if any of its init containers failed or any of its sidecar containers did not
+// become ready. Otherwise, it returns an empty string.
+func getJobFailureReason(pod *corev1.Pod) string {
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		if containerStatus.State.Terminated != nil &&
+			containerStatus.State.Terminated.ExitCode != 0 {
+			return fmt.Sprintf(
+				"Init container %q failed with exit code %d",
+				containerStatus.Name,
+				containerStatus.State.Terminated.ExitCode,
+			)
+		}
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Terminated != nil &&
+			strings.HasPrefix(
+				containerStatus.State.Terminated.Message,
+				myk8s.ReadinessFailurePrefix,
+			) {
+			return fmt.Sprintf(
+				"Sidecar container %q did not become ready: %s",
+				containerStatus.Name,
+				strings.TrimPrefix(
+					containerStatus.State.Terminated.Message,
+					myk8s.ReadinessFailurePrefix,
+				),
+			)
+		}
+	}
+	return ""
+}
+
 // manageJobTimeout takes a pod and job phase as input. If the phase is
 // terminal and the timeout clock is already running for the pod, the clock is
 // stopped. If the phase is NOT terminal and the timeout clock is NOT already
@@ -191,9 +241,8 @@ func (o *observer) manageJobTimeout(
 func (o *observer) runJobTimer(ctx context.Context, pod *corev1.Pod) {
 	namespacedPodName := namespacedPodName(pod.Namespace, pod.Name)
 	defer delete(o.timedPodsSet, namespacedPodName)
-	timer := time.NewTimer(
-		o.getPodTimeoutDuration(pod, o.config.maxJobLifetime),
-	)
+	timeout := o.getPodTimeoutDuration(pod, o.config.maxJobLifetime)
+	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	select {
 	case <-timer.C:
@@ -209,8 +258,14 @@ func (o *observer) runJobTimer(ctx context.Context, pod *corev1.Pod) {
 		timeoutCtx, cancel :=
 			context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		if err :=
-			o.jobsClient.Timeout(timeoutCtx, eventID, jobName, nil); err != nil {
+		if err := o.jobsClient.Timeout(
+			timeoutCtx,
+			eventID,
+			jobName,
+			&sdk.JobTimeoutOptions{
+				Reason: fmt.Sprintf("Job exceeded its timeout of %s", timeout),
+			},
+		); err != nil {
 			o.errFn(
 				errors.Wrapf(
 					err,
@@ -197,6 +197,9 @@ func TestSyncJobPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "nombre",
 					Namespace: "ns",
+					Labels: map[string]string{
+						myk8s.LabelJob: "foo",
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{Name: "foo"}},
@@ -286,6 +289,9 @@ func TestSyncJobPod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "nombre",
 					Namespace: "ns",
+					Labels: map[string]string{
+						myk8s.LabelJob: "foo",
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{Name: "foo"}},
@@ -334,6 +340,169 @@ func TestSyncJobPod(t *testing.T) {
 				cleanupJobFn: func(_, _ string) {},
 			},
 		},
+		{
+			name: "pod phase is pending and container cannot be configured",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nombre",
+					Namespace: "ns",
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "foo",
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{
+									Reason: "CreateContainerConfigError",
+								},
+							},
+						},
+					},
+				},
+			},
+			observer: &observer{
+				timedPodsSet: map[string]context.CancelFunc{
+					"ns:nombre": func() {},
+				},
+				manageJobTimeoutFn: func(
+					context.Context,
+					*corev1.Pod,
+					sdk.JobPhase,
+				) {
+				},
+				jobsClient: &coreTesting.MockJobsClient{
+					UpdateStatusFn: func(
+						ctx context.Context,
+						eventID string,
+						jobName string,
+						status sdk.JobStatus,
+						_ *sdk.JobStatusUpdateOptions,
+					) error {
+						require.Equal(t, sdk.JobPhaseFailed, status.Phase)
+						return nil
+					},
+				},
+				cleanupJobFn: func(_, _ string) {},
+			},
+		},
+		{
+			name: "init container failed",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nombre",
+					Namespace: "ns",
+					Labels: map[string]string{
+						myk8s.LabelJob: "foo",
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					InitContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "setup",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									ExitCode: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+			observer: &observer{
+				timedPodsSet: map[string]context.CancelFunc{
+					"ns:nombre": func() {},
+				},
+				manageJobTimeoutFn: func(
+					context.Context,
+					*corev1.Pod,
+					sdk.JobPhase,
+				) {
+				},
+				jobsClient: &coreTesting.MockJobsClient{
+					UpdateStatusFn: func(
+						ctx context.Context,
+						eventID string,
+						jobName string,
+						status sdk.JobStatus,
+						_ *sdk.JobStatusUpdateOptions,
+					) error {
+						require.Equal(t, sdk.JobPhaseFailed, status.Phase)
+						require.Equal(
+							t,
+							`Init container "setup" failed with exit code 2`,
+							status.Reason,
+						)
+						return nil
+					},
+				},
+				cleanupJobFn: func(_, _ string) {},
+			},
+		},
+		{
+			name: "sidecar container did not become ready",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nombre",
+					Namespace: "ns",
+					Labels: map[string]string{
+						myk8s.LabelJob: "foo",
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "db",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									ExitCode: 137,
+									Message: myk8s.ReadinessFailurePrefix +
+										"not ready after 12 attempts",
+								},
+							},
+						},
+						{
+							Name: "foo",
+							State: corev1.ContainerState{
+								Running: &corev1.ContainerStateRunning{},
+							},
+						},
+					},
+				},
+			},
+			observer: &observer{
+				timedPodsSet: map[string]context.CancelFunc{
+					"ns:nombre": func() {},
+				},
+				manageJobTimeoutFn: func(
+					context.Context,
+					*corev1.Pod,
+					sdk.JobPhase,
+				) {
+				},
+				jobsClient: &coreTesting.MockJobsClient{
+					UpdateStatusFn: func(
+						ctx context.Context,
+						eventID string,
+						jobName string,
+						status sdk.JobStatus,
+						_ *sdk.JobStatusUpdateOptions,
+					) error {
+						require.Equal(t, sdk.JobPhaseFailed, status.Phase)
+						require.Equal(
+							t,
+							`Sidecar container "db" did not become ready: `+
+								"not ready after 12 attempts",
+							status.Reason,
+						)
+						return nil
+					},
+				},
+				cleanupJobFn: func(_, _ string) {},
+			},
+		},
 		{
 			name: "pod phase is succeeded",
 			pod: &corev1.Pod{
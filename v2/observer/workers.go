@@ -172,9 +172,8 @@ func (o *observer) manageWorkerTimeout(
 func (o *observer) runWorkerTimer(ctx context.Context, pod *corev1.Pod) {
 	namespacedPodName := namespacedPodName(pod.Namespace, pod.Name)
 	defer delete(o.timedPodsSet, namespacedPodName)
-	timer := time.NewTimer(
-		o.getPodTimeoutDuration(pod, o.config.maxWorkerLifetime),
-	)
+	timeout := o.getPodTimeoutDuration(pod, o.config.maxWorkerLifetime)
+	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	select {
 	case <-timer.C:
@@ -189,7 +188,13 @@ func (o *observer) runWorkerTimer(ctx context.Context, pod *corev1.Pod) {
 		timeoutCtx, cancel :=
 			context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
-		if err := o.workersClient.Timeout(timeoutCtx, eventID, nil); err != nil {
+		if err := o.workersClient.Timeout(
+			timeoutCtx,
+			eventID,
+			&sdk.WorkerTimeoutOptions{
+				Reason: fmt.Sprintf("Worker exceeded its timeout of %s", timeout),
+			},
+		); err != nil {
 			o.errFn(
 				errors.Wrapf(
 					err,
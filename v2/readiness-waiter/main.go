@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brigadecore/brigade-foundations/version"
+	"github.com/brigadecore/brigade/sdk/v3"
+	myk8s "github.com/brigadecore/brigade/v2/internal/kubernetes"
+)
+
+const (
+	binaryName              = "readiness-waiter"
+	terminationLogPath      = "/dev/termination-log"
+	defaultPeriodSeconds    = 5
+	defaultTimeoutSeconds   = 1
+	defaultFailureThreshold = 12
+)
+
+// The readiness waiter is used in two ways. As an init container of a Job's
+// pod, it installs itself to a volume shared with the Job's sidecar
+// containers. As the post-start hook of a sidecar container, it then probes
+// that sidecar until it is ready. Since a container's post-start hook must
+// complete before the next of the pod's containers is started, this delays the
+// start of the Job's primary container until its sidecars are ready.
+func main() {
+	log.Printf(
+		"Starting Brigade Readiness Waiter -- version %s -- commit %s",
+		version.Version(),
+		version.Commit(),
+	)
+
+	if len(os.Args) != 3 {
+		fmt.Printf("\nusage: %s install DIR | wait PROBE\n\n", binaryName)
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "install":
+		if err := install(os.Args[2]); err != nil {
+			fmt.Printf("\n%s\n\n", err)
+			os.Exit(1)
+		}
+	case "wait":
+		probe := sdk.ReadinessProbe{}
+		if err := json.Unmarshal([]byte(os.Args[2]), &probe); err != nil {
+			fmt.Printf("\nerror unmarshaling readiness probe: %s\n\n", err)
+			os.Exit(1)
+		}
+		if err := wait(context.Background(), probe, probeOnce); err != nil {
+			fmt.Printf("\n%s\n\n", err)
+			// Record why the sidecar never became ready so the reason can be
+			// surfaced in the Job's status.
+			if werr := ioutil.WriteFile(
+				terminationLogPath,
+				[]byte(myk8s.ReadinessFailurePrefix+err.Error()),
+				0644,
+			); werr != nil {
+				fmt.Printf("error writing termination log: %s\n", werr)
+			}
+			os.Exit(1)
+		}
+	default:
+		fmt.Printf("\nunrecognized command %q\n\n", os.Args[1])
+		os.Exit(1)
+	}
+}
+
+// install copies the running executable into the specified directory.
+func install(dir string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "error locating executable")
+	}
+	src, err := os.Open(executable)
+	if err != nil {
+		return errors.Wrapf(err, "error opening %q", executable)
+	}
+	defer src.Close() // nolint: errcheck
+	destPath := filepath.Join(dir, binaryName)
+	dest, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY, 0755)
+	if err != nil {
+		return errors.Wrapf(err, "error creating %q", destPath)
+	}
+	if _, err = io.Copy(dest, src); err != nil {
+		dest.Close() // nolint: errcheck
+		return errors.Wrapf(err, "error copying executable to %q", destPath)
+	}
+	return errors.Wrapf(dest.Close(), "error closing %q", destPath)
+}
+
+// wait repeatedly probes using the provided function until a probe succeeds,
+// the probe's failure threshold is reached, or the context is canceled.
+func wait(
+	ctx context.Context,
+	probe sdk.ReadinessProbe,
+	probeFn func(context.Context, sdk.ReadinessProbe) error,
+) error {
+	period := time.Duration(probe.PeriodSeconds) * time.Second
+	if period <= 0 {
+		period = defaultPeriodSeconds * time.Second
+	}
+	timeout := time.Duration(probe.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeoutSeconds * time.Second
+	}
+	failureThreshold := int(probe.FailureThreshold)
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+
+	delay := time.Duration(probe.InitialDelaySeconds) * time.Second
+	var err error
+	for failures := 0; failures < failureThreshold; failures++ {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay = period
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = probeFn(probeCtx, probe)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		log.Printf("readiness probe failed: %s", err)
+	}
+	return errors.Wrapf(
+		err,
+		"not ready after %d attempts; last attempt failed",
+		failureThreshold,
+	)
+}
+
+// probeOnce makes a single attempt to determine whether a container is ready
+// according to the provided probe. Since all of a pod's containers share a
+// network namespace, TCP and HTTP probes address the container as localhost.
+func probeOnce(ctx context.Context, probe sdk.ReadinessProbe) error {
+	switch {
+	case probe.Exec != nil:
+		if len(probe.Exec.Command) == 0 {
+			return errors.New("no command specified")
+		}
+		// nolint: gosec
+		cmd := exec.CommandContext(
+			ctx,
+			probe.Exec.Command[0],
+			probe.Exec.Command[1:]...,
+		)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "command failed with output %q", output)
+		}
+		return nil
+	case probe.TCPSocket != nil:
+		conn, err := (&net.Dialer{}).DialContext(
+			ctx,
+			"tcp",
+			net.JoinHostPort("localhost", strconv.Itoa(int(probe.TCPSocket.Port))),
+		)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case probe.HTTPGet != nil:
+		path := probe.HTTPGet.Path
+		if path == "" {
+			path = "/"
+		}
+		req, err := http.NewRequestWithContext(
+			ctx,
+			http.MethodGet,
+			fmt.Sprintf("http://localhost:%d%s", probe.HTTPGet.Port, path),
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close() // nolint: errcheck
+		if resp.StatusCode < http.StatusOK ||
+			resp.StatusCode >= http.StatusBadRequest {
+			return errors.Errorf("received status code %d", resp.StatusCode)
+		}
+		return nil
+	}
+	return errors.New("no probe specified")
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/brigadecore/brigade/sdk/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWait(t *testing.T) {
+	testCases := []struct {
+		name       string
+		probe      sdk.ReadinessProbe
+		results    []error
+		assertions func(attempts int, err error)
+	}{
+		{
+			name: "ready on first attempt",
+			probe: sdk.ReadinessProbe{
+				PeriodSeconds: 1,
+			},
+			results: []error{nil},
+			assertions: func(attempts int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, attempts)
+			},
+		},
+		{
+			name: "ready after failures",
+			probe: sdk.ReadinessProbe{
+				PeriodSeconds:    1,
+				FailureThreshold: 3,
+			},
+			results: []error{errors.New("nope"), nil},
+			assertions: func(attempts int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, attempts)
+			},
+		},
+		{
+			name: "failure threshold reached",
+			probe: sdk.ReadinessProbe{
+				PeriodSeconds:    1,
+				FailureThreshold: 2,
+			},
+			results: []error{errors.New("nope"), errors.New("still nope"), nil},
+			assertions: func(attempts int, err error) {
+				require.Error(t, err)
+				require.Equal(t, 2, attempts)
+				require.Contains(t, err.Error(), "not ready after 2 attempts")
+				require.Contains(t, err.Error(), "still nope")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			attempts := 0
+			err := wait(
+				context.Background(),
+				testCase.probe,
+				func(context.Context, sdk.ReadinessProbe) error {
+					err := testCase.results[attempts]
+					attempts++
+					return err
+				},
+			)
+			testCase.assertions(attempts, err)
+		})
+	}
+}
+
+func TestProbeOnce(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	_, portStr, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		probe      sdk.ReadinessProbe
+		assertions func(error)
+	}{
+		{
+			name: "no probe",
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "no probe specified")
+			},
+		},
+		{
+			name: "exec probe fails",
+			probe: sdk.ReadinessProbe{
+				Exec: &sdk.ExecProbe{
+					Command: []string{"false"},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "command failed")
+			},
+		},
+		{
+			name: "exec probe succeeds",
+			probe: sdk.ReadinessProbe{
+				Exec: &sdk.ExecProbe{
+					Command: []string{"true"},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "tcp probe succeeds",
+			probe: sdk.ReadinessProbe{
+				TCPSocket: &sdk.TCPSocketProbe{
+					Port: int32(port),
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "http probe fails",
+			probe: sdk.ReadinessProbe{
+				HTTPGet: &sdk.HTTPGetProbe{
+					Port: int32(port),
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "received status code 503")
+			},
+		},
+		{
+			name: "http probe succeeds",
+			probe: sdk.ReadinessProbe{
+				HTTPGet: &sdk.HTTPGetProbe{
+					Path: "/healthz",
+					Port: int32(port),
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(probeOnce(context.Background(), testCase.probe))
+		})
+	}
+}